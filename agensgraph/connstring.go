@@ -0,0 +1,40 @@
+package agensgraph
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// redactedPassword replaces the password within errors returned while connecting to the database
+const redactedPassword = "xxxxx"
+
+// buildConnString builds the connection string of the specified connection parameters. Values are quoted so that
+// values containing spaces or quotes cannot be confused with other parameters.
+//
+// The connection string contains the plaintext password and hence must never be logged or included in errors.
+func buildConnString(params [][2]string) string {
+	parts := make([]string, 0, len(params))
+	for _, param := range params {
+		parts = append(parts, fmt.Sprintf("%s=%s", param[0], quoteConnValue(param[1])))
+	}
+	return strings.Join(parts, " ")
+}
+
+// quoteConnValue quotes a connection string value, escaping backslashes and single quotes within the value
+func quoteConnValue(value string) string {
+	escaped := strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(value)
+	return fmt.Sprintf("'%s'", escaped)
+}
+
+// redactError returns an error with the message of the specified error in which the specified password is
+// redacted. The original error is not wrapped since it may expose the password.
+func redactError(err error, password string) error {
+	if err == nil || password == "" {
+		return err
+	}
+	if !strings.Contains(err.Error(), password) {
+		return err
+	}
+	return errors.New(strings.ReplaceAll(err.Error(), password, redactedPassword))
+}
@@ -0,0 +1,55 @@
+package agensgraph
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/prahaladd/gograph/core"
+	"github.com/stretchr/testify/suite"
+)
+
+type ConnStringTestSuite struct {
+	suite.Suite
+}
+
+func (suite *ConnStringTestSuite) TestBuildConnString() {
+	connString := buildConnString([][2]string{{"host", "localhost"}, {"password", `it's a \secret`}})
+	suite.Equal(`host='localhost' password='it\'s a \\secret'`, connString)
+}
+
+func (suite *ConnStringTestSuite) TestRedactError() {
+	err := redactError(errors.New("invalid password s3cr3t"), "s3cr3t")
+	suite.EqualError(err, "invalid password xxxxx")
+
+	original := errors.New("connection refused")
+	suite.Same(original, redactError(original, "s3cr3t"))
+	suite.Nil(redactError(nil, "s3cr3t"))
+}
+
+func (suite *ConnStringTestSuite) TestNewConnectionErrorsDoNotContainPassword() {
+	port := int32(5432)
+	auth := core.KVMap{AGENS_USER_KEY: "user", AGENS_PASSWD_KEY: "s3cr3t pwd"}
+	_, err := NewConnection("", "localhost", "", &port, auth, core.KVMap{AGENS_DBNAME_KEY: "graphdb", core.MAX_ROWS_KEY: "all"})
+	suite.Error(err)
+	suite.NotContains(err.Error(), "s3cr3t")
+}
+
+func (suite *ConnStringTestSuite) TestNewConnectionWithQuotedPassword() {
+	port := int32(5432)
+	auth := core.KVMap{AGENS_USER_KEY: "user", AGENS_PASSWD_KEY: "it's a secret"}
+	conn, err := NewConnection("", "localhost", "", &port, auth, core.KVMap{AGENS_DBNAME_KEY: "graphdb"})
+	suite.NoError(err)
+	suite.NotNil(conn)
+}
+
+func (suite *ConnStringTestSuite) TestNewConnectionParametersCannotInjectParameters() {
+	port := int32(5432)
+	auth := core.KVMap{AGENS_USER_KEY: "user", AGENS_PASSWD_KEY: "s3cr3t"}
+	// an unquoted client encoding other than UTF8 would fail parsing the connection parameters
+	_, err := NewConnection("", "localhost", "", &port, auth, core.KVMap{AGENS_DBNAME_KEY: "graphdb client_encoding=LATIN1"})
+	suite.NoError(err)
+}
+
+func TestConnStringTestSuite(t *testing.T) {
+	suite.Run(t, new(ConnStringTestSuite))
+}
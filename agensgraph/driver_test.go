@@ -0,0 +1,124 @@
+package agensgraph
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"io"
+)
+
+// mockConnector is a driver.Connector creating connections which return the same rows for every query. The first
+// badQueries queries fail with driver.ErrBadConn, simulating connections closed by the server. Connections cannot be
+// opened if connectErr is set and transactions fail to commit with commitErr if set.
+//
+// If stall is set, the rows stop after stallAfter rows until the context of the query is done, simulating a server
+// that stops sending rows. The stall function is invoked once the rows stop.
+type mockConnector struct {
+	columns    []string
+	rows       [][]driver.Value
+	badQueries int
+	connectErr error
+	commitErr  error
+	stall      func()
+	stallAfter int
+	// queries records the queries run through the connections, including the queries that failed
+	queries []string
+	// connections is the number of connections opened through the connector
+	connections int
+}
+
+func (mc *mockConnector) Connect(ctx context.Context) (driver.Conn, error) {
+	if mc.connectErr != nil {
+		return nil, mc.connectErr
+	}
+	mc.connections++
+	return &mockConn{connector: mc}, nil
+}
+
+func (mc *mockConnector) Driver() driver.Driver {
+	return mockDriver{connector: mc}
+}
+
+type mockDriver struct {
+	connector *mockConnector
+}
+
+func (md mockDriver) Open(name string) (driver.Conn, error) {
+	return md.connector.Connect(context.Background())
+}
+
+type mockConn struct {
+	connector *mockConnector
+	bad       bool
+}
+
+func (mc *mockConn) Prepare(query string) (driver.Stmt, error) {
+	return nil, errors.New("prepared statements are not supported")
+}
+
+func (mc *mockConn) Close() error {
+	return nil
+}
+
+func (mc *mockConn) Begin() (driver.Tx, error) {
+	return mockTx{conn: mc}, nil
+}
+
+func (mc *mockConn) BeginTx(ctx context.Context, opts driver.TxOptions) (driver.Tx, error) {
+	return mockTx{conn: mc}, nil
+}
+
+func (mc *mockConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	mc.connector.queries = append(mc.connector.queries, query)
+	if mc.connector.badQueries > 0 {
+		mc.connector.badQueries--
+		mc.bad = true
+		return nil, driver.ErrBadConn
+	}
+	return &mockRows{ctx: ctx, connector: mc.connector}, nil
+}
+
+// mockTx fails to roll back on a bad connection with driver.ErrBadConn, similar to lib/pq, so that the connection is
+// discarded instead of being returned to the pool
+type mockTx struct {
+	conn *mockConn
+}
+
+func (mt mockTx) Commit() error {
+	return mt.conn.connector.commitErr
+}
+
+func (mt mockTx) Rollback() error {
+	if mt.conn.bad {
+		return driver.ErrBadConn
+	}
+	return nil
+}
+
+type mockRows struct {
+	ctx       context.Context
+	connector *mockConnector
+	next      int
+}
+
+func (mr *mockRows) Columns() []string {
+	return mr.connector.columns
+}
+
+func (mr *mockRows) Close() error {
+	return nil
+}
+
+func (mr *mockRows) Next(dest []driver.Value) error {
+	if mr.connector.stall != nil && mr.next == mr.connector.stallAfter {
+		mr.connector.stall()
+		<-mr.ctx.Done()
+		return mr.ctx.Err()
+	}
+	if mr.next == len(mr.connector.rows) {
+		return io.EOF
+	}
+	copy(dest, mr.connector.rows[mr.next])
+	mr.next++
+	return nil
+}
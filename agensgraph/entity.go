@@ -0,0 +1,155 @@
+package agensgraph
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+
+	ag "github.com/bitnine-oss/agensgraph-golang"
+)
+
+// vertexEntity is an Agensgraph vertex entity which preserves the precision of
+// numeric properties.
+//
+// The ag.BasicVertex implementation decodes the vertex properties using a plain
+// json.Unmarshal which coerces all numeric values to float64. Large integer
+// values (for e.g. Snowflake style identifiers) lose precision as a result.
+type vertexEntity struct {
+	ag.VertexHeader
+	Properties map[string]interface{}
+}
+
+// SaveProperties implements the ag.PropertiesSaver interface.
+func (v *vertexEntity) SaveProperties(b []byte) error {
+	props, err := decodeProperties(b)
+	if err != nil {
+		return errors.New("invalid vertex properties: " + err.Error())
+	}
+	v.Properties = props
+	return nil
+}
+
+// edgeEntity is an Agensgraph edge entity which preserves the precision of
+// numeric properties.
+type edgeEntity struct {
+	ag.EdgeHeader
+	Properties map[string]interface{}
+}
+
+// SaveProperties implements the ag.PropertiesSaver interface.
+func (e *edgeEntity) SaveProperties(b []byte) error {
+	props, err := decodeProperties(b)
+	if err != nil {
+		return errors.New("invalid edge properties: " + err.Error())
+	}
+	e.Properties = props
+	return nil
+}
+
+// decodeProperties decodes the JSON representation of the properties of a vertex or an edge.
+//
+// Integral numbers are decoded as int64 values and all other numbers are decoded as float64 values.
+func decodeProperties(b []byte) (map[string]interface{}, error) {
+	decoder := json.NewDecoder(bytes.NewReader(b))
+	decoder.UseNumber()
+	var props map[string]interface{}
+	err := decoder.Decode(&props)
+	if err != nil {
+		return nil, err
+	}
+	for k, v := range props {
+		props[k] = normalizeNumbers(v)
+	}
+	return props, nil
+}
+
+func normalizeNumbers(v interface{}) interface{} {
+	switch value := v.(type) {
+	case json.Number:
+		if i, err := value.Int64(); err == nil {
+			return i
+		}
+		if f, err := value.Float64(); err == nil {
+			return f
+		}
+		return value.String()
+	case []interface{}:
+		for i, elem := range value {
+			value[i] = normalizeNumbers(elem)
+		}
+		return value
+	case map[string]interface{}:
+		for k, elem := range value {
+			value[k] = normalizeNumbers(elem)
+		}
+		return value
+	default:
+		return v
+	}
+}
+
+// decodeText decodes a scalar text value returned by Agensgraph. Text values returned as JSON strings
+// are unquoted while all other values are returned as is.
+func decodeText(b []byte) string {
+	var text string
+	if err := json.Unmarshal(b, &text); err == nil {
+		return text
+	}
+	return string(b)
+}
+
+// decodeValue decodes a scalar value returned by Agensgraph. Values are decoded as JSON with integral numbers
+// decoded as int64 values. Empty values, as returned for NULL, are decoded as nil and the PostgreSQL boolean text
+// values t and f are decoded as bool values. All other values that are not valid JSON are returned as strings.
+func decodeValue(b []byte) interface{} {
+	switch string(b) {
+	case "":
+		return nil
+	case "t":
+		return true
+	case "f":
+		return false
+	}
+	decoder := json.NewDecoder(bytes.NewReader(b))
+	decoder.UseNumber()
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return string(b)
+	}
+	// the value is not valid JSON if it has trailing data
+	if _, err := decoder.Token(); err != io.EOF {
+		return string(b)
+	}
+	return normalizeNumbers(value)
+}
+
+// pathEntity is an Agensgraph path whose vertices and edges preserve the precision of numeric properties.
+type pathEntity struct {
+	Vertices []vertexEntity
+	Edges    []edgeEntity
+}
+
+// SavePath implements the ag.PathSaver interface. The elements of a path alternate between vertices and edges,
+// starting and ending with a vertex.
+func (p *pathEntity) SavePath(valid bool, ds []interface{}) error {
+	if !valid {
+		return nil
+	}
+	for i, d := range ds {
+		if i%2 == 0 {
+			var vertex vertexEntity
+			if err := ag.ScanEntity(d, &vertex); err != nil {
+				return err
+			}
+			p.Vertices = append(p.Vertices, vertex)
+			continue
+		}
+		var edge edgeEntity
+		if err := ag.ScanEntity(d, &edge); err != nil {
+			return err
+		}
+		p.Edges = append(p.Edges, edge)
+	}
+	return nil
+}
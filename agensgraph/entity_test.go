@@ -0,0 +1,67 @@
+package agensgraph
+
+import (
+	"testing"
+
+	ag "github.com/bitnine-oss/agensgraph-golang"
+	"github.com/stretchr/testify/suite"
+)
+
+type EntityTestSuite struct {
+	suite.Suite
+}
+
+func (suite *EntityTestSuite) TestDecodeLargeIntegerProperty() {
+	props, err := decodeProperties([]byte(`{"id": 1541815603606036480, "weight": 10.5, "name": "Tom"}`))
+	suite.NoError(err)
+	suite.Equal(int64(1541815603606036480), props["id"])
+	suite.Equal(10.5, props["weight"])
+	suite.Equal("Tom", props["name"])
+}
+
+func (suite *EntityTestSuite) TestDecodeNestedNumericProperties() {
+	props, err := decodeProperties([]byte(`{"ids": [1541815603606036480, 2], "nested": {"count": 3}}`))
+	suite.NoError(err)
+	suite.Equal([]interface{}{int64(1541815603606036480), int64(2)}, props["ids"])
+	suite.Equal(map[string]interface{}{"count": int64(3)}, props["nested"])
+}
+
+func (suite *EntityTestSuite) TestScanVertexEntity() {
+	var v vertexEntity
+	err := ag.ScanEntity([]byte(`person[3.1]{"id": 1541815603606036480}`), &v)
+	suite.NoError(err)
+	suite.Equal("person", v.Label)
+	suite.Equal(int64(1541815603606036480), v.Properties["id"])
+}
+
+func (suite *EntityTestSuite) TestDecodeText() {
+	suite.Equal("WORKS_AT", decodeText([]byte(`"WORKS_AT"`)))
+	suite.Equal("WORKS_AT", decodeText([]byte("WORKS_AT")))
+}
+
+func (suite *EntityTestSuite) TestDecodeValue() {
+	suite.Equal(int64(9007199254740993), decodeValue([]byte("9007199254740993")))
+	suite.Equal(1.5, decodeValue([]byte("1.5")))
+	suite.Equal("Tintin", decodeValue([]byte(`"Tintin"`)))
+	suite.Equal(true, decodeValue([]byte("true")))
+	suite.Equal(true, decodeValue([]byte("t")))
+	suite.Equal(false, decodeValue([]byte("f")))
+	suite.Equal("t", decodeValue([]byte(`"t"`)))
+	suite.Equal("2023-01-01", decodeValue([]byte("2023-01-01")))
+	suite.Nil(decodeValue(nil))
+}
+
+func (suite *EntityTestSuite) TestScanPathEntity() {
+	var p pathEntity
+	err := ag.ScanPath([]byte(`[person[3.1]{"name": "Tintin"},owns[4.1][3.1,5.1]{"since": 1929},dog[5.1]{"name": "Snowy"}]`), &p)
+	suite.NoError(err)
+	suite.Equal(2, len(p.Vertices))
+	suite.Equal(1, len(p.Edges))
+	suite.Equal("Snowy", p.Vertices[1].Properties["name"])
+	suite.Equal("owns", p.Edges[0].Label)
+	suite.Equal(int64(1929), p.Edges[0].Properties["since"])
+}
+
+func TestEntityTestSuite(t *testing.T) {
+	suite.Run(t, new(EntityTestSuite))
+}
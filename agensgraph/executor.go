@@ -3,12 +3,16 @@ package agensgraph
 import (
 	"context"
 	"database/sql"
+	"database/sql/driver"
 	"errors"
 	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
 	"time"
 
 	ag "github.com/bitnine-oss/agensgraph-golang"
-	_ "github.com/lib/pq"
+	"github.com/lib/pq"
 	"github.com/prahaladd/gograph/core"
 	"github.com/prahaladd/gograph/query/cypher"
 )
@@ -17,8 +21,9 @@ type agensContextKey string
 
 // query context constants
 const (
-	// ContextKeyQueryTimeoutSeconds is used in context to specify the query timeout in milli-seconds
-	// The value of this context parameter is currently ignored
+	// ContextKeyQueryTimeoutMillis is used in context to specify the query timeout in milli-seconds as an int64 value.
+	// A timeout specified using core.WithQueryTimeout takes precedence. Queries time out after the default query
+	// timeout of the connection if neither is specified.
 	ContextKeyQueryTimeoutMillis = agensContextKey("QueryTimeout")
 	// ContextKeyIsolationLevel is used in context to specify the isolation level for query execution
 	ContextKeyIsolationLevel = agensContextKey("IsolationLevel")
@@ -29,20 +34,46 @@ const (
 	// ContextKeyWriteModeCreate is used to specify if an vertex or edge creation should be done via CREATE instead of a  MERGE
 	ContextKeyWriteModeCreate = agensContextKey("writeModeCreate")
 )
+
+// graphIdRegex matches the text representation of an Agensgraph graphid, consisting of the label id and the local id
+var graphIdRegex = regexp.MustCompile(`^\d+\.\d+$`)
+
 const (
-	AGENS_USER_KEY              = "username"
-	AGENS_PASSWD_KEY            = "password"
-	AGENS_DBNAME_KEY            = "dbName"
-	AGENS_DEFAULT_PORT          = int32(5432)
-	AGENS_TLS_PROTOCOL          = "tls"
+	AGENS_USER_KEY     = "username"
+	AGENS_PASSWD_KEY   = "password"
+	AGENS_DBNAME_KEY   = "dbName"
+	AGENS_DEFAULT_PORT = int32(5432)
+	AGENS_TLS_PROTOCOL = "tls"
+	// Deprecated: AGENS_QUERY_TIMEOUT_KEY is not read by the connection. Specify the timeout against the
+	// core.DEFAULT_QUERY_TIMEOUT_KEY option instead.
 	AGENS_QUERY_TIMEOUT_KEY     = "QUERY_TIMEOUT"
 	AGENS_DEFAULT_QUERY_TIMEOUT = 50 * time.Second
+	AGENS_PROPERTY_KEY_CASE_KEY = "propertyKeyCase"
+	// AGENS_GRAPH_KEY is the connection option key used to specify the graph against which operations are executed
+	// when the context does not specify a graph using ContextKeyGraphName
+	AGENS_GRAPH_KEY = "graph"
+)
+
+// PropertyKeyCase defines the transformation applied by the connection to property keys of vertices and edges.
+//
+// The transformation is applied consistently to the properties being stored, the selectors and filters
+// used to query and the properties read back from the database. This ensures that the mapper sees
+// predictable property keys irrespective of how the keys were specified by the application.
+type PropertyKeyCase int8
+
+const (
+	// PropertyKeyCaseIdentity retains the property keys as is
+	PropertyKeyCaseIdentity PropertyKeyCase = iota
+	// PropertyKeyCaseLower converts the property keys to lower case
+	PropertyKeyCaseLower
+	// PropertyKeyCaseUpper converts the property keys to upper case
+	PropertyKeyCaseUpper
 )
 
 // query options is a simple struct to accumlate all settings required
 // to execute the SQL query against the underlying database
 type queryOptions struct {
-	timeout         int64
+	timeout         time.Duration
 	txOpts          *sql.TxOptions
 	writeModeCreate bool
 }
@@ -52,7 +83,8 @@ type queryOptions struct {
 // connection is equivalent to connecting to a PostgreSQL instance.
 // Agensgraph stores multiple graphs with a schema created for each defined graph.
 // Hence the graph name must be specified when executing an operation within the context
-// as a string value using the ContextKeyGraphName key.
+// as a string value using the ContextKeyGraphName key. Applications using a single graph can instead specify the
+// graph when creating the connection using the AGENS_GRAPH_KEY option.
 //
 // Compared to other graph databases such as Neo4J, Agensgraph does not allow the MERGE
 // operation to create new node or vertex labels. Only CREATE operations can create non-existent
@@ -60,18 +92,36 @@ type queryOptions struct {
 // Hence applications must provide the correct semantics during a write operation on whether
 // the generated cypher query must use CREATE or MERGE clause. By default the write operation
 // would be done using a MERGE. This behavior can be overrriden by specifying a boolean
-// value of true aganst the context key ContextKeyWriteModeCreate
+// value of true aganst the context key ContextKeyWriteModeCreate, or by specifying core.Create as the write mode
+// using core.WithWriteMode, which takes precedence.
 //
 // Isolation levels can be specified by specifying the correct
 // sql.IsolationLevel valyes against the context key  ContextKeyIsolationLevel key. Defaults
 // to the default isolation provided by the database if not specified
 //
+// A core.Consistency value specified against the core.ContextKeyConsistency context key is mapped to an
+// isolation level. core.ConsistencyStrong uses the serializable isolation level. An isolation level specified
+// using ContextKeyIsolationLevel takes precedence over the consistency level.
+//
 // Read only transactions can be created by specifying a boolean value of true against
 // the context key ContextKeyReadOnly. Defaults to false.
 //
 // [Agensgraph]: https://github.com/bitnine-oss/agensgraph
 type AgensGraphConnection struct {
-	db *sql.DB
+	db              *sql.DB
+	propertyKeyCase PropertyKeyCase
+	labelCase       core.LabelCase
+	// clock measures query timeouts. The system clock is used if not set
+	clock core.Clock
+	// maxRows is the maximum number of rows a query may return. Any number of rows is allowed if 0
+	maxRows int
+	// defaultGraph is the graph used when the context does not specify a graph
+	defaultGraph string
+	// queryTimeout is the timeout of the queries not specifying a timeout within the context. Queries are not timed
+	// out if 0
+	queryTimeout time.Duration
+	// tx is set when the connection is bound to a transaction
+	tx *sql.Tx
 }
 
 // QueryVertex returns a vertex from the graph for the specified label
@@ -80,14 +130,11 @@ type AgensGraphConnection struct {
 //
 // filters are used to filter out the results from the set of selected nodes
 func (agc *AgensGraphConnection) QueryVertex(ctx context.Context, label string, selectors core.KVMap, filters core.KVMap, queryParams core.KVMap) ([]*core.Vertex, error) {
-	vqb := cypher.NewVertexQueryBuilder()
-	vqb.SetQueryMode(core.Read)
-	vqb.SetLabel([]string{label})
-	vqb.SetSelector(selectors)
-	vqb.SetFilters(filters)
-	vqb.SetVarName("v")
-
-	query, err := vqb.Build()
+	limit, _, err := core.SplitLimit(queryParams)
+	if err != nil {
+		return nil, err
+	}
+	query, err := agc.buildVertexQuery(label, selectors, filters, limit)
 
 	if err != nil {
 		return nil, err
@@ -101,7 +148,7 @@ func (agc *AgensGraphConnection) QueryVertex(ctx context.Context, label string,
 	}
 
 	for _, row := range qr.Rows {
-		var agVertex ag.BasicVertex
+		var agVertex vertexEntity
 		err = ag.ScanEntity(row["v"], &agVertex)
 		if err != nil {
 			return nil, err
@@ -112,28 +159,77 @@ func (agc *AgensGraphConnection) QueryVertex(ctx context.Context, label string,
 	return vertices, nil
 }
 
+// QueryVertexFunc invokes the specified function for each of the vertices with the specified label matching the
+// selectors and filters. The rows are scanned as the function consumes them, hence the vertices are never held in
+// memory together.
+//
+// The iteration stops at the first error returned by the function, which is returned as is. The number of vertices
+// is not limited by the maximum number of rows configured on the connection.
+func (agc *AgensGraphConnection) QueryVertexFunc(ctx context.Context, label string, selectors, filters core.KVMap, fn func(*core.Vertex) error) error {
+	query, err := agc.buildVertexQuery(label, selectors, filters, 0)
+	if err != nil {
+		return err
+	}
+	return agc.execute(ctx, query, core.Read, nil, func(ctx context.Context, tx *sql.Tx, query string) error {
+		_, err := scanRows(ctx, tx, query, func(row core.Row) error {
+			var agVertex vertexEntity
+			if err := ag.ScanEntity(row["v"], &agVertex); err != nil {
+				return err
+			}
+			return fn(agc.agVertexToVertex(&agVertex))
+		})
+		return err
+	})
+}
+
+func (agc *AgensGraphConnection) buildVertexQuery(label string, selectors, filters core.KVMap, limit int64) (string, error) {
+	vqb := cypher.NewVertexQueryBuilder()
+	vqb.SetQueryMode(core.Read)
+	vqb.SetLabel([]string{label})
+	vqb.SetSelector(agc.transformKeys(selectors))
+	vqb.SetFilters(agc.transformKeys(filters))
+	vqb.SetVarName("v")
+	vqb.SetLimit(limit)
+	return vqb.Build()
+}
+
 // QueryEdge returns a set of edges for the specified label
 //
 // selctors are required to select a particular relationship within the graph. If selectors are not specified, then all edges in the graph
 // with the specified labels would be selected
 //
+// If the label is empty, then relationships of all types between the start and end vertices are selected.
+//
 // filters are used to filter out the results from the set of selected edges
 //
 // The level of detail about the start and end nodes of an edge  can be controled by the fetch mode. Currently, the library
 // supports returning edges where-in the ids of the start and end vertices of the relations are available.
 func (agc *AgensGraphConnection) QueryEdge(ctx context.Context, startVertexLabel []string, endVertexLabel []string, label string, startVertexSelectors core.KVMap, endVertexSelectors core.KVMap, selectors core.KVMap, startVertexFilters core.KVMap, endVertexFilters core.KVMap, filters core.KVMap, queryParams core.KVMap, fetchMode core.EdgeFetchMode) ([]*core.Edge, error) {
+	paging, _, err := core.SplitPaging(queryParams)
+	if err != nil {
+		return nil, err
+	}
 	edgeQueryBuilder := cypher.NewEdgeQueryBuilder()
 	edgeQueryBuilder.SetEdgeFetchMode(fetchMode)
 	edgeQueryBuilder.SetStartVertexLabels(startVertexLabel)
 	edgeQueryBuilder.SetEndVertexLabels(endVertexLabel)
-	edgeQueryBuilder.SetLabel([]string{label})
-	edgeQueryBuilder.SetStartVertexSelector(startVertexSelectors)
-	edgeQueryBuilder.SetEndVertexSelector(endVertexSelectors)
-	edgeQueryBuilder.SetSelector(selectors)
-	edgeQueryBuilder.SetStartVertexFilters(startVertexFilters)
-	edgeQueryBuilder.SetEndVertexFilters(endVertexFilters)
-	edgeQueryBuilder.SetFilters(filters)
+	if len(label) > 0 {
+		edgeQueryBuilder.SetLabel([]string{label})
+	} else {
+		// an empty label selects relationships of all types
+		edgeQueryBuilder.SetAnyLabel(true)
+	}
+	edgeQueryBuilder.SetStartVertexSelector(agc.transformKeys(startVertexSelectors))
+	edgeQueryBuilder.SetEndVertexSelector(agc.transformKeys(endVertexSelectors))
+	edgeQueryBuilder.SetSelector(agc.transformKeys(selectors))
+	edgeQueryBuilder.SetStartVertexFilters(agc.transformKeys(startVertexFilters))
+	edgeQueryBuilder.SetEndVertexFilters(agc.transformKeys(endVertexFilters))
+	edgeQueryBuilder.SetFilters(agc.transformKeys(filters))
 	edgeQueryBuilder.SetVariableName("r")
+	edgeQueryBuilder.SetLimit(paging.Limit)
+	edgeQueryBuilder.SetSkip(paging.Skip)
+	edgeQueryBuilder.SetOrderByID(paging.OrderByID)
+	edgeQueryBuilder.SetOrderBy(agc.transformOrderBy(paging.OrderBy)...)
 	if fetchMode == core.EdgeWithCompleteVertex {
 		edgeQueryBuilder.SetStartVertexVariableName("sv")
 		edgeQueryBuilder.SetEndVertexVariableName("ev")
@@ -144,24 +240,31 @@ func (agc *AgensGraphConnection) QueryEdge(ctx context.Context, startVertexLabel
 	if err != nil {
 		return nil, err
 	}
-	qr, err := agc.ExecuteQuery(ctx, query, core.Read, filters)
+	// the filters are rendered within the query by the builder
+	qr, err := agc.ExecuteQuery(ctx, query, core.Read, nil)
 	if err != nil {
 		return nil, err
 	}
 	edges := make([]*core.Edge, 0)
 	for _, row := range qr.Rows {
-		var agEdge ag.BasicEdge
+		var agEdge edgeEntity
 		err := ag.ScanEntity(row["r"], &agEdge)
 		if err != nil {
 			return nil, err
 		}
-		var agSrcVertex, agDestVertex *ag.BasicVertex
+		var agSrcVertex, agDestVertex *vertexEntity
 
 		if fetchMode == core.EdgeWithCompleteVertex {
-			agSrcVertex = new(ag.BasicVertex)
-			agDestVertex = new(ag.BasicVertex)
-			ag.ScanEntity(row["sv"], agSrcVertex)
-			ag.ScanEntity(row["ev"], agDestVertex)
+			agSrcVertex = new(vertexEntity)
+			agDestVertex = new(vertexEntity)
+			err = ag.ScanEntity(row["sv"], agSrcVertex)
+			if err != nil {
+				return nil, err
+			}
+			err = ag.ScanEntity(row["ev"], agDestVertex)
+			if err != nil {
+				return nil, err
+			}
 		}
 		e := agc.agEdgeToEdge(&agEdge, agSrcVertex, agDestVertex)
 		edges = append(edges, e)
@@ -169,6 +272,104 @@ func (agc *AgensGraphConnection) QueryEdge(ctx context.Context, startVertexLabel
 	return edges, nil
 }
 
+// QueryConnectedVertices returns the distinct start and end vertices of the edges selected using the specified labels,
+// selectors and filters.
+func (agc *AgensGraphConnection) QueryConnectedVertices(ctx context.Context, startVertexLabel, endVertexLabel []string, label string, startVertexSelectors, endVertexSelectors, selectors core.KVMap, startVertexFilters, endVertexFilters, filters core.KVMap, queryParams core.KVMap) ([]*core.Vertex, error) {
+	edges, err := agc.QueryEdge(ctx, startVertexLabel, endVertexLabel, label, startVertexSelectors, endVertexSelectors, selectors, startVertexFilters, endVertexFilters, filters, queryParams, core.EdgeWithCompleteVertex)
+	if err != nil {
+		return nil, err
+	}
+	return core.DistinctEndpoints(edges), nil
+}
+
+// QuerySubgraph executes the specified read query and returns the distinct vertices and edges returned by the
+// query, including the vertices and edges of the returned paths.
+//
+// Lists of vertices or edges returned within a column are not decoded and are hence ignored.
+func (agc *AgensGraphConnection) QuerySubgraph(ctx context.Context, query string, queryParams core.KVMap) (*core.Subgraph, error) {
+	qr, err := agc.ExecuteQuery(ctx, query, core.Read, queryParams)
+	if err != nil {
+		return nil, err
+	}
+	vertices := make([]*core.Vertex, 0)
+	edges := make([]*core.Edge, 0)
+	for _, row := range qr.Rows {
+		for _, column := range qr.Columns {
+			value, ok := row[column].([]byte)
+			if !ok || len(value) == 0 {
+				continue
+			}
+			// values of other types fail to scan as graph entities and are skipped
+			if value[0] == '[' {
+				var agPath pathEntity
+				if err := ag.ScanPath(value, &agPath); err == nil {
+					for i := range agPath.Vertices {
+						vertices = append(vertices, agc.agVertexToVertex(&agPath.Vertices[i]))
+					}
+					for i := range agPath.Edges {
+						edges = append(edges, agc.agEdgeToEdge(&agPath.Edges[i], nil, nil))
+					}
+				}
+				continue
+			}
+			var agVertex vertexEntity
+			if err := ag.ScanEntity(value, &agVertex); err == nil {
+				vertices = append(vertices, agc.agVertexToVertex(&agVertex))
+				continue
+			}
+			var agEdge edgeEntity
+			if err := ag.ScanEntity(value, &agEdge); err == nil {
+				edges = append(edges, agc.agEdgeToEdge(&agEdge, nil, nil))
+			}
+		}
+	}
+	return core.NewSubgraph(vertices, edges), nil
+}
+
+// QueryPaths returns the distinct pairs of start and end vertices connected by a path of edges with the specified label
+//
+// The path traverses at least minHops and at most maxHops edges. A maxHops of 0 traverses any number of edges.
+func (agc *AgensGraphConnection) QueryPaths(ctx context.Context, startVertexLabel, endVertexLabel []string, label string, startVertexSelectors, endVertexSelectors, selectors core.KVMap, minHops, maxHops int) ([]*core.Path, error) {
+	edgeQueryBuilder := cypher.NewEdgeQueryBuilder()
+	edgeQueryBuilder.SetEdgeFetchMode(core.EdgeWithCompleteVertex)
+	edgeQueryBuilder.SetStartVertexLabels(startVertexLabel)
+	edgeQueryBuilder.SetEndVertexLabels(endVertexLabel)
+	edgeQueryBuilder.SetLabel([]string{label})
+	edgeQueryBuilder.SetStartVertexSelector(agc.transformKeys(startVertexSelectors))
+	edgeQueryBuilder.SetEndVertexSelector(agc.transformKeys(endVertexSelectors))
+	edgeQueryBuilder.SetSelector(agc.transformKeys(selectors))
+	edgeQueryBuilder.SetHops(minHops, maxHops)
+	edgeQueryBuilder.SetVariableName("r")
+	edgeQueryBuilder.SetStartVertexVariableName("sv")
+	edgeQueryBuilder.SetEndVertexVariableName("ev")
+
+	query, err := edgeQueryBuilder.Build()
+	if err != nil {
+		return nil, err
+	}
+	qr, err := agc.ExecuteQuery(ctx, query, core.Read, nil)
+	if err != nil {
+		return nil, err
+	}
+	paths := make([]*core.Path, 0)
+	for _, row := range qr.Rows {
+		var agSrcVertex, agDestVertex vertexEntity
+		err = ag.ScanEntity(row["sv"], &agSrcVertex)
+		if err != nil {
+			return nil, err
+		}
+		err = ag.ScanEntity(row["ev"], &agDestVertex)
+		if err != nil {
+			return nil, err
+		}
+		paths = append(paths, &core.Path{
+			StartVertex: agc.agVertexToVertex(&agSrcVertex),
+			EndVertex:   agc.agVertexToVertex(&agDestVertex),
+		})
+	}
+	return paths, nil
+}
+
 // ExecuteReadQuery executes a query and transforms the native result set obtained from the DB to a QueryResult using the specified transform function
 //
 // The specified query must be a valid Cypher or Gremlin query.
@@ -179,38 +380,210 @@ func (agc *AgensGraphConnection) QueryEdge(ctx context.Context, startVertexLabel
 //
 // The queryParams parameter can be used to inject dynamic data into the query. This is an optional argument and can be nil
 //
-// The context can contain additional query and session configuration parameters required for execution
+// The context can contain additional query and session configuration parameters required for execution. If query
+// debugging is enabled within the context using core.WithDebugQueries, errors are returned wrapped within a
+// core.QueryError identifying the query.
+//
+// Connections closed by the server or a proxy while idle are reported by the driver as driver.ErrBadConn. The
+// transaction of the query is then retried once on a new connection, unless the connection is bound to a
+// transaction.
 func (agc *AgensGraphConnection) ExecuteQuery(ctx context.Context, query string, mode core.QueryMode, queryParams map[string]interface{}) (*core.QueryResult, error) {
+	var queryResult *core.QueryResult
+	run := func(ctx context.Context, tx *sql.Tx, query string) error {
+		var err error
+		queryResult, err = agc.runQuery(ctx, tx, query)
+		return err
+	}
+	err := agc.execute(ctx, query, mode, queryParams, run)
+	if errors.Is(err, driver.ErrBadConn) && agc.tx == nil {
+		// the transaction was rolled back along with the bad connection, which is discarded by the pool
+		err = agc.execute(ctx, query, mode, queryParams, run)
+	}
+	if err != nil {
+		return nil, core.WrapQueryError(ctx, err, query, mode)
+	}
+	return queryResult, nil
+}
 
+// execute invokes the specified function with the transaction the query must be run in and the final query selecting
+// the graph specified within the context, or the default graph of the connection. The transaction is committed if the
+// function returns successfully and rolled back otherwise, unless the connection is bound to a transaction.
+func (agc *AgensGraphConnection) execute(ctx context.Context, query string, mode core.QueryMode, queryParams map[string]interface{}, fn func(ctx context.Context, tx *sql.Tx, query string) error) error {
 	graphName, ok := ctx.Value(ContextKeyGraphName).(string)
-	if !ok {
-		return nil, errors.New("graph name must be specified")
+	if !ok || graphName == "" {
+		graphName = agc.defaultGraph
+	}
+	if graphName == "" {
+		return errors.New("graph name must be specified")
+	}
+
+	err := core.ValidateQueryParamsFromContext(ctx, query, queryParams)
+	if err != nil {
+		return err
 	}
 
 	qopts := agc.queryOptionsFromContext(ctx, mode)
 
 	finalQuery := fmt.Sprintf("set graph_path=%s;%s", graphName, query)
 
-	// txContext, cancel := context.WithTimeout(ctx, time.Duration(qopts.timeout))
-	// defer cancel()
+	if qopts.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = core.WithTimeout(ctx, agc.clockOrDefault(), qopts.timeout)
+		defer cancel()
+	}
+
+	if agc.tx != nil {
+		return fn(ctx, agc.tx, finalQuery)
+	}
+
 	tx, err := agc.db.BeginTx(ctx, qopts.txOpts)
 
+	if err != nil {
+		return err
+	}
+	if err = fn(ctx, tx, finalQuery); err != nil {
+		tx.Rollback()
+		return err
+	}
+	// the transaction has already been rolled back if the timeout elapsed after the function returned
+	return tx.Commit()
+}
+
+// ExecuteQueryTyped executes a query returning a single row and scans the columns of the row into dest.
+//
+// Agensgraph returns the raw text of every column. The text is decoded as JSON before scanning, with integral numbers
+// decoded as int64 values. Text that is not valid JSON is scanned as a string.
+func (agc *AgensGraphConnection) ExecuteQueryTyped(ctx context.Context, query string, mode core.QueryMode, queryParams map[string]interface{}, dest ...interface{}) error {
+	result, err := agc.executeQueryDecoded(ctx, query, mode, queryParams)
+	if err != nil {
+		return err
+	}
+	return core.ScanRow(result, dest...)
+}
+
+// QueryScalarInt executes a read query returning a single integer value. The raw text of the value is decoded as
+// JSON, similar to ExecuteQueryTyped.
+func (agc *AgensGraphConnection) QueryScalarInt(ctx context.Context, query string, queryParams map[string]interface{}) (int64, error) {
+	result, err := agc.executeQueryDecoded(ctx, query, core.Read, queryParams)
+	if err != nil {
+		return 0, err
+	}
+	return core.ScanInt64(result)
+}
+
+// QueryScalarFloat executes a read query returning a single numeric value. The raw text of the value is decoded as
+// JSON, similar to ExecuteQueryTyped.
+func (agc *AgensGraphConnection) QueryScalarFloat(ctx context.Context, query string, queryParams map[string]interface{}) (float64, error) {
+	result, err := agc.executeQueryDecoded(ctx, query, core.Read, queryParams)
+	if err != nil {
+		return 0, err
+	}
+	return core.ScanFloat64(result)
+}
+
+// executeQueryDecoded executes the query and decodes the raw text of every column of the returned rows as JSON
+func (agc *AgensGraphConnection) executeQueryDecoded(ctx context.Context, query string, mode core.QueryMode, queryParams map[string]interface{}) (*core.QueryResult, error) {
+	result, err := agc.ExecuteQuery(ctx, query, mode, queryParams)
 	if err != nil {
 		return nil, err
 	}
-	rows, err := tx.QueryContext(ctx, finalQuery)
+	for _, row := range result.Rows {
+		for column, value := range row {
+			row[column] = decodeValue(value.([]byte))
+		}
+	}
+	return result, nil
+}
 
+// ExecuteQueryNormalized executes a query and returns the result with the values of the rows normalized to plain Go
+// values.
+//
+// Agensgraph returns the raw text of every column. Vertices, edges and paths are decoded from the text while all other
+// values are decoded as JSON, with integral numbers decoded as int64 values. Text that is not valid JSON is returned
+// as a string.
+func (agc *AgensGraphConnection) ExecuteQueryNormalized(ctx context.Context, query string, mode core.QueryMode, queryParams map[string]interface{}) (*core.QueryResult, error) {
+	result, err := agc.ExecuteQuery(ctx, query, mode, queryParams)
 	if err != nil {
-		tx.Rollback()
 		return nil, err
 	}
+	return result.Normalized(agc.ConvertValue), nil
+}
 
-	defer rows.Close()
+// ConvertValue decodes the raw text of a column returned by a query to a core value which can be normalized. Vertices
+// and edges are decoded to core vertices and edges.
+func (agc *AgensGraphConnection) ConvertValue(value interface{}) (interface{}, bool) {
+	b, ok := value.([]byte)
+	if !ok {
+		return value, false
+	}
+	if len(b) > 0 && b[0] == '[' {
+		var agPath pathEntity
+		if err := ag.ScanPath(b, &agPath); err == nil {
+			vertices := make([]*core.Vertex, 0, len(agPath.Vertices))
+			for i := range agPath.Vertices {
+				vertices = append(vertices, agc.agVertexToVertex(&agPath.Vertices[i]))
+			}
+			edges := make([]*core.Edge, 0, len(agPath.Edges))
+			for i := range agPath.Edges {
+				edges = append(edges, agc.agEdgeToEdge(&agPath.Edges[i], nil, nil))
+			}
+			return map[string]interface{}{"vertices": vertices, "edges": edges}, true
+		}
+	}
+	var agVertex vertexEntity
+	if err := ag.ScanEntity(b, &agVertex); err == nil {
+		return agc.agVertexToVertex(&agVertex), true
+	}
+	var agEdge edgeEntity
+	if err := ag.ScanEntity(b, &agEdge); err == nil {
+		return agc.agEdgeToEdge(&agEdge, nil, nil), true
+	}
+	return decodeValue(b), true
+}
+
+// runQuery runs the specified query and collects the returned rows. Agensgraph does not report a summary of the query,
+// hence the summary of the result only contains the timings measured on the clock of the connection.
+func (agc *AgensGraphConnection) runQuery(ctx context.Context, tx *sql.Tx, query string) (*core.QueryResult, error) {
 	queryResult := core.QueryResult{}
+	clock := agc.clockOrDefault()
+	start := clock.Now()
+	var available time.Time
+	keys, err := scanRows(ctx, tx, query, func(row core.Row) error {
+		if available.IsZero() {
+			available = clock.Now()
+		}
+		if err := core.CheckMaxRows(len(queryResult.Rows)+1, agc.maxRows); err != nil {
+			return err
+		}
+		queryResult.Rows = append(queryResult.Rows, row)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	consumed := clock.Now()
+	if available.IsZero() {
+		available = consumed
+	}
+	queryResult.Columns = keys
+	queryResult.Summary = &core.QuerySummary{ResultAvailableAfter: available.Sub(start), ResultConsumedAfter: consumed.Sub(available)}
+	return &queryResult, nil
+}
+
+// scanRows runs the specified query and invokes the specified function with each of the returned rows, as the rows
+// are read. The raw text of each column is copied into the row. Scanning stops at the first error returned by the
+// function or encountered while reading the rows. The names of the columns returned by the query are returned.
+func scanRows(ctx context.Context, tx *sql.Tx, query string, fn func(core.Row) error) ([]string, error) {
+	rows, err := tx.QueryContext(ctx, query)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
 
 	keys, err := rows.Columns()
 	if err != nil {
-		tx.Rollback()
 		return nil, err
 	}
 	vals := make([]interface{}, len(keys))
@@ -220,7 +593,9 @@ func (agc *AgensGraphConnection) ExecuteQuery(ctx context.Context, query string,
 		vals[i] = &rawResults[i]
 	}
 	for rows.Next() {
-		rows.Scan(vals...)
+		if err = rows.Scan(vals...); err != nil {
+			return nil, err
+		}
 		currentRawRow := make([]sql.RawBytes, len(keys))
 		copy(currentRawRow, rawResults)
 		m := make(core.Row)
@@ -229,10 +604,31 @@ func (agc *AgensGraphConnection) ExecuteQuery(ctx context.Context, query string,
 			copy(data, currentRawRow[i])
 			m[key] = data
 		}
-		queryResult.Rows = append(queryResult.Rows, m)
+		if err = fn(m); err != nil {
+			return nil, err
+		}
+	}
+	// the rows stop early if the query fails while the rows are read, e.g. once the query times out
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return keys, nil
+}
+
+// Capabilities returns the features supported by Agensgraph connections. The graph against which a query is executed
+// is selected using the ContextKeyGraphName context key, falling back to the graph specified using the AGENS_GRAPH_KEY
+// option.
+func (agc *AgensGraphConnection) Capabilities() core.Capabilities {
+	return core.Capabilities{
+		DirectedEdges:     true,
+		MultipleDatabases: true,
+		Transactions:      true,
 	}
-	tx.Commit()
-	return &queryResult, nil
+}
+
+// Ping verifies that a connection to the Postgres database backing Agensgraph can be established.
+func (agc *AgensGraphConnection) Ping(ctx context.Context) error {
+	return agc.db.PingContext(ctx)
 }
 
 // Close closes the connection to a database.
@@ -249,11 +645,17 @@ func (agc *AgensGraphConnection) Close(ctx context.Context) error {
 // Upon successful storage, the passed in vertex object's ID field would be set to the ID returned by the database.
 // Returns an error if there is a failure when persisting the vertex
 func (agc *AgensGraphConnection) StoreVertex(ctx context.Context, vertex *core.Vertex) error {
+	if _, ok := core.CreatedTimestampProperty(ctx); ok {
+		return errors.New("server timestamps are not supported by Agensgraph")
+	}
+	if _, ok := core.VertexIdentityPropertiesFromContext(ctx); ok {
+		return errors.New("vertex identity properties are not supported by Agensgraph")
+	}
 	qopts := agc.queryOptionsFromContext(ctx, core.Write)
 	vqb := cypher.NewVertexQueryBuilder()
 	vqb.SetQueryMode(core.Write)
 	vqb.SetLabel(vertex.Labels)
-	vqb.SetSelector(vertex.Properties)
+	vqb.SetSelector(agc.transformKeys(vertex.Properties))
 	vqb.SetVarName("sv")
 	if qopts.writeModeCreate {
 		vqb.SetWriteMode(core.Create)
@@ -273,7 +675,7 @@ func (agc *AgensGraphConnection) StoreVertex(ctx context.Context, vertex *core.V
 	}
 	// support only a single vertex store at a time. hence consider only the first returned row
 	row := qr.Rows[0]
-	var agVertex ag.BasicVertex
+	var agVertex vertexEntity
 	err = ag.ScanEntity(row["sv"], &agVertex)
 	if err != nil {
 		return err
@@ -284,6 +686,20 @@ func (agc *AgensGraphConnection) StoreVertex(ctx context.Context, vertex *core.V
 	return nil
 }
 
+// StoreVerticesDistinct stores the specified vertices within a single transaction.
+func (agc *AgensGraphConnection) StoreVerticesDistinct(ctx context.Context, vertices []*core.Vertex) error {
+	if agc.tx != nil {
+		return core.StoreEachVertex(ctx, agc, vertices)
+	}
+	return core.StoreVerticesInTx(ctx, agc, vertices)
+}
+
+// StoreVertices creates the specified vertices within a single transaction. Queries against Agensgraph cannot be
+// passed parameters to UNWIND, hence the vertices are created one at a time using StoreVertex.
+func (agc *AgensGraphConnection) StoreVertices(ctx context.Context, vertices []*core.Vertex) error {
+	return agc.StoreVerticesDistinct(core.WithWriteMode(ctx, core.Create), vertices)
+}
+
 // StoreEdge stores a connected component to the graph database. It can be used to create a new relation
 // between two vertices or update the properties for an existing relation
 //
@@ -297,14 +713,16 @@ func (agc *AgensGraphConnection) StoreEdge(ctx context.Context, edge *core.Edge)
 		return errors.New("source node must be specified for vertex connectivity")
 	}
 
+	startVertexMode, endVertexMode := core.EndpointModesFromContext(ctx)
+
 	eqb := cypher.NewEdgeQueryBuilder()
 	eqb.SetQueryMode(core.Write)
-	eqb.SetStartVertexSelector(edge.SourceVertex.Properties)
+	eqb.SetStartVertexSelector(agc.transformKeys(edge.SourceVertex.Properties))
 	eqb.SetStartVertexVariableName("sv")
 	eqb.SetStartVertexLabels(edge.SourceVertex.Labels)
 
 	if edge.DestinationVertex != nil {
-		eqb.SetEndVertexSelector(edge.DestinationVertex.Properties)
+		eqb.SetEndVertexSelector(agc.transformKeys(edge.DestinationVertex.Properties))
 		eqb.SetEndVertexVariableName("ev")
 		eqb.SetEndVertexLabels(edge.DestinationVertex.Labels)
 	}
@@ -312,7 +730,18 @@ func (agc *AgensGraphConnection) StoreEdge(ctx context.Context, edge *core.Edge)
 	eqb.SetEdgeFetchMode(core.EdgeWithCompleteVertex)
 	eqb.SetLabel([]string{edge.Type})
 	eqb.SetVariableName("rel")
-	eqb.SetSelector(edge.Properties)
+	eqb.SetStartVertexMode(startVertexMode)
+	eqb.SetEndVertexMode(endVertexMode)
+	if key, ok := core.IdempotencyKeyFromContext(ctx); ok {
+		eqb.SetSelector(agc.transformKeys(core.KVMap{core.IdempotencyKeyProperty: key}))
+		eqb.SetOnCreateProperties(agc.transformKeys(edge.Properties))
+	} else if identity, ok := core.EdgeIdentityPropertiesFromContext(ctx); ok {
+		identityProperties, dataProperties := core.SplitEdgeProperties(edge.Properties, identity)
+		eqb.SetSelector(agc.transformKeys(identityProperties))
+		eqb.SetDataProperties(agc.transformKeys(dataProperties))
+	} else {
+		eqb.SetSelector(agc.transformKeys(edge.Properties))
+	}
 	if qopts.writeModeCreate {
 		eqb.SetWriteMode(core.Create)
 	}
@@ -330,13 +759,16 @@ func (agc *AgensGraphConnection) StoreEdge(ctx context.Context, edge *core.Edge)
 	}
 
 	if len(qr.Rows) == 0 {
+		if startVertexMode == core.EndpointMatch || endVertexMode == core.EndpointMatch {
+			return core.ErrEndpointNotFound
+		}
 		return errors.New("unexpected error. failed to store vertex connectivity")
 	}
 
 	row := qr.Rows[0]
 
-	var agSrcVertex, agDestVertex ag.BasicVertex
-	var agEdge ag.BasicEdge
+	var agSrcVertex, agDestVertex vertexEntity
+	var agEdge edgeEntity
 
 	err = ag.ScanEntity(row["sv"], &agSrcVertex)
 	if err != nil {
@@ -357,15 +789,316 @@ func (agc *AgensGraphConnection) StoreEdge(ctx context.Context, edge *core.Edge)
 	return nil
 }
 
+// AddLabels is not supported by Agensgraph since a vertex belongs to exactly one vertex label.
+//
+// Returns core.ErrNotSupported
+func (agc *AgensGraphConnection) AddLabels(ctx context.Context, id *core.Identifier, labels []string) error {
+	return core.ErrNotSupported
+}
+
+// RemoveLabels is not supported by Agensgraph since a vertex belongs to exactly one vertex label.
+//
+// Returns core.ErrNotSupported
+func (agc *AgensGraphConnection) RemoveLabels(ctx context.Context, id *core.Identifier, labels []string) error {
+	return core.ErrNotSupported
+}
+
+// Degree returns the number of edges of the specified direction and labels connected to the vertex identified by the
+// specified graphid.
+func (agc *AgensGraphConnection) Degree(ctx context.Context, id *core.Identifier, direction core.EdgeDirection, edgeLabels []string) (int64, error) {
+	if id == nil {
+		return 0, errors.New("vertex id must be specified")
+	}
+	// the id is included within the query text and is hence validated to be a graphid
+	if !graphIdRegex.MatchString(id.String()) {
+		return 0, fmt.Errorf("invalid vertex id %s", id)
+	}
+	query, err := cypher.BuildDegreeQuery(fmt.Sprintf("id(v) = '%s'", id), direction, edgeLabels)
+	if err != nil {
+		return 0, err
+	}
+	qr, err := agc.ExecuteQuery(ctx, query, core.Read, nil)
+	if err != nil {
+		return 0, err
+	}
+	if len(qr.Rows) == 0 {
+		return 0, nil
+	}
+	degree, ok := decodeValue(qr.Rows[0][cypher.DegreeVar].([]byte)).(int64)
+	if !ok {
+		return 0, errors.New("unexpected degree returned by the query")
+	}
+	return degree, nil
+}
+
+// ExpandNeighbors reads the edges of the specified direction and labels connecting each of the vertices identified by
+// the specified graphids to their neighbors. Queries against Agensgraph cannot be passed parameters to UNWIND, hence
+// the neighbors of each of the vertices are read using a separate query.
+func (agc *AgensGraphConnection) ExpandNeighbors(ctx context.Context, ids []*core.Identifier, edgeLabels []string, direction core.EdgeDirection) (map[string][]*core.Edge, error) {
+	neighbors := make(map[string][]*core.Edge, len(ids))
+	for _, id := range ids {
+		if id == nil {
+			return nil, errors.New("vertex id must be specified")
+		}
+		// the id is included within the query text and is hence validated to be a graphid
+		if !graphIdRegex.MatchString(id.String()) {
+			return nil, fmt.Errorf("invalid vertex id %s", id)
+		}
+	}
+	for _, id := range ids {
+		query, err := cypher.BuildNeighborsQuery(fmt.Sprintf("id(v) = '%s'", id), direction, edgeLabels)
+		if err != nil {
+			return nil, err
+		}
+		qr, err := agc.ExecuteQuery(ctx, query, core.Read, nil)
+		if err != nil {
+			return nil, err
+		}
+		edges := make([]*core.Edge, 0, len(qr.Rows))
+		for _, row := range qr.Rows {
+			var agEdge edgeEntity
+			if err = ag.ScanEntity(row[cypher.NeighborEdgeVar], &agEdge); err != nil {
+				return nil, err
+			}
+			var agNeighbor vertexEntity
+			if err = ag.ScanEntity(row[cypher.NeighborVar], &agNeighbor); err != nil {
+				return nil, err
+			}
+			e := agc.agEdgeToEdge(&agEdge, nil, nil)
+			e.SetEndpoint(agc.agVertexToVertex(&agNeighbor))
+			edges = append(edges, e)
+		}
+		neighbors[id.String()] = edges
+	}
+	return neighbors, nil
+}
+
+// DeleteVertex detaches and deletes the vertices with the specified label selected by the specified selectors within
+// the graph selected by the context, and returns the number of deleted vertices.
+//
+// The graphids of the deleted vertices and edges are added to the DeletedIds specified within the context.
+func (agc *AgensGraphConnection) DeleteVertex(ctx context.Context, label string, selectors core.KVMap) (int, error) {
+	if err := core.ValidateDeleteSelector(ctx, selectors); err != nil {
+		return 0, err
+	}
+	deletedIds, collectIds := core.DeletedIdsFromContext(ctx)
+	idFunction := ""
+	if collectIds {
+		idFunction = "id"
+	}
+	query, _, err := cypher.BuildDeleteVertexQuery(label, agc.transformKeys(selectors), idFunction, false)
+	if err != nil {
+		return 0, err
+	}
+	qr, err := agc.ExecuteQuery(ctx, query, core.Write, nil)
+	if err != nil {
+		return 0, err
+	}
+	if !collectIds {
+		if len(qr.Rows) == 0 {
+			return 0, nil
+		}
+		deleted, ok := decodeValue(qr.Rows[0][cypher.DeletedCountVar].([]byte)).(int64)
+		if !ok {
+			return 0, errors.New("unexpected count of deleted vertices returned by the query")
+		}
+		return int(deleted), nil
+	}
+	// a row is returned for each of the edges of a vertex, and an edge between two deleted vertices is returned for
+	// both the vertices
+	vertexIds := make(map[string]struct{})
+	edgeIds := make(map[string]struct{})
+	for _, row := range qr.Rows {
+		vertexId := decodeText(row[cypher.DeletedVertexIdVar].([]byte))
+		if _, ok := vertexIds[vertexId]; !ok {
+			vertexIds[vertexId] = struct{}{}
+			deletedIds.Add(core.NewId(vertexId))
+		}
+		edgeId, _ := row[cypher.DeletedEdgeIdVar].([]byte)
+		if len(edgeId) == 0 {
+			continue
+		}
+		if _, ok := edgeIds[string(edgeId)]; !ok {
+			edgeIds[string(edgeId)] = struct{}{}
+			deletedIds.Add(core.NewId(decodeText(edgeId)))
+		}
+	}
+	return len(vertexIds), nil
+}
+
+// DeleteEdge deletes the edges with the specified label between vertices having the specified start and end labels,
+// selected by the specified selectors within the graph selected by the context, and returns the number of deleted
+// edges. The start and end vertices are not deleted.
+//
+// The graphids of the deleted edges are added to the DeletedIds specified within the context.
+func (agc *AgensGraphConnection) DeleteEdge(ctx context.Context, startLabels, endLabels []string, edgeLabel string, startSelectors, endSelectors, edgeSelectors core.KVMap) (int, error) {
+	if err := core.ValidateDeleteSelector(ctx, startSelectors, endSelectors, edgeSelectors); err != nil {
+		return 0, err
+	}
+	deletedIds, collectIds := core.DeletedIdsFromContext(ctx)
+	eqb := cypher.NewEdgeQueryBuilder()
+	eqb.SetQueryMode(core.Delete)
+	eqb.SetStartVertexLabels(startLabels)
+	eqb.SetStartVertexVariableName("sv")
+	eqb.SetStartVertexSelector(agc.transformKeys(startSelectors))
+	eqb.SetEndVertexLabels(endLabels)
+	eqb.SetEndVertexVariableName("ev")
+	eqb.SetEndVertexSelector(agc.transformKeys(endSelectors))
+	if len(edgeLabel) > 0 {
+		eqb.SetLabel([]string{edgeLabel})
+	} else {
+		// an empty label selects edges of all labels
+		eqb.SetAnyLabel(true)
+	}
+	eqb.SetVariableName("r")
+	eqb.SetSelector(agc.transformKeys(edgeSelectors))
+	if collectIds {
+		eqb.SetDeletedIdFunction("id")
+	}
+	query, err := eqb.Build()
+	if err != nil {
+		return 0, err
+	}
+	qr, err := agc.ExecuteQuery(ctx, query, core.Write, nil)
+	if err != nil {
+		return 0, err
+	}
+	if collectIds {
+		for _, row := range qr.Rows {
+			deletedIds.Add(core.NewId(decodeText(row[cypher.DeletedEdgeIdVar].([]byte))))
+		}
+		return len(qr.Rows), nil
+	}
+	if len(qr.Rows) == 0 {
+		return 0, nil
+	}
+	deleted, ok := decodeValue(qr.Rows[0][cypher.DeletedCountVar].([]byte)).(int64)
+	if !ok {
+		return 0, errors.New("unexpected count of deleted edges returned by the query")
+	}
+	return int(deleted), nil
+}
+
+// LabelCombinations returns the distinct labels of the vertices of the graph selected by the context. Agensgraph
+// vertices have a single label, hence each of the returned combinations contains a single label.
+func (agc *AgensGraphConnection) LabelCombinations(ctx context.Context) ([][]string, error) {
+	qr, err := agc.ExecuteQuery(ctx, cypher.BuildLabelCombinationsQuery("label"), core.Read, nil)
+	if err != nil {
+		return nil, err
+	}
+	combinations := make([][]string, 0, len(qr.Rows))
+	for _, row := range qr.Rows {
+		combinations = append(combinations, []string{agc.labelCase.Apply(decodeText(row[cypher.LabelsVar].([]byte)))})
+	}
+	return core.DistinctLabelCombinations(combinations), nil
+}
+
+// RelationshipTypesBetween returns the distinct types of the relationships from vertices having the specified
+// start labels to vertices having the specified end labels.
+func (agc *AgensGraphConnection) RelationshipTypesBetween(ctx context.Context, startLabels, endLabels []string) ([]string, error) {
+	query, err := cypher.BuildRelationshipTypesQuery(startLabels, endLabels)
+	if err != nil {
+		return nil, err
+	}
+	qr, err := agc.ExecuteQuery(ctx, query, core.Read, nil)
+	if err != nil {
+		return nil, err
+	}
+	relationshipTypes := make([]string, 0, len(qr.Rows))
+	for _, row := range qr.Rows {
+		relationshipTypes = append(relationshipTypes, agc.labelCase.Apply(decodeText(row[cypher.RelationshipTypeVar].([]byte))))
+	}
+	return relationshipTypes, nil
+}
+
+// BeginTx starts a new transaction against the Agensgraph database.
+//
+// The isolation level of the transaction can be specified using the ContextKeyIsolationLevel context key.
+// The graph name must still be specified within the context of each operation executed through the
+// transaction.
+func (agc *AgensGraphConnection) BeginTx(ctx context.Context) (core.Tx, error) {
+	qopts := agc.queryOptionsFromContext(ctx, core.Write)
+	tx, err := agc.db.BeginTx(ctx, qopts.txOpts)
+	if err != nil {
+		return nil, err
+	}
+	txConnection := *agc
+	txConnection.tx = tx
+	return &agensTransaction{AgensGraphConnection: txConnection}, nil
+}
+
+// BeginSnapshot starts a new read-only transaction against the Agensgraph database.
+//
+// The transaction uses the repeatable read isolation level unless a different isolation level is specified
+// using the ContextKeyIsolationLevel context key or strong consistency is requested using the core.ContextKeyConsistency
+// context key. Hence all the queries executed through the transaction
+// observe the snapshot of the graph taken at the first query of the transaction.
+func (agc *AgensGraphConnection) BeginSnapshot(ctx context.Context) (core.Tx, error) {
+	qopts := agc.queryOptionsFromContext(ctx, core.Read)
+	if _, ok := ctx.Value(ContextKeyIsolationLevel).(sql.IsolationLevel); !ok && core.ConsistencyFromContext(ctx) != core.ConsistencyStrong {
+		qopts.txOpts.Isolation = sql.LevelRepeatableRead
+	}
+	tx, err := agc.db.BeginTx(ctx, qopts.txOpts)
+	if err != nil {
+		return nil, err
+	}
+	txConnection := *agc
+	txConnection.tx = tx
+	return &agensTransaction{AgensGraphConnection: txConnection}, nil
+}
+
+// agensTransaction is an Agensgraph connection bound to a single sql.Tx
+type agensTransaction struct {
+	AgensGraphConnection
+}
+
+func (atx *agensTransaction) Commit(ctx context.Context) error {
+	return atx.tx.Commit()
+}
+
+func (atx *agensTransaction) Rollback(ctx context.Context) error {
+	return atx.tx.Rollback()
+}
+
+// Close rolls back the transaction if it has not been completed. The database handle shared with
+// the parent connection is not closed.
+func (atx *agensTransaction) Close(ctx context.Context) error {
+	err := atx.tx.Rollback()
+	if errors.Is(err, sql.ErrTxDone) {
+		return nil
+	}
+	return err
+}
+
+func (atx *agensTransaction) BeginTx(ctx context.Context) (core.Tx, error) {
+	return nil, errors.New("nested transactions are not supported")
+}
+
+func (atx *agensTransaction) BeginSnapshot(ctx context.Context) (core.Tx, error) {
+	return nil, errors.New("nested transactions are not supported")
+}
+
+func (agc *AgensGraphConnection) clockOrDefault() core.Clock {
+	if agc.clock == nil {
+		return core.SystemClock
+	}
+	return agc.clock
+}
+
 func (agc *AgensGraphConnection) queryOptionsFromContext(ctx context.Context, queryMode core.QueryMode) *queryOptions {
-	qopts := queryOptions{timeout: int64(5 * time.Millisecond)}
+	qopts := queryOptions{timeout: agc.queryTimeout}
 	txOpts := sql.TxOptions{}
 	if timeout, ok := ctx.Value(ContextKeyQueryTimeoutMillis).(int64); ok {
+		qopts.timeout = time.Duration(timeout) * time.Millisecond
+	}
+	if timeout, ok := core.QueryTimeoutFromContext(ctx); ok {
 		qopts.timeout = timeout
 	}
 
 	if isolation, ok := ctx.Value(ContextKeyIsolationLevel).(sql.IsolationLevel); ok {
 		txOpts.Isolation = isolation
+	} else if core.ConsistencyFromContext(ctx) == core.ConsistencyStrong {
+		txOpts.Isolation = sql.LevelSerializable
 	}
 
 	if queryMode == core.Read {
@@ -375,40 +1108,73 @@ func (agc *AgensGraphConnection) queryOptionsFromContext(ctx context.Context, qu
 	if writeWithCreate, ok := ctx.Value(ContextKeyWriteModeCreate).(bool); ok {
 		qopts.writeModeCreate = writeWithCreate
 	}
+	if writeMode, ok := core.WriteModeFromContext(ctx); ok {
+		qopts.writeModeCreate = writeMode == core.Create
+	}
 	qopts.txOpts = &txOpts
 	return &qopts
 }
 
-func (agc *AgensGraphConnection) agVertexToVertex(agVertex *ag.BasicVertex) *core.Vertex {
+func (agc *AgensGraphConnection) agVertexToVertex(agVertex *vertexEntity) *core.Vertex {
 	v := new(core.Vertex)
 	v.ID = core.NewId(agVertex.Id.String())
-	v.Labels = []string{agVertex.Label}
+	v.Labels = []string{agc.labelCase.Apply(agVertex.Label)}
 	v.Properties = make(core.KVMap)
 	for prop, val := range agVertex.Properties {
-		v.Properties[prop] = val
+		v.Properties[agc.transformKey(prop)] = val
 	}
 	return v
 }
 
-func (agc *AgensGraphConnection) agEdgeToEdge(agEdge *ag.BasicEdge, srcVertex, destVertex *ag.BasicVertex) *core.Edge {
+func (agc *AgensGraphConnection) agEdgeToEdge(agEdge *edgeEntity, srcVertex, destVertex *vertexEntity) *core.Edge {
 	e := new(core.Edge)
 	e.Properties = make(core.KVMap)
 	e.ID = core.NewId(agEdge.Id.String())
-	e.Type = agEdge.Label
+	e.Type = agc.labelCase.Apply(agEdge.Label)
 	e.SourceVertexID = core.NewId(agEdge.Start.String())
 	e.DestinationVertexID = core.NewId(agEdge.End.String())
-	if srcVertex != nil {
-		e.SourceVertex = agc.agVertexToVertex(srcVertex)
-	}
-	if destVertex != nil {
-		e.DestinationVertex = agc.agVertexToVertex(destVertex)
+	if srcVertex != nil && destVertex != nil {
+		e.SetEndpoints(agc.agVertexToVertex(srcVertex), agc.agVertexToVertex(destVertex))
 	}
 	for k, v := range agEdge.Properties {
-		e.Properties[k] = v
+		e.Properties[agc.transformKey(k)] = v
 	}
 	return e
 }
 
+// transformKeys returns a copy of the specified properties with the property keys transformed
+// according to the property key case configured on the connection
+func (agc *AgensGraphConnection) transformKeys(properties core.KVMap) core.KVMap {
+	if agc.propertyKeyCase == PropertyKeyCaseIdentity {
+		return properties
+	}
+	transformed := make(core.KVMap, len(properties))
+	for k, v := range properties {
+		transformed[agc.transformKey(k)] = v
+	}
+	return transformed
+}
+
+// transformOrderBy applies the property key case of the connection to the properties ordering the results
+func (agc *AgensGraphConnection) transformOrderBy(orderBy []core.OrderBy) []core.OrderBy {
+	transformed := make([]core.OrderBy, 0, len(orderBy))
+	for _, o := range orderBy {
+		transformed = append(transformed, core.OrderBy{Property: agc.transformKey(o.Property), Descending: o.Descending})
+	}
+	return transformed
+}
+
+func (agc *AgensGraphConnection) transformKey(key string) string {
+	switch agc.propertyKeyCase {
+	case PropertyKeyCaseLower:
+		return strings.ToLower(key)
+	case PropertyKeyCaseUpper:
+		return strings.ToUpper(key)
+	default:
+		return key
+	}
+}
+
 // NewConnection returns a new connection to the specified Agensgraph database.
 //
 // Agensgraph behind the scenes uses Postgres. Hence the connectivity parameters are similar to
@@ -427,37 +1193,106 @@ func (agc *AgensGraphConnection) agEdgeToEdge(agEdge *ag.BasicEdge, srcVertex, d
 // - port : Optional parameter. If not specified then the default PostgreSQL port 5432 is assumed
 //
 // - realm : Unused parameter. may be used in future
+//
+// The transformation applied to property keys can be configured by specifying a PropertyKeyCase value against the
+// AGENS_PROPERTY_KEY_CASE_KEY key within the options map. Property keys are retained as is if not specified.
+//
+// Agensgraph reports vertex labels and edge types in lower case. A core.LabelCase value can be specified against the
+// core.LABEL_CASE_KEY key within the options map to normalize the labels read from the database.
+//
+// The number of rows returned by a query can be capped by specifying an int value against the core.MAX_ROWS_KEY key
+// within the options map. ExecuteQuery then returns core.ErrMaxRowsExceeded for queries returning more rows than the
+// cap, guarding the application against runaway queries.
+//
+// Applications using a single graph can specify the name of the graph as a string value against the AGENS_GRAPH_KEY
+// key within the options map. The graph is then used by operations whose context does not specify a graph using the
+// ContextKeyGraphName key.
+//
+// Queries are not timed out by default. A timeout can be specified as a time.Duration value against the
+// core.DEFAULT_QUERY_TIMEOUT_KEY key within the options map, and overridden for individual operations using
+// core.WithQueryTimeout or the ContextKeyQueryTimeoutMillis key.
+//
+// Errors constructing the connection identify the Agensgraph database targeted by the connection. The credentials
+// are never included in the errors.
 func NewConnection(protocol, host, realm string, port *int32, auth, options map[string]interface{}) (core.Connection, error) {
 
 	if len(host) == 0 {
-		return nil, errors.New("hostname must be specified to establish connection")
+		return nil, errors.New("failed to create agensgraph connection: hostname must be specified to establish connection")
+	}
+
+	if port == nil {
+		port = new(int32)
+		*port = AGENS_DEFAULT_PORT
+	}
+	target := fmt.Sprintf("%s:%d", host, *port)
+	if dbName, ok := options[AGENS_DBNAME_KEY]; ok {
+		target = fmt.Sprintf("%s/%v", target, dbName)
 	}
+	conn, err := newConnection(protocol, host, *port, auth, options)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create agensgraph connection to %s: %w", target, err)
+	}
+	return conn, nil
+}
 
+func newConnection(protocol, host string, port int32, auth, options map[string]interface{}) (*AgensGraphConnection, error) {
 	if !validateAuthData(auth) {
 		return nil, errors.New("specify a valid AGENS_USER_KEY and AGENS_PWD_KEY")
 	}
 	userName := auth[AGENS_USER_KEY]
 	pwd := auth[AGENS_PASSWD_KEY]
-	if port == nil {
-		port = new(int32)
-		*port = AGENS_DEFAULT_PORT
-	}
 	dbName, ok := options[AGENS_DBNAME_KEY]
 	if !ok {
 		return nil, errors.New("database connection option must contain the AGENS_DB_NAME key specifying the database")
 	}
+	propertyKeyCase := PropertyKeyCaseIdentity
+	if keyCase, ok := options[AGENS_PROPERTY_KEY_CASE_KEY]; ok {
+		propertyKeyCase, ok = keyCase.(PropertyKeyCase)
+		if !ok {
+			return nil, errors.New("value of the AGENS_PROPERTY_KEY_CASE_KEY option must be a PropertyKeyCase")
+		}
+	}
+	labelCase, err := core.LabelCaseFromOptions(options)
+	if err != nil {
+		return nil, err
+	}
+	maxRows, err := core.MaxRowsFromOptions(options)
+	if err != nil {
+		return nil, err
+	}
+	queryTimeout, err := core.DefaultQueryTimeoutFromOptions(options)
+	if err != nil {
+		return nil, err
+	}
+	defaultGraph := ""
+	if graph, ok := options[AGENS_GRAPH_KEY]; ok {
+		if defaultGraph, ok = graph.(string); !ok || defaultGraph == "" {
+			return nil, errors.New("value of the AGENS_GRAPH_KEY option must be a non-empty string")
+		}
+	}
 	sslMode := "disable"
 
 	if protocol == AGENS_TLS_PROTOCOL {
 		sslMode = "enable"
 	}
 
-	psqlInfo := fmt.Sprintf("host=%s port=%d user=%s password=%s dbname=%s sslmode=%s", host, *port, userName, pwd, dbName, sslMode)
-	db, err := sql.Open("postgres", psqlInfo)
+	password := fmt.Sprint(pwd)
+	connString := buildConnString([][2]string{
+		{"host", host},
+		{"port", strconv.Itoa(int(port))},
+		{"user", fmt.Sprint(userName)},
+		{"password", password},
+		{"dbname", fmt.Sprint(dbName)},
+		{"sslmode", sslMode},
+	})
+	// the connector parses the connection string up front instead of on every new connection, hence errors in the
+	// connection parameters are reported here with the password redacted
+	connector, err := pq.NewConnector(connString)
 	if err != nil {
-		return nil, err
+		return nil, redactError(err, password)
 	}
-	agensConnection := AgensGraphConnection{db: db}
+	db := sql.OpenDB(connector)
+	agensConnection := AgensGraphConnection{db: db, propertyKeyCase: propertyKeyCase, labelCase: labelCase, maxRows: maxRows, defaultGraph: defaultGraph, queryTimeout: queryTimeout}
 	return &agensConnection, nil
 }
 
@@ -0,0 +1,454 @@
+package agensgraph
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"testing"
+	"time"
+
+	ag "github.com/bitnine-oss/agensgraph-golang"
+	"github.com/prahaladd/gograph/core"
+	"github.com/stretchr/testify/suite"
+)
+
+type ExecutorTestSuite struct {
+	suite.Suite
+}
+
+func (suite *ExecutorTestSuite) TestTransformKeysLower() {
+	agc := AgensGraphConnection{propertyKeyCase: PropertyKeyCaseLower}
+	props := core.KVMap{"PinCode": int64(400001), "Name": "Mumbai"}
+	suite.Equal(core.KVMap{"pincode": int64(400001), "name": "Mumbai"}, agc.transformKeys(props))
+	// the passed in properties must not be modified
+	suite.Equal(core.KVMap{"PinCode": int64(400001), "Name": "Mumbai"}, props)
+}
+
+func (suite *ExecutorTestSuite) TestTransformKeysUpper() {
+	agc := AgensGraphConnection{propertyKeyCase: PropertyKeyCaseUpper}
+	props := core.KVMap{"PinCode": int64(400001)}
+	suite.Equal(core.KVMap{"PINCODE": int64(400001)}, agc.transformKeys(props))
+}
+
+func (suite *ExecutorTestSuite) TestTransformKeysIdentity() {
+	agc := AgensGraphConnection{}
+	props := core.KVMap{"PinCode": int64(400001)}
+	suite.Equal(core.KVMap{"PinCode": int64(400001)}, agc.transformKeys(props))
+}
+
+func (suite *ExecutorTestSuite) TestVertexPropertyKeysTransformedOnRead() {
+	agc := AgensGraphConnection{propertyKeyCase: PropertyKeyCaseLower}
+	agVertex := vertexEntity{Properties: map[string]interface{}{"PinCode": int64(400001)}}
+	v := agc.agVertexToVertex(&agVertex)
+	suite.Equal(core.KVMap{"pincode": int64(400001)}, v.Properties)
+}
+
+func (suite *ExecutorTestSuite) TestLabelCaseAppliedOnRead() {
+	agc := AgensGraphConnection{labelCase: core.LabelCaseUpper}
+	var agEdge edgeEntity
+	var agSrcVertex, agDestVertex vertexEntity
+	suite.NoError(ag.ScanEntity([]byte(`lives_in[4.1][3.1,5.1]{}`), &agEdge))
+	suite.NoError(ag.ScanEntity([]byte(`person[3.1]{}`), &agSrcVertex))
+	suite.NoError(ag.ScanEntity([]byte(`country[5.1]{}`), &agDestVertex))
+
+	e := agc.agEdgeToEdge(&agEdge, &agSrcVertex, &agDestVertex)
+	suite.Equal("LIVES_IN", e.Type)
+	suite.Equal([]string{"PERSON"}, e.SourceVertex.Labels)
+	suite.Equal([]string{"COUNTRY"}, e.DestinationVertex.Labels)
+}
+
+func (suite *ExecutorTestSuite) TestEdgeWithCompleteVertexPopulatesVertexIds() {
+	agc := AgensGraphConnection{}
+	var agEdge edgeEntity
+	var agSrcVertex, agDestVertex vertexEntity
+	suite.NoError(ag.ScanEntity([]byte(`lives_in[4.1][3.1,5.1]{"since": 1929}`), &agEdge))
+	suite.NoError(ag.ScanEntity([]byte(`person[3.1]{"name": "Tintin"}`), &agSrcVertex))
+	suite.NoError(ag.ScanEntity([]byte(`country[5.1]{"name": "Belgium"}`), &agDestVertex))
+
+	e := agc.agEdgeToEdge(&agEdge, &agSrcVertex, &agDestVertex)
+	suite.Equal(core.NewId("3.1"), e.SourceVertexID)
+	suite.Equal(core.NewId("5.1"), e.DestinationVertexID)
+	suite.Equal(e.SourceVertexID, e.SourceVertex.ID)
+	suite.Equal(e.DestinationVertexID, e.DestinationVertex.ID)
+}
+
+func (suite *ExecutorTestSuite) TestEdgeWithCompleteVertexReportsStoredDirection() {
+	agc := AgensGraphConnection{}
+	var agEdge edgeEntity
+	var agSrcVertex, agDestVertex vertexEntity
+	suite.NoError(ag.ScanEntity([]byte(`lives_in[4.1][3.1,5.1]{}`), &agEdge))
+	// the vertices are bound in the opposite order of the stored direction
+	suite.NoError(ag.ScanEntity([]byte(`country[5.1]{"name": "Belgium"}`), &agSrcVertex))
+	suite.NoError(ag.ScanEntity([]byte(`person[3.1]{"name": "Tintin"}`), &agDestVertex))
+
+	e := agc.agEdgeToEdge(&agEdge, &agSrcVertex, &agDestVertex)
+	suite.Equal([]string{"person"}, e.SourceVertex.Labels)
+	suite.Equal([]string{"country"}, e.DestinationVertex.Labels)
+	suite.Equal(e.SourceVertexID, e.SourceVertex.ID)
+}
+
+func (suite *ExecutorTestSuite) TestNormalizeRawValues() {
+	agc := AgensGraphConnection{}
+	result := &core.QueryResult{
+		Columns: []string{"v", "count", "name", "missing"},
+		Rows: []core.Row{{
+			"v":       []byte(`person[3.1]{"name": "Tintin", "age": 18}`),
+			"count":   []byte(`2`),
+			"name":    []byte(`"Tintin"`),
+			"missing": []byte(``),
+		}},
+	}
+	normalized := result.Normalized(agc.ConvertValue)
+	suite.Equal([]core.Row{{
+		"v": map[string]interface{}{
+			"id":         "3.1",
+			"labels":     []interface{}{"person"},
+			"properties": map[string]interface{}{"name": "Tintin", "age": int64(18)},
+		},
+		"count":   int64(2),
+		"name":    "Tintin",
+		"missing": nil,
+	}}, normalized.Rows)
+}
+
+func (suite *ExecutorTestSuite) TestRowToVertexAndEdge() {
+	agc := AgensGraphConnection{}
+	row := core.Row{
+		"p":       []byte(`person[3.1]{"name": "Tintin"}`),
+		"r":       []byte(`owns[5.3][3.1,4.2]{"since": 1929}`),
+		"missing": []byte(``),
+	}
+	vertex, err := core.RowToVertex(row, "p", agc.ConvertValue)
+	suite.NoError(err)
+	suite.Equal(core.NewId("3.1"), vertex.ID)
+	suite.Equal([]string{"person"}, vertex.Labels)
+	suite.Equal(core.KVMap{"name": "Tintin"}, vertex.Properties)
+
+	edge, err := core.RowToEdge(row, "r", agc.ConvertValue)
+	suite.NoError(err)
+	suite.Equal(core.NewId("5.3"), edge.ID)
+	suite.Equal("owns", edge.Type)
+	suite.Equal(core.NewId("3.1"), edge.SourceVertexID)
+	suite.Equal(core.NewId("4.2"), edge.DestinationVertexID)
+	suite.Equal(int64(1929), edge.Properties["since"])
+
+	vertex, err = core.RowToVertex(row, "missing", agc.ConvertValue)
+	suite.NoError(err)
+	suite.Nil(vertex)
+}
+
+func (suite *ExecutorTestSuite) TestNewConnectionPropertyKeyCaseOption() {
+	port := int32(5432)
+	auth := core.KVMap{AGENS_USER_KEY: "user", AGENS_PASSWD_KEY: "pwd"}
+	conn, err := NewConnection("", "localhost", "", &port, auth, core.KVMap{AGENS_DBNAME_KEY: "graphdb", AGENS_PROPERTY_KEY_CASE_KEY: PropertyKeyCaseLower})
+	suite.NoError(err)
+	suite.Equal(PropertyKeyCaseLower, conn.(*AgensGraphConnection).propertyKeyCase)
+
+	_, err = NewConnection("", "localhost", "", &port, auth, core.KVMap{AGENS_DBNAME_KEY: "graphdb", AGENS_PROPERTY_KEY_CASE_KEY: "lower"})
+	suite.Error(err)
+}
+
+func (suite *ExecutorTestSuite) TestNewConnectionMaxRowsOption() {
+	port := int32(5432)
+	auth := core.KVMap{AGENS_USER_KEY: "user", AGENS_PASSWD_KEY: "pwd"}
+	conn, err := NewConnection("", "localhost", "", &port, auth, core.KVMap{AGENS_DBNAME_KEY: "graphdb", core.MAX_ROWS_KEY: 1000})
+	suite.NoError(err)
+	suite.Equal(1000, conn.(*AgensGraphConnection).maxRows)
+
+	_, err = NewConnection("", "localhost", "", &port, auth, core.KVMap{AGENS_DBNAME_KEY: "graphdb", core.MAX_ROWS_KEY: -1})
+	suite.Error(err)
+}
+
+func (suite *ExecutorTestSuite) TestNewConnectionGraphOption() {
+	port := int32(5432)
+	auth := core.KVMap{AGENS_USER_KEY: "user", AGENS_PASSWD_KEY: "pwd"}
+	conn, err := NewConnection("", "localhost", "", &port, auth, core.KVMap{AGENS_DBNAME_KEY: "graphdb", AGENS_GRAPH_KEY: "agens"})
+	suite.NoError(err)
+	suite.Equal("agens", conn.(*AgensGraphConnection).defaultGraph)
+
+	_, err = NewConnection("", "localhost", "", &port, auth, core.KVMap{AGENS_DBNAME_KEY: "graphdb", AGENS_GRAPH_KEY: ""})
+	suite.ErrorContains(err, "value of the AGENS_GRAPH_KEY option must be a non-empty string")
+}
+
+func (suite *ExecutorTestSuite) TestExecuteQueryWithoutGraph() {
+	agc := AgensGraphConnection{}
+	_, err := agc.ExecuteQuery(context.Background(), "MATCH (v) RETURN v", core.Read, nil)
+	suite.EqualError(err, "graph name must be specified")
+}
+
+func (suite *ExecutorTestSuite) TestExecuteQueryErrorIncludesQueryWhenDebugging() {
+	agc := AgensGraphConnection{}
+	ctx := core.WithDebugQueries(context.Background())
+	_, err := agc.ExecuteQuery(ctx, "MATCH (v:person{name:'Tintin'}) RETURN v", core.Read, nil)
+	suite.EqualError(err, `failed to execute read query "MATCH (v:person{name:'***'}) RETURN v": graph name must be specified`)
+}
+
+func (suite *ExecutorTestSuite) TestNewConnectionErrorIdentifiesTarget() {
+	port := int32(5433)
+	auth := core.KVMap{AGENS_USER_KEY: "user", AGENS_PASSWD_KEY: "secret"}
+	_, err := NewConnection("", "graphhost", "", &port, auth, core.KVMap{AGENS_DBNAME_KEY: "graphdb", core.MAX_ROWS_KEY: -1})
+	suite.EqualError(err, "failed to create agensgraph connection to graphhost:5433/graphdb: value of the MAX_ROWS_KEY option must be a non-negative int")
+	suite.NotContains(err.Error(), "secret")
+
+	_, err = NewConnection("", "graphhost", "", nil, auth, core.KVMap{})
+	suite.EqualError(err, "failed to create agensgraph connection to graphhost:5432: database connection option must contain the AGENS_DB_NAME key specifying the database")
+
+	_, err = NewConnection("", "", "", nil, auth, core.KVMap{AGENS_DBNAME_KEY: "graphdb"})
+	suite.ErrorContains(err, "agensgraph")
+}
+
+func (suite *ExecutorTestSuite) TestQueryTimeoutFromContext() {
+	agc := AgensGraphConnection{}
+	qopts := agc.queryOptionsFromContext(context.Background(), core.Read)
+	suite.Equal(time.Duration(0), qopts.timeout)
+
+	ctx := context.WithValue(context.Background(), ContextKeyQueryTimeoutMillis, int64(250))
+	qopts = agc.queryOptionsFromContext(ctx, core.Read)
+	suite.Equal(250*time.Millisecond, qopts.timeout)
+
+	// the core query timeout takes precedence over the Agensgraph specific context key
+	ctx = core.WithQueryTimeout(ctx, 2*time.Second)
+	qopts = agc.queryOptionsFromContext(ctx, core.Read)
+	suite.Equal(2*time.Second, qopts.timeout)
+}
+
+func (suite *ExecutorTestSuite) TestDefaultQueryTimeout() {
+	agc := AgensGraphConnection{queryTimeout: 30 * time.Second}
+	qopts := agc.queryOptionsFromContext(context.Background(), core.Read)
+	suite.Equal(30*time.Second, qopts.timeout)
+
+	qopts = agc.queryOptionsFromContext(core.WithQueryTimeout(context.Background(), 200*time.Millisecond), core.Read)
+	suite.Equal(200*time.Millisecond, qopts.timeout)
+}
+
+func (suite *ExecutorTestSuite) TestNewConnectionDefaultQueryTimeoutOption() {
+	auth := core.KVMap{AGENS_USER_KEY: "user", AGENS_PASSWD_KEY: "secret"}
+	conn, err := NewConnection("", "graphhost", "", nil, auth, core.KVMap{AGENS_DBNAME_KEY: "graphdb", core.DEFAULT_QUERY_TIMEOUT_KEY: 30 * time.Second})
+	suite.NoError(err)
+	suite.Equal(30*time.Second, conn.(*AgensGraphConnection).queryTimeout)
+
+	_, err = NewConnection("", "graphhost", "", nil, auth, core.KVMap{AGENS_DBNAME_KEY: "graphdb", core.DEFAULT_QUERY_TIMEOUT_KEY: "30s"})
+	suite.ErrorContains(err, "value of the DEFAULT_QUERY_TIMEOUT_KEY option must be a positive time.Duration")
+}
+
+func (suite *ExecutorTestSuite) TestWriteModeFromContext() {
+	agc := AgensGraphConnection{}
+	suite.False(agc.queryOptionsFromContext(context.Background(), core.Write).writeModeCreate)
+
+	ctx := core.WithWriteMode(context.Background(), core.Create)
+	suite.True(agc.queryOptionsFromContext(ctx, core.Write).writeModeCreate)
+
+	// the core write mode takes precedence over the Agensgraph specific context key
+	ctx = context.WithValue(context.Background(), ContextKeyWriteModeCreate, true)
+	suite.True(agc.queryOptionsFromContext(ctx, core.Write).writeModeCreate)
+	ctx = core.WithWriteMode(ctx, core.Merge)
+	suite.False(agc.queryOptionsFromContext(ctx, core.Write).writeModeCreate)
+}
+
+func (suite *ExecutorTestSuite) TestStoreEdgeWriteModeCreate() {
+	agc := AgensGraphConnection{}
+	edge := core.Edge{
+		Type:              "owns",
+		SourceVertex:      &core.Vertex{Labels: []string{"person"}, Properties: core.KVMap{"name": "Tintin"}},
+		DestinationVertex: &core.Vertex{Labels: []string{"dog"}, Properties: core.KVMap{"name": "Snowy"}},
+		Properties:        core.KVMap{},
+	}
+	ctx := context.WithValue(core.WithDebugQueries(context.Background()), ContextKeyWriteModeCreate, true)
+	err := agc.StoreEdge(ctx, &edge)
+	suite.EqualError(err, `failed to execute write query "CREATE (sv:person{name:'***'})-[rel:owns]->(ev:dog{name:'***'})  return sv, rel, ev": graph name must be specified`)
+
+	err = agc.StoreEdge(core.WithDebugQueries(context.Background()), &edge)
+	suite.ErrorContains(err, `"MERGE (sv:person{name:'***'})-[rel:owns]->(ev:dog{name:'***'})  return sv, rel, ev"`)
+}
+
+func (suite *ExecutorTestSuite) TestStoreVertexWithIdentityProperties() {
+	agc := AgensGraphConnection{}
+	ctx := core.WithVertexIdentityProperties(context.Background(), "name")
+	err := agc.StoreVertex(ctx, &core.Vertex{Labels: []string{"person"}, Properties: core.KVMap{"name": "Tintin", "age": 17}})
+	suite.EqualError(err, "vertex identity properties are not supported by Agensgraph")
+}
+
+func (suite *ExecutorTestSuite) TestStrongConsistencyUsesSerializableIsolation() {
+	agc := AgensGraphConnection{}
+	qopts := agc.queryOptionsFromContext(context.Background(), core.Write)
+	suite.Equal(sql.LevelDefault, qopts.txOpts.Isolation)
+
+	ctx := core.WithConsistency(context.Background(), core.ConsistencyStrong)
+	qopts = agc.queryOptionsFromContext(ctx, core.Write)
+	suite.Equal(sql.LevelSerializable, qopts.txOpts.Isolation)
+
+	// an explicitly specified isolation level takes precedence
+	ctx = context.WithValue(ctx, ContextKeyIsolationLevel, sql.LevelReadCommitted)
+	qopts = agc.queryOptionsFromContext(ctx, core.Write)
+	suite.Equal(sql.LevelReadCommitted, qopts.txOpts.Isolation)
+}
+
+func (suite *ExecutorTestSuite) TestClockDefaultsToSystemClock() {
+	agc := AgensGraphConnection{}
+	suite.Equal(core.SystemClock, agc.clockOrDefault())
+}
+
+func (suite *ExecutorTestSuite) TestCapabilities() {
+	agc := AgensGraphConnection{}
+	capabilities := agc.Capabilities()
+	suite.True(capabilities.DirectedEdges)
+	suite.True(capabilities.MultipleDatabases)
+	suite.False(capabilities.FullTextSearch)
+	suite.False(capabilities.BatchImport)
+	suite.True(capabilities.Transactions)
+}
+
+func (suite *ExecutorTestSuite) TestDegreeRejectsInvalidId() {
+	agc := AgensGraphConnection{}
+	_, err := agc.Degree(context.Background(), core.NewId("3.1' OR true"), core.DirectionBoth, nil)
+	suite.EqualError(err, "invalid vertex id 3.1' OR true")
+	_, err = agc.Degree(context.Background(), nil, core.DirectionBoth, nil)
+	suite.Error(err)
+}
+
+func (suite *ExecutorTestSuite) TestExpandNeighborsRejectsInvalidId() {
+	agc := AgensGraphConnection{}
+	_, err := agc.ExpandNeighbors(context.Background(), []*core.Identifier{core.NewId("3.1"), core.NewId("3.1' OR true")}, nil, core.DirectionBoth)
+	suite.EqualError(err, "invalid vertex id 3.1' OR true")
+	_, err = agc.ExpandNeighbors(context.Background(), []*core.Identifier{nil}, nil, core.DirectionBoth)
+	suite.EqualError(err, "vertex id must be specified")
+}
+
+func (suite *ExecutorTestSuite) TestDeleteVertex() {
+	agc := AgensGraphConnection{}
+	_, err := agc.DeleteVertex(context.Background(), "person", nil)
+	suite.ErrorIs(err, core.ErrEmptySelector)
+
+	ctx := core.WithDebugQueries(context.Background())
+	_, err = agc.DeleteVertex(ctx, "person", core.KVMap{"name": "Tintin"})
+	suite.EqualError(err, `failed to execute write query "MATCH (v:person{name:'***'}) DETACH DELETE v RETURN count(v) AS deleted": graph name must be specified`)
+	_, err = agc.DeleteVertex(core.WithAllowEmptySelector(ctx), "person", nil)
+	suite.EqualError(err, `failed to execute write query "MATCH (v:person) DETACH DELETE v RETURN count(v) AS deleted": graph name must be specified`)
+}
+
+func (suite *ExecutorTestSuite) TestDeleteEdge() {
+	agc := AgensGraphConnection{}
+	_, err := agc.DeleteEdge(context.Background(), []string{"person"}, []string{"dog"}, "owns", nil, nil, nil)
+	suite.ErrorIs(err, core.ErrEmptySelector)
+
+	ctx := core.WithDebugQueries(context.Background())
+	_, err = agc.DeleteEdge(ctx, []string{"person"}, []string{"dog"}, "owns", core.KVMap{"name": "Tintin"}, nil, nil)
+	suite.EqualError(err, `failed to execute write query "MATCH (sv:person{name:'***'})-[r:owns]->(ev:dog)  DELETE r RETURN count(r) AS deleted": graph name must be specified`)
+}
+
+func (suite *ExecutorTestSuite) TestQueryEdgeOrderedByProperty() {
+	connector := &mockConnector{columns: []string{"r"}}
+	agc := AgensGraphConnection{db: sql.OpenDB(connector), defaultGraph: "agens", propertyKeyCase: PropertyKeyCaseLower}
+	queryParams := core.KVMap{core.QueryParamOrderBy: "Since", core.QueryParamOrderByID: true}
+	edges, err := agc.QueryEdge(context.Background(), []string{"person"}, []string{"person"}, "knows", nil, nil, nil, nil, nil, nil, queryParams, core.EdgeWithVertexIds)
+	suite.NoError(err)
+	suite.Empty(edges)
+	// the property names are transformed to the property key case of the connection
+	suite.Equal([]string{"set graph_path=agens;MATCH (person0:person)-[r:knows]->(person2:person)  return r ORDER BY r.since, id(r)"}, connector.queries)
+}
+
+func (suite *ExecutorTestSuite) TestExecuteQueryRetriesBadConnection() {
+	connector := &mockConnector{columns: []string{"n"}, rows: [][]driver.Value{{[]byte("1")}}, badQueries: 1}
+	agc := AgensGraphConnection{db: sql.OpenDB(connector), defaultGraph: "agens"}
+	result, err := agc.ExecuteQuery(context.Background(), "RETURN 1 AS n", core.Read, nil)
+	suite.NoError(err)
+	suite.Equal([]core.Row{{"n": []byte("1")}}, result.Rows)
+	suite.Equal([]string{"set graph_path=agens;RETURN 1 AS n", "set graph_path=agens;RETURN 1 AS n"}, connector.queries)
+	// the bad connection is discarded
+	suite.Equal(2, connector.connections)
+}
+
+func (suite *ExecutorTestSuite) TestExecuteQueryRetriesBadConnectionOnce() {
+	connector := &mockConnector{columns: []string{"n"}, badQueries: 2}
+	agc := AgensGraphConnection{db: sql.OpenDB(connector), defaultGraph: "agens"}
+	_, err := agc.ExecuteQuery(context.Background(), "RETURN 1 AS n", core.Read, nil)
+	suite.ErrorIs(err, driver.ErrBadConn)
+	suite.Equal(2, len(connector.queries))
+}
+
+// steppingClock advances by a fixed step every time the current time is read
+type steppingClock struct {
+	now  time.Time
+	step time.Duration
+}
+
+func (sc *steppingClock) Now() time.Time {
+	now := sc.now
+	sc.now = sc.now.Add(sc.step)
+	return now
+}
+
+func (sc *steppingClock) After(d time.Duration) <-chan time.Time {
+	return make(chan time.Time)
+}
+
+func (suite *ExecutorTestSuite) TestExecuteQuerySummaryTimings() {
+	connector := &mockConnector{columns: []string{"n"}, rows: [][]driver.Value{{[]byte("1")}, {[]byte("2")}}}
+	agc := AgensGraphConnection{db: sql.OpenDB(connector), defaultGraph: "agens", clock: &steppingClock{now: time.Unix(0, 0), step: time.Millisecond}}
+	result, err := agc.ExecuteQuery(context.Background(), "CREATE (v:person{name: 'Tintin'}) RETURN v", core.Write, nil)
+	suite.NoError(err)
+	// the clock is read when the query is run, when the first row is available and once all the rows are consumed
+	suite.Equal(&core.QuerySummary{ResultAvailableAfter: time.Millisecond, ResultConsumedAfter: time.Millisecond}, result.Summary)
+	suite.Nil(result.Counters)
+}
+
+// expiringClock is a clock whose timeouts elapse only when expire is invoked
+type expiringClock struct {
+	expired chan time.Time
+}
+
+func newExpiringClock() *expiringClock {
+	return &expiringClock{expired: make(chan time.Time, 1)}
+}
+
+func (ec *expiringClock) Now() time.Time {
+	return time.Unix(0, 0)
+}
+
+func (ec *expiringClock) After(d time.Duration) <-chan time.Time {
+	return ec.expired
+}
+
+func (ec *expiringClock) expire() {
+	ec.expired <- time.Unix(0, 0)
+}
+
+func (suite *ExecutorTestSuite) TestExecuteQueryTimesOutWhileReadingRows() {
+	clock := newExpiringClock()
+	rows := [][]driver.Value{{[]byte("1")}, {[]byte("2")}, {[]byte("3")}}
+	// the server stops sending rows after the first row until the timeout elapses
+	connector := &mockConnector{columns: []string{"n"}, rows: rows, stall: clock.expire, stallAfter: 1}
+	agc := AgensGraphConnection{db: sql.OpenDB(connector), defaultGraph: "agens", clock: clock, queryTimeout: time.Second}
+	result, err := agc.ExecuteQuery(context.Background(), "UNWIND [1, 2, 3] AS n RETURN n", core.Read, nil)
+	suite.ErrorIs(err, context.DeadlineExceeded)
+	suite.Nil(result)
+}
+
+func (suite *ExecutorTestSuite) TestStoreVertexFailsIfCommitFails() {
+	connector := &mockConnector{columns: []string{"sv"}, rows: [][]driver.Value{{[]byte(`person[3.1]{"name": "Tintin"}`)}}, commitErr: sql.ErrTxDone}
+	agc := AgensGraphConnection{db: sql.OpenDB(connector), defaultGraph: "agens"}
+	vertex := core.Vertex{Labels: []string{"person"}, Properties: core.KVMap{"name": "Tintin"}}
+	err := agc.StoreVertex(context.Background(), &vertex)
+	suite.ErrorIs(err, sql.ErrTxDone)
+	suite.Nil(vertex.ID)
+
+	connector.commitErr = nil
+	suite.NoError(agc.StoreVertex(context.Background(), &vertex))
+	suite.Equal(core.NewId("3.1"), vertex.ID)
+}
+
+func (suite *ExecutorTestSuite) TestPing() {
+	connector := &mockConnector{}
+	agc := AgensGraphConnection{db: sql.OpenDB(connector)}
+	suite.NoError(agc.Ping(context.Background()))
+
+	connector = &mockConnector{connectErr: errors.New("connection refused")}
+	agc = AgensGraphConnection{db: sql.OpenDB(connector)}
+	err := agc.Ping(context.Background())
+	suite.Same(connector.connectErr, err)
+}
+
+func TestExecutorTestSuite(t *testing.T) {
+	suite.Run(t, new(ExecutorTestSuite))
+}
@@ -0,0 +1,20 @@
+package core
+
+// Capabilities describes the features supported by a connection. Applications targeting multiple graph databases
+// can use the capabilities to adapt to, or gracefully reject, features not supported by a connection.
+type Capabilities struct {
+	// DirectedEdges is set if edges are stored with a direction from the source to the destination vertex
+	DirectedEdges bool
+	// MultipleDatabases is set if the database or graph against which a query is executed can be selected per query
+	MultipleDatabases bool
+	// FullTextSearch is set if the database supports full text indexes over vertex and edge properties
+	FullTextSearch bool
+	// BatchImport is set if the connection supports importing data in batches committed periodically
+	BatchImport bool
+	// Transactions is set if the connection supports explicit transactions spanning multiple queries
+	Transactions bool
+	// DynamicLabels is set if the labels of stored vertices are passed to the database as query parameters and the
+	// labels of queried vertices are quoted within the query text, allowing labels chosen at runtime to be used without
+	// any risk of injection
+	DynamicLabels bool
+}
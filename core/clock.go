@@ -0,0 +1,63 @@
+package core
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+)
+
+// Clock abstracts the passage of time for timeouts and retries. Tests can substitute a fake clock to drive
+// timeouts deterministically instead of sleeping.
+type Clock interface {
+	// Now returns the current time
+	Now() time.Time
+	// After returns a channel on which the current time is sent once the specified duration has elapsed
+	After(d time.Duration) <-chan time.Time
+}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+func (systemClock) After(d time.Duration) <-chan time.Time {
+	return time.After(d)
+}
+
+// SystemClock is the Clock backed by the wall clock time
+var SystemClock Clock = systemClock{}
+
+// timeoutContext is a context cancelled once a timeout measured on a Clock elapses
+type timeoutContext struct {
+	context.Context
+	timedOut atomic.Bool
+}
+
+// Err returns context.DeadlineExceeded once the timeout has elapsed, consistent with contexts created using
+// context.WithTimeout
+func (tc *timeoutContext) Err() error {
+	if tc.timedOut.Load() {
+		return context.DeadlineExceeded
+	}
+	return tc.Context.Err()
+}
+
+// WithTimeout returns a copy of the specified context that is cancelled once the specified timeout elapses on the
+// specified clock. The returned cancel function must be called to release the resources associated with the context
+// once the operation completes.
+func WithTimeout(ctx context.Context, clock Clock, timeout time.Duration) (context.Context, context.CancelFunc) {
+	cancelCtx, cancel := context.WithCancel(ctx)
+	tc := &timeoutContext{Context: cancelCtx}
+	// obtain the channel before returning so that the timeout is measured from the time of the call
+	expired := clock.After(timeout)
+	go func() {
+		select {
+		case <-expired:
+			tc.timedOut.Store(true)
+			cancel()
+		case <-cancelCtx.Done():
+		}
+	}()
+	return tc, cancel
+}
@@ -0,0 +1,98 @@
+package core
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+// fakeClock is a Clock whose time advances only when Advance is invoked
+type fakeClock struct {
+	mu      sync.Mutex
+	now     time.Time
+	waiters []fakeClockWaiter
+}
+
+type fakeClockWaiter struct {
+	deadline time.Time
+	ch       chan time.Time
+}
+
+func (fc *fakeClock) Now() time.Time {
+	fc.mu.Lock()
+	defer fc.mu.Unlock()
+	return fc.now
+}
+
+func (fc *fakeClock) After(d time.Duration) <-chan time.Time {
+	fc.mu.Lock()
+	defer fc.mu.Unlock()
+	ch := make(chan time.Time, 1)
+	fc.waiters = append(fc.waiters, fakeClockWaiter{deadline: fc.now.Add(d), ch: ch})
+	return ch
+}
+
+func (fc *fakeClock) Advance(d time.Duration) {
+	fc.mu.Lock()
+	defer fc.mu.Unlock()
+	fc.now = fc.now.Add(d)
+	pending := fc.waiters[:0]
+	for _, waiter := range fc.waiters {
+		if waiter.deadline.After(fc.now) {
+			pending = append(pending, waiter)
+			continue
+		}
+		waiter.ch <- fc.now
+	}
+	fc.waiters = pending
+}
+
+type ClockTestSuite struct {
+	suite.Suite
+	clock *fakeClock
+}
+
+func (suite *ClockTestSuite) SetupTest() {
+	suite.clock = &fakeClock{now: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)}
+}
+
+func (suite *ClockTestSuite) TestTimeoutElapses() {
+	ctx, cancel := WithTimeout(context.Background(), suite.clock, 5*time.Second)
+	defer cancel()
+
+	suite.clock.Advance(4 * time.Second)
+	suite.NoError(ctx.Err())
+
+	suite.clock.Advance(time.Second)
+	<-ctx.Done()
+	suite.Equal(context.DeadlineExceeded, ctx.Err())
+}
+
+func (suite *ClockTestSuite) TestCancelBeforeTimeout() {
+	ctx, cancel := WithTimeout(context.Background(), suite.clock, 5*time.Second)
+	cancel()
+	<-ctx.Done()
+	suite.Equal(context.Canceled, ctx.Err())
+}
+
+func (suite *ClockTestSuite) TestParentCancelled() {
+	parent, cancelParent := context.WithCancel(context.Background())
+	ctx, cancel := WithTimeout(parent, suite.clock, 5*time.Second)
+	defer cancel()
+	cancelParent()
+	<-ctx.Done()
+	suite.Equal(context.Canceled, ctx.Err())
+}
+
+func (suite *ClockTestSuite) TestFakeClockNow() {
+	start := suite.clock.Now()
+	suite.clock.Advance(time.Minute)
+	suite.Equal(time.Minute, suite.clock.Now().Sub(start))
+}
+
+func TestClockTestSuite(t *testing.T) {
+	suite.Run(t, new(ClockTestSuite))
+}
@@ -0,0 +1,13 @@
+package core
+
+import "context"
+
+// NoopCloser implements a Close method that does nothing. Connections that do not hold any resources requiring an
+// explicit close, for e.g. connections to databases accessed over stateless HTTP(s) interfaces, can embed NoopCloser
+// to satisfy the Close method of the Connection interface.
+type NoopCloser struct{}
+
+// Close does nothing and always returns nil
+func (NoopCloser) Close(ctx context.Context) error {
+	return nil
+}
@@ -0,0 +1,28 @@
+package core
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+// restConnection mimics a connection to a database accessed over a stateless HTTP interface
+type restConnection struct {
+	NoopCloser
+}
+
+type CloseTestSuite struct {
+	suite.Suite
+}
+
+func (suite *CloseTestSuite) TestNoopCloseIsSafe() {
+	var conn restConnection
+	suite.NoError(conn.Close(context.Background()))
+	// closing again must be safe
+	suite.NoError(conn.Close(context.Background()))
+}
+
+func TestCloseTestSuite(t *testing.T) {
+	suite.Run(t, new(CloseTestSuite))
+}
@@ -0,0 +1,36 @@
+package core
+
+import "context"
+
+const (
+	// ContextKeyConsistency is used in context to specify the consistency required of the operations executed
+	// using the context. The value must be a Consistency
+	ContextKeyConsistency = coreContextKey("consistency")
+)
+
+// Consistency is a backend agnostic consistency level. Connections map the consistency level to the native
+// mechanism of the graph database, for e.g. transaction isolation levels or causal consistency bookmarks.
+type Consistency int8
+
+const (
+	// ConsistencyDefault uses the default consistency provided by the graph database
+	ConsistencyDefault Consistency = iota
+	// ConsistencyStrong requires every operation to observe the effects of all the operations completed before it.
+	// Connections use the strongest native mechanism of the graph database providing this guarantee.
+	ConsistencyStrong
+)
+
+// WithConsistency returns a copy of the specified context carrying the specified consistency level
+func WithConsistency(ctx context.Context, consistency Consistency) context.Context {
+	return context.WithValue(ctx, ContextKeyConsistency, consistency)
+}
+
+// ConsistencyFromContext returns the consistency level specified within the context. ConsistencyDefault is
+// returned if the context does not specify a consistency level.
+func ConsistencyFromContext(ctx context.Context) Consistency {
+	consistency, ok := ctx.Value(ContextKeyConsistency).(Consistency)
+	if !ok {
+		return ConsistencyDefault
+	}
+	return consistency
+}
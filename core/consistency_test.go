@@ -0,0 +1,28 @@
+package core
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type ConsistencyTestSuite struct {
+	suite.Suite
+}
+
+func (suite *ConsistencyTestSuite) TestConsistencyFromContext() {
+	suite.Equal(ConsistencyDefault, ConsistencyFromContext(context.Background()))
+
+	ctx := WithConsistency(context.Background(), ConsistencyStrong)
+	suite.Equal(ConsistencyStrong, ConsistencyFromContext(ctx))
+}
+
+func (suite *ConsistencyTestSuite) TestConsistencyOfUnexpectedType() {
+	ctx := context.WithValue(context.Background(), ContextKeyConsistency, "strong")
+	suite.Equal(ConsistencyDefault, ConsistencyFromContext(ctx))
+}
+
+func TestConsistencyTestSuite(t *testing.T) {
+	suite.Run(t, new(ConsistencyTestSuite))
+}
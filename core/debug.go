@@ -0,0 +1,74 @@
+package core
+
+import (
+	"context"
+	"fmt"
+)
+
+const (
+	// ContextKeyDebugQueries is used in context to include the text of a query within the errors returned when the
+	// query fails to execute. Enabled by specifying a boolean value of true against the key.
+	ContextKeyDebugQueries = coreContextKey("debugQueries")
+)
+
+// MaxDebugQueryLength limits the length of the query text included within a QueryError. A value of 0 or less
+// includes the complete text.
+var MaxDebugQueryLength = 1000
+
+// QueryError is returned by ExecuteQuery when a query fails to execute and query debugging has been enabled within
+// the context using WithDebugQueries.
+//
+// The query text is truncated to MaxDebugQueryLength characters and the string literals within the query are
+// redacted. The values of the query parameters are never included since they may carry sensitive data.
+type QueryError struct {
+	// Query is the redacted text of the query
+	Query string
+	// Mode is the mode the query was executed in
+	Mode QueryMode
+	// Err is the error the query failed with
+	Err error
+}
+
+func (qe *QueryError) Error() string {
+	mode := "read"
+	if qe.Mode == Write {
+		mode = "write"
+	}
+	return fmt.Sprintf("failed to execute %s query %q: %v", mode, qe.Query, qe.Err)
+}
+
+func (qe *QueryError) Unwrap() error {
+	return qe.Err
+}
+
+// WithDebugQueries returns a copy of the specified context requesting the errors returned by failed queries to
+// include the text of the query
+func WithDebugQueries(ctx context.Context) context.Context {
+	return context.WithValue(ctx, ContextKeyDebugQueries, true)
+}
+
+// DebugQueriesEnabled returns true if the context requests the errors returned by failed queries to include the text
+// of the query
+func DebugQueriesEnabled(ctx context.Context) bool {
+	debug, ok := ctx.Value(ContextKeyDebugQueries).(bool)
+	return ok && debug
+}
+
+// WrapQueryError wraps the error returned by the specified query within a QueryError if query debugging has been
+// enabled within the context. Otherwise the error is returned as is.
+func WrapQueryError(ctx context.Context, err error, query string, mode QueryMode) error {
+	if err == nil || !DebugQueriesEnabled(ctx) {
+		return err
+	}
+	return &QueryError{Query: redactQuery(query), Mode: mode, Err: err}
+}
+
+// redactQuery replaces the string literals within the specified query and truncates the query to MaxDebugQueryLength
+// characters
+func redactQuery(query string) string {
+	redacted := []rune(stringLiteralRegex.ReplaceAllString(query, "'***'"))
+	if MaxDebugQueryLength > 0 && len(redacted) > MaxDebugQueryLength {
+		return string(redacted[:MaxDebugQueryLength]) + "..."
+	}
+	return string(redacted)
+}
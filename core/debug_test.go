@@ -0,0 +1,46 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type DebugTestSuite struct {
+	suite.Suite
+}
+
+func (suite *DebugTestSuite) TestWrapQueryErrorWithoutDebug() {
+	err := errors.New("syntax error")
+	suite.Equal(err, WrapQueryError(context.Background(), err, "MATCH (v) RETURN v", Read))
+	suite.Nil(WrapQueryError(WithDebugQueries(context.Background()), nil, "MATCH (v) RETURN v", Read))
+}
+
+func (suite *DebugTestSuite) TestWrapQueryErrorWithDebug() {
+	cause := errors.New("syntax error")
+	err := WrapQueryError(WithDebugQueries(context.Background()), cause, "MERGE (v:Person{name: $p0}) return v", Write)
+	suite.EqualError(err, `failed to execute write query "MERGE (v:Person{name: $p0}) return v": syntax error`)
+	suite.ErrorIs(err, cause)
+	var queryError *QueryError
+	suite.True(errors.As(err, &queryError))
+	suite.Equal(Write, queryError.Mode)
+}
+
+func (suite *DebugTestSuite) TestQueryTextIsRedacted() {
+	err := WrapQueryError(WithDebugQueries(context.Background()), errors.New("failed"), `MATCH (v:Person{name:'O\'Brien'}) WHERE v.ssn="123" return v`, Read)
+	suite.EqualError(err, `failed to execute read query "MATCH (v:Person{name:'***'}) WHERE v.ssn='***' return v": failed`)
+}
+
+func (suite *DebugTestSuite) TestQueryTextIsTruncated() {
+	defer func(maxLength int) { MaxDebugQueryLength = maxLength }(MaxDebugQueryLength)
+	MaxDebugQueryLength = 10
+	err := WrapQueryError(WithDebugQueries(context.Background()), errors.New("failed"), "MATCH (v:"+strings.Repeat("Label", 100)+") return v", Read)
+	suite.EqualError(err, `failed to execute read query "MATCH (v:L...": failed`)
+}
+
+func TestDebugTestSuite(t *testing.T) {
+	suite.Run(t, new(DebugTestSuite))
+}
@@ -0,0 +1,74 @@
+package core
+
+import (
+	"context"
+	"errors"
+)
+
+const (
+	// ContextKeyDeletedIds is used in context to request the identifiers of the vertices and edges removed by a
+	// delete operation. The value must be a *DeletedIds which is populated by the delete operation.
+	ContextKeyDeletedIds = coreContextKey("deletedIds")
+	// ContextKeyAllowEmptySelector is used in context to allow DeleteVertex and DeleteEdge to delete all the
+	// elements of a label when no selectors are specified. Enabled by specifying a boolean value of true against the key.
+	ContextKeyAllowEmptySelector = coreContextKey("allowEmptySelector")
+)
+
+// ErrEmptySelector is returned by delete operations invoked without selectors, unless deleting all the elements of a
+// label is explicitly allowed using WithAllowEmptySelector
+var ErrEmptySelector = errors.New("selectors must be specified unless an empty selector is explicitly allowed")
+
+// DeletedIds accumulates the identifiers of the vertices and edges removed by delete operations.
+//
+// Collecting the identifiers requires additional work by the graph database and is hence opt-in. Connections
+// populate the identifiers only if a DeletedIds is specified within the context using WithDeletedIds.
+type DeletedIds struct {
+	IDs []*Identifier
+}
+
+// Add appends the specified identifiers to the collected identifiers
+func (d *DeletedIds) Add(ids ...*Identifier) {
+	d.IDs = append(d.IDs, ids...)
+}
+
+// WithDeletedIds returns a copy of the specified context requesting the identifiers of deleted elements along
+// with the DeletedIds to which the identifiers are added.
+func WithDeletedIds(ctx context.Context) (context.Context, *DeletedIds) {
+	deletedIds := &DeletedIds{IDs: make([]*Identifier, 0)}
+	return context.WithValue(ctx, ContextKeyDeletedIds, deletedIds), deletedIds
+}
+
+// DeletedIdsFromContext returns the DeletedIds specified within the context
+func DeletedIdsFromContext(ctx context.Context) (*DeletedIds, bool) {
+	deletedIds, ok := ctx.Value(ContextKeyDeletedIds).(*DeletedIds)
+	if !ok || deletedIds == nil {
+		return nil, false
+	}
+	return deletedIds, true
+}
+
+// WithAllowEmptySelector returns a copy of the specified context allowing delete operations invoked without
+// selectors to delete all the elements of the specified label
+func WithAllowEmptySelector(ctx context.Context) context.Context {
+	return context.WithValue(ctx, ContextKeyAllowEmptySelector, true)
+}
+
+// AllowEmptySelector returns true if the context allows delete operations to be invoked without selectors
+func AllowEmptySelector(ctx context.Context) bool {
+	allow, ok := ctx.Value(ContextKeyAllowEmptySelector).(bool)
+	return ok && allow
+}
+
+// ValidateDeleteSelector returns ErrEmptySelector if all the specified selectors are empty and the context does not
+// allow an empty selector
+func ValidateDeleteSelector(ctx context.Context, selectors ...KVMap) error {
+	if AllowEmptySelector(ctx) {
+		return nil
+	}
+	for _, selector := range selectors {
+		if len(selector) > 0 {
+			return nil
+		}
+	}
+	return ErrEmptySelector
+}
@@ -0,0 +1,41 @@
+package core
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type DeleteTestSuite struct {
+	suite.Suite
+}
+
+func (suite *DeleteTestSuite) TestWithDeletedIds() {
+	ctx, deletedIds := WithDeletedIds(context.Background())
+	fromContext, ok := DeletedIdsFromContext(ctx)
+	suite.True(ok)
+	fromContext.Add(NewId("4:abc:1"), NewId("4:abc:2"))
+	suite.Equal([]*Identifier{NewId("4:abc:1"), NewId("4:abc:2")}, deletedIds.IDs)
+}
+
+func (suite *DeleteTestSuite) TestDeletedIdsNotRequested() {
+	_, ok := DeletedIdsFromContext(context.Background())
+	suite.False(ok)
+	ctx := context.WithValue(context.Background(), ContextKeyDeletedIds, (*DeletedIds)(nil))
+	_, ok = DeletedIdsFromContext(ctx)
+	suite.False(ok)
+}
+
+func (suite *DeleteTestSuite) TestValidateDeleteSelector() {
+	suite.NoError(ValidateDeleteSelector(context.Background(), KVMap{"name": "Tintin"}))
+	suite.ErrorIs(ValidateDeleteSelector(context.Background(), nil), ErrEmptySelector)
+	suite.ErrorIs(ValidateDeleteSelector(context.Background(), KVMap{}), ErrEmptySelector)
+	suite.NoError(ValidateDeleteSelector(WithAllowEmptySelector(context.Background()), nil))
+	suite.NoError(ValidateDeleteSelector(context.Background(), nil, KVMap{"name": "Tintin"}))
+	suite.ErrorIs(ValidateDeleteSelector(context.Background(), nil, KVMap{}), ErrEmptySelector)
+}
+
+func TestDeleteTestSuite(t *testing.T) {
+	suite.Run(t, new(DeleteTestSuite))
+}
@@ -1,6 +1,21 @@
 package core
 
+// EdgeDirection is the direction of edges relative to a vertex
+type EdgeDirection int8
+
+const (
+	// DirectionBoth selects both the outgoing and the incoming edges of a vertex
+	DirectionBoth EdgeDirection = iota
+	// DirectionOutgoing selects the edges starting at a vertex
+	DirectionOutgoing
+	// DirectionIncoming selects the edges ending at a vertex
+	DirectionIncoming
+)
+
 // Edge represents an edge within the graph
+//
+// The SourceVertexID and DestinationVertexID of edges read from the graph database identify the start and end
+// vertices of the edge in the direction the edge was stored in.
 type Edge struct {
 	ID                  *Identifier
 	Type                string
@@ -16,6 +31,33 @@ func (e *Edge) GetId() *Identifier {
 	return e.ID
 }
 
+// SetEndpoints sets the source and destination vertices of the edge to the specified vertices found at either end of
+// the edge. The vertices are assigned according to the SourceVertexID and DestinationVertexID of the edge irrespective
+// of the order in which they are specified, so that the vertices are reported in the direction the edge was stored in.
+func (e *Edge) SetEndpoints(v1, v2 *Vertex) {
+	if e.SourceVertexID != nil && v1 != nil && v2 != nil && v1.ID != nil && v2.ID != nil &&
+		v1.ID.String() != e.SourceVertexID.String() && v2.ID.String() == e.SourceVertexID.String() {
+		v1, v2 = v2, v1
+	}
+	e.SourceVertex = v1
+	e.DestinationVertex = v2
+}
+
+// SetEndpoint sets either the source or the destination vertex of the edge to the specified vertex, depending on
+// whether the vertex is identified by the SourceVertexID or the DestinationVertexID of the edge. Both are set for a
+// self loop. The endpoints are left unchanged if the vertex is not connected by the edge.
+func (e *Edge) SetEndpoint(v *Vertex) {
+	if v == nil || v.ID == nil {
+		return
+	}
+	if e.SourceVertexID != nil && e.SourceVertexID.String() == v.ID.String() {
+		e.SourceVertex = v
+	}
+	if e.DestinationVertexID != nil && e.DestinationVertexID.String() == v.ID.String() {
+		e.DestinationVertex = v
+	}
+}
+
 // GetLabel returns the set of labels associated with a Graph element
 func (e *Edge) GetLabel() []string {
 	return []string{e.Type}
@@ -0,0 +1,70 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type EdgeTestSuite struct {
+	suite.Suite
+}
+
+func (suite *EdgeTestSuite) TestSetEndpoints() {
+	tintin := &Vertex{ID: NewId("1")}
+	snowy := &Vertex{ID: NewId("2")}
+	e := Edge{SourceVertexID: NewId("1"), DestinationVertexID: NewId("2")}
+	e.SetEndpoints(tintin, snowy)
+	suite.Same(tintin, e.SourceVertex)
+	suite.Same(snowy, e.DestinationVertex)
+}
+
+func (suite *EdgeTestSuite) TestSetEndpointsFollowsStoredDirection() {
+	tintin := &Vertex{ID: NewId("1")}
+	snowy := &Vertex{ID: NewId("2")}
+	e := Edge{SourceVertexID: NewId("1"), DestinationVertexID: NewId("2")}
+	e.SetEndpoints(snowy, tintin)
+	suite.Same(tintin, e.SourceVertex)
+	suite.Same(snowy, e.DestinationVertex)
+}
+
+func (suite *EdgeTestSuite) TestSetEndpointsSelfLoop() {
+	tintin := &Vertex{ID: NewId("1")}
+	e := Edge{SourceVertexID: NewId("1"), DestinationVertexID: NewId("1")}
+	e.SetEndpoints(tintin, tintin)
+	suite.Same(tintin, e.SourceVertex)
+	suite.Same(tintin, e.DestinationVertex)
+}
+
+func (suite *EdgeTestSuite) TestSetEndpointsWithoutIds() {
+	tintin := &Vertex{}
+	snowy := &Vertex{}
+	e := Edge{}
+	e.SetEndpoints(tintin, snowy)
+	suite.Same(tintin, e.SourceVertex)
+	suite.Same(snowy, e.DestinationVertex)
+}
+
+func (suite *EdgeTestSuite) TestSetEndpoint() {
+	tintin := &Vertex{ID: NewId("1")}
+	snowy := &Vertex{ID: NewId("2")}
+	e := Edge{SourceVertexID: NewId("1"), DestinationVertexID: NewId("2")}
+	e.SetEndpoint(snowy)
+	suite.Nil(e.SourceVertex)
+	suite.Same(snowy, e.DestinationVertex)
+	e.SetEndpoint(tintin)
+	suite.Same(tintin, e.SourceVertex)
+
+	loop := Edge{SourceVertexID: NewId("1"), DestinationVertexID: NewId("1")}
+	loop.SetEndpoint(tintin)
+	suite.Same(tintin, loop.SourceVertex)
+	suite.Same(tintin, loop.DestinationVertex)
+
+	loop.SetEndpoint(&Vertex{ID: NewId("3")})
+	suite.Same(tintin, loop.SourceVertex)
+	suite.Same(tintin, loop.DestinationVertex)
+}
+
+func TestEdgeTestSuite(t *testing.T) {
+	suite.Run(t, new(EdgeTestSuite))
+}
@@ -0,0 +1,11 @@
+package core
+
+// URISchemeGraphTypes returns the graph types mapped to the URI schemes, allowing the tests of the core_test package
+// to check the graph types against the connectors
+func URISchemeGraphTypes() map[string]string {
+	graphTypes := make(map[string]string, len(uriSchemes))
+	for scheme, s := range uriSchemes {
+		graphTypes[scheme] = s.graphType
+	}
+	return graphTypes
+}
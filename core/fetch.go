@@ -0,0 +1,24 @@
+package core
+
+import "context"
+
+const (
+	// ContextKeyEdgeFetchMode is used in context to specify the EdgeFetchMode used by the higher level helpers that
+	// read edges without accepting a fetch mode, for e.g. the ReadEdge method of the object graph mapper store.
+	ContextKeyEdgeFetchMode = coreContextKey("edgeFetchMode")
+)
+
+// WithEdgeFetchMode returns a copy of the specified context carrying the specified default edge fetch mode
+func WithEdgeFetchMode(ctx context.Context, fetchMode EdgeFetchMode) context.Context {
+	return context.WithValue(ctx, ContextKeyEdgeFetchMode, fetchMode)
+}
+
+// EdgeFetchModeFromContext returns the edge fetch mode specified within the context. The specified default mode is
+// returned if the context does not specify a fetch mode.
+func EdgeFetchModeFromContext(ctx context.Context, defaultMode EdgeFetchMode) EdgeFetchMode {
+	fetchMode, ok := ctx.Value(ContextKeyEdgeFetchMode).(EdgeFetchMode)
+	if !ok {
+		return defaultMode
+	}
+	return fetchMode
+}
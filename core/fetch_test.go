@@ -0,0 +1,26 @@
+package core
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type FetchTestSuite struct {
+	suite.Suite
+}
+
+func (suite *FetchTestSuite) TestEdgeFetchModeFromContext() {
+	ctx := WithEdgeFetchMode(context.Background(), EdgeWithVertexIds)
+	suite.Equal(EdgeWithVertexIds, EdgeFetchModeFromContext(ctx, EdgeWithCompleteVertex))
+}
+
+func (suite *FetchTestSuite) TestEdgeFetchModeFromContextNotSpecified() {
+	suite.Equal(EdgeWithCompleteVertex, EdgeFetchModeFromContext(context.Background(), EdgeWithCompleteVertex))
+	suite.Equal(EdgeWithVertexIds, EdgeFetchModeFromContext(context.Background(), EdgeWithVertexIds))
+}
+
+func TestFetchTestSuite(t *testing.T) {
+	suite.Run(t, new(FetchTestSuite))
+}
@@ -0,0 +1,47 @@
+package core
+
+// FilterOperator is the comparison applied by a Filter between a property and the value of the filter
+type FilterOperator int8
+
+const (
+	// Eq selects the properties equal to the value
+	Eq FilterOperator = iota
+	// Gt selects the properties greater than the value
+	Gt
+	// Gte selects the properties greater than or equal to the value
+	Gte
+	// Lt selects the properties less than the value
+	Lt
+	// Lte selects the properties less than or equal to the value
+	Lte
+	// Neq selects the properties not equal to the value
+	Neq
+	// Within selects the point properties closer to a point than a radius. The value must be a Distance, see
+	// WithinDistance.
+	Within
+)
+
+// Filter compares a property with a value using an operator. Filters are specified as the values of the filters
+// passed to the query methods of a Connection, for e.g. core.KVMap{"age": core.Filter{Operator: core.Gt, Value: 18}}
+// selects the vertices with an age greater than 18.
+//
+// Filter values which are not a Filter select the properties equal to the value, hence the filters need not be
+// wrapped for equality.
+type Filter struct {
+	Operator FilterOperator
+	Value    interface{}
+}
+
+// FilterOf returns the Filter specified as the value of a filter. Values other than a Filter are returned as a
+// filter selecting the properties equal to the value.
+func FilterOf(value interface{}) Filter {
+	switch f := value.(type) {
+	case Filter:
+		return f
+	case *Filter:
+		if f != nil {
+			return *f
+		}
+	}
+	return Filter{Operator: Eq, Value: value}
+}
@@ -0,0 +1,25 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type FilterTestSuite struct {
+	suite.Suite
+}
+
+func (suite *FilterTestSuite) TestFilterOfPlainValue() {
+	suite.Equal(Filter{Operator: Eq, Value: 18}, FilterOf(18))
+	suite.Equal(Filter{Operator: Eq, Value: nil}, FilterOf(nil))
+}
+
+func (suite *FilterTestSuite) TestFilterOfFilter() {
+	suite.Equal(Filter{Operator: Gt, Value: 18}, FilterOf(Filter{Operator: Gt, Value: 18}))
+	suite.Equal(Filter{Operator: Lte, Value: 65}, FilterOf(&Filter{Operator: Lte, Value: 65}))
+}
+
+func TestFilterTestSuite(t *testing.T) {
+	suite.Run(t, new(FilterTestSuite))
+}
@@ -0,0 +1,93 @@
+package core
+
+import (
+	"errors"
+	"sort"
+	"strings"
+)
+
+const (
+	// LABEL_CASE_KEY is the connection option key used to specify the LabelCase applied by the connection to the
+	// labels of the vertices and edges read from the database
+	LABEL_CASE_KEY = "labelCase"
+)
+
+// LabelCase defines the normalization applied by a connection to the vertex labels and edge types read from the
+// database.
+//
+// Graph databases differ in how they retain the case of labels. For e.g. Neo4j retains labels as specified while
+// Agensgraph reports labels in lower case. Normalizing the labels to a specific case allows applications working
+// with multiple graph databases to compare labels without special casing any database.
+type LabelCase int8
+
+const (
+	// LabelCaseIdentity retains the labels as reported by the database
+	LabelCaseIdentity LabelCase = iota
+	// LabelCaseLower converts the labels to lower case
+	LabelCaseLower
+	// LabelCaseUpper converts the labels to upper case
+	LabelCaseUpper
+)
+
+// Apply returns the specified label converted to the label case
+func (lc LabelCase) Apply(label string) string {
+	switch lc {
+	case LabelCaseLower:
+		return strings.ToLower(label)
+	case LabelCaseUpper:
+		return strings.ToUpper(label)
+	default:
+		return label
+	}
+}
+
+// ApplyAll returns a copy of the specified labels converted to the label case
+func (lc LabelCase) ApplyAll(labels []string) []string {
+	converted := make([]string, 0, len(labels))
+	for _, label := range labels {
+		converted = append(converted, lc.Apply(label))
+	}
+	return converted
+}
+
+// LabelCaseFromOptions returns the label case specified against the LABEL_CASE_KEY key within the connection options.
+// LabelCaseIdentity is returned if the options do not specify a label case.
+func LabelCaseFromOptions(options map[string]interface{}) (LabelCase, error) {
+	value, ok := options[LABEL_CASE_KEY]
+	if !ok {
+		return LabelCaseIdentity, nil
+	}
+	labelCase, ok := value.(LabelCase)
+	if !ok {
+		return LabelCaseIdentity, errors.New("value of the LABEL_CASE_KEY option must be a LabelCase")
+	}
+	return labelCase, nil
+}
+
+// DistinctLabelCombinations returns the distinct combinations within the specified label combinations. The labels of
+// each combination are sorted, so that combinations differing only in the order of the labels are considered the
+// same. The combinations are returned in the order of their first occurrence.
+func DistinctLabelCombinations(combinations [][]string) [][]string {
+	distinct := make([][]string, 0, len(combinations))
+	seen := make(map[string]struct{}, len(combinations))
+	for _, combination := range combinations {
+		key := LabelSetKey(combination)
+		if _, ok := seen[key]; ok {
+			continue
+		}
+		seen[key] = struct{}{}
+		sorted := append([]string{}, combination...)
+		sort.Strings(sorted)
+		distinct = append(distinct, sorted)
+	}
+	return distinct
+}
+
+// LabelSetKey returns a key identifying the specified set of labels irrespective of the order of the labels, for
+// e.g. to group vertices having the same labels
+func LabelSetKey(labels []string) string {
+	sorted := append([]string{}, labels...)
+	sort.Strings(sorted)
+	// labels cannot contain a NUL, hence the joined labels identify the set
+	return strings.Join(sorted, "\x00")
+}
@@ -0,0 +1,52 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type LabelTestSuite struct {
+	suite.Suite
+}
+
+func (suite *LabelTestSuite) TestApply() {
+	suite.Equal("Lives_In", LabelCaseIdentity.Apply("Lives_In"))
+	suite.Equal("lives_in", LabelCaseLower.Apply("Lives_In"))
+	suite.Equal("LIVES_IN", LabelCaseUpper.Apply("Lives_In"))
+}
+
+func (suite *LabelTestSuite) TestApplyAll() {
+	labels := []string{"Person", "Employee"}
+	suite.Equal([]string{"PERSON", "EMPLOYEE"}, LabelCaseUpper.ApplyAll(labels))
+	// the passed in labels must not be modified
+	suite.Equal([]string{"Person", "Employee"}, labels)
+}
+
+func (suite *LabelTestSuite) TestLabelCaseFromOptions() {
+	labelCase, err := LabelCaseFromOptions(map[string]interface{}{LABEL_CASE_KEY: LabelCaseUpper})
+	suite.NoError(err)
+	suite.Equal(LabelCaseUpper, labelCase)
+
+	labelCase, err = LabelCaseFromOptions(nil)
+	suite.NoError(err)
+	suite.Equal(LabelCaseIdentity, labelCase)
+
+	_, err = LabelCaseFromOptions(map[string]interface{}{LABEL_CASE_KEY: "upper"})
+	suite.Error(err)
+}
+
+func (suite *LabelTestSuite) TestDistinctLabelCombinations() {
+	combinations := DistinctLabelCombinations([][]string{{"Person"}, {"Person", "Employee"}, {"Employee", "Person"}, {}, {"Person"}})
+	suite.Equal([][]string{{"Person"}, {"Employee", "Person"}, {}}, combinations)
+}
+
+func (suite *LabelTestSuite) TestLabelSetKey() {
+	suite.Equal(LabelSetKey([]string{"Person", "Employee"}), LabelSetKey([]string{"Employee", "Person"}))
+	suite.NotEqual(LabelSetKey([]string{"Person"}), LabelSetKey([]string{"Person", "Employee"}))
+	suite.NotEqual(LabelSetKey([]string{"ab", "c"}), LabelSetKey([]string{"a", "bc"}))
+}
+
+func TestLabelTestSuite(t *testing.T) {
+	suite.Run(t, new(LabelTestSuite))
+}
@@ -0,0 +1,165 @@
+package core
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+)
+
+// ValueConverter converts a database specific value returned by a query, for e.g. a node returned by the driver, to
+// a value that can be normalized by NormalizeValue. The second return value is false if the value is not database
+// specific and must be normalized as is.
+type ValueConverter func(value interface{}) (interface{}, bool)
+
+// RowToVertex returns the vertex bound to the specified key within a row returned by ExecuteQuery, for e.g. by a
+// custom query returning nodes. The value is converted using the specified converter, which must be the ConvertValue
+// method of the connection the query was executed on. A nil vertex is returned if the value is null, for e.g. when
+// bound by an OPTIONAL MATCH.
+//
+// Returns an error if the row does not contain the key or the value is not a vertex.
+func RowToVertex(row Row, key string, convert ValueConverter) (*Vertex, error) {
+	value, err := convertRowValue(row, key, convert)
+	if value == nil || err != nil {
+		return nil, err
+	}
+	vertex, ok := value.(*Vertex)
+	if !ok {
+		return nil, fmt.Errorf("value of the column %s is not a vertex", key)
+	}
+	return vertex, nil
+}
+
+// RowToEdge returns the edge bound to the specified key within a row returned by ExecuteQuery. The value is converted
+// using the specified converter, which must be the ConvertValue method of the connection the query was executed on.
+// A nil edge is returned if the value is null.
+//
+// Only the identifiers of the start and end vertices of the returned edge are populated. Returns an error if the row
+// does not contain the key or the value is not an edge.
+func RowToEdge(row Row, key string, convert ValueConverter) (*Edge, error) {
+	value, err := convertRowValue(row, key, convert)
+	if value == nil || err != nil {
+		return nil, err
+	}
+	edge, ok := value.(*Edge)
+	if !ok {
+		return nil, fmt.Errorf("value of the column %s is not an edge", key)
+	}
+	return edge, nil
+}
+
+func convertRowValue(row Row, key string, convert ValueConverter) (interface{}, error) {
+	value, ok := row[key]
+	if !ok {
+		return nil, fmt.Errorf("row does not contain the column %s", key)
+	}
+	if convert != nil {
+		if converted, ok := convert(value); ok {
+			return converted, nil
+		}
+	}
+	return value, nil
+}
+
+// Normalized returns a copy of the query result in which the values of the rows are normalized using NormalizeValue
+// and the specified converter. The columns, notifications, counters and summary of the result are retained.
+func (qr *QueryResult) Normalized(convert ValueConverter) *QueryResult {
+	normalized := &QueryResult{
+		Rows:          make([]Row, 0, len(qr.Rows)),
+		Columns:       qr.Columns,
+		Notifications: qr.Notifications,
+		Counters:      qr.Counters,
+		Summary:       qr.Summary,
+	}
+	for _, row := range qr.Rows {
+		normalizedRow := make(Row, len(row))
+		for column, value := range row {
+			normalizedRow[column] = NormalizeValue(value, convert)
+		}
+		normalized.Rows = append(normalized.Rows, normalizedRow)
+	}
+	return normalized
+}
+
+// NormalizeValue converts the specified value to a plain Go value which can be serialized without knowledge of the
+// graph database it was returned by. Database specific values are first converted using the specified converter.
+//
+// Integers are normalized to int64, floating point numbers to float64 and byte slices to strings. Lists are normalized
+// to []interface{} and maps to map[string]interface{}, with their elements normalized recursively. Vertices are
+// normalized to maps containing the id, labels and properties of the vertex and edges to maps containing the id,
+// type, startId, endId and properties of the edge. Points are normalized to maps containing the srid and the
+// coordinates of the point. Unsigned integers that do not fit an int64 are normalized to float64. All other values,
+// for e.g. strings, booleans and time.Time values, are retained as is.
+func NormalizeValue(value interface{}, convert ValueConverter) interface{} {
+	if convert != nil {
+		if converted, ok := convert(value); ok {
+			value = converted
+		}
+	}
+	switch v := value.(type) {
+	case nil:
+		return nil
+	case string, bool, int64, float64:
+		return v
+	case Point:
+		return map[string]interface{}{"srid": int64(v.SRID), "x": v.X, "y": v.Y}
+	case []byte:
+		return string(v)
+	case *Vertex:
+		if v == nil {
+			return nil
+		}
+		return map[string]interface{}{
+			"id":         identifierValue(v.ID),
+			"labels":     NormalizeValue(v.Labels, convert),
+			"properties": NormalizeValue(map[string]interface{}(v.Properties), convert),
+		}
+	case *Edge:
+		if v == nil {
+			return nil
+		}
+		return map[string]interface{}{
+			"id":         identifierValue(v.ID),
+			"type":       v.Type,
+			"startId":    identifierValue(v.SourceVertexID),
+			"endId":      identifierValue(v.DestinationVertexID),
+			"properties": NormalizeValue(map[string]interface{}(v.Properties), convert),
+		}
+	}
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return rv.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		if rv.Uint() > math.MaxInt64 {
+			return float64(rv.Uint())
+		}
+		return int64(rv.Uint())
+	case reflect.Float32, reflect.Float64:
+		return rv.Float()
+	case reflect.Slice, reflect.Array:
+		list := make([]interface{}, rv.Len())
+		for i := range list {
+			list[i] = NormalizeValue(rv.Index(i).Interface(), convert)
+		}
+		return list
+	case reflect.Map:
+		if rv.Type().Key().Kind() != reflect.String {
+			return value
+		}
+		m := make(map[string]interface{}, rv.Len())
+		iter := rv.MapRange()
+		for iter.Next() {
+			m[iter.Key().String()] = NormalizeValue(iter.Value().Interface(), convert)
+		}
+		return m
+	default:
+		return value
+	}
+}
+
+func identifierValue(id *Identifier) interface{} {
+	if id == nil {
+		return nil
+	}
+	return id.Value()
+}
@@ -0,0 +1,108 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type NormalizeTestSuite struct {
+	suite.Suite
+}
+
+func (suite *NormalizeTestSuite) TestNormalizeScalars() {
+	suite.Nil(NormalizeValue(nil, nil))
+	suite.Equal("Tintin", NormalizeValue("Tintin", nil))
+	suite.Equal(true, NormalizeValue(true, nil))
+	suite.Equal(int64(18), NormalizeValue(18, nil))
+	suite.Equal(int64(7), NormalizeValue(uint8(7), nil))
+	suite.Equal(float64(1.5), NormalizeValue(float32(1.5), nil))
+	suite.Equal("raw", NormalizeValue([]byte("raw"), nil))
+	now := time.Now()
+	suite.Equal(now, NormalizeValue(now, nil))
+}
+
+func (suite *NormalizeTestSuite) TestNormalizeCollections() {
+	suite.Equal([]interface{}{"a", "b"}, NormalizeValue([]string{"a", "b"}, nil))
+	suite.Equal(map[string]interface{}{"age": int64(18), "tags": []interface{}{int64(1)}},
+		NormalizeValue(KVMap{"age": int32(18), "tags": []int{1}}, nil))
+}
+
+func (suite *NormalizeTestSuite) TestNormalizeVertexAndEdge() {
+	vertex := &Vertex{ID: NewId("1"), Labels: []string{"Person"}, Properties: KVMap{"age": 18}}
+	suite.Equal(map[string]interface{}{
+		"id":         "1",
+		"labels":     []interface{}{"Person"},
+		"properties": map[string]interface{}{"age": int64(18)},
+	}, NormalizeValue(vertex, nil))
+
+	edge := &Edge{ID: NewId("3"), Type: "KNOWS", SourceVertexID: NewId("1"), DestinationVertexID: NewId("2")}
+	suite.Equal(map[string]interface{}{
+		"id":         "3",
+		"type":       "KNOWS",
+		"startId":    "1",
+		"endId":      "2",
+		"properties": map[string]interface{}{},
+	}, NormalizeValue(edge, nil))
+}
+
+type driverValue struct {
+	name string
+}
+
+func (suite *NormalizeTestSuite) TestNormalizedUsesConverter() {
+	convert := func(value interface{}) (interface{}, bool) {
+		if v, ok := value.(driverValue); ok {
+			return &Vertex{Labels: []string{v.name}}, true
+		}
+		return value, false
+	}
+	result := &QueryResult{
+		Columns: []string{"v", "count"},
+		Rows:    []Row{{"v": driverValue{name: "Person"}, "count": 2}},
+	}
+	normalized := result.Normalized(convert)
+	suite.Equal([]string{"v", "count"}, normalized.Columns)
+	suite.Equal([]Row{{
+		"v":     map[string]interface{}{"id": nil, "labels": []interface{}{"Person"}, "properties": map[string]interface{}{}},
+		"count": int64(2),
+	}}, normalized.Rows)
+	// the original result is not modified
+	suite.Equal(2, result.Rows[0]["count"])
+}
+
+func (suite *NormalizeTestSuite) TestRowToVertexAndEdge() {
+	convert := func(value interface{}) (interface{}, bool) {
+		if v, ok := value.(driverValue); ok {
+			if v.name == "KNOWS" {
+				return &Edge{Type: v.name}, true
+			}
+			return &Vertex{Labels: []string{v.name}}, true
+		}
+		return value, false
+	}
+	row := Row{"v": driverValue{name: "Person"}, "r": driverValue{name: "KNOWS"}, "missing": nil, "count": 2}
+
+	vertex, err := RowToVertex(row, "v", convert)
+	suite.NoError(err)
+	suite.Equal(&Vertex{Labels: []string{"Person"}}, vertex)
+	edge, err := RowToEdge(row, "r", convert)
+	suite.NoError(err)
+	suite.Equal(&Edge{Type: "KNOWS"}, edge)
+
+	vertex, err = RowToVertex(row, "missing", convert)
+	suite.NoError(err)
+	suite.Nil(vertex)
+
+	_, err = RowToVertex(row, "r", convert)
+	suite.EqualError(err, "value of the column r is not a vertex")
+	_, err = RowToEdge(row, "count", convert)
+	suite.EqualError(err, "value of the column count is not an edge")
+	_, err = RowToVertex(row, "e", convert)
+	suite.EqualError(err, "row does not contain the column e")
+}
+
+func TestNormalizeTestSuite(t *testing.T) {
+	suite.Run(t, new(NormalizeTestSuite))
+}
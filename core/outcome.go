@@ -0,0 +1,79 @@
+package core
+
+import "context"
+
+const (
+	// ContextKeyWriteOutcomes is used in context to request the classification of the outcome of write operations.
+	// The value must be a *WriteOutcomes which is updated by the write operations.
+	ContextKeyWriteOutcomes = coreContextKey("writeOutcomes")
+)
+
+// WriteOutcome classifies the effect of a MERGE based write on the graph
+type WriteOutcome int8
+
+const (
+	// WriteCreated indicates that the write created a new element
+	WriteCreated WriteOutcome = iota
+	// WriteUpdated indicates that the write matched an existing element and modified its properties
+	WriteUpdated
+	// WriteUnchanged indicates that the write matched an existing element without modifying it
+	WriteUnchanged
+)
+
+// WriteCounters contains the update statistics reported by a graph database for a query.
+type WriteCounters struct {
+	NodesCreated         int
+	RelationshipsCreated int
+	PropertiesSet        int
+	LabelsAdded          int
+}
+
+// Outcome classifies the write described by the counters. Writes creating nodes or relationships are classified as
+// created, writes only setting properties or labels as updated and writes without any effect as unchanged.
+func (wc WriteCounters) Outcome() WriteOutcome {
+	if wc.NodesCreated > 0 || wc.RelationshipsCreated > 0 {
+		return WriteCreated
+	}
+	if wc.PropertiesSet > 0 || wc.LabelsAdded > 0 {
+		return WriteUpdated
+	}
+	return WriteUnchanged
+}
+
+// WriteOutcomes accumulates the number of writes classified under each WriteOutcome.
+//
+// Connections record the outcomes only if a WriteOutcomes is specified within the context using WithWriteOutcomes
+// and the graph database reports update statistics for queries. Agensgraph does not report update statistics.
+type WriteOutcomes struct {
+	Created   int
+	Updated   int
+	Unchanged int
+}
+
+// Record counts the specified outcome
+func (wo *WriteOutcomes) Record(outcome WriteOutcome) {
+	switch outcome {
+	case WriteCreated:
+		wo.Created++
+	case WriteUpdated:
+		wo.Updated++
+	default:
+		wo.Unchanged++
+	}
+}
+
+// WithWriteOutcomes returns a copy of the specified context requesting the classification of write outcomes along
+// with the WriteOutcomes in which the outcomes are recorded.
+func WithWriteOutcomes(ctx context.Context) (context.Context, *WriteOutcomes) {
+	writeOutcomes := &WriteOutcomes{}
+	return context.WithValue(ctx, ContextKeyWriteOutcomes, writeOutcomes), writeOutcomes
+}
+
+// WriteOutcomesFromContext returns the WriteOutcomes specified within the context
+func WriteOutcomesFromContext(ctx context.Context) (*WriteOutcomes, bool) {
+	writeOutcomes, ok := ctx.Value(ContextKeyWriteOutcomes).(*WriteOutcomes)
+	if !ok || writeOutcomes == nil {
+		return nil, false
+	}
+	return writeOutcomes, true
+}
@@ -0,0 +1,40 @@
+package core
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type OutcomeTestSuite struct {
+	suite.Suite
+}
+
+func (suite *OutcomeTestSuite) TestOutcomeFromCounters() {
+	suite.Equal(WriteCreated, WriteCounters{NodesCreated: 1, PropertiesSet: 2}.Outcome())
+	suite.Equal(WriteCreated, WriteCounters{RelationshipsCreated: 1}.Outcome())
+	suite.Equal(WriteUpdated, WriteCounters{PropertiesSet: 1}.Outcome())
+	suite.Equal(WriteUpdated, WriteCounters{LabelsAdded: 1}.Outcome())
+	suite.Equal(WriteUnchanged, WriteCounters{}.Outcome())
+}
+
+func (suite *OutcomeTestSuite) TestWithWriteOutcomes() {
+	ctx, writeOutcomes := WithWriteOutcomes(context.Background())
+	fromContext, ok := WriteOutcomesFromContext(ctx)
+	suite.True(ok)
+	fromContext.Record(WriteCreated)
+	fromContext.Record(WriteUpdated)
+	fromContext.Record(WriteUnchanged)
+	fromContext.Record(WriteUnchanged)
+	suite.Equal(WriteOutcomes{Created: 1, Updated: 1, Unchanged: 2}, *writeOutcomes)
+}
+
+func (suite *OutcomeTestSuite) TestWriteOutcomesNotRequested() {
+	_, ok := WriteOutcomesFromContext(context.Background())
+	suite.False(ok)
+}
+
+func TestOutcomeTestSuite(t *testing.T) {
+	suite.Run(t, new(OutcomeTestSuite))
+}
@@ -0,0 +1,213 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+type coreContextKey string
+
+const (
+	// ContextKeyValidateQueryParams is used in context to enable the validation of query parameters prior to query execution.
+	// Validation is enabled by specifying a boolean value of true against the key.
+	ContextKeyValidateQueryParams = coreContextKey("validateQueryParams")
+)
+
+// QueryParamLimit is the key within the query parameters of QueryVertex and QueryEdge used to specify the maximum
+// number of results to be returned. The value must be a non-negative integer.
+const QueryParamLimit = "__limit"
+
+// QueryParamSkip is the key within the query parameters of QueryEdge used to specify the number of results to be
+// skipped. The value must be a non-negative integer.
+const QueryParamSkip = "__skip"
+
+// QueryParamOrderByID is the key within the query parameters of QueryEdge used to order the results by the identity
+// of the edges. The value must be a bool. Ordering is required for the pages read using QueryParamSkip and
+// QueryParamLimit to be consistent with one another.
+const QueryParamOrderByID = "__orderById"
+
+// QueryParamOrderBy is the key within the query parameters of QueryEdge used to order the results by properties of
+// the edges. The value must be an OrderBy, a []OrderBy ordering the results by each of the properties in turn, or the
+// name of a property ordering the results in ascending order. Results ordered by properties are additionally ordered
+// by identity if QueryParamOrderByID is specified, which keeps the order of the edges with equal properties stable.
+const QueryParamOrderBy = "__orderBy"
+
+// OrderBy orders the results of a query by a property
+type OrderBy struct {
+	// Property is the name of the property
+	Property string
+	// Descending orders the results in descending instead of ascending order of the property
+	Descending bool
+}
+
+// Paging specifies the page of results to be returned by a query
+type Paging struct {
+	// Limit is the maximum number of results to be returned. All the results are returned for a limit of 0
+	Limit int64
+	// Skip is the number of results to be skipped
+	Skip int64
+	// OrderByID orders the results by the identity of the edges
+	OrderByID bool
+	// OrderBy orders the results by properties of the edges, prior to ordering them by identity
+	OrderBy []OrderBy
+}
+
+var (
+	// stringLiteralRegex matches single and double quoted string literals along with escaped quotes within the literals
+	stringLiteralRegex = regexp.MustCompile(`'(?:[^'\\]|\\.)*'|"(?:[^"\\]|\\.)*"`)
+	// paramRegex matches parameter references of the form $name
+	paramRegex = regexp.MustCompile(`\$(\w+)`)
+)
+
+// QueryParamNames returns the distinct names of the parameters referenced within the specified query.
+//
+// Parameter references within string literals are ignored.
+func QueryParamNames(query string) []string {
+	stripped := stringLiteralRegex.ReplaceAllString(query, "''")
+	matches := paramRegex.FindAllStringSubmatch(stripped, -1)
+	seen := make(map[string]bool)
+	names := make([]string, 0)
+	for _, match := range matches {
+		if !seen[match[1]] {
+			seen[match[1]] = true
+			names = append(names, match[1])
+		}
+	}
+	return names
+}
+
+// ValidateQueryParams checks that all the parameters referenced within the specified query are present within the
+// specified query parameters.
+//
+// Returns an error listing the missing parameters. Parameters which are not referenced in the query are ignored.
+func ValidateQueryParams(query string, queryParams map[string]interface{}) error {
+	missing := make([]string, 0)
+	for _, name := range QueryParamNames(query) {
+		if _, ok := queryParams[name]; !ok {
+			missing = append(missing, name)
+		}
+	}
+	if len(missing) > 0 {
+		sort.Strings(missing)
+		return fmt.Errorf("query parameters missing: %s", strings.Join(missing, ", "))
+	}
+	return nil
+}
+
+// ValidateQueryParamsFromContext validates the query parameters if validation has been enabled within the context
+// using the ContextKeyValidateQueryParams key.
+func ValidateQueryParamsFromContext(ctx context.Context, query string, queryParams map[string]interface{}) error {
+	if validate, ok := ctx.Value(ContextKeyValidateQueryParams).(bool); ok && validate {
+		return ValidateQueryParams(query, queryParams)
+	}
+	return nil
+}
+
+// SplitLimit returns the limit specified within the query parameters using the QueryParamLimit key along with
+// the remaining query parameters. A limit of 0 is returned if no limit is specified.
+//
+// Returns an error if the limit is not a non-negative integer.
+func SplitLimit(queryParams KVMap) (int64, KVMap, error) {
+	return splitCount(queryParams, QueryParamLimit, "limit")
+}
+
+// SplitPaging returns the paging specified within the query parameters using the QueryParamLimit, QueryParamSkip,
+// QueryParamOrderByID and QueryParamOrderBy keys along with the remaining query parameters.
+//
+// Returns an error if the limit or the skip is not a non-negative integer, the ordering by id is not a bool or the
+// ordering by properties is not one of the values accepted by QueryParamOrderBy.
+func SplitPaging(queryParams KVMap) (Paging, KVMap, error) {
+	var paging Paging
+	var err error
+	if paging.Limit, queryParams, err = splitCount(queryParams, QueryParamLimit, "limit"); err != nil {
+		return Paging{}, nil, err
+	}
+	if paging.Skip, queryParams, err = splitCount(queryParams, QueryParamSkip, "skip"); err != nil {
+		return Paging{}, nil, err
+	}
+	if value, ok := queryParams[QueryParamOrderByID]; ok {
+		if paging.OrderByID, ok = value.(bool); !ok {
+			return Paging{}, nil, fmt.Errorf("order by id must be a bool, found %T", value)
+		}
+		queryParams = withoutParam(queryParams, QueryParamOrderByID)
+	}
+	if value, ok := queryParams[QueryParamOrderBy]; ok {
+		switch v := value.(type) {
+		case string:
+			paging.OrderBy = []OrderBy{{Property: v}}
+		case OrderBy:
+			paging.OrderBy = []OrderBy{v}
+		case []OrderBy:
+			paging.OrderBy = v
+		default:
+			return Paging{}, nil, fmt.Errorf("order by must be a property name, an OrderBy or a []OrderBy, found %T", value)
+		}
+		queryParams = withoutParam(queryParams, QueryParamOrderBy)
+	}
+	return paging, queryParams, nil
+}
+
+// splitCount returns the non-negative integer specified within the query parameters against the specified key
+// along with the remaining query parameters. The name is used to describe the value within errors.
+func splitCount(queryParams KVMap, key, name string) (int64, KVMap, error) {
+	value, ok := queryParams[key]
+	if !ok {
+		return 0, queryParams, nil
+	}
+	var count int64
+	switch v := value.(type) {
+	case int:
+		count = int64(v)
+	case int32:
+		count = int64(v)
+	case int64:
+		count = v
+	default:
+		return 0, nil, fmt.Errorf("%s must be an integer, found %T", name, value)
+	}
+	if count < 0 {
+		return 0, nil, fmt.Errorf("%s must not be negative, found %d", name, count)
+	}
+	return count, withoutParam(queryParams, key), nil
+}
+
+// withoutParam returns a copy of the query parameters without the specified key
+func withoutParam(queryParams KVMap, key string) KVMap {
+	remaining := make(KVMap, len(queryParams)-1)
+	for k, v := range queryParams {
+		if k != key {
+			remaining[k] = v
+		}
+	}
+	return remaining
+}
+
+// MergeQueryParams merges the parameters generated by a query builder with the parameters specified by the caller.
+//
+// Returns an error if a caller specified parameter has the same name as a generated parameter since the caller
+// specified value would otherwise silently replace the generated value.
+func MergeQueryParams(generated, queryParams KVMap) (KVMap, error) {
+	if len(generated) == 0 {
+		return queryParams, nil
+	}
+	merged := make(KVMap, len(generated)+len(queryParams))
+	for k, v := range generated {
+		merged[k] = v
+	}
+	collisions := make([]string, 0)
+	for k, v := range queryParams {
+		if _, ok := generated[k]; ok {
+			collisions = append(collisions, k)
+			continue
+		}
+		merged[k] = v
+	}
+	if len(collisions) > 0 {
+		sort.Strings(collisions)
+		return nil, fmt.Errorf("query parameters collide with generated parameters: %s", strings.Join(collisions, ", "))
+	}
+	return merged, nil
+}
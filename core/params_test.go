@@ -0,0 +1,128 @@
+package core
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type ParamsTestSuite struct {
+	suite.Suite
+}
+
+func (suite *ParamsTestSuite) TestQueryParamNames() {
+	names := QueryParamNames("MATCH (v:Person{name:$name}) WHERE v.age > $age AND v.nick = $name return v")
+	suite.Equal([]string{"name", "age"}, names)
+}
+
+func (suite *ParamsTestSuite) TestQueryParamNamesIgnoresStringLiterals() {
+	names := QueryParamNames(`MATCH (v:Person{name:'$notaparam', nick: "it\"s $notaparam"}) SET v.message = $message return v`)
+	suite.Equal([]string{"message"}, names)
+}
+
+func (suite *ParamsTestSuite) TestValidateMissingParams() {
+	err := ValidateQueryParams("CREATE (a:Greeting) SET a.message = $message, a.lang = $lang RETURN a", map[string]interface{}{})
+	suite.Error(err)
+	suite.Equal("query parameters missing: lang, message", err.Error())
+}
+
+func (suite *ParamsTestSuite) TestValidateExtraParams() {
+	err := ValidateQueryParams("CREATE (a:Greeting) SET a.message = $message RETURN a", map[string]interface{}{"message": "hello", "unused": 1})
+	suite.NoError(err)
+}
+
+func (suite *ParamsTestSuite) TestValidateFromContext() {
+	query := "CREATE (a:Greeting) SET a.message = $message RETURN a"
+	suite.NoError(ValidateQueryParamsFromContext(context.Background(), query, nil))
+	ctx := context.WithValue(context.Background(), ContextKeyValidateQueryParams, true)
+	suite.Error(ValidateQueryParamsFromContext(ctx, query, nil))
+}
+
+func (suite *ParamsTestSuite) TestSplitLimit() {
+	limit, remaining, err := SplitLimit(KVMap{QueryParamLimit: 10, "name": "Tom"})
+	suite.NoError(err)
+	suite.Equal(int64(10), limit)
+	suite.Equal(KVMap{"name": "Tom"}, remaining)
+}
+
+func (suite *ParamsTestSuite) TestSplitLimitNotSpecified() {
+	limit, remaining, err := SplitLimit(KVMap{"name": "Tom"})
+	suite.NoError(err)
+	suite.Equal(int64(0), limit)
+	suite.Equal(KVMap{"name": "Tom"}, remaining)
+
+	limit, remaining, err = SplitLimit(nil)
+	suite.NoError(err)
+	suite.Equal(int64(0), limit)
+	suite.Nil(remaining)
+}
+
+func (suite *ParamsTestSuite) TestSplitInvalidLimit() {
+	_, _, err := SplitLimit(KVMap{QueryParamLimit: "10"})
+	suite.Error(err)
+	_, _, err = SplitLimit(KVMap{QueryParamLimit: int64(-1)})
+	suite.Error(err)
+}
+
+func (suite *ParamsTestSuite) TestSplitPaging() {
+	paging, remaining, err := SplitPaging(KVMap{QueryParamLimit: 10, QueryParamSkip: int64(20), QueryParamOrderByID: true, "name": "Tom"})
+	suite.NoError(err)
+	suite.Equal(Paging{Limit: 10, Skip: 20, OrderByID: true}, paging)
+	suite.Equal(KVMap{"name": "Tom"}, remaining)
+
+	paging, remaining, err = SplitPaging(KVMap{"name": "Tom"})
+	suite.NoError(err)
+	suite.Equal(Paging{}, paging)
+	suite.Equal(KVMap{"name": "Tom"}, remaining)
+}
+
+func (suite *ParamsTestSuite) TestSplitPagingOrderBy() {
+	paging, remaining, err := SplitPaging(KVMap{QueryParamOrderBy: "since", "name": "Tom"})
+	suite.NoError(err)
+	suite.Equal(Paging{OrderBy: []OrderBy{{Property: "since"}}}, paging)
+	suite.Equal(KVMap{"name": "Tom"}, remaining)
+
+	paging, _, err = SplitPaging(KVMap{QueryParamOrderBy: OrderBy{Property: "weight", Descending: true}, QueryParamOrderByID: true})
+	suite.NoError(err)
+	suite.Equal(Paging{OrderByID: true, OrderBy: []OrderBy{{Property: "weight", Descending: true}}}, paging)
+
+	orderBy := []OrderBy{{Property: "weight", Descending: true}, {Property: "since"}}
+	paging, remaining, err = SplitPaging(KVMap{QueryParamOrderBy: orderBy})
+	suite.NoError(err)
+	suite.Equal(Paging{OrderBy: orderBy}, paging)
+	suite.Equal(KVMap{}, remaining)
+
+	_, _, err = SplitPaging(KVMap{QueryParamOrderBy: true})
+	suite.EqualError(err, "order by must be a property name, an OrderBy or a []OrderBy, found bool")
+}
+
+func (suite *ParamsTestSuite) TestSplitPagingInvalid() {
+	_, _, err := SplitPaging(KVMap{QueryParamSkip: -5})
+	suite.EqualError(err, "skip must not be negative, found -5")
+	_, _, err = SplitPaging(KVMap{QueryParamSkip: 1.5})
+	suite.EqualError(err, "skip must be an integer, found float64")
+	_, _, err = SplitPaging(KVMap{QueryParamOrderByID: "yes"})
+	suite.EqualError(err, "order by id must be a bool, found string")
+}
+
+func (suite *ParamsTestSuite) TestMergeQueryParams() {
+	merged, err := MergeQueryParams(KVMap{"p0": "Tom", "p1": 10}, KVMap{"since": 1982})
+	suite.NoError(err)
+	suite.Equal(KVMap{"p0": "Tom", "p1": 10, "since": 1982}, merged)
+}
+
+func (suite *ParamsTestSuite) TestMergeQueryParamsWithoutGeneratedParams() {
+	merged, err := MergeQueryParams(nil, KVMap{"since": 1982})
+	suite.NoError(err)
+	suite.Equal(KVMap{"since": 1982}, merged)
+}
+
+func (suite *ParamsTestSuite) TestMergeQueryParamsWithCollision() {
+	_, err := MergeQueryParams(KVMap{"p0": "Tom", "p1": 10}, KVMap{"p1": 20, "p0": "Jerry", "since": 1982})
+	suite.EqualError(err, "query parameters collide with generated parameters: p0, p1")
+}
+
+func TestParamsTestSuite(t *testing.T) {
+	suite.Run(t, new(ParamsTestSuite))
+}
@@ -0,0 +1,53 @@
+package core
+
+import "context"
+
+const (
+	// ContextKeyPartitionKey is used in context to specify the partition key to be included in write operations
+	// against partitioned graph databases. The value must be a *PartitionKey
+	ContextKeyPartitionKey = coreContextKey("partitionKey")
+)
+
+// PartitionKey identifies the partition to which a vertex or an edge being written belongs.
+//
+// Partitioned graph databases (for e.g. Azure Cosmos DB) require every write to include the partition key
+// as a property of the element being written. Connections to graph databases without partitioning, which
+// include all the connections within this module, ignore the partition key.
+type PartitionKey struct {
+	// Property is the name of the partition key property
+	Property string
+	// Value is the value of the partition key property
+	Value any
+}
+
+// WithPartitionKey returns a copy of the specified context carrying the specified partition key
+func WithPartitionKey(ctx context.Context, property string, value any) context.Context {
+	return context.WithValue(ctx, ContextKeyPartitionKey, &PartitionKey{Property: property, Value: value})
+}
+
+// PartitionKeyFromContext returns the partition key specified within the context
+func PartitionKeyFromContext(ctx context.Context) (*PartitionKey, bool) {
+	partitionKey, ok := ctx.Value(ContextKeyPartitionKey).(*PartitionKey)
+	if !ok || partitionKey == nil || partitionKey.Property == "" {
+		return nil, false
+	}
+	return partitionKey, true
+}
+
+// ApplyPartitionKey returns a copy of the specified properties including the partition key property specified
+// within the context. The properties are returned as is if the context does not carry a partition key.
+//
+// Connections to partitioned graph databases are expected to use this to thread the partition key through
+// StoreVertex and StoreEdge.
+func ApplyPartitionKey(ctx context.Context, properties KVMap) KVMap {
+	partitionKey, ok := PartitionKeyFromContext(ctx)
+	if !ok {
+		return properties
+	}
+	withPartitionKey := make(KVMap, len(properties)+1)
+	for k, v := range properties {
+		withPartitionKey[k] = v
+	}
+	withPartitionKey[partitionKey.Property] = partitionKey.Value
+	return withPartitionKey
+}
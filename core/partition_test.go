@@ -0,0 +1,43 @@
+package core
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type PartitionTestSuite struct {
+	suite.Suite
+}
+
+func (suite *PartitionTestSuite) TestApplyPartitionKey() {
+	ctx := WithPartitionKey(context.Background(), "tenant", "acme")
+	props := KVMap{"name": "Tom"}
+	suite.Equal(KVMap{"name": "Tom", "tenant": "acme"}, ApplyPartitionKey(ctx, props))
+	// the passed in properties must not be modified
+	suite.Equal(KVMap{"name": "Tom"}, props)
+}
+
+func (suite *PartitionTestSuite) TestApplyPartitionKeyNotSpecified() {
+	props := KVMap{"name": "Tom"}
+	suite.Equal(KVMap{"name": "Tom"}, ApplyPartitionKey(context.Background(), props))
+}
+
+func (suite *PartitionTestSuite) TestPartitionKeyFromContext() {
+	_, ok := PartitionKeyFromContext(context.Background())
+	suite.False(ok)
+
+	ctx := WithPartitionKey(context.Background(), "tenant", "acme")
+	partitionKey, ok := PartitionKeyFromContext(ctx)
+	suite.True(ok)
+	suite.Equal(&PartitionKey{Property: "tenant", Value: "acme"}, partitionKey)
+
+	ctx = WithPartitionKey(context.Background(), "", "acme")
+	_, ok = PartitionKeyFromContext(ctx)
+	suite.False(ok)
+}
+
+func TestPartitionTestSuite(t *testing.T) {
+	suite.Run(t, new(PartitionTestSuite))
+}
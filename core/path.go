@@ -0,0 +1,26 @@
+package core
+
+// Path represents a traversal from a start vertex to an end vertex across one or more edges
+type Path struct {
+	StartVertex *Vertex
+	EndVertex   *Vertex
+}
+
+// DistinctEndpoints returns the distinct start and end vertices of the specified edges in the order in which the
+// vertices are first encountered. Vertices are considered identical if they have the same identifier.
+//
+// The edges must contain the complete start and end vertices.
+func DistinctEndpoints(edges []*Edge) []*Vertex {
+	seen := make(map[string]bool)
+	vertices := make([]*Vertex, 0)
+	for _, edge := range edges {
+		for _, vertex := range []*Vertex{edge.SourceVertex, edge.DestinationVertex} {
+			if vertex == nil || seen[vertex.ID.String()] {
+				continue
+			}
+			seen[vertex.ID.String()] = true
+			vertices = append(vertices, vertex)
+		}
+	}
+	return vertices
+}
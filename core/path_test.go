@@ -0,0 +1,36 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type PathTestSuite struct {
+	suite.Suite
+}
+
+func (suite *PathTestSuite) TestDistinctEndpointsOfStar() {
+	center := &Vertex{ID: NewId("1"), Labels: []string{"Hub"}}
+	edges := make([]*Edge, 0)
+	for _, id := range []string{"2", "3", "4"} {
+		edges = append(edges, &Edge{SourceVertex: center, DestinationVertex: &Vertex{ID: NewId(id), Labels: []string{"Spoke"}}})
+	}
+	vertices := DistinctEndpoints(edges)
+	suite.Equal(4, len(vertices))
+	ids := make([]string, 0)
+	for _, v := range vertices {
+		ids = append(ids, v.ID.String())
+	}
+	suite.Equal([]string{"1", "2", "3", "4"}, ids)
+}
+
+func (suite *PathTestSuite) TestDistinctEndpointsOfSelfLoop() {
+	v := &Vertex{ID: NewId("1")}
+	vertices := DistinctEndpoints([]*Edge{{SourceVertex: v, DestinationVertex: v}})
+	suite.Equal([]*Vertex{v}, vertices)
+}
+
+func TestPathTestSuite(t *testing.T) {
+	suite.Run(t, new(PathTestSuite))
+}
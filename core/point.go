@@ -0,0 +1,39 @@
+package core
+
+// spatial reference identifiers of the coordinate reference systems of points
+const (
+	// SRIDWGS84 identifies the WGS 84 geographic coordinate reference system, in which the x and y coordinates of a
+	// point are its longitude and latitude in degrees
+	SRIDWGS84 uint32 = 4326
+	// SRIDCartesian identifies the two dimensional cartesian coordinate reference system
+	SRIDCartesian uint32 = 7203
+)
+
+// Point is a two dimensional point stored as a property value, for e.g. the location of a vertex. The coordinates are
+// interpreted within the coordinate reference system identified by the SRID of the point.
+//
+// Point properties are supported by the graph databases with a native spatial type, for e.g. Neo4j and Memgraph.
+type Point struct {
+	SRID uint32
+	X    float64
+	Y    float64
+}
+
+// NewGeographicPoint returns the WGS 84 point at the specified latitude and longitude in degrees
+func NewGeographicPoint(latitude, longitude float64) Point {
+	return Point{SRID: SRIDWGS84, X: longitude, Y: latitude}
+}
+
+// Distance is the value of a Within filter, selecting the points closer to Point than Radius. The radius of
+// geographic points is in meters, while the radius of cartesian points is in the units of the coordinates.
+type Distance struct {
+	Point  Point
+	Radius float64
+}
+
+// WithinDistance returns a filter selecting the point properties closer to the specified point than the radius, for
+// e.g. core.KVMap{"location": core.WithinDistance(core.NewGeographicPoint(51.5, -0.12), 1000)} selects the vertices
+// located within a kilometer of the point.
+func WithinDistance(point Point, radius float64) Filter {
+	return Filter{Operator: Within, Value: Distance{Point: point, Radius: radius}}
+}
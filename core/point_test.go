@@ -0,0 +1,30 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type PointTestSuite struct {
+	suite.Suite
+}
+
+func (suite *PointTestSuite) TestNewGeographicPoint() {
+	suite.Equal(Point{SRID: 4326, X: -0.12, Y: 51.5}, NewGeographicPoint(51.5, -0.12))
+}
+
+func (suite *PointTestSuite) TestWithinDistance() {
+	point := NewGeographicPoint(51.5, -0.12)
+	filter := WithinDistance(point, 1000)
+	suite.Equal(Filter{Operator: Within, Value: Distance{Point: point, Radius: 1000}}, filter)
+}
+
+func (suite *PointTestSuite) TestNormalizePoint() {
+	normalized := NormalizeValue(NewGeographicPoint(51.5, -0.12), nil)
+	suite.Equal(map[string]interface{}{"srid": int64(4326), "x": -0.12, "y": 51.5}, normalized)
+}
+
+func TestPointTestSuite(t *testing.T) {
+	suite.Run(t, new(PointTestSuite))
+}
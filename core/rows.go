@@ -0,0 +1,42 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+)
+
+const (
+	// MAX_ROWS_KEY is the connection option key used to specify the maximum number of rows a query executed through
+	// the connection may return. The value must be a non-negative int. A value of 0 allows any number of rows.
+	MAX_ROWS_KEY = "maxRows"
+)
+
+// ErrMaxRowsExceeded is returned by ExecuteQuery when a query returns more rows than the maximum number of rows
+// configured on the connection
+var ErrMaxRowsExceeded = errors.New("query returned more rows than the maximum allowed")
+
+// MaxRowsFromOptions returns the maximum number of rows specified against the MAX_ROWS_KEY key within the connection
+// options. 0 is returned if the options do not specify a maximum.
+func MaxRowsFromOptions(options map[string]interface{}) (int, error) {
+	value, ok := options[MAX_ROWS_KEY]
+	if !ok {
+		return 0, nil
+	}
+	maxRows, ok := value.(int)
+	if !ok || maxRows < 0 {
+		return 0, errors.New("value of the MAX_ROWS_KEY option must be a non-negative int")
+	}
+	return maxRows, nil
+}
+
+// CheckMaxRows returns an error wrapping ErrMaxRowsExceeded if the specified number of rows accumulated for a query
+// exceeds the specified maximum. A maximum of 0 allows any number of rows.
+//
+// Connections check the rows while accumulating them, hence a runaway query is aborted as soon as the maximum is
+// exceeded instead of after all its rows are held in memory.
+func CheckMaxRows(rows, maxRows int) error {
+	if maxRows > 0 && rows > maxRows {
+		return fmt.Errorf("%w: maximum is %d rows", ErrMaxRowsExceeded, maxRows)
+	}
+	return nil
+}
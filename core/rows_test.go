@@ -0,0 +1,45 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type RowsTestSuite struct {
+	suite.Suite
+}
+
+func (suite *RowsTestSuite) TestMaxRowsFromOptions() {
+	maxRows, err := MaxRowsFromOptions(map[string]interface{}{MAX_ROWS_KEY: 100})
+	suite.NoError(err)
+	suite.Equal(100, maxRows)
+}
+
+func (suite *RowsTestSuite) TestMaxRowsFromOptionsNotSpecified() {
+	maxRows, err := MaxRowsFromOptions(map[string]interface{}{})
+	suite.NoError(err)
+	suite.Equal(0, maxRows)
+	maxRows, err = MaxRowsFromOptions(nil)
+	suite.NoError(err)
+	suite.Equal(0, maxRows)
+}
+
+func (suite *RowsTestSuite) TestMaxRowsFromOptionsInvalid() {
+	_, err := MaxRowsFromOptions(map[string]interface{}{MAX_ROWS_KEY: "100"})
+	suite.EqualError(err, "value of the MAX_ROWS_KEY option must be a non-negative int")
+	_, err = MaxRowsFromOptions(map[string]interface{}{MAX_ROWS_KEY: -1})
+	suite.EqualError(err, "value of the MAX_ROWS_KEY option must be a non-negative int")
+}
+
+func (suite *RowsTestSuite) TestCheckMaxRows() {
+	suite.NoError(CheckMaxRows(10, 10))
+	suite.NoError(CheckMaxRows(1000, 0))
+	err := CheckMaxRows(11, 10)
+	suite.ErrorIs(err, ErrMaxRowsExceeded)
+	suite.EqualError(err, "query returned more rows than the maximum allowed: maximum is 10 rows")
+}
+
+func TestRowsTestSuite(t *testing.T) {
+	suite.Run(t, new(RowsTestSuite))
+}
@@ -0,0 +1,123 @@
+package core
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// ScanRow copies the columns of the single row within the specified query result into the values pointed to by
+// dest, in the order in which the columns were returned by the query.
+//
+// An error is returned if the result does not contain exactly one row, if the number of columns does not match the
+// number of destinations or if a column value cannot be assigned or converted to the type of its destination.
+// Numeric values are converted across numeric types, for e.g. an int64 count can be scanned into an *int.
+// A nil column value sets the destination to its zero value.
+func ScanRow(result *QueryResult, dest ...interface{}) error {
+	if err := checkSingleRow(result); err != nil {
+		return err
+	}
+	if len(result.Columns) != len(dest) {
+		return fmt.Errorf("query returned %d columns but %d destinations were specified", len(result.Columns), len(dest))
+	}
+	row := result.Rows[0]
+	for i, column := range result.Columns {
+		if err := assignScanValue(dest[i], row[column]); err != nil {
+			return fmt.Errorf("column %s: %w", column, err)
+		}
+	}
+	return nil
+}
+
+// ScanInt64 returns the value of the single column of the single row within the specified query result, for e.g. the
+// result of RETURN count(*), as an int64.
+//
+// An error is returned if the result does not contain exactly one row with a single column or if the value is not an
+// integer. Floating point values are not truncated and are hence rejected. A nil value is returned as 0.
+func ScanInt64(result *QueryResult) (int64, error) {
+	value, err := scalarValue(result)
+	if err != nil || value == nil {
+		return 0, err
+	}
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int(), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return int64(v.Uint()), nil
+	default:
+		return 0, fmt.Errorf("cannot scan %T into int64", value)
+	}
+}
+
+// ScanFloat64 returns the value of the single column of the single row within the specified query result, for e.g.
+// the result of RETURN avg(v.age), as a float64.
+//
+// An error is returned if the result does not contain exactly one row with a single column or if the value is not
+// numeric. Integral values are converted to float64. A nil value, for e.g. the average of no values, is returned as 0.
+func ScanFloat64(result *QueryResult) (float64, error) {
+	value, err := scalarValue(result)
+	if err != nil || value == nil {
+		return 0, err
+	}
+	v := reflect.ValueOf(value)
+	if !isNumericKind(v.Kind()) {
+		return 0, fmt.Errorf("cannot scan %T into float64", value)
+	}
+	return v.Convert(reflect.TypeOf(float64(0))).Float(), nil
+}
+
+// scalarValue returns the value of the single column of the single row within the specified query result
+func scalarValue(result *QueryResult) (interface{}, error) {
+	if err := checkSingleRow(result); err != nil {
+		return nil, err
+	}
+	if len(result.Columns) != 1 {
+		return nil, fmt.Errorf("expected a single column, query returned %d columns", len(result.Columns))
+	}
+	return result.Rows[0][result.Columns[0]], nil
+}
+
+// checkSingleRow returns an error if the specified query result does not contain exactly one row
+func checkSingleRow(result *QueryResult) error {
+	if result == nil || len(result.Rows) != 1 {
+		rowCount := 0
+		if result != nil {
+			rowCount = len(result.Rows)
+		}
+		return fmt.Errorf("expected a single row, query returned %d rows", rowCount)
+	}
+	return nil
+}
+
+func assignScanValue(dest, src interface{}) error {
+	destValue := reflect.ValueOf(dest)
+	if destValue.Kind() != reflect.Pointer || destValue.IsNil() {
+		return fmt.Errorf("destination must be a non nil pointer, got %T", dest)
+	}
+	target := destValue.Elem()
+	if src == nil {
+		target.Set(reflect.Zero(target.Type()))
+		return nil
+	}
+	srcValue := reflect.ValueOf(src)
+	if srcValue.Type().AssignableTo(target.Type()) {
+		target.Set(srcValue)
+		return nil
+	}
+	if isNumericKind(srcValue.Kind()) && isNumericKind(target.Kind()) {
+		target.Set(srcValue.Convert(target.Type()))
+		return nil
+	}
+	return fmt.Errorf("cannot scan %T into %s", src, target.Type())
+}
+
+func isNumericKind(kind reflect.Kind) bool {
+	switch kind {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,93 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type ScanTestSuite struct {
+	suite.Suite
+}
+
+func (suite *ScanTestSuite) TestScanCountAndString() {
+	result := &QueryResult{
+		Columns: []string{"total", "name"},
+		Rows:    []Row{{"total": int64(42), "name": "Tintin"}},
+	}
+	var total int
+	var name string
+	suite.NoError(ScanRow(result, &total, &name))
+	suite.Equal(42, total)
+	suite.Equal("Tintin", name)
+}
+
+func (suite *ScanTestSuite) TestScanNilSetsZeroValue() {
+	result := &QueryResult{Columns: []string{"name"}, Rows: []Row{{"name": nil}}}
+	name := "Tintin"
+	suite.NoError(ScanRow(result, &name))
+	suite.Equal("", name)
+}
+
+func (suite *ScanTestSuite) TestScanRowCountMismatch() {
+	var total int64
+	err := ScanRow(&QueryResult{Columns: []string{"total"}}, &total)
+	suite.EqualError(err, "expected a single row, query returned 0 rows")
+
+	result := &QueryResult{Columns: []string{"total"}, Rows: []Row{{"total": int64(1)}, {"total": int64(2)}}}
+	err = ScanRow(result, &total)
+	suite.EqualError(err, "expected a single row, query returned 2 rows")
+}
+
+func (suite *ScanTestSuite) TestScanColumnCountMismatch() {
+	result := &QueryResult{Columns: []string{"total", "name"}, Rows: []Row{{"total": int64(1), "name": "Tintin"}}}
+	var total int64
+	err := ScanRow(result, &total)
+	suite.EqualError(err, "query returned 2 columns but 1 destinations were specified")
+}
+
+func (suite *ScanTestSuite) TestScanIncompatibleType() {
+	result := &QueryResult{Columns: []string{"name"}, Rows: []Row{{"name": "Tintin"}}}
+	var total int64
+	suite.Error(ScanRow(result, &total))
+	suite.Error(ScanRow(result, total))
+}
+
+func (suite *ScanTestSuite) TestScanInt64() {
+	total, err := ScanInt64(&QueryResult{Columns: []string{"count(*)"}, Rows: []Row{{"count(*)": int64(42)}}})
+	suite.NoError(err)
+	suite.Equal(int64(42), total)
+
+	total, err = ScanInt64(&QueryResult{Columns: []string{"total"}, Rows: []Row{{"total": nil}}})
+	suite.NoError(err)
+	suite.Equal(int64(0), total)
+
+	_, err = ScanInt64(&QueryResult{Columns: []string{"average"}, Rows: []Row{{"average": 2.5}}})
+	suite.EqualError(err, "cannot scan float64 into int64")
+}
+
+func (suite *ScanTestSuite) TestScanFloat64() {
+	average, err := ScanFloat64(&QueryResult{Columns: []string{"avg(v.age)"}, Rows: []Row{{"avg(v.age)": 37.5}}})
+	suite.NoError(err)
+	suite.Equal(37.5, average)
+
+	average, err = ScanFloat64(&QueryResult{Columns: []string{"total"}, Rows: []Row{{"total": int64(3)}}})
+	suite.NoError(err)
+	suite.Equal(3.0, average)
+
+	_, err = ScanFloat64(&QueryResult{Columns: []string{"name"}, Rows: []Row{{"name": "Tintin"}}})
+	suite.EqualError(err, "cannot scan string into float64")
+}
+
+func (suite *ScanTestSuite) TestScanScalarRequiresSingleValue() {
+	_, err := ScanInt64(&QueryResult{Columns: []string{"total"}})
+	suite.EqualError(err, "expected a single row, query returned 0 rows")
+
+	result := &QueryResult{Columns: []string{"total", "name"}, Rows: []Row{{"total": int64(1), "name": "Tintin"}}}
+	_, err = ScanFloat64(result)
+	suite.EqualError(err, "expected a single column, query returned 2 columns")
+}
+
+func TestScanTestSuite(t *testing.T) {
+	suite.Run(t, new(ScanTestSuite))
+}
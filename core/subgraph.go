@@ -0,0 +1,45 @@
+package core
+
+// Subgraph is a set of distinct vertices along with the distinct edges between them
+type Subgraph struct {
+	Vertices []*Vertex
+	Edges    []*Edge
+}
+
+// NewSubgraph returns a subgraph containing the distinct vertices and edges from the specified vertices and edges,
+// retaining the order in which they are first encountered. Vertices and edges are considered identical if they have
+// the same identifier.
+//
+// The start and end vertices of each edge are set to the vertices of the subgraph identified by the SourceVertexID
+// and DestinationVertexID of the edge. Hence the edges reference the same vertex instances as the Vertices of the
+// subgraph. The start or end vertex of an edge is left unchanged if the subgraph does not contain the vertex.
+func NewSubgraph(vertices []*Vertex, edges []*Edge) *Subgraph {
+	subgraph := Subgraph{Vertices: make([]*Vertex, 0), Edges: make([]*Edge, 0)}
+	vertexIndex := make(map[string]*Vertex)
+	for _, vertex := range vertices {
+		if _, ok := vertexIndex[vertex.ID.String()]; ok {
+			continue
+		}
+		vertexIndex[vertex.ID.String()] = vertex
+		subgraph.Vertices = append(subgraph.Vertices, vertex)
+	}
+	seenEdges := make(map[string]bool)
+	for _, edge := range edges {
+		if seenEdges[edge.ID.String()] {
+			continue
+		}
+		seenEdges[edge.ID.String()] = true
+		if edge.SourceVertexID != nil {
+			if vertex, ok := vertexIndex[edge.SourceVertexID.String()]; ok {
+				edge.SourceVertex = vertex
+			}
+		}
+		if edge.DestinationVertexID != nil {
+			if vertex, ok := vertexIndex[edge.DestinationVertexID.String()]; ok {
+				edge.DestinationVertex = vertex
+			}
+		}
+		subgraph.Edges = append(subgraph.Edges, edge)
+	}
+	return &subgraph
+}
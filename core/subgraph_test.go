@@ -0,0 +1,41 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type SubgraphTestSuite struct {
+	suite.Suite
+}
+
+func (suite *SubgraphTestSuite) TestNewSubgraphRemovesDuplicates() {
+	tintin := &Vertex{ID: NewId("1"), Labels: []string{"Person"}}
+	snowy := &Vertex{ID: NewId("2"), Labels: []string{"Dog"}}
+	owns := &Edge{ID: NewId("3"), Type: "OWNS", SourceVertexID: NewId("1"), DestinationVertexID: NewId("2")}
+	duplicateOwns := &Edge{ID: NewId("3"), Type: "OWNS", SourceVertexID: NewId("1"), DestinationVertexID: NewId("2")}
+
+	subgraph := NewSubgraph([]*Vertex{tintin, snowy, {ID: NewId("1"), Labels: []string{"Person"}}}, []*Edge{owns, duplicateOwns})
+	suite.Equal([]*Vertex{tintin, snowy}, subgraph.Vertices)
+	suite.Equal(1, len(subgraph.Edges))
+	suite.Same(owns, subgraph.Edges[0])
+}
+
+func (suite *SubgraphTestSuite) TestNewSubgraphLinksEdgesToVertices() {
+	tintin := &Vertex{ID: NewId("1"), Labels: []string{"Person"}}
+	snowy := &Vertex{ID: NewId("2"), Labels: []string{"Dog"}}
+	owns := &Edge{ID: NewId("3"), Type: "OWNS", SourceVertexID: NewId("1"), DestinationVertexID: NewId("2")}
+	walks := &Edge{ID: NewId("4"), Type: "WALKS", SourceVertexID: NewId("1"), DestinationVertexID: NewId("5")}
+
+	subgraph := NewSubgraph([]*Vertex{tintin, snowy}, []*Edge{owns, walks})
+	suite.Same(tintin, subgraph.Edges[0].SourceVertex)
+	suite.Same(snowy, subgraph.Edges[0].DestinationVertex)
+	suite.Same(tintin, subgraph.Edges[1].SourceVertex)
+	// the end vertex is not a part of the subgraph
+	suite.Nil(subgraph.Edges[1].DestinationVertex)
+}
+
+func TestSubgraphTestSuite(t *testing.T) {
+	suite.Run(t, new(SubgraphTestSuite))
+}
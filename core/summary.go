@@ -0,0 +1,37 @@
+package core
+
+import "time"
+
+// QueryType classifies a query by its effect on the graph
+type QueryType int8
+
+const (
+	// QueryTypeUnknown indicates that the graph database did not classify the query
+	QueryTypeUnknown QueryType = iota
+	// QueryTypeReadOnly indicates a query only reading from the graph
+	QueryTypeReadOnly
+	// QueryTypeReadWrite indicates a query both reading from and writing to the graph
+	QueryTypeReadWrite
+	// QueryTypeWriteOnly indicates a query only writing to the graph
+	QueryTypeWriteOnly
+	// QueryTypeSchemaWrite indicates a query modifying the schema, for e.g. creating an index
+	QueryTypeSchemaWrite
+)
+
+// QuerySummary contains the details reported by a graph database on the execution of a query.
+//
+// The embedded WriteCounters along with the delete and removal counters are only populated for graph databases
+// reporting update statistics. The timings of graph databases not reporting them are measured by the client and hence
+// include the network round trip.
+type QuerySummary struct {
+	WriteCounters
+	NodesDeleted         int
+	RelationshipsDeleted int
+	LabelsRemoved        int
+	// QueryType classifies the query. Graph databases that do not classify queries report QueryTypeUnknown.
+	QueryType QueryType
+	// ResultAvailableAfter is the time elapsed until the first row of the result was available
+	ResultAvailableAfter time.Duration
+	// ResultConsumedAfter is the time elapsed from the result being available until all of its rows were consumed
+	ResultConsumedAfter time.Duration
+}
@@ -0,0 +1,44 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+const (
+	// DEFAULT_QUERY_TIMEOUT_KEY is the connection option key used to specify the timeout applied to the queries
+	// executed through the connection. The value must be a positive time.Duration.
+	DEFAULT_QUERY_TIMEOUT_KEY = "defaultQueryTimeout"
+	// ContextKeyQueryTimeout is used in context to override the default query timeout of the connection for the
+	// operations executed using the context. The value must be a positive time.Duration
+	ContextKeyQueryTimeout = coreContextKey("queryTimeout")
+)
+
+// DefaultQueryTimeoutFromOptions returns the query timeout specified against the DEFAULT_QUERY_TIMEOUT_KEY key within
+// the connection options. 0 is returned if the options do not specify a timeout, in which case the connection
+// applies its own default.
+func DefaultQueryTimeoutFromOptions(options map[string]interface{}) (time.Duration, error) {
+	value, ok := options[DEFAULT_QUERY_TIMEOUT_KEY]
+	if !ok {
+		return 0, nil
+	}
+	timeout, ok := value.(time.Duration)
+	if !ok || timeout <= 0 {
+		return 0, errors.New("value of the DEFAULT_QUERY_TIMEOUT_KEY option must be a positive time.Duration")
+	}
+	return timeout, nil
+}
+
+// WithQueryTimeout returns a copy of the specified context requesting the queries executed using the context to time
+// out after the specified duration instead of the default query timeout of the connection.
+func WithQueryTimeout(ctx context.Context, timeout time.Duration) context.Context {
+	return context.WithValue(ctx, ContextKeyQueryTimeout, timeout)
+}
+
+// QueryTimeoutFromContext returns the query timeout specified within the context using WithQueryTimeout. The second
+// return value is false if the context does not specify a positive timeout.
+func QueryTimeoutFromContext(ctx context.Context) (time.Duration, bool) {
+	timeout, ok := ctx.Value(ContextKeyQueryTimeout).(time.Duration)
+	return timeout, ok && timeout > 0
+}
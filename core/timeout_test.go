@@ -0,0 +1,46 @@
+package core
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type TimeoutTestSuite struct {
+	suite.Suite
+}
+
+func (suite *TimeoutTestSuite) TestDefaultQueryTimeoutFromOptions() {
+	timeout, err := DefaultQueryTimeoutFromOptions(map[string]interface{}{})
+	suite.NoError(err)
+	suite.Equal(time.Duration(0), timeout)
+
+	timeout, err = DefaultQueryTimeoutFromOptions(map[string]interface{}{DEFAULT_QUERY_TIMEOUT_KEY: 2 * time.Second})
+	suite.NoError(err)
+	suite.Equal(2*time.Second, timeout)
+}
+
+func (suite *TimeoutTestSuite) TestInvalidDefaultQueryTimeout() {
+	for _, value := range []interface{}{time.Duration(0), -time.Second, 2000, "2s"} {
+		_, err := DefaultQueryTimeoutFromOptions(map[string]interface{}{DEFAULT_QUERY_TIMEOUT_KEY: value})
+		suite.EqualError(err, "value of the DEFAULT_QUERY_TIMEOUT_KEY option must be a positive time.Duration")
+	}
+}
+
+func (suite *TimeoutTestSuite) TestQueryTimeoutFromContext() {
+	_, ok := QueryTimeoutFromContext(context.Background())
+	suite.False(ok)
+
+	timeout, ok := QueryTimeoutFromContext(WithQueryTimeout(context.Background(), 300*time.Millisecond))
+	suite.True(ok)
+	suite.Equal(300*time.Millisecond, timeout)
+
+	_, ok = QueryTimeoutFromContext(WithQueryTimeout(context.Background(), 0))
+	suite.False(ok)
+}
+
+func TestTimeoutTestSuite(t *testing.T) {
+	suite.Run(t, new(TimeoutTestSuite))
+}
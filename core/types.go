@@ -2,18 +2,57 @@ package core
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strconv"
+	"strings"
 )
 
+// ErrNotSupported is returned by connections for operations that are not supported by the underlying graph database
+var ErrNotSupported = errors.New("operation not supported by the graph database")
+
 // Identifier defines an in interface to be implemented by all comparable types serving as Graph node identifiers.
 type Identifier struct {
 	value any
+	// numericId is the legacy numeric id of the graph element, if known
+	numericId *int64
 }
 
 func (id *Identifier) Value() any {
 	return id.value
 }
 
+// Int64 returns the numeric id of the graph element. The numeric id is available if it was specified using
+// NewIdWithInt64, if the value of the identifier is an integer or a string containing an integer, or if the value
+// is a Neo4j element id, which embeds the legacy numeric id as the last of its colon separated parts. The second
+// return value is false if no numeric id is available.
+func (id *Identifier) Int64() (int64, bool) {
+	if id.numericId != nil {
+		return *id.numericId, true
+	}
+	switch v := id.value.(type) {
+	case int64:
+		return v, true
+	case int:
+		return int64(v), true
+	case int32:
+		return int64(v), true
+	case string:
+		if numericId, err := strconv.ParseInt(v, 10, 64); err == nil {
+			return numericId, true
+		}
+		// Neo4j element ids are of the form <version>:<database id>:<numeric id>
+		parts := strings.Split(v, ":")
+		if len(parts) != 3 {
+			return 0, false
+		}
+		numericId, err := strconv.ParseInt(parts[2], 10, 64)
+		return numericId, err == nil
+	default:
+		return 0, false
+	}
+}
+
 func (id *Identifier) String() string {
 	return fmt.Sprintf("%v", id.value)
 }
@@ -22,6 +61,12 @@ func NewId(value any) *Identifier {
 	return &Identifier{value: value}
 }
 
+// NewIdWithInt64 returns an identifier with the specified value along with the legacy numeric id of the graph
+// element, which is returned by Int64.
+func NewIdWithInt64(value any, numericId int64) *Identifier {
+	return &Identifier{value: value, numericId: &numericId}
+}
+
 // Properties is a type alias for a map of key value pairs representing vertex or edge properties
 type KVMap map[string]interface{}
 
@@ -43,6 +88,17 @@ type Row map[string]interface{}
 // QueryResult represents the result of a query execution and is made up of 0 or more rows.
 type QueryResult struct {
 	Rows []Row
+	// Columns contains the names of the columns returned by the query in the order in which they were returned
+	Columns []string
+	// Notifications contains the notifications (for e.g. performance warnings) reported by the database while
+	// executing the query. Databases that do not report notifications leave this empty.
+	Notifications []string
+	// Counters contains the update statistics reported by the database for the query. Databases that do not report
+	// update statistics leave this nil.
+	Counters *WriteCounters
+	// Summary contains the counters, timings and type of the query reported by the database. Connections that cannot
+	// report a summary for the query leave this nil.
+	Summary *QuerySummary
 }
 
 type QueryMode int8
@@ -50,19 +106,29 @@ type QueryMode int8
 const (
 	Read QueryMode = iota
 	Write
+	// Delete is used by the query builders to build queries deleting the selected elements. Queries built using
+	// the Delete mode are executed using the Write mode.
+	Delete
 )
 
+// EdgeFetchMode controls whether the edges read from the graph carry the complete start and end vertices or only
+// their identifiers
 type EdgeFetchMode int8
 
 const (
-	EdgeWithVertexIds = iota
+	// EdgeWithVertexIds reads only the identifiers of the start and end vertices of the edges
+	EdgeWithVertexIds EdgeFetchMode = iota
+	// EdgeWithCompleteVertex reads the complete start and end vertices of the edges
 	EdgeWithCompleteVertex
 )
 
+// WriteMode specifies the clause used to write vertices and edges
 type WriteMode int8
 
 const (
+	// Merge writes using MERGE, which reuses a matching vertex or edge if one exists
 	Merge WriteMode = iota
+	// Create writes using CREATE, which always writes a new vertex or edge
 	Create
 )
 
@@ -79,20 +145,63 @@ type Connection interface {
 	// selectors are required to "select" a particular node within the graph. If selectors are not specified, then all nodes in the graph
 	// with the specified label woould be selected.
 	//
-	// filters are used to filter out the results from the set of selected nodes
+	// filters are used to filter out the results from the set of selected nodes. Properties are compared for equality
+	// with the filter values unless the value is a Filter specifying another operator.
+	//
+	// The number of returned vertices can be capped by specifying a limit against the QueryParamLimit key within the queryParams.
 	QueryVertex(ctx context.Context, label string, selectors, filters, queryParams KVMap) ([]*Vertex, error)
 
+	// QueryVertexFunc invokes the specified function for each of the vertices with the specified label matching the
+	// selectors and filters, as the vertices are read from the database. Large sets of vertices can hence be processed
+	// without holding all of them in memory.
+	//
+	// The iteration stops at the first error returned by the function, which is then returned by QueryVertexFunc.
+	QueryVertexFunc(ctx context.Context, label string, selectors, filters KVMap, fn func(*Vertex) error) error
+
 	// QueryEdge returns a set of edges for the specified label
 	//
 	// selctors are required to select a particular relationship within the graph. If selectors are not specified, then all edges in the graph
 	// with the specified labels would be selected
 	//
-	// filters are used to filter out the results from the set of selected edges
+	// If the label is empty, then relationships of all types between the start and end vertices are selected.
+	//
+	// filters are used to filter out the results from the set of selected edges. As in QueryVertex, the values can be
+	// a Filter specifying the comparison operator.
 	//
 	// The level of detail about the start and end nodes of an edge  can be controled by the fetch mode. Currently, the library
 	// supports returning edges where-in the ids of the start and end vertices of the relations are available.
+	//
+	// The number of returned edges can be capped by specifying a limit against the QueryParamLimit key within the queryParams.
+	//
+	// Edges can be read a page at a time by specifying the number of edges to be skipped against the QueryParamSkip key
+	// along with a limit. Specifying a boolean value of true against the QueryParamOrderByID key orders the edges by
+	// identity and must be used when paging for the pages to be consistent with one another. The edges can instead be
+	// ordered by their properties, for e.g. the date since which a relationship holds, by specifying the properties
+	// against the QueryParamOrderBy key.
 	QueryEdge(ctx context.Context, startVertexLabel, endVertexLabel []string, label string, startVertexSelectors, endVertexSelectors, selectors KVMap, startVertexFilters, endVertexFilters, filters KVMap, queryParams KVMap, fetchMode EdgeFetchMode) ([]*Edge, error)
 
+	// QueryConnectedVertices returns the distinct start and end vertices of the edges selected using the specified labels,
+	// selectors and filters. The arguments are interpreted as in QueryEdge.
+	//
+	// Vertices participating in more than one selected edge are returned once.
+	QueryConnectedVertices(ctx context.Context, startVertexLabel, endVertexLabel []string, label string, startVertexSelectors, endVertexSelectors, selectors KVMap, startVertexFilters, endVertexFilters, filters KVMap, queryParams KVMap) ([]*Vertex, error)
+
+	// QueryPaths returns the distinct pairs of start and end vertices connected by a path of edges with the specified label
+	//
+	// The path traverses at least minHops and at most maxHops edges. A maxHops of 0 traverses any number of edges.
+	//
+	// selectors are applied to each of the edges traversed by the path
+	QueryPaths(ctx context.Context, startVertexLabel, endVertexLabel []string, label string, startVertexSelectors, endVertexSelectors, selectors KVMap, minHops, maxHops int) ([]*Path, error)
+
+	// QuerySubgraph executes the specified read query and returns the distinct vertices and edges returned by the query
+	//
+	// The query may return vertices, edges and paths within any of its columns. Vertices and edges contained within the
+	// returned paths are included in the subgraph. Values of any other type are ignored.
+	//
+	// The start and end vertices of the edges reference the vertices of the subgraph. Hence the query must also return
+	// the start and end vertices of the edges for them to be available.
+	QuerySubgraph(ctx context.Context, query string, queryParams KVMap) (*Subgraph, error)
+
 	// ExecuteReadQuery executes a query and transforms the native result set obtained from the DB to a QueryResult using the specified transform function
 	//
 	// The specified query must be a valid Cypher or Gremlin query.
@@ -104,28 +213,210 @@ type Connection interface {
 	// The queryParams parameter can be used to inject dynamic data into the query. This is an optional argument and can be nil
 	//
 	// The context can contain additional query and session configuration parameters required for execution
+	//
+	// ErrMaxRowsExceeded is returned if the query returns more rows than the maximum configured on the connection
+	// using the MAX_ROWS_KEY option.
+	//
+	// Errors are wrapped within a QueryError including the redacted query text if query debugging is enabled within
+	// the context using WithDebugQueries.
 	ExecuteQuery(ctx context.Context, query string, mode QueryMode, queryParams map[string]interface{}) (*QueryResult, error)
 
+	// ExecuteQueryTyped executes a query expected to return a single row and scans the columns of the row into the
+	// values pointed to by dest, similar to sql.Row.Scan. This is convenient for aggregate queries such as counts.
+	//
+	// An error is returned if the query does not return exactly one row or if the number of columns returned does
+	// not match the number of destinations. See ScanRow for the conversions applied to the column values.
+	ExecuteQueryTyped(ctx context.Context, query string, mode QueryMode, queryParams map[string]interface{}, dest ...interface{}) error
+
+	// QueryScalarInt executes a read query returning a single row with a single integer column, for e.g.
+	// RETURN count(*), and returns the value of the column. See ScanInt64 for the values accepted.
+	QueryScalarInt(ctx context.Context, query string, queryParams map[string]interface{}) (int64, error)
+
+	// QueryScalarFloat executes a read query returning a single row with a single numeric column, for e.g.
+	// RETURN avg(v.age), and returns the value of the column as a float64. See ScanFloat64 for the values accepted.
+	QueryScalarFloat(ctx context.Context, query string, queryParams map[string]interface{}) (float64, error)
+
+	// ExecuteQueryNormalized executes a query and returns the result with the values of the rows normalized to plain
+	// Go values using NormalizeValue, so that the result can be consumed without knowledge of the driver types.
+	//
+	// Vertices and edges are returned as maps and paths as maps containing the lists of vertices and edges of the path
+	// against the vertices and edges keys.
+	ExecuteQueryNormalized(ctx context.Context, query string, mode QueryMode, queryParams map[string]interface{}) (*QueryResult, error)
+
+	// ConvertValue converts a database specific value within a row returned by ExecuteQuery, for e.g. a node, to the
+	// corresponding core value, for e.g. a *Vertex. The second return value is false if the value is not database
+	// specific. The method can be passed as the ValueConverter of RowToVertex and RowToEdge.
+	ConvertValue(value interface{}) (interface{}, bool)
+
+	// Capabilities returns the features supported by the connection.
+	Capabilities() Capabilities
+
+	// Ping verifies that the graph database can be reached through the connection, for e.g. to detect a dropped
+	// connection within a readiness probe. The error returned by the underlying driver is returned as is so that it
+	// can be inspected by the caller.
+	Ping(ctx context.Context) error
+
 	// Close closes the connection to a database.
 	//
-	// Not all implementations of the below method ould actually close a connection. For e.g. if the database is being
+	// Not all implementations of the below method would actually close a connection. For e.g. if the database is being
+	// updated using an HTTP(s) interface then there is no requirement to close the connection explicitly. Such
+	// implementations can embed NoopCloser.
 	//
-	// updated using an HTTP(s) interface then there is no requirement to close the connection explicitly.
+	// Calling Close is always safe, including on connections that hold no resources and on connections that have
+	// already been closed.
 	Close(ctx context.Context) error
 
 	// StoreVertex stores a vertex to the underlying graph database.
 	//
 	// Upon successful storage, the passed in vertex object's ID field would be set to the ID returned by the database.
 	// Returns an error if there is a failure when persisting the vertex
+	//
+	// A partition key may be specified within the context using the ContextKeyPartitionKey key for connections to
+	// partitioned graph databases, which are expected to include it in the write using ApplyPartitionKey. The Neo4j
+	// and Agensgraph connections are not partitioned and ignore the partition key.
+	//
+	// The vertex is merged on all of its properties by default. Identity properties specified within the context
+	// using WithVertexIdentityProperties merge the vertex on those properties alone, while the remaining properties
+	// are set whenever they differ from the properties of the matched vertex. Connections unable to set the
+	// properties conditionally return an error.
+	//
+	// The outcome of the write is recorded in the WriteOutcomes specified within the context using the
+	// ContextKeyWriteOutcomes key. A write merging the vertex on all of its properties either creates a vertex or
+	// leaves the matched vertex unchanged, while a write merging the vertex on its identity properties updates the
+	// matched vertex if any of the remaining properties differs.
+	//
+	// A property of a newly created vertex can be set to the server timestamp by specifying the name of the property
+	// against the ContextKeyCreatedTimestamp key. The property is populated within the properties of the passed in
+	// vertex. Connections to databases without a server timestamp function return an error.
 	StoreVertex(ctx context.Context, vertex *Vertex) error
 
+	// StoreVerticesDistinct stores the specified vertices, which may have different labels and properties, within a
+	// single transaction. The ID field of each of the vertices is set to the ID returned by the database.
+	//
+	// Either all the vertices are stored or none are. When invoked on a transaction the vertices are stored as part
+	// of the transaction.
+	StoreVerticesDistinct(ctx context.Context, vertices []*Vertex) error
+
+	// StoreVertices creates the specified vertices in bulk within a single transaction. Unlike StoreVertex, the
+	// vertices are always created and never merged with existing vertices. The ID field of each of the vertices is
+	// set to the ID returned by the database.
+	//
+	// Either all the vertices are stored or none are. When invoked on a transaction the vertices are stored as part
+	// of the transaction.
+	StoreVertices(ctx context.Context, vertices []*Vertex) error
+
 	// StoreEdge stores a connected component to the graph database. It can be used to create a new relation
 	// between two vertices or update the properties for an existing relation
 	//
 	// Upon successful storage, the ID field of the participating vertex and edge object are populated
 	// with the DB specific identifier.
 	// Returns an error if there is a failure when persisting the edge
+	//
+	// Missing start and end vertices are created along with the edge unless a boolean value of true is specified
+	// within the context against the ContextKeyRequireExistingEndpoints key. In that case ErrEndpointNotFound
+	// is returned if either of the vertices does not exist.
+	//
+	// The start and end vertices can be written using different modes specified within the context using
+	// WithEndpointModes. For e.g. the start vertex can be required to exist while the end vertex is merged, in which
+	// case ErrEndpointNotFound is returned if the start vertex does not exist.
+	//
+	// An idempotency key specified within the context using WithIdempotencyKey merges the edge on the key alone.
+	// The remaining properties are set only when the edge is created, so that retrying a store results in a single
+	// edge.
+	//
+	// The edge is merged on all of its properties by default. Identity properties specified within the context using
+	// WithEdgeIdentityProperties merge the edge on those properties alone, while the remaining properties are set
+	// whenever the edge is written.
+	//
+	// As with StoreVertex, the partition key specified within the context using the ContextKeyPartitionKey key is
+	// meant for connections to partitioned graph databases and is ignored by the Neo4j and Agensgraph connections.
 	StoreEdge(ctx context.Context, edge *Edge) error
+
+	// BeginTx starts a new transaction against the underlying graph database.
+	//
+	// All operations performed through the returned transaction are executed as a single unit of work
+	// and are persisted only when the transaction is committed.
+	// Returns an error if a transaction cannot be started or if the connection is itself a transaction.
+	BeginTx(ctx context.Context) (Tx, error)
+
+	// BeginSnapshot starts a new read-only transaction against the underlying graph database.
+	//
+	// All the queries executed through the returned transaction are served by the same transaction. Hence the
+	// queries observe a consistent snapshot of the graph to the extent guaranteed by the isolation level of the
+	// underlying graph database. The snapshot is released by invoking Commit, Rollback or Close on the transaction.
+	// Returns an error if a transaction cannot be started or if the connection is itself a transaction.
+	BeginSnapshot(ctx context.Context) (Tx, error)
+
+	// AddLabels adds the specified labels to an existing vertex identified by the specified id.
+	//
+	// Returns an error if the vertex cannot be found. Graph databases which do not support multiple
+	// labels on a vertex return ErrNotSupported.
+	AddLabels(ctx context.Context, id *Identifier, labels []string) error
+
+	// RemoveLabels removes the specified labels from an existing vertex identified by the specified id.
+	//
+	// Returns an error if the vertex cannot be found. Graph databases which do not support multiple
+	// labels on a vertex return ErrNotSupported.
+	RemoveLabels(ctx context.Context, id *Identifier, labels []string) error
+
+	// RelationshipTypesBetween returns the distinct types of the relationships from vertices having the specified
+	// start labels to vertices having the specified end labels.
+	//
+	// Empty start or end labels match vertices of any label.
+	RelationshipTypesBetween(ctx context.Context, startLabels, endLabels []string) ([]string, error)
+
+	// LabelCombinations returns the distinct combinations of labels present on the vertices of the graph, for e.g.
+	// [Person] and [Employee Person]. The labels of each combination are sorted.
+	LabelCombinations(ctx context.Context) ([][]string, error)
+
+	// Degree returns the number of edges of the specified direction connected to the vertex identified by the specified
+	// id. Only edges with the specified labels are counted. Edges of all labels are counted if no edge labels are
+	// specified.
+	//
+	// A degree of 0 is returned if the vertex cannot be found.
+	Degree(ctx context.Context, id *Identifier, direction EdgeDirection, edgeLabels []string) (int64, error)
+
+	// ExpandNeighbors reads the edges of the specified direction connecting each of the vertices identified by the
+	// specified ids to their neighbors. Only edges with the specified labels are read. Edges of all labels are read
+	// if no edge labels are specified.
+	//
+	// The edges are returned against the string representation of the id of the expanded vertex. The neighbor is set
+	// as the source or destination vertex of each edge, depending on the direction of the edge. Vertices without
+	// any edges, or which cannot be found, are returned with no edges.
+	ExpandNeighbors(ctx context.Context, ids []*Identifier, edgeLabels []string, direction EdgeDirection) (map[string][]*Edge, error)
+
+	// DeleteVertex deletes the vertices with the specified label selected by the specified selectors, along with the
+	// edges connected to the vertices, and returns the number of deleted vertices.
+	//
+	// ErrEmptySelector is returned if no selectors are specified, so that all the vertices of the label are not
+	// deleted by accident. Deleting all the vertices of the label can be explicitly allowed using
+	// WithAllowEmptySelector. The identifiers of the deleted vertices and edges are added to the DeletedIds
+	// specified within the context using WithDeletedIds.
+	DeleteVertex(ctx context.Context, label string, selectors KVMap) (int, error)
+
+	// DeleteEdge deletes the edges with the specified label between vertices having the specified start and end
+	// labels, selected by the specified selectors, and returns the number of deleted edges. The start and end
+	// vertices are not deleted. An empty label selects edges of all types and empty start or end labels match
+	// vertices of any label.
+	//
+	// ErrEmptySelector is returned if none of the selectors are specified, unless an empty selector is explicitly
+	// allowed using WithAllowEmptySelector. The identifiers of the deleted edges are added to the DeletedIds
+	// specified within the context using WithDeletedIds.
+	DeleteEdge(ctx context.Context, startLabels, endLabels []string, edgeLabel string, startSelectors, endSelectors, edgeSelectors KVMap) (int, error)
+}
+
+// Tx represents a connection bound to a single transaction within the underlying graph database.
+//
+// A transaction must be completed by either invoking Commit or Rollback. Closing a transaction which
+// has not been committed rolls back all the operations performed through the transaction.
+type Tx interface {
+	Connection
+
+	// Commit commits all the operations performed through the transaction
+	Commit(ctx context.Context) error
+
+	// Rollback discards all the operations performed through the transaction
+	Rollback(ctx context.Context) error
 }
 
 // GetConnection returns a connection to a specified graph type.
@@ -0,0 +1,49 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type IdentifierTestSuite struct {
+	suite.Suite
+}
+
+func (suite *IdentifierTestSuite) TestInt64FromNumericValue() {
+	numericId, ok := NewId(int64(42)).Int64()
+	suite.True(ok)
+	suite.Equal(int64(42), numericId)
+
+	numericId, ok = NewId("42").Int64()
+	suite.True(ok)
+	suite.Equal(int64(42), numericId)
+}
+
+func (suite *IdentifierTestSuite) TestInt64FromElementId() {
+	numericId, ok := NewId("4:c5e4e8a3-5b4d-4b3e-9c1c-1f1d2e3a4b5c:17").Int64()
+	suite.True(ok)
+	suite.Equal(int64(17), numericId)
+}
+
+func (suite *IdentifierTestSuite) TestInt64WithNumericId() {
+	id := NewIdWithInt64("4:abc:def", 17)
+	suite.Equal("4:abc:def", id.Value())
+	numericId, ok := id.Int64()
+	suite.True(ok)
+	suite.Equal(int64(17), numericId)
+}
+
+func (suite *IdentifierTestSuite) TestInt64NotAvailable() {
+	// Agensgraph graph ids consist of the label id and the local id
+	_, ok := NewId("3.1").Int64()
+	suite.False(ok)
+	_, ok = NewId("4:abc").Int64()
+	suite.False(ok)
+	_, ok = NewId(1.5).Int64()
+	suite.False(ok)
+}
+
+func TestIdentifierTestSuite(t *testing.T) {
+	suite.Run(t, new(IdentifierTestSuite))
+}
@@ -0,0 +1,82 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+)
+
+// uriScheme is the graph type and the protocol of the connections to the graph databases identified by a URI scheme
+type uriScheme struct {
+	graphType string
+	// protocol is the protocol passed to the connector factory. The protocol of PostgreSQL based graph databases
+	// depends on the sslmode parameter of the URI instead.
+	protocol string
+}
+
+// uriSchemes maps the URI schemes to the graph types of the registered connectors. Bolt URIs are served by the neo4j
+// connector, which connects to any database speaking the Bolt protocol, for e.g. Memgraph.
+var uriSchemes = map[string]uriScheme{
+	"neo4j":      {graphType: "neo4j", protocol: "neo4j"},
+	"neo4j+s":    {graphType: "neo4j", protocol: "neo4j+s"},
+	"neo4j+ssc":  {graphType: "neo4j", protocol: "neo4j+ssc"},
+	"bolt":       {graphType: "neo4j", protocol: "bolt"},
+	"bolt+s":     {graphType: "neo4j", protocol: "bolt+s"},
+	"bolt+ssc":   {graphType: "neo4j", protocol: "bolt+ssc"},
+	"postgres":   {graphType: "agens"},
+	"postgresql": {graphType: "agens"},
+}
+
+// postgresTLSProtocol is the protocol enabling TLS for the connections to PostgreSQL based graph databases
+const postgresTLSProtocol = "tls"
+
+// InferGraphType parses the specified connection URI, for e.g. neo4j+s://graph.example.com:7687, and returns the
+// graph type of the connector serving the URI along with the protocol, host and port to be passed to the connector
+// factory. The port is nil if the URI does not specify a port, in which case the connector uses its default port.
+//
+// Neo4j and Bolt URIs map to the neo4j connector and PostgreSQL URIs to the agens connector. TLS is enabled for
+// PostgreSQL URIs specifying an sslmode other than disable.
+//
+// The credentials, database name and other parameters of the URI are not returned and must be specified using the
+// auth and options of the connector.
+func InferGraphType(uri string) (graphType, protocol, host string, port *int32, err error) {
+	parsed, err := url.Parse(uri)
+	if err != nil {
+		// the parse error is not wrapped since it includes the uri, which may contain credentials
+		return "", "", "", nil, errors.New("invalid connection uri")
+	}
+	scheme, ok := uriSchemes[parsed.Scheme]
+	if !ok {
+		return "", "", "", nil, fmt.Errorf("unsupported connection uri scheme %q", parsed.Scheme)
+	}
+	host = parsed.Hostname()
+	if host == "" {
+		return "", "", "", nil, errors.New("connection uri must specify a host")
+	}
+	if portText := parsed.Port(); portText != "" {
+		parsedPort, err := strconv.ParseInt(portText, 10, 32)
+		if err != nil {
+			return "", "", "", nil, fmt.Errorf("invalid port %s in the connection uri", portText)
+		}
+		port = new(int32)
+		*port = int32(parsedPort)
+	}
+	protocol = scheme.protocol
+	if scheme.graphType == "agens" {
+		if sslMode := parsed.Query().Get("sslmode"); sslMode != "" && sslMode != "disable" {
+			protocol = postgresTLSProtocol
+		}
+	}
+	return scheme.graphType, protocol, host, port, nil
+}
+
+// GetConnectionFromURI returns a connection to the graph database identified by the specified connection URI. The
+// graph type, protocol, host and port of the connection are inferred from the URI using InferGraphType.
+func GetConnectionFromURI(uri, realm string, auth, options map[string]interface{}) (Connection, error) {
+	graphType, protocol, host, port, err := InferGraphType(uri)
+	if err != nil {
+		return nil, err
+	}
+	return GetConnection(graphType, protocol, host, realm, port, auth, options)
+}
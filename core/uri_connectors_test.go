@@ -0,0 +1,18 @@
+package core_test
+
+import (
+	"testing"
+
+	_ "github.com/prahaladd/gograph/agensgraph"
+	"github.com/prahaladd/gograph/core"
+	_ "github.com/prahaladd/gograph/neo"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestURISchemesMapToRegisteredConnectors checks that the graph type of every URI scheme is the name under which a
+// connector registers itself, since GetConnectionFromURI would otherwise never find a connector for the scheme
+func TestURISchemesMapToRegisteredConnectors(t *testing.T) {
+	for scheme, graphType := range core.URISchemeGraphTypes() {
+		assert.NotNilf(t, core.GetConnectorFactory(graphType), "no connector registered for the graph type %s of the uri scheme %s", graphType, scheme)
+	}
+}
@@ -0,0 +1,215 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+const (
+	// ContextKeyRequireExistingEndpoints is used in context to specify that StoreEdge must only store the relationship
+	// between existing start and end vertices instead of creating the vertices if absent. Enabled by specifying
+	// a boolean value of true against the key.
+	ContextKeyRequireExistingEndpoints = coreContextKey("requireExistingEndpoints")
+	// ContextKeyCreatedTimestamp is used in context to request StoreVertex to set a property of a newly created
+	// vertex to the server timestamp. The value must be the name of the property.
+	ContextKeyCreatedTimestamp = coreContextKey("createdTimestamp")
+	// ContextKeyStartVertexMode is used in context to specify how StoreEdge writes the start vertex of the edge.
+	// The value must be an EndpointMode
+	ContextKeyStartVertexMode = coreContextKey("startVertexMode")
+	// ContextKeyEndVertexMode is used in context to specify how StoreEdge writes the end vertex of the edge.
+	// The value must be an EndpointMode
+	ContextKeyEndVertexMode = coreContextKey("endVertexMode")
+	// ContextKeyWriteMode is used in context to specify whether StoreVertex and StoreEdge write using CREATE or
+	// MERGE. The value must be a WriteMode
+	ContextKeyWriteMode = coreContextKey("writeMode")
+	// ContextKeyIdempotencyKey is used in context to specify the idempotency key of the edge stored by StoreEdge.
+	// The value must be a string
+	ContextKeyIdempotencyKey = coreContextKey("idempotencyKey")
+	// ContextKeyEdgeIdentityProperties is used in context to specify the names of the properties identifying the edge
+	// stored by StoreEdge. The value must be a []string
+	ContextKeyEdgeIdentityProperties = coreContextKey("edgeIdentityProperties")
+	// ContextKeyVertexIdentityProperties is used in context to specify the names of the properties identifying the
+	// vertex stored by StoreVertex. The value must be a []string
+	ContextKeyVertexIdentityProperties = coreContextKey("vertexIdentityProperties")
+)
+
+// IdempotencyKeyProperty is the property of an edge holding the idempotency key of the edge
+const IdempotencyKeyProperty = "idempotencyKey"
+
+// EndpointMode specifies how a vertex at either end of an edge is written along with the edge
+type EndpointMode int8
+
+const (
+	// EndpointDefault writes the vertex as part of the pattern written for the edge
+	EndpointDefault EndpointMode = iota
+	// EndpointMatch requires the vertex to exist. The edge is not written if the vertex cannot be found
+	EndpointMatch
+	// EndpointMerge merges the vertex on its own before the edge is written
+	EndpointMerge
+	// EndpointCreate creates the vertex before the edge is written
+	EndpointCreate
+)
+
+// ErrEndpointNotFound is returned by StoreEdge when existing endpoints are required and either the start or the
+// end vertex of the edge cannot be found
+var ErrEndpointNotFound = errors.New("start or end vertex of the edge not found")
+
+// RequireExistingEndpoints returns true if the context specifies that StoreEdge must only store relationships
+// between existing vertices.
+func RequireExistingEndpoints(ctx context.Context) bool {
+	requireExisting, ok := ctx.Value(ContextKeyRequireExistingEndpoints).(bool)
+	return ok && requireExisting
+}
+
+// WithEndpointModes returns a copy of the specified context requesting StoreEdge to write the start and end vertices
+// of the edge using the specified modes. For e.g. a known start vertex can be required to exist while the end vertex
+// is merged.
+func WithEndpointModes(ctx context.Context, startVertexMode, endVertexMode EndpointMode) context.Context {
+	ctx = context.WithValue(ctx, ContextKeyStartVertexMode, startVertexMode)
+	return context.WithValue(ctx, ContextKeyEndVertexMode, endVertexMode)
+}
+
+// EndpointModesFromContext returns the modes used by StoreEdge to write the start and end vertices of the edge.
+// Vertices without a mode specified within the context are matched if existing endpoints are required and use
+// EndpointDefault otherwise.
+func EndpointModesFromContext(ctx context.Context) (EndpointMode, EndpointMode) {
+	defaultMode := EndpointDefault
+	if RequireExistingEndpoints(ctx) {
+		defaultMode = EndpointMatch
+	}
+	startVertexMode, ok := ctx.Value(ContextKeyStartVertexMode).(EndpointMode)
+	if !ok || startVertexMode == EndpointDefault {
+		startVertexMode = defaultMode
+	}
+	endVertexMode, ok := ctx.Value(ContextKeyEndVertexMode).(EndpointMode)
+	if !ok || endVertexMode == EndpointDefault {
+		endVertexMode = defaultMode
+	}
+	return startVertexMode, endVertexMode
+}
+
+// WithWriteMode returns a copy of the specified context requesting StoreVertex and StoreEdge to write using the
+// specified write mode. The write mode takes precedence over the connection specific context keys controlling the
+// write mode.
+func WithWriteMode(ctx context.Context, writeMode WriteMode) context.Context {
+	return context.WithValue(ctx, ContextKeyWriteMode, writeMode)
+}
+
+// WriteModeFromContext returns the write mode specified within the context using WithWriteMode. The second return
+// value is false if no write mode is specified.
+func WriteModeFromContext(ctx context.Context) (WriteMode, bool) {
+	writeMode, ok := ctx.Value(ContextKeyWriteMode).(WriteMode)
+	return writeMode, ok
+}
+
+// WithIdempotencyKey returns a copy of the specified context requesting StoreEdge to merge the edge on the specified
+// idempotency key instead of the complete set of properties of the edge. The key is stored against the
+// IdempotencyKeyProperty of the edge and the remaining properties are set only when the edge is created.
+//
+// Storing an edge again with the same key, type and endpoints, for e.g. when retrying a failed request, hence
+// always results in a single edge even if the properties of the edge differ.
+func WithIdempotencyKey(ctx context.Context, key string) context.Context {
+	return context.WithValue(ctx, ContextKeyIdempotencyKey, key)
+}
+
+// IdempotencyKeyFromContext returns the idempotency key specified within the context using WithIdempotencyKey. The
+// second return value is false if no key is specified.
+func IdempotencyKeyFromContext(ctx context.Context) (string, bool) {
+	key, ok := ctx.Value(ContextKeyIdempotencyKey).(string)
+	return key, ok && key != ""
+}
+
+// WithEdgeIdentityProperties returns a copy of the specified context requesting StoreEdge to merge the edge on the
+// specified identity properties alone, along with its type and endpoints. The remaining properties of the edge are
+// data properties, which are set whenever the edge is written. Storing an edge again with a changed data property,
+// for e.g. a weight, hence updates the existing edge instead of creating another edge.
+//
+// An idempotency key specified using WithIdempotencyKey takes precedence over the identity properties.
+func WithEdgeIdentityProperties(ctx context.Context, properties ...string) context.Context {
+	return context.WithValue(ctx, ContextKeyEdgeIdentityProperties, properties)
+}
+
+// EdgeIdentityPropertiesFromContext returns the names of the identity properties of an edge specified within the
+// context using WithEdgeIdentityProperties. The second return value is false if no identity properties are specified.
+func EdgeIdentityPropertiesFromContext(ctx context.Context) ([]string, bool) {
+	properties, ok := ctx.Value(ContextKeyEdgeIdentityProperties).([]string)
+	return properties, ok
+}
+
+// WithVertexIdentityProperties returns a copy of the specified context requesting StoreVertex to merge the vertex on
+// the specified identity properties alone, along with its labels. The remaining properties of the vertex are data
+// properties, which are set whenever they differ from the properties of the existing vertex. Storing a vertex again
+// with a changed data property hence updates the existing vertex, while storing the same vertex again leaves the
+// vertex unchanged.
+func WithVertexIdentityProperties(ctx context.Context, properties ...string) context.Context {
+	return context.WithValue(ctx, ContextKeyVertexIdentityProperties, properties)
+}
+
+// VertexIdentityPropertiesFromContext returns the names of the identity properties of a vertex specified within the
+// context using WithVertexIdentityProperties. The second return value is false if no identity properties are
+// specified.
+func VertexIdentityPropertiesFromContext(ctx context.Context) ([]string, bool) {
+	properties, ok := ctx.Value(ContextKeyVertexIdentityProperties).([]string)
+	return properties, ok
+}
+
+// SplitEdgeProperties splits the properties of an edge into the identity properties with the specified names and the
+// remaining data properties. Identity properties absent from the edge are ignored.
+func SplitEdgeProperties(properties KVMap, identity []string) (KVMap, KVMap) {
+	return SplitIdentityProperties(properties, identity)
+}
+
+// SplitIdentityProperties splits the properties of a vertex or an edge into the identity properties with the
+// specified names and the remaining data properties. Identity properties absent from the properties are ignored.
+func SplitIdentityProperties(properties KVMap, identity []string) (KVMap, KVMap) {
+	identityProperties := make(KVMap)
+	dataProperties := make(KVMap)
+	for k, v := range properties {
+		dataProperties[k] = v
+	}
+	for _, name := range identity {
+		if v, ok := dataProperties[name]; ok {
+			identityProperties[name] = v
+			delete(dataProperties, name)
+		}
+	}
+	return identityProperties, dataProperties
+}
+
+// CreatedTimestampProperty returns the name of the property to be set to the server timestamp when StoreVertex
+// creates a vertex
+func CreatedTimestampProperty(ctx context.Context) (string, bool) {
+	property, ok := ctx.Value(ContextKeyCreatedTimestamp).(string)
+	return property, ok && property != ""
+}
+
+// StoreEachVertex stores the specified vertices one at a time using StoreVertex on the specified connection. The
+// vertices may have different labels and properties. Storing stops at the first vertex that fails to store.
+func StoreEachVertex(ctx context.Context, conn Connection, vertices []*Vertex) error {
+	for i, vertex := range vertices {
+		if err := conn.StoreVertex(ctx, vertex); err != nil {
+			return fmt.Errorf("failed to store vertex %d: %w", i, err)
+		}
+	}
+	return nil
+}
+
+// StoreVerticesInTx stores the specified vertices within a single transaction started on the specified connection.
+//
+// The transaction is rolled back if any of the vertices fails to store, in which case the IDs populated for the
+// vertices stored till then are reset to nil.
+func StoreVerticesInTx(ctx context.Context, conn Connection, vertices []*Vertex) error {
+	tx, err := conn.BeginTx(ctx)
+	if err != nil {
+		return err
+	}
+	if err = StoreEachVertex(ctx, tx, vertices); err != nil {
+		tx.Rollback(ctx)
+		for _, vertex := range vertices {
+			vertex.ID = nil
+		}
+		return err
+	}
+	return tx.Commit(ctx)
+}
@@ -0,0 +1,175 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+// recordingConnection is a Connection that assigns sequential IDs to the stored vertices. Only the methods used by
+// the tests are implemented.
+type recordingConnection struct {
+	Connection
+	stored    []*Vertex
+	failAfter int
+	committed bool
+}
+
+func (rc *recordingConnection) StoreVertex(ctx context.Context, vertex *Vertex) error {
+	if rc.failAfter > 0 && len(rc.stored) == rc.failAfter {
+		return errors.New("store failed")
+	}
+	rc.stored = append(rc.stored, vertex)
+	vertex.ID = NewId(strconv.Itoa(len(rc.stored)))
+	return nil
+}
+
+func (rc *recordingConnection) BeginTx(ctx context.Context) (Tx, error) {
+	return &recordingTx{recordingConnection: rc}, nil
+}
+
+type recordingTx struct {
+	*recordingConnection
+}
+
+func (rt *recordingTx) Commit(ctx context.Context) error {
+	rt.committed = true
+	return nil
+}
+
+func (rt *recordingTx) Rollback(ctx context.Context) error {
+	rt.stored = nil
+	return nil
+}
+
+type WriteTestSuite struct {
+	suite.Suite
+}
+
+func (suite *WriteTestSuite) TestRequireExistingEndpoints() {
+	ctx := context.WithValue(context.Background(), ContextKeyRequireExistingEndpoints, true)
+	suite.True(RequireExistingEndpoints(ctx))
+}
+
+func (suite *WriteTestSuite) TestRequireExistingEndpointsNotSpecified() {
+	suite.False(RequireExistingEndpoints(context.Background()))
+	ctx := context.WithValue(context.Background(), ContextKeyRequireExistingEndpoints, "true")
+	suite.False(RequireExistingEndpoints(ctx))
+}
+
+func (suite *WriteTestSuite) TestEndpointModes() {
+	ctx := WithEndpointModes(context.Background(), EndpointMatch, EndpointMerge)
+	startVertexMode, endVertexMode := EndpointModesFromContext(ctx)
+	suite.Equal(EndpointMatch, startVertexMode)
+	suite.Equal(EndpointMerge, endVertexMode)
+}
+
+func (suite *WriteTestSuite) TestEndpointModesNotSpecified() {
+	startVertexMode, endVertexMode := EndpointModesFromContext(context.Background())
+	suite.Equal(EndpointDefault, startVertexMode)
+	suite.Equal(EndpointDefault, endVertexMode)
+}
+
+func (suite *WriteTestSuite) TestEndpointModesWithRequireExistingEndpoints() {
+	ctx := context.WithValue(context.Background(), ContextKeyRequireExistingEndpoints, true)
+	startVertexMode, endVertexMode := EndpointModesFromContext(ctx)
+	suite.Equal(EndpointMatch, startVertexMode)
+	suite.Equal(EndpointMatch, endVertexMode)
+
+	// an explicit mode takes precedence over requiring existing endpoints
+	ctx = WithEndpointModes(ctx, EndpointDefault, EndpointCreate)
+	startVertexMode, endVertexMode = EndpointModesFromContext(ctx)
+	suite.Equal(EndpointMatch, startVertexMode)
+	suite.Equal(EndpointCreate, endVertexMode)
+}
+
+func (suite *WriteTestSuite) TestWriteMode() {
+	writeMode, ok := WriteModeFromContext(WithWriteMode(context.Background(), Create))
+	suite.True(ok)
+	suite.Equal(Create, writeMode)
+
+	_, ok = WriteModeFromContext(context.Background())
+	suite.False(ok)
+}
+
+func (suite *WriteTestSuite) TestIdempotencyKey() {
+	key, ok := IdempotencyKeyFromContext(WithIdempotencyKey(context.Background(), "request-1"))
+	suite.True(ok)
+	suite.Equal("request-1", key)
+
+	_, ok = IdempotencyKeyFromContext(context.Background())
+	suite.False(ok)
+	_, ok = IdempotencyKeyFromContext(WithIdempotencyKey(context.Background(), ""))
+	suite.False(ok)
+}
+
+func (suite *WriteTestSuite) TestEdgeIdentityProperties() {
+	identity, ok := EdgeIdentityPropertiesFromContext(WithEdgeIdentityProperties(context.Background(), "since"))
+	suite.True(ok)
+	suite.Equal([]string{"since"}, identity)
+
+	_, ok = EdgeIdentityPropertiesFromContext(context.Background())
+	suite.False(ok)
+}
+
+func (suite *WriteTestSuite) TestVertexIdentityProperties() {
+	identity, ok := VertexIdentityPropertiesFromContext(WithVertexIdentityProperties(context.Background(), "name"))
+	suite.True(ok)
+	suite.Equal([]string{"name"}, identity)
+
+	_, ok = VertexIdentityPropertiesFromContext(context.Background())
+	suite.False(ok)
+	// the identity properties of edges do not apply to vertices
+	_, ok = VertexIdentityPropertiesFromContext(WithEdgeIdentityProperties(context.Background(), "since"))
+	suite.False(ok)
+}
+
+func (suite *WriteTestSuite) TestSplitEdgeProperties() {
+	properties := KVMap{"since": 1941, "weight": 0.5, "note": "friends"}
+	identityProperties, dataProperties := SplitEdgeProperties(properties, []string{"since", "missing"})
+	suite.Equal(KVMap{"since": 1941}, identityProperties)
+	suite.Equal(KVMap{"weight": 0.5, "note": "friends"}, dataProperties)
+	// the properties of the edge are left untouched
+	suite.Equal(KVMap{"since": 1941, "weight": 0.5, "note": "friends"}, properties)
+}
+
+func (suite *WriteTestSuite) TestCreatedTimestampProperty() {
+	ctx := context.WithValue(context.Background(), ContextKeyCreatedTimestamp, "createdAt")
+	property, ok := CreatedTimestampProperty(ctx)
+	suite.True(ok)
+	suite.Equal("createdAt", property)
+
+	_, ok = CreatedTimestampProperty(context.Background())
+	suite.False(ok)
+	_, ok = CreatedTimestampProperty(context.WithValue(context.Background(), ContextKeyCreatedTimestamp, ""))
+	suite.False(ok)
+}
+
+func (suite *WriteTestSuite) TestStoreVerticesInTx() {
+	conn := &recordingConnection{}
+	person := &Vertex{Labels: []string{"Person"}, Properties: KVMap{"name": "Tintin"}}
+	company := &Vertex{Labels: []string{"Company"}, Properties: KVMap{"name": "Moulinsart", "founded": 1929}}
+	suite.NoError(StoreVerticesInTx(context.Background(), conn, []*Vertex{person, company}))
+	suite.True(conn.committed)
+	suite.Equal([]*Vertex{person, company}, conn.stored)
+	suite.Equal("1", person.ID.String())
+	suite.Equal("2", company.ID.String())
+}
+
+func (suite *WriteTestSuite) TestStoreVerticesInTxRollsBackOnFailure() {
+	conn := &recordingConnection{failAfter: 1}
+	person := &Vertex{Labels: []string{"Person"}}
+	company := &Vertex{Labels: []string{"Company"}}
+	err := StoreVerticesInTx(context.Background(), conn, []*Vertex{person, company})
+	suite.EqualError(err, "failed to store vertex 1: store failed")
+	suite.False(conn.committed)
+	suite.Nil(person.ID)
+	suite.Nil(company.ID)
+}
+
+func TestWriteTestSuite(t *testing.T) {
+	suite.Run(t, new(WriteTestSuite))
+}
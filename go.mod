@@ -3,15 +3,15 @@ module github.com/prahaladd/gograph
 go 1.19
 
 require (
+	github.com/bitnine-oss/agensgraph-golang v0.1.0
+	github.com/lib/pq v1.10.7
+	github.com/mitchellh/mapstructure v1.5.0
 	github.com/neo4j/neo4j-go-driver/v5 v5.2.0
 	github.com/stretchr/testify v1.8.1
 )
 
 require (
-	github.com/bitnine-oss/agensgraph-golang v0.1.0 // indirect
 	github.com/davecgh/go-spew v1.1.1 // indirect
-	github.com/lib/pq v1.10.7 // indirect
-	github.com/mitchellh/mapstructure v1.5.0 // indirect
 	github.com/pmezard/go-difflib v1.0.0 // indirect
 	gopkg.in/yaml.v3 v3.0.1 // indirect
 )
@@ -2,6 +2,7 @@ package agensgraph
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strconv"
 	"strings"
@@ -24,6 +25,20 @@ type AgensGraphIntegrationTestSuite struct {
 }
 
 func (suite *AgensGraphIntegrationTestSuite) SetupTest() {
+	suite.connection = suite.newConnection(nil)
+	suite.context = context.Background()
+	suite.context = context.WithValue(suite.context, agensgraph.ContextKeyGraphName, "agens")
+	suite.context = context.WithValue(suite.context, agensgraph.ContextKeyWriteModeCreate, true)
+	suite.vlabelsToCleanUp = make([]string, 0)
+	suite.elabelsToCleanUp = make([]string, 0)
+	suite.cleanupDB()
+	suite.store = omg.NewGenericStore(suite.connection, omg.NewReflectionMapper())
+
+}
+
+// newConnection returns a new connection to the Agensgraph instance configured via the environment
+// along with the additional connection options specified
+func (suite *AgensGraphIntegrationTestSuite) newConnection(options core.KVMap) core.Connection {
 	host := integrationtests.GetFromEnvWithDefault("AGENS_HOST", "localhost")
 	portFromEnv := integrationtests.GetFromEnvWithDefault("AGENS_PORT", "5432")
 	portParsed, err := strconv.ParseInt(portFromEnv, 10, 32)
@@ -37,18 +52,14 @@ func (suite *AgensGraphIntegrationTestSuite) SetupTest() {
 	suite.NotEqual("", userName)
 	pwd := integrationtests.GetFromEnvWithDefault("AGENS_PWD", "")
 	suite.NotEqual("", pwd)
+	connectionOptions := core.KVMap{agensgraph.AGENS_DBNAME_KEY: db}
+	for k, v := range options {
+		connectionOptions[k] = v
+	}
 	agensConnectionFactory := core.GetConnectorFactory("agens")
-	conn, err := agensConnectionFactory(protocol, host, "", port, core.KVMap{agensgraph.AGENS_PASSWD_KEY: pwd, agensgraph.AGENS_USER_KEY: userName}, core.KVMap{agensgraph.AGENS_DBNAME_KEY: db})
+	conn, err := agensConnectionFactory(protocol, host, "", port, core.KVMap{agensgraph.AGENS_PASSWD_KEY: pwd, agensgraph.AGENS_USER_KEY: userName}, connectionOptions)
 	suite.NoError(err)
-	suite.connection = conn
-	suite.context = context.Background()
-	suite.context = context.WithValue(suite.context, agensgraph.ContextKeyGraphName, "agens")
-	suite.context = context.WithValue(suite.context, agensgraph.ContextKeyWriteModeCreate, true)
-	suite.vlabelsToCleanUp = make([]string, 0)
-	suite.elabelsToCleanUp = make([]string, 0)
-	suite.cleanupDB()
-	suite.store = omg.NewGenericStore(suite.connection, omg.NewReflectionMapper())
-
+	return conn
 }
 
 func (suite *AgensGraphIntegrationTestSuite) TestWriteAndQuery() {
@@ -89,6 +100,418 @@ func (suite *AgensGraphIntegrationTestSuite) TestVertexQuery() {
 	suite.Equal(strings.ToLower("LIVES_IN"), edge[0].Type)
 }
 
+func (suite *AgensGraphIntegrationTestSuite) TestQueryEdgeAnyLabel() {
+	suite.vlabelsToCleanUp = append(suite.vlabelsToCleanUp, "Person", "Country")
+	suite.elabelsToCleanUp = append(suite.elabelsToCleanUp, "LIVES_IN", "VISITED")
+	query := "create (p:Person{name:'Tintin'})-[r:LIVES_IN{since:1929}]->(c:Country{name:'Belgium'}), (p)-[v:VISITED{year:1931}]->(c) return p, r, v, c"
+	_, err := suite.connection.ExecuteQuery(suite.context, query, core.Write, nil)
+	suite.NoError(err)
+
+	edges, err := suite.connection.QueryEdge(suite.context, []string{"Person"}, []string{"Country"}, "",
+		core.KVMap{"name": "Tintin"}, core.KVMap{"name": "Belgium"}, nil, nil, nil, nil, nil, core.EdgeWithVertexIds)
+	suite.NoError(err)
+	suite.Equal(2, len(edges))
+	edgeTypes := make([]string, 0)
+	for _, edge := range edges {
+		edgeTypes = append(edgeTypes, strings.ToUpper(edge.Type))
+	}
+	suite.ElementsMatch([]string{"LIVES_IN", "VISITED"}, edgeTypes)
+}
+
+func (suite *AgensGraphIntegrationTestSuite) TestRelationshipTypesBetween() {
+	suite.vlabelsToCleanUp = append(suite.vlabelsToCleanUp, "Person", "Company")
+	suite.elabelsToCleanUp = append(suite.elabelsToCleanUp, "WORKS_AT", "FOUNDED", "INVESTED_IN")
+	query := "create (p:Person{name:'Elon'})-[:WORKS_AT]->(c:Company{name:'Rockets Inc'}), (p)-[:FOUNDED]->(c), (p)-[:INVESTED_IN]->(c), (q:Person{name:'Gwynne'})-[:WORKS_AT]->(c) return p, q, c"
+	_, err := suite.connection.ExecuteQuery(suite.context, query, core.Write, nil)
+	suite.NoError(err)
+
+	relationshipTypes, err := suite.connection.RelationshipTypesBetween(suite.context, []string{"Person"}, []string{"Company"})
+	suite.NoError(err)
+	for i, relationshipType := range relationshipTypes {
+		relationshipTypes[i] = strings.ToUpper(relationshipType)
+	}
+	suite.ElementsMatch([]string{"WORKS_AT", "FOUNDED", "INVESTED_IN"}, relationshipTypes)
+}
+
+func (suite *AgensGraphIntegrationTestSuite) TestQueryWithLimit() {
+	suite.vlabelsToCleanUp = append(suite.vlabelsToCleanUp, "Person", "Country")
+	suite.elabelsToCleanUp = append(suite.elabelsToCleanUp, "VISITED")
+	query := "create (t1:Person{name:'Tom', age:10})-[:VISITED]->(c:Country{name:'India'}), (t2:Person{name:'Tom', age:20})-[:VISITED]->(c), (t3:Person{name:'Tom', age:30})-[:VISITED]->(c) return t1, t2, t3, c"
+	_, err := suite.connection.ExecuteQuery(suite.context, query, core.Write, nil)
+	suite.NoError(err)
+
+	vertices, err := suite.connection.QueryVertex(suite.context, "Person", core.KVMap{"name": "Tom"}, nil, nil)
+	suite.NoError(err)
+	suite.Equal(3, len(vertices))
+	vertices, err = suite.connection.QueryVertex(suite.context, "Person", core.KVMap{"name": "Tom"}, nil, core.KVMap{core.QueryParamLimit: 2})
+	suite.NoError(err)
+	suite.Equal(2, len(vertices))
+
+	edges, err := suite.connection.QueryEdge(suite.context, []string{"Person"}, []string{"Country"}, "VISITED",
+		core.KVMap{"name": "Tom"}, core.KVMap{"name": "India"}, nil, nil, nil, nil, core.KVMap{core.QueryParamLimit: 1}, core.EdgeWithCompleteVertex)
+	suite.NoError(err)
+	suite.Equal(1, len(edges))
+}
+
+func (suite *AgensGraphIntegrationTestSuite) TestQueryEdgeWithFilters() {
+	suite.vlabelsToCleanUp = append(suite.vlabelsToCleanUp, "Person", "Country")
+	suite.elabelsToCleanUp = append(suite.elabelsToCleanUp, "VISITED")
+	query := "create (p:Person{name:'Tintin'})-[:VISITED{year:1931}]->(c:Country{name:'Congo'}), (p)-[:VISITED{year:1946}]->(c) return p, c"
+	_, err := suite.connection.ExecuteQuery(suite.context, query, core.Write, nil)
+	suite.NoError(err)
+
+	edges, err := suite.connection.QueryEdge(suite.context, []string{"Person"}, []string{"Country"}, "VISITED",
+		core.KVMap{"name": "Tintin"}, core.KVMap{"name": "Congo"}, nil, nil, nil, core.KVMap{"year": 1946}, nil, core.EdgeWithVertexIds)
+	suite.NoError(err)
+	suite.Equal(1, len(edges))
+	suite.Equal(int64(1946), edges[0].Properties["year"])
+}
+
+func (suite *AgensGraphIntegrationTestSuite) TestQueryConnectedVerticesOfStar() {
+	suite.vlabelsToCleanUp = append(suite.vlabelsToCleanUp, "Airport")
+	suite.elabelsToCleanUp = append(suite.elabelsToCleanUp, "FLIES_TO")
+	query := "create (hub:Airport{code:'BOM'})-[:FLIES_TO]->(:Airport{code:'DEL'}), (hub)-[:FLIES_TO]->(:Airport{code:'BLR'}), (hub)-[:FLIES_TO]->(:Airport{code:'MAA'}) return hub"
+	_, err := suite.connection.ExecuteQuery(suite.context, query, core.Write, nil)
+	suite.NoError(err)
+
+	vertices, err := suite.connection.QueryConnectedVertices(suite.context, []string{"Airport"}, []string{"Airport"}, "FLIES_TO",
+		core.KVMap{"code": "BOM"}, nil, nil, nil, nil, nil, nil)
+	suite.NoError(err)
+	codes := make([]string, 0)
+	for _, v := range vertices {
+		codes = append(codes, v.Properties["code"].(string))
+	}
+	suite.ElementsMatch([]string{"BOM", "DEL", "BLR", "MAA"}, codes)
+}
+
+func (suite *AgensGraphIntegrationTestSuite) TestExecuteQueryTyped() {
+	suite.vlabelsToCleanUp = append(suite.vlabelsToCleanUp, "Person")
+	query := "create (:Person{name:'Tintin'}), (:Person{name:'Haddock'}) return 1"
+	_, err := suite.connection.ExecuteQuery(suite.context, query, core.Write, nil)
+	suite.NoError(err)
+
+	var total int64
+	var name string
+	query = "match (p:Person) return count(p) as total, min(p.name) as name"
+	err = suite.connection.ExecuteQueryTyped(suite.context, query, core.Read, nil, &total, &name)
+	suite.NoError(err)
+	suite.Equal(int64(2), total)
+	suite.Equal("Haddock", name)
+
+	err = suite.connection.ExecuteQueryTyped(suite.context, query, core.Read, nil, &total)
+	suite.Error(err)
+}
+
+func (suite *AgensGraphIntegrationTestSuite) TestQueryScalar() {
+	suite.vlabelsToCleanUp = append(suite.vlabelsToCleanUp, "Person")
+	query := "create (:Person{name:'Tintin', age: 20}), (:Person{name:'Haddock', age: 45}) return 1"
+	_, err := suite.connection.ExecuteQuery(suite.context, query, core.Write, nil)
+	suite.NoError(err)
+
+	total, err := suite.connection.QueryScalarInt(suite.context, "match (p:Person) return count(*)", nil)
+	suite.NoError(err)
+	suite.Equal(int64(2), total)
+	average, err := suite.connection.QueryScalarFloat(suite.context, "match (p:Person) return avg(p.age)", nil)
+	suite.NoError(err)
+	suite.Equal(32.5, average)
+}
+
+func (suite *AgensGraphIntegrationTestSuite) TestStoreVerticesDistinct() {
+	suite.vlabelsToCleanUp = append(suite.vlabelsToCleanUp, "Person", "Company")
+	person := &core.Vertex{Labels: []string{"Person"}, Properties: core.KVMap{"name": "Tintin"}}
+	company := &core.Vertex{Labels: []string{"Company"}, Properties: core.KVMap{"name": "Moulinsart", "founded": int64(1929)}}
+	err := suite.connection.StoreVerticesDistinct(suite.context, []*core.Vertex{person, company})
+	suite.NoError(err)
+	suite.NotNil(person.ID)
+	suite.NotNil(company.ID)
+
+	vertices, err := suite.connection.QueryVertex(suite.context, "Company", core.KVMap{"name": "Moulinsart"}, nil, nil)
+	suite.NoError(err)
+	suite.Equal(1, len(vertices))
+	suite.Equal(company.ID, vertices[0].ID)
+	vertices, err = suite.connection.QueryVertex(suite.context, "Person", core.KVMap{"name": "Tintin"}, nil, nil)
+	suite.NoError(err)
+	suite.Equal(1, len(vertices))
+	suite.Equal(person.ID, vertices[0].ID)
+}
+
+func (suite *AgensGraphIntegrationTestSuite) TestStoreVertices() {
+	suite.vlabelsToCleanUp = append(suite.vlabelsToCleanUp, "Person", "Company")
+	vertices := []*core.Vertex{
+		{Labels: []string{"Person"}, Properties: core.KVMap{"name": "Tintin"}},
+		{Labels: []string{"Person"}, Properties: core.KVMap{"name": "Haddock"}},
+		{Labels: []string{"Company"}, Properties: core.KVMap{"name": "Moulinsart"}},
+	}
+	err := suite.connection.StoreVertices(suite.context, vertices)
+	suite.NoError(err)
+	for _, vertex := range vertices {
+		suite.NotNil(vertex.ID)
+	}
+
+	persons, err := suite.connection.QueryVertex(suite.context, "Person", core.KVMap{"name": "Haddock"}, nil, nil)
+	suite.NoError(err)
+	suite.Equal(1, len(persons))
+	suite.Equal(vertices[1].ID, persons[0].ID)
+	companies, err := suite.connection.QueryVertex(suite.context, "Company", nil, nil, nil)
+	suite.NoError(err)
+	suite.Equal(1, len(companies))
+	suite.Equal(vertices[2].ID, companies[0].ID)
+}
+
+func (suite *AgensGraphIntegrationTestSuite) TestStoreEdgeSelfLoop() {
+	suite.vlabelsToCleanUp = append(suite.vlabelsToCleanUp, "Person")
+	suite.elabelsToCleanUp = append(suite.elabelsToCleanUp, "KNOWS")
+	rel := core.Edge{
+		Type:              "KNOWS",
+		SourceVertex:      &core.Vertex{Labels: []string{"Person"}, Properties: core.KVMap{"name": "Narcissus"}},
+		DestinationVertex: &core.Vertex{Labels: []string{"Person"}, Properties: core.KVMap{"name": "Narcissus"}},
+	}
+	err := suite.connection.StoreEdge(suite.context, &rel)
+	suite.NoError(err)
+	suite.Equal(rel.SourceVertex.ID, rel.DestinationVertex.ID)
+
+	vertices, err := suite.connection.QueryVertex(suite.context, "Person", core.KVMap{"name": "Narcissus"}, nil, nil)
+	suite.NoError(err)
+	suite.Equal(1, len(vertices))
+
+	edges, err := suite.connection.QueryEdge(suite.context, []string{"Person"}, []string{"Person"}, "KNOWS", nil, nil, nil, nil, nil, nil, nil, core.EdgeWithVertexIds)
+	suite.NoError(err)
+	suite.Equal(1, len(edges))
+	suite.Equal(rel.ID, edges[0].ID)
+	suite.Equal(vertices[0].ID, edges[0].SourceVertexID)
+	suite.Equal(vertices[0].ID, edges[0].DestinationVertexID)
+}
+
+func (suite *AgensGraphIntegrationTestSuite) TestReadOmgStructBySingleField() {
+	suite.vlabelsToCleanUp = append(suite.vlabelsToCleanUp, "person")
+	p := person{Name: "Tom", Age: 10}
+	err := suite.store.PersistVertex(suite.context, &p)
+	suite.NoError(err)
+
+	// the age in the example differs from the stored age and must not be used as a selector
+	read, err := suite.store.ReadVertexBy(suite.context, &person{Name: "Tom", Age: 11}, []string{"Name"})
+	suite.NoError(err)
+	suite.Equal(1, len(read))
+	suite.Equal(p, *(read[0].(*person)))
+
+	read, err = suite.store.ReadVertex(suite.context, &person{Name: "Tom", Age: 11})
+	suite.NoError(err)
+	suite.Equal(0, len(read))
+}
+
+func (suite *AgensGraphIntegrationTestSuite) TestPersistOmgStructWithAdjacentVertices() {
+	suite.vlabelsToCleanUp = append(suite.vlabelsToCleanUp, "purchaseorder", "lineitem")
+	suite.elabelsToCleanUp = append(suite.elabelsToCleanUp, "has_item")
+	o := purchaseOrder{Number: "A-1", LineItems: []lineItem{{Product: "Pen", Quantity: 2}, {Product: "Ink", Quantity: 1}}}
+	err := suite.store.PersistVertex(suite.context, &o)
+	suite.NoError(err)
+
+	read, err := suite.store.ReadVertex(suite.context, &purchaseOrder{Number: "A-1"})
+	suite.NoError(err)
+	suite.Equal(1, len(read))
+	readOrder := read[0].(*purchaseOrder)
+	suite.Equal("A-1", readOrder.Number)
+	suite.ElementsMatch(o.LineItems, readOrder.LineItems)
+
+	// the line items are stored as vertices connected to the order
+	edges, err := suite.connection.QueryEdge(suite.context, []string{"purchaseorder"}, []string{"lineitem"}, "has_item", core.KVMap{"number": "A-1"}, nil, nil, nil, nil, nil, nil, core.EdgeWithCompleteVertex)
+	suite.NoError(err)
+	suite.Equal(2, len(edges))
+}
+
+func (suite *AgensGraphIntegrationTestSuite) TestQueryEdgePaged() {
+	suite.vlabelsToCleanUp = append(suite.vlabelsToCleanUp, "reader")
+	suite.elabelsToCleanUp = append(suite.elabelsToCleanUp, "follows")
+	for i := 0; i < 5; i++ {
+		edge := core.Edge{
+			Type:              "follows",
+			SourceVertex:      &core.Vertex{Labels: []string{"reader"}, Properties: core.KVMap{"name": "Tintin"}},
+			DestinationVertex: &core.Vertex{Labels: []string{"reader"}, Properties: core.KVMap{"name": "Reader " + strconv.Itoa(i)}},
+			Properties:        core.KVMap{},
+		}
+		suite.NoError(suite.connection.StoreEdge(suite.context, &edge))
+	}
+
+	seen := make(map[string]bool)
+	for skip := 0; skip < 6; skip += 2 {
+		queryParams := core.KVMap{core.QueryParamSkip: skip, core.QueryParamLimit: 2, core.QueryParamOrderByID: true}
+		page, err := suite.connection.QueryEdge(suite.context, []string{"reader"}, []string{"reader"}, "follows", nil, nil, nil, nil, nil, nil, queryParams, core.EdgeWithCompleteVertex)
+		suite.NoError(err)
+		if skip < 4 {
+			suite.Equal(2, len(page))
+		} else {
+			suite.Equal(1, len(page))
+		}
+		for _, edge := range page {
+			suite.False(seen[edge.ID.String()])
+			seen[edge.ID.String()] = true
+		}
+	}
+	suite.Equal(5, len(seen))
+}
+
+func (suite *AgensGraphIntegrationTestSuite) TestQueryEdgeOrderedByProperty() {
+	suite.vlabelsToCleanUp = append(suite.vlabelsToCleanUp, "reader")
+	suite.elabelsToCleanUp = append(suite.elabelsToCleanUp, "follows")
+	for i, since := range []int{1941, 1929, 1950, 1934} {
+		edge := core.Edge{
+			Type:              "follows",
+			SourceVertex:      &core.Vertex{Labels: []string{"reader"}, Properties: core.KVMap{"name": "Tintin"}},
+			DestinationVertex: &core.Vertex{Labels: []string{"reader"}, Properties: core.KVMap{"name": "Reader " + strconv.Itoa(i)}},
+			Properties:        core.KVMap{"since": since},
+		}
+		suite.NoError(suite.connection.StoreEdge(suite.context, &edge))
+	}
+
+	queryParams := core.KVMap{core.QueryParamOrderBy: core.OrderBy{Property: "since", Descending: true}}
+	edges, err := suite.connection.QueryEdge(suite.context, []string{"reader"}, []string{"reader"}, "follows", nil, nil, nil, nil, nil, nil, queryParams, core.EdgeWithCompleteVertex)
+	suite.NoError(err)
+	since := make([]int64, 0, len(edges))
+	for _, edge := range edges {
+		suite.NotNil(edge.DestinationVertex)
+		since = append(since, edge.Properties["since"].(int64))
+	}
+	suite.Equal([]int64{1950, 1941, 1934, 1929}, since)
+}
+
+func (suite *AgensGraphIntegrationTestSuite) TestQuerySubgraph() {
+	suite.vlabelsToCleanUp = append(suite.vlabelsToCleanUp, "reader")
+	suite.elabelsToCleanUp = append(suite.elabelsToCleanUp, "follows")
+	for _, reader := range []string{"Haddock", "Calculus"} {
+		edge := core.Edge{
+			Type:              "follows",
+			SourceVertex:      &core.Vertex{Labels: []string{"reader"}, Properties: core.KVMap{"name": reader}},
+			DestinationVertex: &core.Vertex{Labels: []string{"reader"}, Properties: core.KVMap{"name": "Tintin"}},
+			Properties:        core.KVMap{},
+		}
+		suite.NoError(suite.connection.StoreEdge(suite.context, &edge))
+	}
+
+	subgraph, err := suite.connection.QuerySubgraph(suite.context, "MATCH p = (:reader)-[:follows]->(:reader) RETURN p", nil)
+	suite.NoError(err)
+	suite.Equal(3, len(subgraph.Vertices))
+	suite.Equal(2, len(subgraph.Edges))
+	for _, edge := range subgraph.Edges {
+		suite.Contains(subgraph.Vertices, edge.SourceVertex)
+		suite.Contains(subgraph.Vertices, edge.DestinationVertex)
+		suite.Equal("Tintin", edge.DestinationVertex.Properties["name"])
+	}
+	// both the edges end at the same vertex
+	suite.Same(subgraph.Edges[0].DestinationVertex, subgraph.Edges[1].DestinationVertex)
+}
+
+func (suite *AgensGraphIntegrationTestSuite) TestDegree() {
+	suite.vlabelsToCleanUp = append(suite.vlabelsToCleanUp, "reader")
+	suite.elabelsToCleanUp = append(suite.elabelsToCleanUp, "follows", "walks")
+	hub := core.Vertex{Labels: []string{"reader"}, Properties: core.KVMap{"name": "Tintin"}}
+	for _, reader := range []string{"Haddock", "Calculus", "Nestor"} {
+		edge := core.Edge{
+			Type:              "follows",
+			SourceVertex:      &core.Vertex{Labels: []string{"reader"}, Properties: core.KVMap{"name": reader}},
+			DestinationVertex: &hub,
+			Properties:        core.KVMap{},
+		}
+		suite.NoError(suite.connection.StoreEdge(suite.context, &edge))
+	}
+	edge := core.Edge{
+		Type:              "walks",
+		SourceVertex:      &hub,
+		DestinationVertex: &core.Vertex{Labels: []string{"reader"}, Properties: core.KVMap{"name": "Snowy"}},
+		Properties:        core.KVMap{},
+	}
+	suite.NoError(suite.connection.StoreEdge(suite.context, &edge))
+
+	degree, err := suite.connection.Degree(suite.context, hub.ID, core.DirectionBoth, nil)
+	suite.NoError(err)
+	suite.Equal(int64(4), degree)
+	degree, err = suite.connection.Degree(suite.context, hub.ID, core.DirectionIncoming, nil)
+	suite.NoError(err)
+	suite.Equal(int64(3), degree)
+	degree, err = suite.connection.Degree(suite.context, hub.ID, core.DirectionOutgoing, nil)
+	suite.NoError(err)
+	suite.Equal(int64(1), degree)
+	degree, err = suite.connection.Degree(suite.context, hub.ID, core.DirectionBoth, []string{"walks"})
+	suite.NoError(err)
+	suite.Equal(int64(1), degree)
+}
+
+func (suite *AgensGraphIntegrationTestSuite) TestExpandNeighbors() {
+	suite.vlabelsToCleanUp = append(suite.vlabelsToCleanUp, "reader")
+	suite.elabelsToCleanUp = append(suite.elabelsToCleanUp, "follows", "walks")
+	tintin := core.Vertex{Labels: []string{"reader"}, Properties: core.KVMap{"name": "Tintin"}}
+	haddock := core.Vertex{Labels: []string{"reader"}, Properties: core.KVMap{"name": "Haddock"}}
+	nestor := core.Vertex{Labels: []string{"reader"}, Properties: core.KVMap{"name": "Nestor"}}
+	for _, edge := range []core.Edge{
+		{Type: "follows", SourceVertex: &haddock, DestinationVertex: &tintin, Properties: core.KVMap{}},
+		{Type: "follows", SourceVertex: &tintin, DestinationVertex: &haddock, Properties: core.KVMap{}},
+		{Type: "walks", SourceVertex: &tintin, DestinationVertex: &core.Vertex{Labels: []string{"reader"}, Properties: core.KVMap{"name": "Snowy"}}, Properties: core.KVMap{}},
+	} {
+		edge := edge
+		suite.NoError(suite.connection.StoreEdge(suite.context, &edge))
+	}
+	suite.NoError(suite.connection.StoreVertex(suite.context, &nestor))
+
+	neighbors, err := suite.connection.ExpandNeighbors(suite.context, []*core.Identifier{tintin.ID, haddock.ID, nestor.ID}, []string{"follows"}, core.DirectionOutgoing)
+	suite.NoError(err)
+	suite.Equal(3, len(neighbors))
+	suite.Equal(1, len(neighbors[tintin.ID.String()]))
+	suite.Equal("Haddock", neighbors[tintin.ID.String()][0].DestinationVertex.Properties["name"])
+	suite.Equal(1, len(neighbors[haddock.ID.String()]))
+	suite.Equal("Tintin", neighbors[haddock.ID.String()][0].DestinationVertex.Properties["name"])
+	suite.Empty(neighbors[nestor.ID.String()])
+
+	neighbors, err = suite.connection.ExpandNeighbors(suite.context, []*core.Identifier{tintin.ID}, nil, core.DirectionBoth)
+	suite.NoError(err)
+	suite.Equal(3, len(neighbors[tintin.ID.String()]))
+}
+
+func (suite *AgensGraphIntegrationTestSuite) TestStoreEdgeWithMatchedStartAndMergedEndVertex() {
+	suite.vlabelsToCleanUp = append(suite.vlabelsToCleanUp, "cartoon", "team")
+	suite.elabelsToCleanUp = append(suite.elabelsToCleanUp, "created_by")
+	ctx := core.WithEndpointModes(suite.context, core.EndpointMatch, core.EndpointMerge)
+	cv := core.Vertex{Labels: []string{"cartoon"}, Properties: core.KVMap{"name": "Tom and Jerry"}}
+	pv := core.Vertex{Labels: []string{"team"}, Properties: core.KVMap{"name": "William Hanna and Joseph Barbara"}}
+	rel := core.Edge{Type: "created_by", Properties: core.KVMap{}, SourceVertex: &cv, DestinationVertex: &pv}
+
+	// Agensgraph cannot match vertices of a label which does not exist
+	err := suite.connection.StoreVertex(suite.context, &core.Vertex{Labels: []string{"cartoon"}, Properties: core.KVMap{"name": "Popeye"}})
+	suite.NoError(err)
+
+	// the start vertex does not exist and must not be created
+	err = suite.connection.StoreEdge(ctx, &rel)
+	suite.ErrorIs(err, core.ErrEndpointNotFound)
+	storedVertex, err := suite.connection.QueryVertex(suite.context, "cartoon", core.KVMap{"name": "Tom and Jerry"}, nil, nil)
+	suite.NoError(err)
+	suite.Equal(0, len(storedVertex))
+
+	// the end vertex is created once the start vertex exists
+	err = suite.connection.StoreVertex(suite.context, &cv)
+	suite.NoError(err)
+	err = suite.connection.StoreEdge(ctx, &rel)
+	suite.NoError(err)
+	suite.NotNil(pv.ID)
+
+	// storing the edge again reuses the existing end vertex
+	err = suite.connection.StoreEdge(ctx, &rel)
+	suite.NoError(err)
+	storedVertex, err = suite.connection.QueryVertex(suite.context, "team", core.KVMap{"name": "William Hanna and Joseph Barbara"}, nil, nil)
+	suite.NoError(err)
+	suite.Equal(1, len(storedVertex))
+	suite.Equal(pv.ID, storedVertex[0].ID)
+}
+
+func (suite *AgensGraphIntegrationTestSuite) TestExecuteQueryWithMaxRows() {
+	connection := suite.newConnection(core.KVMap{core.MAX_ROWS_KEY: 3})
+	defer connection.Close(suite.context)
+
+	queryResult, err := connection.ExecuteQuery(suite.context, "UNWIND range(1, 3) AS n RETURN n", core.Read, nil)
+	suite.NoError(err)
+	suite.Equal(3, len(queryResult.Rows))
+
+	_, err = connection.ExecuteQuery(suite.context, "UNWIND range(1, 100000) AS n RETURN n", core.Read, nil)
+	suite.ErrorIs(err, core.ErrMaxRowsExceeded)
+}
+
 func (suite *AgensGraphIntegrationTestSuite) TestStoreVertex() {
 	suite.vlabelsToCleanUp = append(suite.vlabelsToCleanUp, "OMGStoreVertex")
 	vertex := core.Vertex{
@@ -105,6 +528,252 @@ func (suite *AgensGraphIntegrationTestSuite) TestStoreVertex() {
 	suite.Equal(storedVertex[0].ID, vertex.ID)
 }
 
+func (suite *AgensGraphIntegrationTestSuite) TestStoreVertexWithListProperties() {
+	suite.vlabelsToCleanUp = append(suite.vlabelsToCleanUp, "publication")
+	vertex := core.Vertex{
+		Labels:     []string{"publication"},
+		Properties: core.KVMap{"name": "Tintin", "tags": []string{"comics", "adventure"}, "ratings": []float64{4, 4.5}},
+	}
+	suite.NoError(suite.connection.StoreVertex(suite.context, &vertex))
+
+	vertices, err := suite.connection.QueryVertex(suite.context, "publication", core.KVMap{"name": "Tintin"}, nil, nil)
+	suite.NoError(err)
+	suite.Equal(1, len(vertices))
+	suite.Equal([]interface{}{"comics", "adventure"}, vertices[0].Properties["tags"])
+	suite.Equal([]interface{}{4.0, 4.5}, vertices[0].Properties["ratings"])
+}
+
+func (suite *AgensGraphIntegrationTestSuite) TestAddAndRemoveLabels() {
+	err := suite.connection.AddLabels(suite.context, core.NewId("3.1"), []string{"Admin"})
+	suite.ErrorIs(err, core.ErrNotSupported)
+	err = suite.connection.RemoveLabels(suite.context, core.NewId("3.1"), []string{"Admin"})
+	suite.ErrorIs(err, core.ErrNotSupported)
+}
+
+func (suite *AgensGraphIntegrationTestSuite) TestStoreMixedCasePropertyWithLowerCaseKeys() {
+	suite.vlabelsToCleanUp = append(suite.vlabelsToCleanUp, "city")
+	conn := suite.newConnection(core.KVMap{agensgraph.AGENS_PROPERTY_KEY_CASE_KEY: agensgraph.PropertyKeyCaseLower})
+	defer conn.Close(suite.context)
+
+	vertex := core.Vertex{
+		Labels:     []string{"city"},
+		Properties: core.KVMap{"Name": "Mumbai", "PinCode": 400001},
+	}
+	err := conn.StoreVertex(suite.context, &vertex)
+	suite.NoError(err)
+
+	storedVertex, err := conn.QueryVertex(suite.context, "city", core.KVMap{"PinCode": 400001}, nil, nil)
+	suite.NoError(err)
+	suite.Equal(1, len(storedVertex))
+	suite.Equal(core.KVMap{"name": "Mumbai", "pincode": int64(400001)}, storedVertex[0].Properties)
+
+	store := omg.NewGenericStore(conn, omg.NewReflectionMapper())
+	cities, err := store.ReadVertex(suite.context, &city{PinCode: 400001})
+	suite.NoError(err)
+	suite.Equal(1, len(cities))
+	suite.Equal(city{Name: "Mumbai", PinCode: 400001}, *(cities[0].(*city)))
+}
+
+func (suite *AgensGraphIntegrationTestSuite) TestPersistVertexWithStoreWriteMode() {
+	suite.vlabelsToCleanUp = append(suite.vlabelsToCleanUp, "person")
+	// the context does not specify the Agensgraph specific write mode
+	ctx := context.WithValue(context.Background(), agensgraph.ContextKeyGraphName, "agens")
+
+	// new labels can only be created using CREATE
+	createStore := omg.NewGenericStoreWithWriteMode(suite.connection, omg.NewReflectionMapper(), core.Create)
+	suite.NoError(createStore.PersistVertex(ctx, &person{Name: "Tom", Age: 10}))
+
+	mergeStore := omg.NewGenericStoreWithWriteMode(suite.connection, omg.NewReflectionMapper(), core.Merge)
+	suite.NoError(mergeStore.PersistVertex(ctx, &person{Name: "Tom", Age: 10}))
+	read, err := mergeStore.ReadVertex(ctx, &person{Name: "Tom", Age: 10})
+	suite.NoError(err)
+	suite.Equal(1, len(read))
+}
+
+func (suite *AgensGraphIntegrationTestSuite) TestQueryVertexFunc() {
+	suite.vlabelsToCleanUp = append(suite.vlabelsToCleanUp, "person")
+	for i := 0; i < 5; i++ {
+		v := &core.Vertex{Labels: []string{"person"}, Properties: core.KVMap{"seq": i}}
+		suite.NoError(suite.connection.StoreVertex(suite.context, v))
+	}
+	count := 0
+	err := suite.connection.QueryVertexFunc(suite.context, "person", nil, nil, func(v *core.Vertex) error {
+		count++
+		return nil
+	})
+	suite.NoError(err)
+	suite.Equal(5, count)
+
+	errStop := errors.New("stop")
+	count = 0
+	err = suite.connection.QueryVertexFunc(suite.context, "person", nil, nil, func(v *core.Vertex) error {
+		count++
+		if count == 2 {
+			return errStop
+		}
+		return nil
+	})
+	suite.ErrorIs(err, errStop)
+	suite.Equal(2, count)
+}
+
+func (suite *AgensGraphIntegrationTestSuite) TestDefaultGraphFromOptions() {
+	suite.vlabelsToCleanUp = append(suite.vlabelsToCleanUp, "person")
+	conn := suite.newConnection(core.KVMap{agensgraph.AGENS_GRAPH_KEY: "agens"})
+	defer conn.Close(context.Background())
+	// the context does not specify the graph
+	ctx := core.WithWriteMode(context.Background(), core.Create)
+
+	v := &core.Vertex{Labels: []string{"person"}, Properties: core.KVMap{"name": "Tintin"}}
+	suite.NoError(conn.StoreVertex(ctx, v))
+
+	// the vertex is stored to the same graph as the one specified within the context
+	vertices, err := suite.connection.QueryVertex(suite.context, "person", core.KVMap{"name": "Tintin"}, nil, nil)
+	suite.NoError(err)
+	suite.Equal(1, len(vertices))
+
+	vertices, err = conn.QueryVertex(context.Background(), "person", core.KVMap{"name": "Tintin"}, nil, nil)
+	suite.NoError(err)
+	suite.Equal(1, len(vertices))
+}
+
+func (suite *AgensGraphIntegrationTestSuite) TestStoreAndQueryValuesWithQuotes() {
+	suite.vlabelsToCleanUp = append(suite.vlabelsToCleanUp, "person")
+	v := &core.Vertex{Labels: []string{"person"}, Properties: core.KVMap{"name": "O'Brien"}}
+	suite.NoError(suite.connection.StoreVertex(suite.context, v))
+
+	vertices, err := suite.connection.QueryVertex(suite.context, "person", core.KVMap{"name": "O'Brien"}, nil, nil)
+	suite.NoError(err)
+	suite.Equal(1, len(vertices))
+	suite.Equal("O'Brien", vertices[0].Properties["name"])
+}
+
+func (suite *AgensGraphIntegrationTestSuite) TestQueryVertexWithComparisonFilters() {
+	suite.vlabelsToCleanUp = append(suite.vlabelsToCleanUp, "person")
+	for _, age := range []int64{12, 18, 40, 70} {
+		v := &core.Vertex{Labels: []string{"person"}, Properties: core.KVMap{"name": fmt.Sprintf("person%d", age), "age": age}}
+		suite.NoError(suite.connection.StoreVertex(suite.context, v))
+	}
+	filters := core.KVMap{"age": core.Filter{Operator: core.Gte, Value: 40}}
+	vertices, err := suite.connection.QueryVertex(suite.context, "person", nil, filters, nil)
+	suite.NoError(err)
+	suite.Equal(2, len(vertices))
+
+	filters = core.KVMap{"age": core.Filter{Operator: core.Lt, Value: 18}}
+	vertices, err = suite.connection.QueryVertex(suite.context, "person", nil, filters, nil)
+	suite.NoError(err)
+	suite.Equal(1, len(vertices))
+}
+
+func (suite *AgensGraphIntegrationTestSuite) TestStoreEdgeWithIdempotencyKey() {
+	suite.vlabelsToCleanUp = append(suite.vlabelsToCleanUp, "person", "dog")
+	suite.elabelsToCleanUp = append(suite.elabelsToCleanUp, "owns")
+	ctx := core.WithIdempotencyKey(suite.context, "request-1")
+	for _, since := range []int64{1929, 1930} {
+		edge := core.Edge{
+			Type:              "owns",
+			SourceVertex:      &core.Vertex{Labels: []string{"person"}, Properties: core.KVMap{"name": "Tintin"}},
+			DestinationVertex: &core.Vertex{Labels: []string{"dog"}, Properties: core.KVMap{"name": "Snowy"}},
+			Properties:        core.KVMap{"since": since},
+		}
+		suite.NoError(suite.connection.StoreEdge(ctx, &edge))
+	}
+	edges, err := suite.connection.QueryEdge(suite.context, []string{"person"}, []string{"dog"}, "owns", nil, nil, nil, nil, nil, nil, nil, core.EdgeWithVertexIds)
+	suite.NoError(err)
+	suite.Equal(1, len(edges))
+}
+
+func (suite *AgensGraphIntegrationTestSuite) TestStoreEdgeWithIdentityProperties() {
+	suite.vlabelsToCleanUp = append(suite.vlabelsToCleanUp, "person", "dog")
+	suite.elabelsToCleanUp = append(suite.elabelsToCleanUp, "owns")
+	ctx := core.WithEdgeIdentityProperties(suite.context, "since")
+	for _, weight := range []float64{0.5, 0.8} {
+		edge := core.Edge{
+			Type:              "owns",
+			SourceVertex:      &core.Vertex{Labels: []string{"person"}, Properties: core.KVMap{"name": "Tintin"}},
+			DestinationVertex: &core.Vertex{Labels: []string{"dog"}, Properties: core.KVMap{"name": "Snowy"}},
+			Properties:        core.KVMap{"since": int64(1929), "weight": weight},
+		}
+		suite.NoError(suite.connection.StoreEdge(ctx, &edge))
+	}
+	edges, err := suite.connection.QueryEdge(suite.context, []string{"person"}, []string{"dog"}, "owns", nil, nil, nil, nil, nil, nil, nil, core.EdgeWithVertexIds)
+	suite.NoError(err)
+	// the change of the weight updates the existing edge
+	suite.Equal(1, len(edges))
+	suite.Equal(0.8, edges[0].Properties["weight"])
+}
+
+func (suite *AgensGraphIntegrationTestSuite) TestDeleteVertex() {
+	suite.vlabelsToCleanUp = append(suite.vlabelsToCleanUp, "person", "dog")
+	suite.elabelsToCleanUp = append(suite.elabelsToCleanUp, "owns")
+	edge := core.Edge{
+		Type:              "owns",
+		SourceVertex:      &core.Vertex{Labels: []string{"person"}, Properties: core.KVMap{"name": "Tintin"}},
+		DestinationVertex: &core.Vertex{Labels: []string{"dog"}, Properties: core.KVMap{"name": "Snowy"}},
+		Properties:        core.KVMap{},
+	}
+	suite.NoError(suite.connection.StoreEdge(suite.context, &edge))
+	suite.NoError(suite.connection.StoreVertex(suite.context, &core.Vertex{Labels: []string{"person"}, Properties: core.KVMap{"name": "Haddock"}}))
+
+	_, err := suite.connection.DeleteVertex(suite.context, "person", nil)
+	suite.ErrorIs(err, core.ErrEmptySelector)
+
+	ctx, deletedIds := core.WithDeletedIds(suite.context)
+	deleted, err := suite.connection.DeleteVertex(ctx, "person", core.KVMap{"name": "Tintin"})
+	suite.NoError(err)
+	suite.Equal(1, deleted)
+	suite.ElementsMatch([]*core.Identifier{edge.SourceVertex.ID, edge.ID}, deletedIds.IDs)
+	vertices, err := suite.connection.QueryVertex(suite.context, "person", nil, nil, nil)
+	suite.NoError(err)
+	suite.Equal(1, len(vertices))
+
+	deleted, err = suite.connection.DeleteVertex(core.WithAllowEmptySelector(suite.context), "person", nil)
+	suite.NoError(err)
+	suite.Equal(1, deleted)
+}
+
+func (suite *AgensGraphIntegrationTestSuite) TestLabelCombinations() {
+	suite.vlabelsToCleanUp = append(suite.vlabelsToCleanUp, "person", "dog")
+	for _, vertex := range []*core.Vertex{
+		{Labels: []string{"person"}, Properties: core.KVMap{"name": "Tintin"}},
+		{Labels: []string{"person"}, Properties: core.KVMap{"name": "Haddock"}},
+		{Labels: []string{"dog"}, Properties: core.KVMap{"name": "Snowy"}},
+	} {
+		suite.NoError(suite.connection.StoreVertex(suite.context, vertex))
+	}
+	combinations, err := suite.connection.LabelCombinations(suite.context)
+	suite.NoError(err)
+	suite.ElementsMatch([][]string{{"person"}, {"dog"}}, combinations)
+}
+
+func (suite *AgensGraphIntegrationTestSuite) TestDeleteEdge() {
+	suite.vlabelsToCleanUp = append(suite.vlabelsToCleanUp, "person", "dog")
+	suite.elabelsToCleanUp = append(suite.elabelsToCleanUp, "owns")
+	for _, dog := range []string{"Snowy", "Milou"} {
+		edge := core.Edge{
+			Type:              "owns",
+			SourceVertex:      &core.Vertex{Labels: []string{"person"}, Properties: core.KVMap{"name": "Tintin"}},
+			DestinationVertex: &core.Vertex{Labels: []string{"dog"}, Properties: core.KVMap{"name": dog}},
+			Properties:        core.KVMap{},
+		}
+		suite.NoError(suite.connection.StoreEdge(suite.context, &edge))
+	}
+
+	ctx, deletedIds := core.WithDeletedIds(suite.context)
+	deleted, err := suite.connection.DeleteEdge(ctx, []string{"person"}, []string{"dog"}, "owns", nil, core.KVMap{"name": "Snowy"}, nil)
+	suite.NoError(err)
+	suite.Equal(1, deleted)
+	suite.Equal(1, len(deletedIds.IDs))
+
+	edges, err := suite.connection.QueryEdge(suite.context, []string{"person"}, []string{"dog"}, "owns", nil, nil, nil, nil, nil, nil, nil, core.EdgeWithVertexIds)
+	suite.NoError(err)
+	suite.Equal(1, len(edges))
+	// the vertices of the deleted edge are retained
+	vertices, err := suite.connection.QueryVertex(suite.context, "dog", core.KVMap{"name": "Snowy"}, nil, nil)
+	suite.NoError(err)
+	suite.Equal(1, len(vertices))
+}
+
 func (suite *AgensGraphIntegrationTestSuite) TestStoreEdge() {
 	suite.vlabelsToCleanUp = append(suite.vlabelsToCleanUp, "Cartoon", "Team")
 	suite.elabelsToCleanUp = append(suite.elabelsToCleanUp, "CREATED_BY")
@@ -148,6 +817,52 @@ func (suite *AgensGraphIntegrationTestSuite) TestStoreEdge() {
 	suite.Equal(pv.ID, storedEdge[0].DestinationVertexID)
 }
 
+func (suite *AgensGraphIntegrationTestSuite) TestStoreEdgeRequireExistingEndpoints() {
+	suite.vlabelsToCleanUp = append(suite.vlabelsToCleanUp, "Cartoon", "Team")
+	suite.elabelsToCleanUp = append(suite.elabelsToCleanUp, "CREATED_BY")
+	ctx := context.WithValue(suite.context, core.ContextKeyRequireExistingEndpoints, true)
+	cv := core.Vertex{
+		Labels:     []string{"Cartoon"},
+		Properties: core.KVMap{"Name": "Tom and Jerry", "Genre": "Kids Cartoon series"},
+	}
+	err := suite.connection.StoreVertex(suite.context, &cv)
+	suite.NoError(err)
+
+	pv := core.Vertex{
+		Labels:     []string{"Team"},
+		Properties: core.KVMap{"Name": "William Hanna and Joseph Barbara"},
+	}
+	rel := core.Edge{
+		Type:              "CREATED_BY",
+		Properties:        core.KVMap{"Year": 1940},
+		SourceVertex:      &cv,
+		DestinationVertex: &pv,
+	}
+
+	// Agensgraph cannot match vertices of a label which does not exist
+	err = suite.connection.StoreVertex(suite.context, &core.Vertex{Labels: []string{"Team"}, Properties: core.KVMap{"Name": "MGM"}})
+	suite.NoError(err)
+
+	// the end vertex does not exist and must not be created
+	err = suite.connection.StoreEdge(ctx, &rel)
+	suite.ErrorIs(err, core.ErrEndpointNotFound)
+	storedVertex, err := suite.connection.QueryVertex(suite.context, "Team", core.KVMap{"Name": "William Hanna and Joseph Barbara"}, nil, nil)
+	suite.NoError(err)
+	suite.Equal(0, len(storedVertex))
+
+	err = suite.connection.StoreVertex(suite.context, &pv)
+	suite.NoError(err)
+	err = suite.connection.StoreEdge(ctx, &rel)
+	suite.NoError(err)
+
+	storedEdge, err := suite.connection.QueryEdge(suite.context, []string{"Cartoon"}, []string{"Team"}, "CREATED_BY", nil, nil, core.KVMap{"Year": 1940}, nil, nil, nil, nil, core.EdgeWithVertexIds)
+	suite.NoError(err)
+	suite.Equal(1, len(storedEdge))
+	suite.Equal(storedEdge[0].ID, rel.ID)
+	suite.Equal(cv.ID, storedEdge[0].SourceVertexID)
+	suite.Equal(pv.ID, storedEdge[0].DestinationVertexID)
+}
+
 func (suite *AgensGraphIntegrationTestSuite) TestStoreEdgeWithInvalidData() {
 
 	pv := core.Vertex{
@@ -179,6 +894,50 @@ func (suite *AgensGraphIntegrationTestSuite) TestStoreOmgStructAsVertex() {
 
 }
 
+func (suite *AgensGraphIntegrationTestSuite) TestReadOrCreateOmgStructAsVertex() {
+	suite.vlabelsToCleanUp = append(suite.vlabelsToCleanUp, "person")
+	// Agensgraph does not allow reading vertices of a non-existent label. Hence ensure that the label exists
+	err := suite.store.PersistVertex(suite.context, &person{Name: "Jerry", Age: 8})
+	suite.NoError(err)
+
+	p := person{Name: "Tom", Age: 10}
+	created, isCreated, err := suite.store.ReadOrCreateVertex(suite.context, &p)
+	suite.NoError(err)
+	suite.True(isCreated)
+	suite.Equal(p, *(created.(*person)))
+
+	read, isCreated, err := suite.store.ReadOrCreateVertex(suite.context, &p)
+	suite.NoError(err)
+	suite.False(isCreated)
+	suite.Equal(p, *(read.(*person)))
+
+	p2, err := suite.store.ReadVertex(suite.context, &p)
+	suite.NoError(err)
+	suite.Equal(1, len(p2))
+}
+
+func (suite *AgensGraphIntegrationTestSuite) TestStoreOmgStructWithLargeInteger() {
+	suite.vlabelsToCleanUp = append(suite.vlabelsToCleanUp, "person")
+	p := person{Name: "Snowflake", Age: 1541815603606036480}
+	err := suite.store.PersistVertex(suite.context, &p)
+	suite.NoError(err)
+	p2, err := suite.store.ReadVertex(suite.context, &person{Name: "Snowflake"})
+	suite.NoError(err)
+	suite.Equal(1, len(p2))
+	suite.Equal(p, *(p2[0].(*person)))
+}
+
+func (suite *AgensGraphIntegrationTestSuite) TestStoreOmgStructWithBool() {
+	suite.vlabelsToCleanUp = append(suite.vlabelsToCleanUp, "subscriber")
+	s := subscriber{Name: "Tom", Active: true}
+	err := suite.store.PersistVertex(suite.context, &s)
+	suite.NoError(err)
+	s2, err := suite.store.ReadVertex(suite.context, &subscriber{Name: "Tom"})
+	suite.NoError(err)
+	suite.Equal(1, len(s2))
+	suite.Equal(s, *(s2[0].(*subscriber)))
+}
+
 func (suite *AgensGraphIntegrationTestSuite) TestStoreOmgStructsAsEdge() {
 	suite.vlabelsToCleanUp = append(suite.vlabelsToCleanUp, "person", "city")
 	suite.elabelsToCleanUp = append(suite.elabelsToCleanUp, "lives_in")
@@ -199,6 +958,164 @@ func (suite *AgensGraphIntegrationTestSuite) TestStoreOmgStructsAsEdge() {
 	suite.Equal(vc, *vrs[0])
 }
 
+func (suite *AgensGraphIntegrationTestSuite) TestReadVertexWithNeighbors() {
+	suite.vlabelsToCleanUp = append(suite.vlabelsToCleanUp, "person", "city")
+	suite.elabelsToCleanUp = append(suite.elabelsToCleanUp, "lives_in")
+	omg.RegisterGraphObject(&person{})
+	omg.RegisterGraphObject(&city{})
+	omg.RegisterGraphObject(&livesin{})
+
+	c := city{Name: "Mumbai", PinCode: 400001}
+	for _, p := range []person{{Name: "Tom", Age: 10}, {Name: "Jerry", Age: 8}} {
+		p := p
+		r := livesin{Area: "Town Hall", Since: 1982}
+		err := suite.store.PersistEdge(suite.context, &omg.VertexRelation{SourceVertex: &p, DestinationVertex: &c, Relationship: &r})
+		suite.NoError(err)
+	}
+	err := suite.store.PersistVertex(suite.context, &person{Name: "Spike", Age: 12})
+	suite.NoError(err)
+
+	relations, err := suite.store.ReadVertexWithNeighbors(suite.context, &city{Name: "Mumbai"}, []string{"LIVES_IN"})
+	suite.NoError(err)
+	suite.Equal(2, len(relations))
+	residents := make([]person, 0)
+	for _, relation := range relations {
+		suite.Equal(c, *(relation.DestinationVertex.(*city)))
+		suite.Equal(livesin{Area: "Town Hall", Since: 1982}, *(relation.Relationship.(*livesin)))
+		residents = append(residents, *(relation.SourceVertex.(*person)))
+	}
+	suite.ElementsMatch([]person{{Name: "Tom", Age: 10}, {Name: "Jerry", Age: 8}}, residents)
+
+	relations, err = suite.store.ReadVertexWithNeighbors(suite.context, &person{Name: "Spike"}, nil)
+	suite.NoError(err)
+	suite.Equal(1, len(relations))
+	suite.Equal(person{Name: "Spike", Age: 12}, *(relations[0].SourceVertex.(*person)))
+	suite.Nil(relations[0].Relationship)
+	suite.Nil(relations[0].DestinationVertex)
+}
+
+func (suite *AgensGraphIntegrationTestSuite) TestSnapshotReadsAreConsistent() {
+	suite.vlabelsToCleanUp = append(suite.vlabelsToCleanUp, "Reading")
+	err := suite.connection.StoreVertex(suite.context, &core.Vertex{Labels: []string{"Reading"}, Properties: core.KVMap{"sensor": "s1", "value": 10}})
+	suite.NoError(err)
+
+	snapshot, err := suite.connection.BeginSnapshot(suite.context)
+	suite.NoError(err)
+	defer snapshot.Close(suite.context)
+	readings, err := snapshot.QueryVertex(suite.context, "Reading", core.KVMap{"sensor": "s1"}, nil, nil)
+	suite.NoError(err)
+	suite.Equal(1, len(readings))
+
+	// concurrent write committed outside of the snapshot
+	err = suite.connection.StoreVertex(suite.context, &core.Vertex{Labels: []string{"Reading"}, Properties: core.KVMap{"sensor": "s1", "value": 20}})
+	suite.NoError(err)
+
+	readings, err = snapshot.QueryVertex(suite.context, "Reading", core.KVMap{"sensor": "s1"}, nil, nil)
+	suite.NoError(err)
+	suite.Equal(1, len(readings))
+	suite.NoError(snapshot.Commit(suite.context))
+
+	readings, err = suite.connection.QueryVertex(suite.context, "Reading", core.KVMap{"sensor": "s1"}, nil, nil)
+	suite.NoError(err)
+	suite.Equal(2, len(readings))
+}
+
+func (suite *AgensGraphIntegrationTestSuite) TestReadOmgStructsAcrossMultipleHops() {
+	suite.vlabelsToCleanUp = append(suite.vlabelsToCleanUp, "person")
+	suite.elabelsToCleanUp = append(suite.elabelsToCleanUp, "parent_of")
+	grandParent := person{Name: "Alice", Age: 70}
+	parent := person{Name: "Bob", Age: 45}
+	child := person{Name: "Carol", Age: 20}
+	for _, vc := range []omg.VertexRelation{
+		{SourceVertex: &grandParent, Relationship: &parentof{}, DestinationVertex: &parent},
+		{SourceVertex: &parent, Relationship: &parentof{}, DestinationVertex: &child},
+	} {
+		vc := vc
+		err := suite.store.PersistEdge(suite.context, &vc)
+		suite.NoError(err)
+	}
+
+	// all the ancestors of the child
+	vrs, err := suite.store.ReadEdge(suite.context, &omg.VertexRelation{SourceVertex: &person{}, Relationship: &parentof{}, DestinationVertex: &child, MinHops: 1})
+	suite.NoError(err)
+	ancestors := make([]person, 0)
+	for _, vr := range vrs {
+		suite.Equal(child, *(vr.DestinationVertex.(*person)))
+		suite.Nil(vr.Relationship)
+		ancestors = append(ancestors, *(vr.SourceVertex.(*person)))
+	}
+	suite.ElementsMatch([]person{grandParent, parent}, ancestors)
+
+	// only the grand parents of the child
+	vrs, err = suite.store.ReadEdge(suite.context, &omg.VertexRelation{SourceVertex: &person{}, Relationship: &parentof{}, DestinationVertex: &child, MinHops: 2, MaxHops: 2})
+	suite.NoError(err)
+	suite.Equal(1, len(vrs))
+	suite.Equal(grandParent, *(vrs[0].SourceVertex.(*person)))
+}
+
+func (suite *AgensGraphIntegrationTestSuite) TestStoreOmgStructsInTransaction() {
+	suite.vlabelsToCleanUp = append(suite.vlabelsToCleanUp, "person", "city")
+	suite.elabelsToCleanUp = append(suite.elabelsToCleanUp, "lives_in")
+
+	p := person{Name: "Tom", Age: 10}
+	c := city{Name: "Mumbai", PinCode: 400001}
+	r := livesin{Area: "Town Hall", Since: 1982}
+	vc := omg.VertexRelation{SourceVertex: &p, DestinationVertex: &c, Relationship: &r}
+
+	err := suite.store.RunInTransaction(suite.context, func(txStore omg.Store) error {
+		err := txStore.PersistVertex(suite.context, &p)
+		if err != nil {
+			return err
+		}
+		return txStore.PersistEdge(suite.context, &vc)
+	})
+	suite.NoError(err)
+
+	vrs, err := suite.store.ReadEdge(suite.context, &vc)
+	suite.NoError(err)
+	suite.Equal(1, len(vrs))
+	suite.Equal(vc, *vrs[0])
+}
+
+func (suite *AgensGraphIntegrationTestSuite) TestStoreOmgStructsInTransactionRollback() {
+	suite.vlabelsToCleanUp = append(suite.vlabelsToCleanUp, "person")
+	// vertex labels created within a rolled back transaction are discarded as well. Hence ensure that
+	// the label exists so that it can be queried after the rollback
+	err := suite.store.PersistVertex(suite.context, &person{Name: "Tom", Age: 10})
+	suite.NoError(err)
+
+	p := person{Name: "Jerry", Age: 8}
+	forcedErr := errors.New("forced error")
+
+	err = suite.store.RunInTransaction(suite.context, func(txStore omg.Store) error {
+		err := txStore.PersistVertex(suite.context, &p)
+		if err != nil {
+			return err
+		}
+		return forcedErr
+	})
+	suite.ErrorIs(err, forcedErr)
+
+	p2, err := suite.store.ReadVertex(suite.context, &person{Name: "Jerry", Age: 8})
+	suite.NoError(err)
+	suite.Equal(0, len(p2))
+}
+
+func (suite *AgensGraphIntegrationTestSuite) TestStoreOmgStructsAsEdgeOnlySrcVertex() {
+	suite.vlabelsToCleanUp = append(suite.vlabelsToCleanUp, "person")
+	p := person{Name: "Isolated", Age: 40}
+	err := suite.store.PersistEdge(suite.context, &omg.VertexRelation{SourceVertex: &p})
+	suite.NoError(err)
+
+	p2, err := suite.store.ReadVertex(suite.context, &person{Name: "Isolated"})
+	suite.NoError(err)
+	suite.Equal(1, len(p2))
+	suite.Equal(p, *(p2[0].(*person)))
+
+	err = suite.store.PersistEdge(suite.context, &omg.VertexRelation{SourceVertex: &p, Relationship: &livesin{Since: 1982}})
+	suite.Error(err)
+}
+
 func (suite *AgensGraphIntegrationTestSuite) TestStoreOmgStructsAsEdgeNoSrcVertex() {
 	p := person{Name: "Tom", Age: 10}
 	c := city{Name: "Mumbai", PinCode: 400001}
@@ -288,6 +1205,28 @@ func (lv *livesin) GetType() omg.GraphObjectType {
 	return omg.Edge
 }
 
+func (suite *AgensGraphIntegrationTestSuite) TestQueryEdgeWithCompleteVertexPopulatesVertexIds() {
+	suite.vlabelsToCleanUp = append(suite.vlabelsToCleanUp, "Person", "Country")
+	suite.elabelsToCleanUp = append(suite.elabelsToCleanUp, "LIVES_IN")
+	query := "create (p:Person{name:'Tintin'})-[r:LIVES_IN{since:1929}]->(c:Country{name:'Belgium'}) return p, r, c"
+	_, err := suite.connection.ExecuteQuery(suite.context, query, core.Write, nil)
+	suite.NoError(err)
+
+	edges, err := suite.connection.QueryEdge(suite.context, []string{"Person"}, []string{"Country"}, "LIVES_IN",
+		core.KVMap{"name": "Tintin"}, core.KVMap{"name": "Belgium"}, nil, nil, nil, nil, nil, core.EdgeWithCompleteVertex)
+	suite.NoError(err)
+	suite.Equal(1, len(edges))
+	edge := edges[0]
+	suite.NotNil(edge.SourceVertex)
+	suite.NotNil(edge.DestinationVertex)
+	suite.NotNil(edge.SourceVertexID)
+	suite.NotNil(edge.DestinationVertexID)
+	suite.Equal(edge.SourceVertexID, edge.SourceVertex.ID)
+	suite.Equal(edge.DestinationVertexID, edge.DestinationVertex.ID)
+	suite.Equal("Tintin", edge.SourceVertex.Properties["name"])
+	suite.Equal("Belgium", edge.DestinationVertex.Properties["name"])
+}
+
 func (suite *AgensGraphIntegrationTestSuite) TearDownTest() {
 	suite.cleanupDB()
 }
@@ -322,3 +1261,61 @@ func (suite *AgensGraphIntegrationTestSuite) cleanupDB() {
 func TestAgensGraphIntegrationTestSuite(t *testing.T) {
 	suite.Run(t, new(AgensGraphIntegrationTestSuite))
 }
+
+type parentof struct {
+}
+
+// GetLabel returns the label associated with the graph object
+func (po *parentof) GetLabel() string {
+	return "PARENT_OF"
+}
+
+// GraphType() returns the type associated with the Graph object
+func (po *parentof) GetType() omg.GraphObjectType {
+	return omg.Edge
+}
+
+type subscriber struct {
+	Name   string
+	Active bool
+}
+
+// GetLabel returns the label associated with the graph object
+func (s *subscriber) GetLabel() string {
+	return "subscriber"
+}
+
+// GetType returns the type of the graph object
+func (s *subscriber) GetType() omg.GraphObjectType {
+	return omg.Vertex
+}
+
+type purchaseOrder struct {
+	Number    string     `ogm:"number"`
+	LineItems []lineItem `ogm:",edge:has_item"`
+}
+
+// GetLabel returns the label associated with the graph object
+func (po *purchaseOrder) GetLabel() string {
+	return "purchaseorder"
+}
+
+// GetType returns the type of the graph object
+func (po *purchaseOrder) GetType() omg.GraphObjectType {
+	return omg.Vertex
+}
+
+type lineItem struct {
+	Product  string `ogm:"product"`
+	Quantity int64  `ogm:"quantity"`
+}
+
+// GetLabel returns the label associated with the graph object
+func (li *lineItem) GetLabel() string {
+	return "lineitem"
+}
+
+// GetType returns the type of the graph object
+func (li *lineItem) GetType() omg.GraphObjectType {
+	return omg.Vertex
+}
@@ -84,6 +84,26 @@ func (suite *MemgraphIntegrationTestSuite) TestVertexQuery() {
 	suite.Equal("LIVES_IN", edge[0].Type)
 }
 
+func (suite *MemgraphIntegrationTestSuite) TestQueryEdgeWithCompleteVertexPopulatesVertexIds() {
+	query := "create (p:Person{name:'Tintin'})-[r:LIVES_IN{since:1929}]->(c:Country{name:'Belgium'}) return p, r, c"
+	_, err := suite.connection.ExecuteQuery(context.Background(), query, core.Write, nil)
+	suite.NoError(err)
+
+	edges, err := suite.connection.QueryEdge(context.Background(), []string{"Person"}, []string{"Country"}, "LIVES_IN",
+		core.KVMap{"name": "Tintin"}, core.KVMap{"name": "Belgium"}, nil, nil, nil, nil, nil, core.EdgeWithCompleteVertex)
+	suite.NoError(err)
+	suite.Equal(1, len(edges))
+	edge := edges[0]
+	suite.NotNil(edge.SourceVertex)
+	suite.NotNil(edge.DestinationVertex)
+	suite.NotNil(edge.SourceVertexID)
+	suite.NotNil(edge.DestinationVertexID)
+	suite.Equal(edge.SourceVertexID, edge.SourceVertex.ID)
+	suite.Equal(edge.DestinationVertexID, edge.DestinationVertex.ID)
+	suite.Equal("Tintin", edge.SourceVertex.Properties["name"])
+	suite.Equal("Belgium", edge.DestinationVertex.Properties["name"])
+}
+
 func (suite *MemgraphIntegrationTestSuite) TearDownTest() {
 	suite.cleanupDB()
 }
@@ -2,13 +2,18 @@ package neo
 
 import (
 	"context"
+	"errors"
 	"strconv"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
 	"github.com/prahaladd/gograph/core"
 	itests "github.com/prahaladd/gograph/integrationtests"
 	"github.com/prahaladd/gograph/neo"
 	"github.com/prahaladd/gograph/omg"
+	"github.com/prahaladd/gograph/query/cypher"
 	"github.com/stretchr/testify/suite"
 )
 
@@ -28,6 +33,15 @@ type Neo4JIntegrationTestSuite struct {
 }
 
 func (suite *Neo4JIntegrationTestSuite) SetupTest() {
+	connection, err := suite.newConnection(nil)
+	suite.connection = connection
+	suite.NoErrorf(err, "error whe setting up Neo4j Test : %v", err)
+	suite.cleanupDB()
+	suite.store = omg.NewGenericStore(connection, omg.NewReflectionMapper())
+}
+
+// newConnection creates a connection to the test database with the specified options
+func (suite *Neo4JIntegrationTestSuite) newConnection(options map[string]interface{}) (core.Connection, error) {
 	protocol := itests.GetFromEnvWithDefault("NEO4J_PROTOCOL", defaultProtocol)
 	host := itests.GetFromEnvWithDefault("NEO4J_HOST", defaultHost)
 	portString := itests.GetFromEnvWithDefault("NEO4J_PORT", "")
@@ -44,11 +58,7 @@ func (suite *Neo4JIntegrationTestSuite) SetupTest() {
 	user := itests.GetFromEnvWithDefault("NEO4J_USER", defaultUsername)
 	pwd := itests.GetFromEnvWithDefault("NEO4J_PWD", defaultPassword)
 	neo4jConnectionFactory := core.GetConnectorFactory("neo4j")
-	connection, err := neo4jConnectionFactory(protocol, host, realm, port, map[string]interface{}{neo.NEO4J_USER_KEY: user, neo.NEO4J_PWD_KEY: pwd}, nil)
-	suite.connection = connection
-	suite.NoErrorf(err, "error whe setting up Neo4j Test : %v", err)
-	suite.cleanupDB()
-	suite.store = omg.NewGenericStore(connection, omg.NewReflectionMapper())
+	return neo4jConnectionFactory(protocol, host, realm, port, map[string]interface{}{neo.NEO4J_USER_KEY: user, neo.NEO4J_PWD_KEY: pwd}, options)
 }
 
 func (suite *Neo4JIntegrationTestSuite) TestWriteAndQuery() {
@@ -65,6 +75,18 @@ func (suite *Neo4JIntegrationTestSuite) TestWriteAndQuery() {
 
 }
 
+func (suite *Neo4JIntegrationTestSuite) TestWriteWithQueryParamValidation() {
+	ctx := context.WithValue(context.Background(), core.ContextKeyValidateQueryParams, true)
+	query := "CREATE (a:Greeting) SET a.message = $message RETURN a"
+	queryResult, err := suite.connection.ExecuteQuery(ctx, query, core.Write, map[string]any{"msg": "hello, world"})
+	suite.Nil(queryResult)
+	suite.ErrorContains(err, "message")
+
+	queryResult, err = suite.connection.ExecuteQuery(ctx, query, core.Write, map[string]any{"message": "hello, world", "unused": 1})
+	suite.NoError(err)
+	suite.Equal(1, len(queryResult.Rows))
+}
+
 func (suite *Neo4JIntegrationTestSuite) TestVertexQuery() {
 	query := "create (p:Person{name:'Tintin'})-[r:LIVES_IN{since:1929}]->(c:Country{name:'Belgium'}) return p, r, c"
 	queryResult, err := suite.connection.ExecuteQuery(context.Background(), query, core.Write, map[string]any{"message": "hello, world"})
@@ -86,6 +108,791 @@ func (suite *Neo4JIntegrationTestSuite) TestVertexQuery() {
 	suite.Equal("LIVES_IN", edge[0].Type)
 }
 
+func (suite *Neo4JIntegrationTestSuite) TestQueryEdgeAnyLabel() {
+	query := "create (p:Person{name:'Tintin'})-[r:LIVES_IN{since:1929}]->(c:Country{name:'Belgium'}), (p)-[v:VISITED{year:1931}]->(c) return p, r, v, c"
+	_, err := suite.connection.ExecuteQuery(context.Background(), query, core.Write, nil)
+	suite.NoError(err)
+
+	edges, err := suite.connection.QueryEdge(context.Background(), []string{"Person"}, []string{"Country"}, "",
+		core.KVMap{"name": "Tintin"}, core.KVMap{"name": "Belgium"}, nil, nil, nil, nil, nil, core.EdgeWithVertexIds)
+	suite.NoError(err)
+	suite.Equal(2, len(edges))
+	edgeTypes := make([]string, 0)
+	for _, edge := range edges {
+		edgeTypes = append(edgeTypes, strings.ToUpper(edge.Type))
+	}
+	suite.ElementsMatch([]string{"LIVES_IN", "VISITED"}, edgeTypes)
+}
+
+func (suite *Neo4JIntegrationTestSuite) TestQueryEdgeWithFiltersAndQueryParams() {
+	query := "create (p:Person{name:'Tintin'})-[:VISITED{year:1931}]->(c:Country{name:'Congo'}), (p)-[:VISITED{year:1946}]->(c) return p, c"
+	_, err := suite.connection.ExecuteQuery(context.Background(), query, core.Write, nil)
+	suite.NoError(err)
+
+	// the query parameters are passed to the query in place of the filters which are rendered by the builder
+	ctx := context.WithValue(context.Background(), core.ContextKeyValidateQueryParams, true)
+	edges, err := suite.connection.QueryEdge(ctx, []string{"Person"}, []string{"Country"}, "VISITED",
+		core.KVMap{"name": "Tintin"}, core.KVMap{"name": "Congo"}, nil, nil, nil, core.KVMap{"year": 1946}, core.KVMap{"year": "unused", core.QueryParamLimit: 1}, core.EdgeWithVertexIds)
+	suite.NoError(err)
+	suite.Equal(1, len(edges))
+	suite.Equal(int64(1946), edges[0].Properties["year"])
+}
+
+func (suite *Neo4JIntegrationTestSuite) TestRelationshipTypesBetween() {
+	query := "create (p:Person{name:'Elon'})-[:WORKS_AT]->(c:Company{name:'Rockets Inc'}), (p)-[:FOUNDED]->(c), (p)-[:INVESTED_IN]->(c), (q:Person{name:'Gwynne'})-[:WORKS_AT]->(c) return p, q, c"
+	_, err := suite.connection.ExecuteQuery(context.Background(), query, core.Write, nil)
+	suite.NoError(err)
+
+	relationshipTypes, err := suite.connection.RelationshipTypesBetween(context.Background(), []string{"Person"}, []string{"Company"})
+	suite.NoError(err)
+	suite.ElementsMatch([]string{"WORKS_AT", "FOUNDED", "INVESTED_IN"}, relationshipTypes)
+}
+
+func (suite *Neo4JIntegrationTestSuite) TestQueryWithLimit() {
+	query := "create (t1:Person{name:'Tom', age:10})-[:VISITED]->(c:Country{name:'India'}), (t2:Person{name:'Tom', age:20})-[:VISITED]->(c), (t3:Person{name:'Tom', age:30})-[:VISITED]->(c) return t1, t2, t3, c"
+	_, err := suite.connection.ExecuteQuery(context.Background(), query, core.Write, nil)
+	suite.NoError(err)
+
+	vertices, err := suite.connection.QueryVertex(context.Background(), "Person", core.KVMap{"name": "Tom"}, nil, nil)
+	suite.NoError(err)
+	suite.Equal(3, len(vertices))
+	vertices, err = suite.connection.QueryVertex(context.Background(), "Person", core.KVMap{"name": "Tom"}, nil, core.KVMap{core.QueryParamLimit: 2})
+	suite.NoError(err)
+	suite.Equal(2, len(vertices))
+
+	edges, err := suite.connection.QueryEdge(context.Background(), []string{"Person"}, []string{"Country"}, "VISITED",
+		core.KVMap{"name": "Tom"}, core.KVMap{"name": "India"}, nil, nil, nil, nil, core.KVMap{core.QueryParamLimit: 1}, core.EdgeWithCompleteVertex)
+	suite.NoError(err)
+	suite.Equal(1, len(edges))
+}
+
+func (suite *Neo4JIntegrationTestSuite) TestQueryConnectedVerticesOfStar() {
+	query := "create (hub:Airport{code:'BOM'})-[:FLIES_TO]->(:Airport{code:'DEL'}), (hub)-[:FLIES_TO]->(:Airport{code:'BLR'}), (hub)-[:FLIES_TO]->(:Airport{code:'MAA'}) return hub"
+	_, err := suite.connection.ExecuteQuery(context.Background(), query, core.Write, nil)
+	suite.NoError(err)
+
+	vertices, err := suite.connection.QueryConnectedVertices(context.Background(), []string{"Airport"}, []string{"Airport"}, "FLIES_TO",
+		core.KVMap{"code": "BOM"}, nil, nil, nil, nil, nil, nil)
+	suite.NoError(err)
+	codes := make([]string, 0)
+	for _, v := range vertices {
+		codes = append(codes, v.Properties["code"].(string))
+	}
+	suite.ElementsMatch([]string{"BOM", "DEL", "BLR", "MAA"}, codes)
+}
+
+func (suite *Neo4JIntegrationTestSuite) TestExecuteQueryTyped() {
+	query := "create (:Person{name:'Tintin'}), (:Person{name:'Haddock'}) return 1"
+	_, err := suite.connection.ExecuteQuery(context.Background(), query, core.Write, nil)
+	suite.NoError(err)
+
+	var total int64
+	var name string
+	query = "match (p:Person) return count(p) as total, min(p.name) as name"
+	err = suite.connection.ExecuteQueryTyped(context.Background(), query, core.Read, nil, &total, &name)
+	suite.NoError(err)
+	suite.Equal(int64(2), total)
+	suite.Equal("Haddock", name)
+
+	err = suite.connection.ExecuteQueryTyped(context.Background(), query, core.Read, nil, &total)
+	suite.Error(err)
+}
+
+func (suite *Neo4JIntegrationTestSuite) TestQueryScalar() {
+	query := "create (:Person{name:'Tintin', age: 20}), (:Person{name:'Haddock', age: 45}) return 1"
+	_, err := suite.connection.ExecuteQuery(context.Background(), query, core.Write, nil)
+	suite.NoError(err)
+
+	total, err := suite.connection.QueryScalarInt(context.Background(), "match (p:Person) return count(*)", nil)
+	suite.NoError(err)
+	suite.Equal(int64(2), total)
+	average, err := suite.connection.QueryScalarFloat(context.Background(), "match (p:Person) return avg(p.age)", nil)
+	suite.NoError(err)
+	suite.Equal(32.5, average)
+
+	_, err = suite.connection.QueryScalarInt(context.Background(), "match (p:Person) return avg(p.age)", nil)
+	suite.Error(err)
+}
+
+func (suite *Neo4JIntegrationTestSuite) TestStoreVerticesDistinct() {
+	person := &core.Vertex{Labels: []string{"Person"}, Properties: core.KVMap{"name": "Tintin"}}
+	company := &core.Vertex{Labels: []string{"Company"}, Properties: core.KVMap{"name": "Moulinsart", "founded": int64(1929)}}
+	err := suite.connection.StoreVerticesDistinct(context.Background(), []*core.Vertex{person, company})
+	suite.NoError(err)
+	suite.NotNil(person.ID)
+	suite.NotNil(company.ID)
+
+	vertices, err := suite.connection.QueryVertex(context.Background(), "Company", core.KVMap{"name": "Moulinsart"}, nil, nil)
+	suite.NoError(err)
+	suite.Equal(1, len(vertices))
+	suite.Equal(company.ID, vertices[0].ID)
+	vertices, err = suite.connection.QueryVertex(context.Background(), "Person", core.KVMap{"name": "Tintin"}, nil, nil)
+	suite.NoError(err)
+	suite.Equal(1, len(vertices))
+	suite.Equal(person.ID, vertices[0].ID)
+}
+
+func (suite *Neo4JIntegrationTestSuite) TestStoreVertices() {
+	vertices := []*core.Vertex{
+		{Labels: []string{"Person"}, Properties: core.KVMap{"name": "Tintin"}},
+		{Labels: []string{"Person"}, Properties: core.KVMap{"name": "Haddock"}},
+		{Labels: []string{"Company"}, Properties: core.KVMap{"name": "Moulinsart", "founded": int64(1929)}},
+	}
+	err := suite.connection.StoreVertices(context.Background(), vertices)
+	suite.NoError(err)
+	for _, vertex := range vertices {
+		suite.NotNil(vertex.ID)
+	}
+
+	persons, err := suite.connection.QueryVertex(context.Background(), "Person", core.KVMap{"name": "Haddock"}, nil, nil)
+	suite.NoError(err)
+	suite.Equal(1, len(persons))
+	suite.Equal(vertices[1].ID, persons[0].ID)
+	companies, err := suite.connection.QueryVertex(context.Background(), "Company", nil, nil, nil)
+	suite.NoError(err)
+	suite.Equal(1, len(companies))
+	suite.Equal(vertices[2].ID, companies[0].ID)
+	suite.Equal(int64(1929), companies[0].Properties["founded"])
+}
+
+func (suite *Neo4JIntegrationTestSuite) TestStoreEdgeSelfLoop() {
+	rel := core.Edge{
+		Type:              "KNOWS",
+		SourceVertex:      &core.Vertex{Labels: []string{"Person"}, Properties: core.KVMap{"name": "Narcissus"}},
+		DestinationVertex: &core.Vertex{Labels: []string{"Person"}, Properties: core.KVMap{"name": "Narcissus"}},
+	}
+	err := suite.connection.StoreEdge(context.Background(), &rel)
+	suite.NoError(err)
+	suite.Equal(rel.SourceVertex.ID, rel.DestinationVertex.ID)
+
+	vertices, err := suite.connection.QueryVertex(context.Background(), "Person", core.KVMap{"name": "Narcissus"}, nil, nil)
+	suite.NoError(err)
+	suite.Equal(1, len(vertices))
+
+	edges, err := suite.connection.QueryEdge(context.Background(), []string{"Person"}, []string{"Person"}, "KNOWS", nil, nil, nil, nil, nil, nil, nil, core.EdgeWithVertexIds)
+	suite.NoError(err)
+	suite.Equal(1, len(edges))
+	suite.Equal(rel.ID, edges[0].ID)
+	suite.Equal(vertices[0].ID, edges[0].SourceVertexID)
+	suite.Equal(vertices[0].ID, edges[0].DestinationVertexID)
+}
+
+func (suite *Neo4JIntegrationTestSuite) TestStoreVertexWriteOutcomes() {
+	ctx, writeOutcomes := core.WithWriteOutcomes(context.Background())
+	vertex := core.Vertex{Labels: []string{"Person"}, Properties: core.KVMap{"name": "Tintin"}}
+	suite.NoError(suite.connection.StoreVertex(ctx, &vertex))
+	suite.Equal(core.WriteOutcomes{Created: 1}, *writeOutcomes)
+
+	// storing the identical vertex again matches the existing vertex
+	suite.NoError(suite.connection.StoreVertex(ctx, &core.Vertex{Labels: []string{"Person"}, Properties: core.KVMap{"name": "Tintin"}}))
+	suite.Equal(core.WriteOutcomes{Created: 1, Unchanged: 1}, *writeOutcomes)
+}
+
+func (suite *Neo4JIntegrationTestSuite) TestStoreVertexWithIdentityPropertiesWriteOutcomes() {
+	ctx, writeOutcomes := core.WithWriteOutcomes(core.WithVertexIdentityProperties(context.Background(), "name"))
+	suite.NoError(suite.connection.StoreVertex(ctx, &core.Vertex{Labels: []string{"Person"}, Properties: core.KVMap{"name": "Tintin", "age": 17}}))
+	suite.Equal(core.WriteOutcomes{Created: 1}, *writeOutcomes)
+
+	// the changed age updates the vertex matched on the name
+	suite.NoError(suite.connection.StoreVertex(ctx, &core.Vertex{Labels: []string{"Person"}, Properties: core.KVMap{"name": "Tintin", "age": 18}}))
+	suite.Equal(core.WriteOutcomes{Created: 1, Updated: 1}, *writeOutcomes)
+
+	suite.NoError(suite.connection.StoreVertex(ctx, &core.Vertex{Labels: []string{"Person"}, Properties: core.KVMap{"name": "Tintin", "age": 18}}))
+	suite.Equal(core.WriteOutcomes{Created: 1, Updated: 1, Unchanged: 1}, *writeOutcomes)
+
+	vertices, err := suite.connection.QueryVertex(context.Background(), "Person", core.KVMap{"name": "Tintin"}, nil, nil)
+	suite.NoError(err)
+	suite.Equal(1, len(vertices))
+	suite.Equal(int64(18), vertices[0].Properties["age"])
+}
+
+func (suite *Neo4JIntegrationTestSuite) TestReadOmgStructBySingleField() {
+	p := person{Name: "Tom", Age: 10}
+	err := suite.store.PersistVertex(context.Background(), &p)
+	suite.NoError(err)
+
+	// the age in the example differs from the stored age and must not be used as a selector
+	read, err := suite.store.ReadVertexBy(context.Background(), &person{Name: "Tom", Age: 11}, []string{"Name"})
+	suite.NoError(err)
+	suite.Equal(1, len(read))
+	suite.Equal(p, *(read[0].(*person)))
+
+	read, err = suite.store.ReadVertex(context.Background(), &person{Name: "Tom", Age: 11})
+	suite.NoError(err)
+	suite.Equal(0, len(read))
+}
+
+func (suite *Neo4JIntegrationTestSuite) TestStoreVertexWithCreatedTimestamp() {
+	ctx := context.WithValue(context.Background(), core.ContextKeyCreatedTimestamp, "createdAt")
+	vertex := core.Vertex{Labels: []string{"Person"}, Properties: core.KVMap{"name": "Tintin"}}
+	suite.NoError(suite.connection.StoreVertex(ctx, &vertex))
+	createdAt, ok := vertex.Properties["createdAt"].(int64)
+	suite.True(ok)
+	suite.Greater(createdAt, int64(0))
+
+	vertices, err := suite.connection.QueryVertex(context.Background(), "Person", core.KVMap{"name": "Tintin"}, nil, nil)
+	suite.NoError(err)
+	suite.Equal(1, len(vertices))
+	suite.Equal(createdAt, vertices[0].Properties["createdAt"])
+}
+
+func (suite *Neo4JIntegrationTestSuite) TestStoreVertexWithRuntimeLabelUsingApoc() {
+	qr, err := suite.connection.ExecuteQuery(context.Background(), "SHOW PROCEDURES YIELD name WHERE name = 'apoc.merge.node' RETURN name", core.Read, nil)
+	suite.NoError(err)
+	if len(qr.Rows) == 0 {
+		suite.T().Skip("APOC procedures are not installed")
+	}
+	connection, err := suite.newConnection(map[string]interface{}{neo.NEO4J_APOC_KEY: true})
+	suite.NoError(err)
+	defer connection.Close(context.Background())
+	suite.True(connection.Capabilities().DynamicLabels)
+
+	// the label is not a valid identifier and would break a query built with the label within the query text
+	label := "Runtime Label " + strconv.FormatInt(time.Now().UnixNano(), 10)
+	vertex := core.Vertex{Labels: []string{label}, Properties: core.KVMap{"name": "Tintin"}}
+	suite.NoError(connection.StoreVertex(context.Background(), &vertex))
+	suite.NotNil(vertex.ID)
+
+	vertices, err := connection.QueryVertex(context.Background(), label, core.KVMap{"name": "Tintin"}, nil, nil)
+	suite.NoError(err)
+	suite.Equal(1, len(vertices))
+	suite.Equal(vertex.ID, vertices[0].ID)
+	suite.Equal([]string{label}, vertices[0].Labels)
+}
+
+func (suite *Neo4JIntegrationTestSuite) TestPersistOmgStructWithAdjacentVertices() {
+	o := purchaseOrder{Number: "A-1", LineItems: []lineItem{{Product: "Pen", Quantity: 2}, {Product: "Ink", Quantity: 1}}}
+	err := suite.store.PersistVertex(context.Background(), &o)
+	suite.NoError(err)
+
+	read, err := suite.store.ReadVertex(context.Background(), &purchaseOrder{Number: "A-1"})
+	suite.NoError(err)
+	suite.Equal(1, len(read))
+	readOrder := read[0].(*purchaseOrder)
+	suite.Equal("A-1", readOrder.Number)
+	suite.ElementsMatch(o.LineItems, readOrder.LineItems)
+
+	// the line items are stored as vertices connected to the order
+	edges, err := suite.connection.QueryEdge(context.Background(), []string{"Order"}, []string{"LineItem"}, "CONTAINS", core.KVMap{"number": "A-1"}, nil, nil, nil, nil, nil, nil, core.EdgeWithCompleteVertex)
+	suite.NoError(err)
+	suite.Equal(2, len(edges))
+}
+
+func (suite *Neo4JIntegrationTestSuite) TestQueryEdgePaged() {
+	for i := 0; i < 5; i++ {
+		edge := core.Edge{
+			Type:              "FOLLOWS",
+			SourceVertex:      &core.Vertex{Labels: []string{"Reader"}, Properties: core.KVMap{"name": "Tintin"}},
+			DestinationVertex: &core.Vertex{Labels: []string{"Reader"}, Properties: core.KVMap{"name": "Reader " + strconv.Itoa(i)}},
+			Properties:        core.KVMap{},
+		}
+		suite.NoError(suite.connection.StoreEdge(context.Background(), &edge))
+	}
+
+	seen := make(map[string]bool)
+	for skip := 0; skip < 6; skip += 2 {
+		queryParams := core.KVMap{core.QueryParamSkip: skip, core.QueryParamLimit: 2, core.QueryParamOrderByID: true}
+		page, err := suite.connection.QueryEdge(context.Background(), []string{"Reader"}, []string{"Reader"}, "FOLLOWS", nil, nil, nil, nil, nil, nil, queryParams, core.EdgeWithCompleteVertex)
+		suite.NoError(err)
+		if skip < 4 {
+			suite.Equal(2, len(page))
+		} else {
+			suite.Equal(1, len(page))
+		}
+		for _, edge := range page {
+			suite.False(seen[edge.ID.String()])
+			seen[edge.ID.String()] = true
+		}
+	}
+	suite.Equal(5, len(seen))
+}
+
+func (suite *Neo4JIntegrationTestSuite) TestQueryEdgeOrderedByProperty() {
+	for i, since := range []int{1941, 1929, 1950, 1934} {
+		edge := core.Edge{
+			Type:              "FOLLOWS",
+			SourceVertex:      &core.Vertex{Labels: []string{"Reader"}, Properties: core.KVMap{"name": "Tintin"}},
+			DestinationVertex: &core.Vertex{Labels: []string{"Reader"}, Properties: core.KVMap{"name": "Reader " + strconv.Itoa(i)}},
+			Properties:        core.KVMap{"since": since},
+		}
+		suite.NoError(suite.connection.StoreEdge(context.Background(), &edge))
+	}
+
+	queryParams := core.KVMap{core.QueryParamOrderBy: "since"}
+	edges, err := suite.connection.QueryEdge(context.Background(), []string{"Reader"}, []string{"Reader"}, "FOLLOWS", nil, nil, nil, nil, nil, nil, queryParams, core.EdgeWithCompleteVertex)
+	suite.NoError(err)
+	since := make([]int64, 0, len(edges))
+	for _, edge := range edges {
+		suite.NotNil(edge.DestinationVertex)
+		since = append(since, edge.Properties["since"].(int64))
+	}
+	suite.Equal([]int64{1929, 1934, 1941, 1950}, since)
+
+	queryParams = core.KVMap{core.QueryParamOrderBy: core.OrderBy{Property: "since", Descending: true}, core.QueryParamLimit: 2}
+	edges, err = suite.connection.QueryEdge(context.Background(), []string{"Reader"}, []string{"Reader"}, "FOLLOWS", nil, nil, nil, nil, nil, nil, queryParams, core.EdgeWithVertexIds)
+	suite.NoError(err)
+	suite.Equal(2, len(edges))
+	suite.Equal(int64(1950), edges[0].Properties["since"])
+	suite.Equal(int64(1941), edges[1].Properties["since"])
+}
+
+func (suite *Neo4JIntegrationTestSuite) TestQuerySubgraph() {
+	for _, reader := range []string{"Haddock", "Calculus"} {
+		edge := core.Edge{
+			Type:              "FOLLOWS",
+			SourceVertex:      &core.Vertex{Labels: []string{"Reader"}, Properties: core.KVMap{"name": reader}},
+			DestinationVertex: &core.Vertex{Labels: []string{"Reader"}, Properties: core.KVMap{"name": "Tintin"}},
+			Properties:        core.KVMap{},
+		}
+		suite.NoError(suite.connection.StoreEdge(context.Background(), &edge))
+	}
+
+	subgraph, err := suite.connection.QuerySubgraph(context.Background(), "MATCH p = (:Reader)-[:FOLLOWS]->(:Reader) RETURN p", nil)
+	suite.NoError(err)
+	suite.Equal(3, len(subgraph.Vertices))
+	suite.Equal(2, len(subgraph.Edges))
+	for _, edge := range subgraph.Edges {
+		suite.Contains(subgraph.Vertices, edge.SourceVertex)
+		suite.Contains(subgraph.Vertices, edge.DestinationVertex)
+		suite.Equal("Tintin", edge.DestinationVertex.Properties["name"])
+	}
+	// both the edges end at the same vertex
+	suite.Same(subgraph.Edges[0].DestinationVertex, subgraph.Edges[1].DestinationVertex)
+}
+
+func (suite *Neo4JIntegrationTestSuite) TestStoreWithWriteModeCreate() {
+	ctx := context.WithValue(context.Background(), neo.ContextKeyWriteModeCreate, true)
+	// CREATE does not match existing vertices and hence stores a duplicate vertex
+	for i := 0; i < 2; i++ {
+		vertex := core.Vertex{Labels: []string{"Person"}, Properties: core.KVMap{"name": "Tintin"}}
+		suite.NoError(suite.connection.StoreVertex(ctx, &vertex))
+	}
+	vertices, err := suite.connection.QueryVertex(context.Background(), "Person", core.KVMap{"name": "Tintin"}, nil, nil)
+	suite.NoError(err)
+	suite.Equal(2, len(vertices))
+
+	edge := core.Edge{
+		Type:              "OWNS",
+		SourceVertex:      &core.Vertex{Labels: []string{"Person"}, Properties: core.KVMap{"name": "Haddock"}},
+		DestinationVertex: &core.Vertex{Labels: []string{"Ship"}, Properties: core.KVMap{"name": "Unicorn"}},
+		Properties:        core.KVMap{},
+	}
+	suite.NoError(suite.connection.StoreEdge(ctx, &edge))
+	suite.NoError(suite.connection.StoreEdge(ctx, &edge))
+	edges, err := suite.connection.QueryEdge(context.Background(), []string{"Person"}, []string{"Ship"}, "OWNS", nil, nil, nil, nil, nil, nil, nil, core.EdgeWithVertexIds)
+	suite.NoError(err)
+	suite.Equal(2, len(edges))
+}
+
+func (suite *Neo4JIntegrationTestSuite) TestDegree() {
+	hub := core.Vertex{Labels: []string{"Reader"}, Properties: core.KVMap{"name": "Tintin"}}
+	for _, reader := range []string{"Haddock", "Calculus", "Nestor"} {
+		edge := core.Edge{
+			Type:              "FOLLOWS",
+			SourceVertex:      &core.Vertex{Labels: []string{"Reader"}, Properties: core.KVMap{"name": reader}},
+			DestinationVertex: &hub,
+			Properties:        core.KVMap{},
+		}
+		suite.NoError(suite.connection.StoreEdge(context.Background(), &edge))
+	}
+	edge := core.Edge{
+		Type:              "WALKS",
+		SourceVertex:      &hub,
+		DestinationVertex: &core.Vertex{Labels: []string{"Reader"}, Properties: core.KVMap{"name": "Snowy"}},
+		Properties:        core.KVMap{},
+	}
+	suite.NoError(suite.connection.StoreEdge(context.Background(), &edge))
+
+	degree, err := suite.connection.Degree(context.Background(), hub.ID, core.DirectionBoth, nil)
+	suite.NoError(err)
+	suite.Equal(int64(4), degree)
+	degree, err = suite.connection.Degree(context.Background(), hub.ID, core.DirectionIncoming, nil)
+	suite.NoError(err)
+	suite.Equal(int64(3), degree)
+	degree, err = suite.connection.Degree(context.Background(), hub.ID, core.DirectionOutgoing, nil)
+	suite.NoError(err)
+	suite.Equal(int64(1), degree)
+	degree, err = suite.connection.Degree(context.Background(), hub.ID, core.DirectionBoth, []string{"WALKS"})
+	suite.NoError(err)
+	suite.Equal(int64(1), degree)
+}
+
+func (suite *Neo4JIntegrationTestSuite) TestExpandNeighbors() {
+	tintin := core.Vertex{Labels: []string{"Reader"}, Properties: core.KVMap{"name": "Tintin"}}
+	haddock := core.Vertex{Labels: []string{"Reader"}, Properties: core.KVMap{"name": "Haddock"}}
+	nestor := core.Vertex{Labels: []string{"Reader"}, Properties: core.KVMap{"name": "Nestor"}}
+	for _, edge := range []core.Edge{
+		{Type: "FOLLOWS", SourceVertex: &haddock, DestinationVertex: &tintin, Properties: core.KVMap{}},
+		{Type: "FOLLOWS", SourceVertex: &tintin, DestinationVertex: &haddock, Properties: core.KVMap{}},
+		{Type: "WALKS", SourceVertex: &tintin, DestinationVertex: &core.Vertex{Labels: []string{"Reader"}, Properties: core.KVMap{"name": "Snowy"}}, Properties: core.KVMap{}},
+	} {
+		edge := edge
+		suite.NoError(suite.connection.StoreEdge(context.Background(), &edge))
+	}
+	suite.NoError(suite.connection.StoreVertex(context.Background(), &nestor))
+
+	neighbors, err := suite.connection.ExpandNeighbors(context.Background(), []*core.Identifier{tintin.ID, haddock.ID, nestor.ID}, []string{"FOLLOWS"}, core.DirectionOutgoing)
+	suite.NoError(err)
+	suite.Equal(3, len(neighbors))
+	suite.Equal(1, len(neighbors[tintin.ID.String()]))
+	suite.Equal("Haddock", neighbors[tintin.ID.String()][0].DestinationVertex.Properties["name"])
+	suite.Equal(1, len(neighbors[haddock.ID.String()]))
+	suite.Equal("Tintin", neighbors[haddock.ID.String()][0].DestinationVertex.Properties["name"])
+	suite.Empty(neighbors[nestor.ID.String()])
+
+	neighbors, err = suite.connection.ExpandNeighbors(context.Background(), []*core.Identifier{tintin.ID}, nil, core.DirectionBoth)
+	suite.NoError(err)
+	suite.Equal(3, len(neighbors[tintin.ID.String()]))
+}
+
+func (suite *Neo4JIntegrationTestSuite) TestReadOmgStructWithListProperties() {
+	query := "CREATE (b:Book {title: $title, chapters: $chapters, tags: $tags})"
+	_, err := suite.connection.ExecuteQuery(context.Background(), query, core.Write, map[string]any{"title": "Tintin in Tibet", "chapters": []int64{1, 2, 3}, "tags": []string{"comics", "adventure"}})
+	suite.NoError(err)
+
+	read, err := suite.store.ReadVertexBy(context.Background(), &book{Title: "Tintin in Tibet"}, []string{"Title"})
+	suite.NoError(err)
+	suite.Equal(1, len(read))
+	suite.Equal(&book{Title: "Tintin in Tibet", Chapters: []int64{1, 2, 3}, Tags: []string{"comics", "adventure"}}, read[0])
+}
+
+func (suite *Neo4JIntegrationTestSuite) TestStoreEdgeWithMatchedStartAndMergedEndVertex() {
+	ctx := core.WithEndpointModes(context.Background(), core.EndpointMatch, core.EndpointMerge)
+	cv := core.Vertex{Labels: []string{"Cartoon"}, Properties: core.KVMap{"Name": "Tom and Jerry"}}
+	pv := core.Vertex{Labels: []string{"Team"}, Properties: core.KVMap{"Name": "William Hanna and Joseph Barbara"}}
+	rel := core.Edge{Type: "CREATED_BY", Properties: core.KVMap{}, SourceVertex: &cv, DestinationVertex: &pv}
+
+	// the start vertex does not exist and must not be created
+	err := suite.connection.StoreEdge(ctx, &rel)
+	suite.ErrorIs(err, core.ErrEndpointNotFound)
+	storedVertex, err := suite.connection.QueryVertex(context.Background(), "Cartoon", core.KVMap{"Name": "Tom and Jerry"}, nil, nil)
+	suite.NoError(err)
+	suite.Equal(0, len(storedVertex))
+
+	// the end vertex is created once the start vertex exists
+	err = suite.connection.StoreVertex(context.Background(), &cv)
+	suite.NoError(err)
+	err = suite.connection.StoreEdge(ctx, &rel)
+	suite.NoError(err)
+	suite.NotNil(pv.ID)
+
+	// storing the edge again reuses the existing end vertex
+	err = suite.connection.StoreEdge(ctx, &rel)
+	suite.NoError(err)
+	storedVertex, err = suite.connection.QueryVertex(context.Background(), "Team", core.KVMap{"Name": "William Hanna and Joseph Barbara"}, nil, nil)
+	suite.NoError(err)
+	suite.Equal(1, len(storedVertex))
+	suite.Equal(pv.ID, storedVertex[0].ID)
+}
+
+func (suite *Neo4JIntegrationTestSuite) TestQueryEdgeReportsStoredDirection() {
+	tintin := core.Vertex{Labels: []string{"Person"}, Properties: core.KVMap{"name": "Tintin"}}
+	haddock := core.Vertex{Labels: []string{"Person"}, Properties: core.KVMap{"name": "Haddock"}}
+	rel := core.Edge{Type: "RESCUED", Properties: core.KVMap{}, SourceVertex: &tintin, DestinationVertex: &haddock}
+	err := suite.connection.StoreEdge(context.Background(), &rel)
+	suite.NoError(err)
+
+	edges, err := suite.connection.QueryEdge(context.Background(), []string{"Person"}, []string{"Person"}, "RESCUED", nil, nil, nil, nil, nil, nil, nil, core.EdgeWithCompleteVertex)
+	suite.NoError(err)
+	suite.Equal(1, len(edges))
+	suite.Equal(tintin.ID, edges[0].SourceVertexID)
+	suite.Equal(haddock.ID, edges[0].DestinationVertexID)
+	suite.Equal("Tintin", edges[0].SourceVertex.Properties["name"])
+	suite.Equal("Haddock", edges[0].DestinationVertex.Properties["name"])
+
+	// the edge is not selected when the end vertex is selected as the start vertex
+	edges, err = suite.connection.QueryEdge(context.Background(), []string{"Person"}, []string{"Person"}, "RESCUED", core.KVMap{"name": "Haddock"}, nil, nil, nil, nil, nil, nil, core.EdgeWithVertexIds)
+	suite.NoError(err)
+	suite.Equal(0, len(edges))
+}
+
+func (suite *Neo4JIntegrationTestSuite) TestExecuteQueryWithMaxRows() {
+	connection, err := suite.newConnection(map[string]interface{}{core.MAX_ROWS_KEY: 3})
+	suite.NoError(err)
+	defer connection.Close(context.Background())
+
+	queryResult, err := connection.ExecuteQuery(context.Background(), "UNWIND range(1, 3) AS n RETURN n", core.Read, nil)
+	suite.NoError(err)
+	suite.Equal(3, len(queryResult.Rows))
+
+	_, err = connection.ExecuteQuery(context.Background(), "UNWIND range(1, 100000) AS n RETURN n", core.Read, nil)
+	suite.ErrorIs(err, core.ErrMaxRowsExceeded)
+}
+
+func (suite *Neo4JIntegrationTestSuite) TestQueryWithExistsConditions() {
+	query := "CREATE (:Person {name: 'Tintin'})-[:HAS]->(:Order {number: 'A-1'}), (:Person {name: 'Haddock'})"
+	_, err := suite.connection.ExecuteQuery(context.Background(), query, core.Write, nil)
+	suite.NoError(err)
+	pattern := cypher.RelationshipPattern{EdgeLabel: "HAS", Direction: core.DirectionOutgoing, EndVertexLabels: []string{"Order"}}
+
+	query, err = cypher.NewVertexQueryBuilder().SetLabel([]string{"Person"}).SetVarName("v").AddExistsCondition(pattern).Build()
+	suite.NoError(err)
+	queryResult, err := suite.connection.ExecuteQuery(context.Background(), query, core.Read, nil)
+	suite.NoError(err)
+	suite.Equal(1, len(queryResult.Rows))
+	suite.Equal("Tintin", queryResult.Rows[0]["v"].(neo4j.Node).Props["name"])
+
+	query, err = cypher.NewVertexQueryBuilder().SetLabel([]string{"Person"}).SetVarName("v").AddNotExistsCondition(pattern).Build()
+	suite.NoError(err)
+	queryResult, err = suite.connection.ExecuteQuery(context.Background(), query, core.Read, nil)
+	suite.NoError(err)
+	suite.Equal(1, len(queryResult.Rows))
+	suite.Equal("Haddock", queryResult.Rows[0]["v"].(neo4j.Node).Props["name"])
+}
+
+func (suite *Neo4JIntegrationTestSuite) TestQueryVertexWithFiltersOnly() {
+	for _, v := range []*core.Vertex{
+		{Labels: []string{"Person"}, Properties: core.KVMap{"name": "Tintin", "age": 18}},
+		{Labels: []string{"Person"}, Properties: core.KVMap{"name": "Haddock", "age": 50}},
+	} {
+		suite.NoError(suite.connection.StoreVertex(context.Background(), v))
+	}
+
+	vertices, err := suite.connection.QueryVertex(context.Background(), "Person", nil, core.KVMap{"age": 18}, nil)
+	suite.NoError(err)
+	suite.Equal(1, len(vertices))
+	suite.Equal("Tintin", vertices[0].Properties["name"])
+}
+
+func (suite *Neo4JIntegrationTestSuite) TestStoreAndQueryReservedWords() {
+	v := &core.Vertex{Labels: []string{"Match"}, Properties: core.KVMap{"name": "Final", "order": 1}}
+	suite.NoError(suite.connection.StoreVertex(context.Background(), v))
+
+	vertices, err := suite.connection.QueryVertex(context.Background(), "Match", core.KVMap{"order": 1}, nil, nil)
+	suite.NoError(err)
+	suite.Equal(1, len(vertices))
+	suite.Equal("Final", vertices[0].Properties["name"])
+
+	vertices, err = suite.connection.QueryVertex(context.Background(), "Match", nil, core.KVMap{"order": 1}, nil)
+	suite.NoError(err)
+	suite.Equal(1, len(vertices))
+}
+
+func (suite *Neo4JIntegrationTestSuite) TestExecuteQueryNormalized() {
+	v := &core.Vertex{Labels: []string{"Person"}, Properties: core.KVMap{"name": "Tintin", "age": 18}}
+	suite.NoError(suite.connection.StoreVertex(context.Background(), v))
+
+	result, err := suite.connection.ExecuteQueryNormalized(context.Background(), "MATCH (v:Person) RETURN v, count(v) AS count", core.Read, nil)
+	suite.NoError(err)
+	suite.Equal(1, len(result.Rows))
+	vertex := result.Rows[0]["v"].(map[string]interface{})
+	suite.Equal([]interface{}{"Person"}, vertex["labels"])
+	suite.Equal(map[string]interface{}{"name": "Tintin", "age": int64(18)}, vertex["properties"])
+	suite.Equal(int64(1), result.Rows[0]["count"])
+}
+
+func (suite *Neo4JIntegrationTestSuite) TestPersistVertexWithStoreWriteMode() {
+	createStore := omg.NewGenericStoreWithWriteMode(suite.connection, omg.NewReflectionMapper(), core.Create)
+	suite.NoError(createStore.PersistVertex(context.Background(), &person{Name: "Tom", Age: 10}))
+	suite.NoError(createStore.PersistVertex(context.Background(), &person{Name: "Tom", Age: 10}))
+	read, err := suite.store.ReadVertex(context.Background(), &person{Name: "Tom", Age: 10})
+	suite.NoError(err)
+	suite.Equal(2, len(read))
+
+	mergeStore := omg.NewGenericStoreWithWriteMode(suite.connection, omg.NewReflectionMapper(), core.Merge)
+	suite.NoError(mergeStore.PersistVertex(context.Background(), &person{Name: "Jerry", Age: 8}))
+	suite.NoError(mergeStore.PersistVertex(context.Background(), &person{Name: "Jerry", Age: 8}))
+	read, err = suite.store.ReadVertex(context.Background(), &person{Name: "Jerry", Age: 8})
+	suite.NoError(err)
+	suite.Equal(1, len(read))
+}
+
+func (suite *Neo4JIntegrationTestSuite) TestQueryVertexWithNumericIds() {
+	conn, err := suite.newConnection(map[string]interface{}{neo.NEO4J_NUMERIC_IDS_KEY: true})
+	suite.NoError(err)
+	defer conn.Close(context.Background())
+
+	v := &core.Vertex{Labels: []string{"Person"}, Properties: core.KVMap{"name": "Tintin"}}
+	suite.NoError(conn.StoreVertex(context.Background(), v))
+	storedId, ok := v.ID.Int64()
+	suite.True(ok)
+
+	var legacyId int64
+	suite.NoError(conn.ExecuteQueryTyped(context.Background(), "MATCH (v:Person {name: 'Tintin'}) RETURN id(v)", core.Read, nil, &legacyId))
+	suite.Equal(legacyId, storedId)
+
+	vertices, err := conn.QueryVertex(context.Background(), "Person", core.KVMap{"name": "Tintin"}, nil, nil)
+	suite.NoError(err)
+	suite.Equal(1, len(vertices))
+	readId, ok := vertices[0].ID.Int64()
+	suite.True(ok)
+	suite.Equal(legacyId, readId)
+}
+
+func (suite *Neo4JIntegrationTestSuite) TestQueryVertexFunc() {
+	for i := 0; i < 5; i++ {
+		v := &core.Vertex{Labels: []string{"Person"}, Properties: core.KVMap{"seq": i}}
+		suite.NoError(suite.connection.StoreVertex(context.Background(), v))
+	}
+	count := 0
+	err := suite.connection.QueryVertexFunc(context.Background(), "Person", nil, nil, func(v *core.Vertex) error {
+		count++
+		return nil
+	})
+	suite.NoError(err)
+	suite.Equal(5, count)
+
+	errStop := errors.New("stop")
+	count = 0
+	err = suite.connection.QueryVertexFunc(context.Background(), "Person", nil, nil, func(v *core.Vertex) error {
+		count++
+		if count == 2 {
+			return errStop
+		}
+		return nil
+	})
+	suite.ErrorIs(err, errStop)
+	suite.Equal(2, count)
+}
+
+func (suite *Neo4JIntegrationTestSuite) TestReadOmgStructWithAlternatePropertyName() {
+	v := &core.Vertex{Labels: []string{"Address"}, Properties: core.KVMap{"city": "Mumbai", "pin_code": 400001}}
+	suite.NoError(suite.connection.StoreVertex(context.Background(), v))
+
+	vertices, err := suite.connection.QueryVertex(context.Background(), "Address", core.KVMap{"city": "Mumbai"}, nil, nil)
+	suite.NoError(err)
+	suite.Equal(1, len(vertices))
+	var a address
+	suite.NoError(omg.NewReflectionMapper().FromVertex(vertices[0], &a))
+	suite.Equal(address{City: "Mumbai", Zip: 400001}, a)
+}
+
+func (suite *Neo4JIntegrationTestSuite) TestStoreAndQueryValuesWithQuotes() {
+	v := &core.Vertex{Labels: []string{"Person"}, Properties: core.KVMap{"name": "O'Brien", "note": "said \"hi\"\nthen left \\"}}
+	suite.NoError(suite.connection.StoreVertex(context.Background(), v))
+
+	vertices, err := suite.connection.QueryVertex(context.Background(), "Person", core.KVMap{"name": "O'Brien"}, nil, nil)
+	suite.NoError(err)
+	suite.Equal(1, len(vertices))
+	suite.Equal("said \"hi\"\nthen left \\", vertices[0].Properties["note"])
+
+	vertices, err = suite.connection.QueryVertex(context.Background(), "Person", nil, core.KVMap{"note": "said \"hi\"\nthen left \\"}, nil)
+	suite.NoError(err)
+	suite.Equal(1, len(vertices))
+}
+
+func (suite *Neo4JIntegrationTestSuite) TestQueryVertexWithComparisonFilters() {
+	for _, age := range []int64{12, 18, 40, 70} {
+		v := &core.Vertex{Labels: []string{"Person"}, Properties: core.KVMap{"name": "person" + strconv.FormatInt(age, 10), "age": age}}
+		suite.NoError(suite.connection.StoreVertex(context.Background(), v))
+	}
+	filters := core.KVMap{"age": core.Filter{Operator: core.Gt, Value: 18}}
+	vertices, err := suite.connection.QueryVertex(context.Background(), "Person", nil, filters, nil)
+	suite.NoError(err)
+	suite.Equal(2, len(vertices))
+
+	filters = core.KVMap{"age": core.Filter{Operator: core.Lte, Value: 18}, "name": core.Filter{Operator: core.Neq, Value: "person12"}}
+	vertices, err = suite.connection.QueryVertex(context.Background(), "Person", nil, filters, nil)
+	suite.NoError(err)
+	suite.Equal(1, len(vertices))
+	suite.Equal("person18", vertices[0].Properties["name"])
+}
+
+func (suite *Neo4JIntegrationTestSuite) TestStoreEdgeWithIdempotencyKey() {
+	ctx := core.WithIdempotencyKey(context.Background(), "request-1")
+	for _, since := range []int64{1929, 1930} {
+		edge := core.Edge{
+			Type:              "OWNS",
+			SourceVertex:      &core.Vertex{Labels: []string{"Person"}, Properties: core.KVMap{"name": "Tintin"}},
+			DestinationVertex: &core.Vertex{Labels: []string{"Dog"}, Properties: core.KVMap{"name": "Snowy"}},
+			Properties:        core.KVMap{"since": since},
+		}
+		suite.NoError(suite.connection.StoreEdge(ctx, &edge))
+	}
+	edges, err := suite.connection.QueryEdge(context.Background(), []string{"Person"}, []string{"Dog"}, "OWNS", nil, nil, nil, nil, nil, nil, nil, core.EdgeWithVertexIds)
+	suite.NoError(err)
+	suite.Equal(1, len(edges))
+	// the properties of the edge are retained from the first store
+	suite.Equal(int64(1929), edges[0].Properties["since"])
+	suite.Equal("request-1", edges[0].Properties[core.IdempotencyKeyProperty])
+}
+
+func (suite *Neo4JIntegrationTestSuite) TestStoreEdgeWithIdentityProperties() {
+	ctx := core.WithEdgeIdentityProperties(context.Background(), "since")
+	for _, weight := range []float64{0.5, 0.8} {
+		edge := core.Edge{
+			Type:              "OWNS",
+			SourceVertex:      &core.Vertex{Labels: []string{"Person"}, Properties: core.KVMap{"name": "Tintin"}},
+			DestinationVertex: &core.Vertex{Labels: []string{"Dog"}, Properties: core.KVMap{"name": "Snowy"}},
+			Properties:        core.KVMap{"since": int64(1929), "weight": weight},
+		}
+		suite.NoError(suite.connection.StoreEdge(ctx, &edge))
+	}
+	edges, err := suite.connection.QueryEdge(context.Background(), []string{"Person"}, []string{"Dog"}, "OWNS", nil, nil, nil, nil, nil, nil, nil, core.EdgeWithVertexIds)
+	suite.NoError(err)
+	// the change of the weight updates the existing edge
+	suite.Equal(1, len(edges))
+	suite.Equal(core.KVMap{"since": int64(1929), "weight": 0.8}, edges[0].Properties)
+}
+
+func (suite *Neo4JIntegrationTestSuite) TestDeleteVertex() {
+	edge := core.Edge{
+		Type:              "OWNS",
+		SourceVertex:      &core.Vertex{Labels: []string{"Person"}, Properties: core.KVMap{"name": "Tintin"}},
+		DestinationVertex: &core.Vertex{Labels: []string{"Dog"}, Properties: core.KVMap{"name": "Snowy"}},
+		Properties:        core.KVMap{},
+	}
+	suite.NoError(suite.connection.StoreEdge(context.Background(), &edge))
+	suite.NoError(suite.connection.StoreVertex(context.Background(), &core.Vertex{Labels: []string{"Person"}, Properties: core.KVMap{"name": "Haddock"}}))
+
+	_, err := suite.connection.DeleteVertex(context.Background(), "Person", nil)
+	suite.ErrorIs(err, core.ErrEmptySelector)
+
+	ctx, deletedIds := core.WithDeletedIds(context.Background())
+	deleted, err := suite.connection.DeleteVertex(ctx, "Person", core.KVMap{"name": "Tintin"})
+	suite.NoError(err)
+	suite.Equal(1, deleted)
+	suite.ElementsMatch([]*core.Identifier{edge.SourceVertex.ID, edge.ID}, deletedIds.IDs)
+	vertices, err := suite.connection.QueryVertex(context.Background(), "Person", nil, nil, nil)
+	suite.NoError(err)
+	suite.Equal(1, len(vertices))
+
+	deleted, err = suite.connection.DeleteVertex(core.WithAllowEmptySelector(context.Background()), "Person", nil)
+	suite.NoError(err)
+	suite.Equal(1, deleted)
+}
+
+func (suite *Neo4JIntegrationTestSuite) TestLabelCombinations() {
+	vertices := []*core.Vertex{
+		{Labels: []string{"Person"}, Properties: core.KVMap{"name": "Tintin"}},
+		{Labels: []string{"Person", "Employee"}, Properties: core.KVMap{"name": "Nestor"}},
+		{Labels: []string{"Employee", "Person"}, Properties: core.KVMap{"name": "Haddock"}},
+		{Labels: []string{"Dog"}, Properties: core.KVMap{"name": "Snowy"}},
+	}
+	for _, vertex := range vertices {
+		suite.NoError(suite.connection.StoreVertex(context.Background(), vertex))
+	}
+	combinations, err := suite.connection.LabelCombinations(context.Background())
+	suite.NoError(err)
+	suite.ElementsMatch([][]string{{"Person"}, {"Employee", "Person"}, {"Dog"}}, combinations)
+}
+
+func (suite *Neo4JIntegrationTestSuite) TestDeleteEdge() {
+	for _, dog := range []string{"Snowy", "Milou"} {
+		edge := core.Edge{
+			Type:              "OWNS",
+			SourceVertex:      &core.Vertex{Labels: []string{"Person"}, Properties: core.KVMap{"name": "Tintin"}},
+			DestinationVertex: &core.Vertex{Labels: []string{"Dog"}, Properties: core.KVMap{"name": dog}},
+			Properties:        core.KVMap{},
+		}
+		suite.NoError(suite.connection.StoreEdge(context.Background(), &edge))
+	}
+
+	ctx, deletedIds := core.WithDeletedIds(context.Background())
+	deleted, err := suite.connection.DeleteEdge(ctx, []string{"Person"}, []string{"Dog"}, "OWNS", nil, core.KVMap{"name": "Snowy"}, nil)
+	suite.NoError(err)
+	suite.Equal(1, deleted)
+	suite.Equal(1, len(deletedIds.IDs))
+
+	edges, err := suite.connection.QueryEdge(context.Background(), []string{"Person"}, []string{"Dog"}, "OWNS", nil, nil, nil, nil, nil, nil, nil, core.EdgeWithVertexIds)
+	suite.NoError(err)
+	suite.Equal(1, len(edges))
+	// the vertices of the deleted edge are retained
+	vertices, err := suite.connection.QueryVertex(context.Background(), "Dog", core.KVMap{"name": "Snowy"}, nil, nil)
+	suite.NoError(err)
+	suite.Equal(1, len(vertices))
+}
+
 func (suite *Neo4JIntegrationTestSuite) TestStoreVertex() {
 	vertex := core.Vertex{
 		Labels:     []string{"OMGStoreVertex"},
@@ -101,6 +908,31 @@ func (suite *Neo4JIntegrationTestSuite) TestStoreVertex() {
 	suite.Equal(storedVertex[0].ID, vertex.ID)
 }
 
+func (suite *Neo4JIntegrationTestSuite) TestAddAndRemoveLabels() {
+	vertex := core.Vertex{
+		Labels:     []string{"Person"},
+		Properties: core.KVMap{"Name": "Tom"},
+	}
+	err := suite.connection.StoreVertex(context.Background(), &vertex)
+	suite.NoError(err)
+
+	err = suite.connection.AddLabels(context.Background(), vertex.ID, []string{"Admin"})
+	suite.NoError(err)
+	admins, err := suite.connection.QueryVertex(context.Background(), "Admin", core.KVMap{"Name": "Tom"}, nil, nil)
+	suite.NoError(err)
+	suite.Equal(1, len(admins))
+	suite.ElementsMatch([]string{"Person", "Admin"}, admins[0].Labels)
+
+	err = suite.connection.RemoveLabels(context.Background(), vertex.ID, []string{"Admin"})
+	suite.NoError(err)
+	admins, err = suite.connection.QueryVertex(context.Background(), "Admin", core.KVMap{"Name": "Tom"}, nil, nil)
+	suite.NoError(err)
+	suite.Equal(0, len(admins))
+
+	err = suite.connection.AddLabels(context.Background(), core.NewId("unknown"), []string{"Admin"})
+	suite.Error(err)
+}
+
 func (suite *Neo4JIntegrationTestSuite) TestStoreEdge() {
 	cv := core.Vertex{
 		Labels:     []string{"Cartoon"},
@@ -141,6 +973,46 @@ func (suite *Neo4JIntegrationTestSuite) TestStoreEdge() {
 	suite.Equal(pv.ID, storedEdge[0].DestinationVertexID)
 }
 
+func (suite *Neo4JIntegrationTestSuite) TestStoreEdgeRequireExistingEndpoints() {
+	ctx := context.WithValue(context.Background(), core.ContextKeyRequireExistingEndpoints, true)
+	cv := core.Vertex{
+		Labels:     []string{"Cartoon"},
+		Properties: core.KVMap{"Name": "Tom and Jerry", "Genre": "Kids Cartoon series"},
+	}
+	err := suite.connection.StoreVertex(context.Background(), &cv)
+	suite.NoError(err)
+
+	pv := core.Vertex{
+		Labels:     []string{"Team"},
+		Properties: core.KVMap{"Name": "William Hanna and Joseph Barbara"},
+	}
+	rel := core.Edge{
+		Type:              "CREATED_BY",
+		Properties:        core.KVMap{"Year": 1940},
+		SourceVertex:      &cv,
+		DestinationVertex: &pv,
+	}
+
+	// the end vertex does not exist and must not be created
+	err = suite.connection.StoreEdge(ctx, &rel)
+	suite.ErrorIs(err, core.ErrEndpointNotFound)
+	storedVertex, err := suite.connection.QueryVertex(context.Background(), "Team", core.KVMap{"Name": "William Hanna and Joseph Barbara"}, nil, nil)
+	suite.NoError(err)
+	suite.Equal(0, len(storedVertex))
+
+	err = suite.connection.StoreVertex(context.Background(), &pv)
+	suite.NoError(err)
+	err = suite.connection.StoreEdge(ctx, &rel)
+	suite.NoError(err)
+
+	storedEdge, err := suite.connection.QueryEdge(context.Background(), []string{"Cartoon"}, []string{"Team"}, "CREATED_BY", nil, nil, core.KVMap{"Year": 1940}, nil, nil, nil, nil, core.EdgeWithVertexIds)
+	suite.NoError(err)
+	suite.Equal(1, len(storedEdge))
+	suite.Equal(storedEdge[0].ID, rel.ID)
+	suite.Equal(cv.ID, storedEdge[0].SourceVertexID)
+	suite.Equal(pv.ID, storedEdge[0].DestinationVertexID)
+}
+
 func (suite *Neo4JIntegrationTestSuite) TestStoreEdgeWithInvalidData() {
 
 	pv := core.Vertex{
@@ -171,6 +1043,33 @@ func (suite *Neo4JIntegrationTestSuite) TestStoreOmgStructAsVertex() {
 
 }
 
+func (suite *Neo4JIntegrationTestSuite) TestReadOrCreateOmgStructAsVertex() {
+	p := person{Name: "Tom", Age: 10}
+	created, isCreated, err := suite.store.ReadOrCreateVertex(context.TODO(), &p)
+	suite.NoError(err)
+	suite.True(isCreated)
+	suite.Equal(p, *(created.(*person)))
+
+	read, isCreated, err := suite.store.ReadOrCreateVertex(context.TODO(), &p)
+	suite.NoError(err)
+	suite.False(isCreated)
+	suite.Equal(p, *(read.(*person)))
+
+	p2, err := suite.store.ReadVertex(context.TODO(), &p)
+	suite.NoError(err)
+	suite.Equal(1, len(p2))
+}
+
+func (suite *Neo4JIntegrationTestSuite) TestStoreOmgStructWithLargeInteger() {
+	p := person{Name: "Snowflake", Age: 1541815603606036480}
+	err := suite.store.PersistVertex(context.TODO(), &p)
+	suite.NoError(err)
+	p2, err := suite.store.ReadVertex(context.TODO(), &person{Name: "Snowflake"})
+	suite.NoError(err)
+	suite.Equal(1, len(p2))
+	suite.Equal(p, *(p2[0].(*person)))
+}
+
 func (suite *Neo4JIntegrationTestSuite) TestStoreOmgStructsAsEdge() {
 	p := person{Name: "Tom", Age: 10}
 	c := city{Name: "Mumbai", PinCode: 400001}
@@ -188,6 +1087,142 @@ func (suite *Neo4JIntegrationTestSuite) TestStoreOmgStructsAsEdge() {
 	suite.Equal(vc, *vrs[0])
 }
 
+func (suite *Neo4JIntegrationTestSuite) TestReadVertexWithNeighbors() {
+	omg.RegisterGraphObject(&person{})
+	omg.RegisterGraphObject(&city{})
+	omg.RegisterGraphObject(&livesin{})
+
+	c := city{Name: "Mumbai", PinCode: 400001}
+	for _, p := range []person{{Name: "Tom", Age: 10}, {Name: "Jerry", Age: 8}} {
+		p := p
+		r := livesin{Area: "Town Hall", Since: 1982}
+		err := suite.store.PersistEdge(context.TODO(), &omg.VertexRelation{SourceVertex: &p, DestinationVertex: &c, Relationship: &r})
+		suite.NoError(err)
+	}
+	err := suite.store.PersistVertex(context.TODO(), &person{Name: "Spike", Age: 12})
+	suite.NoError(err)
+
+	relations, err := suite.store.ReadVertexWithNeighbors(context.TODO(), &city{Name: "Mumbai"}, []string{"LIVES_IN"})
+	suite.NoError(err)
+	suite.Equal(2, len(relations))
+	residents := make([]person, 0)
+	for _, relation := range relations {
+		suite.Equal(c, *(relation.DestinationVertex.(*city)))
+		suite.Equal(livesin{Area: "Town Hall", Since: 1982}, *(relation.Relationship.(*livesin)))
+		residents = append(residents, *(relation.SourceVertex.(*person)))
+	}
+	suite.ElementsMatch([]person{{Name: "Tom", Age: 10}, {Name: "Jerry", Age: 8}}, residents)
+
+	relations, err = suite.store.ReadVertexWithNeighbors(context.TODO(), &person{Name: "Spike"}, nil)
+	suite.NoError(err)
+	suite.Equal(1, len(relations))
+	suite.Equal(person{Name: "Spike", Age: 12}, *(relations[0].SourceVertex.(*person)))
+	suite.Nil(relations[0].Relationship)
+	suite.Nil(relations[0].DestinationVertex)
+}
+
+func (suite *Neo4JIntegrationTestSuite) TestSnapshotReads() {
+	ctx := context.Background()
+	err := suite.connection.StoreVertex(ctx, &core.Vertex{Labels: []string{"Reading"}, Properties: core.KVMap{"sensor": "s1", "value": 10}})
+	suite.NoError(err)
+
+	snapshot, err := suite.connection.BeginSnapshot(ctx)
+	suite.NoError(err)
+	defer snapshot.Close(ctx)
+	for i := 0; i < 2; i++ {
+		readings, err := snapshot.QueryVertex(ctx, "Reading", core.KVMap{"sensor": "s1"}, nil, nil)
+		suite.NoError(err)
+		suite.Equal(1, len(readings))
+	}
+	// the snapshot is read-only
+	err = snapshot.StoreVertex(ctx, &core.Vertex{Labels: []string{"Reading"}, Properties: core.KVMap{"sensor": "s1", "value": 20}})
+	suite.Error(err)
+}
+
+func (suite *Neo4JIntegrationTestSuite) TestReadOmgStructsAcrossMultipleHops() {
+	grandParent := person{Name: "Alice", Age: 70}
+	parent := person{Name: "Bob", Age: 45}
+	child := person{Name: "Carol", Age: 20}
+	for _, vc := range []omg.VertexRelation{
+		{SourceVertex: &grandParent, Relationship: &parentof{}, DestinationVertex: &parent},
+		{SourceVertex: &parent, Relationship: &parentof{}, DestinationVertex: &child},
+	} {
+		vc := vc
+		err := suite.store.PersistEdge(context.TODO(), &vc)
+		suite.NoError(err)
+	}
+
+	// all the ancestors of the child
+	vrs, err := suite.store.ReadEdge(context.TODO(), &omg.VertexRelation{SourceVertex: &person{}, Relationship: &parentof{}, DestinationVertex: &child, MinHops: 1})
+	suite.NoError(err)
+	ancestors := make([]person, 0)
+	for _, vr := range vrs {
+		suite.Equal(child, *(vr.DestinationVertex.(*person)))
+		suite.Nil(vr.Relationship)
+		ancestors = append(ancestors, *(vr.SourceVertex.(*person)))
+	}
+	suite.ElementsMatch([]person{grandParent, parent}, ancestors)
+
+	// only the grand parents of the child
+	vrs, err = suite.store.ReadEdge(context.TODO(), &omg.VertexRelation{SourceVertex: &person{}, Relationship: &parentof{}, DestinationVertex: &child, MinHops: 2, MaxHops: 2})
+	suite.NoError(err)
+	suite.Equal(1, len(vrs))
+	suite.Equal(grandParent, *(vrs[0].SourceVertex.(*person)))
+}
+
+func (suite *Neo4JIntegrationTestSuite) TestStoreOmgStructsInTransaction() {
+	p := person{Name: "Tom", Age: 10}
+	c := city{Name: "Mumbai", PinCode: 400001}
+	r := livesin{Area: "Town Hall", Since: 1982}
+	vc := omg.VertexRelation{SourceVertex: &p, DestinationVertex: &c, Relationship: &r}
+
+	err := suite.store.RunInTransaction(context.TODO(), func(txStore omg.Store) error {
+		err := txStore.PersistVertex(context.TODO(), &p)
+		if err != nil {
+			return err
+		}
+		return txStore.PersistEdge(context.TODO(), &vc)
+	})
+	suite.NoError(err)
+
+	vrs, err := suite.store.ReadEdge(context.TODO(), &vc)
+	suite.NoError(err)
+	suite.Equal(1, len(vrs))
+	suite.Equal(vc, *vrs[0])
+}
+
+func (suite *Neo4JIntegrationTestSuite) TestStoreOmgStructsInTransactionRollback() {
+	p := person{Name: "Jerry", Age: 8}
+	forcedErr := errors.New("forced error")
+
+	err := suite.store.RunInTransaction(context.TODO(), func(txStore omg.Store) error {
+		err := txStore.PersistVertex(context.TODO(), &p)
+		if err != nil {
+			return err
+		}
+		return forcedErr
+	})
+	suite.ErrorIs(err, forcedErr)
+
+	p2, err := suite.store.ReadVertex(context.TODO(), &person{Name: "Jerry", Age: 8})
+	suite.NoError(err)
+	suite.Equal(0, len(p2))
+}
+
+func (suite *Neo4JIntegrationTestSuite) TestStoreOmgStructsAsEdgeOnlySrcVertex() {
+	p := person{Name: "Isolated", Age: 40}
+	err := suite.store.PersistEdge(context.TODO(), &omg.VertexRelation{SourceVertex: &p})
+	suite.NoError(err)
+
+	p2, err := suite.store.ReadVertex(context.TODO(), &person{Name: "Isolated"})
+	suite.NoError(err)
+	suite.Equal(1, len(p2))
+	suite.Equal(p, *(p2[0].(*person)))
+
+	err = suite.store.PersistEdge(context.TODO(), &omg.VertexRelation{SourceVertex: &p, Relationship: &livesin{Since: 1982}})
+	suite.Error(err)
+}
+
 func (suite *Neo4JIntegrationTestSuite) TestStoreOmgStructsAsEdgeNoSrcVertex() {
 	p := person{Name: "Tom", Age: 10}
 	c := city{Name: "Mumbai", PinCode: 400001}
@@ -242,6 +1277,99 @@ func (suite *Neo4JIntegrationTestSuite) TestCustomDatabaseWithEmptyName() {
 	suite.NotNil(res)
 }
 
+func (suite *Neo4JIntegrationTestSuite) TestQueryEdgeWithCompleteVertexPopulatesVertexIds() {
+	query := "create (p:Person{name:'Tintin'})-[r:LIVES_IN{since:1929}]->(c:Country{name:'Belgium'}) return p, r, c"
+	_, err := suite.connection.ExecuteQuery(context.Background(), query, core.Write, nil)
+	suite.NoError(err)
+
+	edges, err := suite.connection.QueryEdge(context.Background(), []string{"Person"}, []string{"Country"}, "LIVES_IN",
+		core.KVMap{"name": "Tintin"}, core.KVMap{"name": "Belgium"}, nil, nil, nil, nil, nil, core.EdgeWithCompleteVertex)
+	suite.NoError(err)
+	suite.Equal(1, len(edges))
+	edge := edges[0]
+	suite.NotNil(edge.SourceVertex)
+	suite.NotNil(edge.DestinationVertex)
+	suite.NotNil(edge.SourceVertexID)
+	suite.NotNil(edge.DestinationVertexID)
+	suite.Equal(edge.SourceVertexID, edge.SourceVertex.ID)
+	suite.Equal(edge.DestinationVertexID, edge.DestinationVertex.ID)
+	suite.Equal("Tintin", edge.SourceVertex.Properties["name"])
+	suite.Equal("Belgium", edge.DestinationVertex.Properties["name"])
+}
+
+func (suite *Neo4JIntegrationTestSuite) TestImportInBatches() {
+	connection, ok := suite.connection.(*neo.Neo4jConnection)
+	suite.True(ok)
+	rows := make([]core.KVMap, 0)
+	for i := 0; i < 20000; i++ {
+		rows = append(rows, core.KVMap{"name": "Person" + strconv.Itoa(i), "age": i % 100})
+	}
+	err := connection.Import(context.Background(), "CREATE (:Person{name: row.name, age: row.age})", rows, 500)
+	suite.NoError(err)
+
+	result, err := suite.connection.ExecuteQuery(context.Background(), "MATCH (p:Person) RETURN count(p) AS total", core.Read, nil)
+	suite.NoError(err)
+	suite.Equal(int64(20000), result.Rows[0]["total"])
+}
+
+func (suite *Neo4JIntegrationTestSuite) TestQueryNotifications() {
+	query := "MATCH (p:Person), (c:City) RETURN p, c"
+	result, err := suite.connection.ExecuteQuery(context.Background(), query, core.Read, nil)
+	suite.NoError(err)
+	found := false
+	for _, notification := range result.Notifications {
+		if strings.Contains(notification, "CartesianProduct") {
+			found = true
+		}
+	}
+	suite.Truef(found, "cartesian product notification not found in %v", result.Notifications)
+}
+
+func (suite *Neo4JIntegrationTestSuite) TestQuerySummary() {
+	result, err := suite.connection.ExecuteQuery(context.Background(), "CREATE (p:Person{name:'Tintin', age: 17}) RETURN p", core.Write, nil)
+	suite.NoError(err)
+	suite.NotNil(result.Summary)
+	suite.Equal(core.QueryTypeReadWrite, result.Summary.QueryType)
+	suite.Equal(1, result.Summary.NodesCreated)
+	suite.Equal(2, result.Summary.PropertiesSet)
+	suite.Equal(1, result.Summary.LabelsAdded)
+	suite.GreaterOrEqual(result.Summary.ResultAvailableAfter, time.Duration(0))
+	suite.GreaterOrEqual(result.Summary.ResultConsumedAfter, time.Duration(0))
+
+	result, err = suite.connection.ExecuteQuery(context.Background(), "MATCH (p:Person{name:'Tintin'}) DETACH DELETE p", core.Write, nil)
+	suite.NoError(err)
+	suite.Equal(core.QueryTypeWriteOnly, result.Summary.QueryType)
+	suite.Equal(1, result.Summary.NodesDeleted)
+}
+
+func (suite *Neo4JIntegrationTestSuite) TestQueryVertexWithinDistance() {
+	brussels := core.NewGeographicPoint(50.8503, 4.3517)
+	cities := map[string]core.Point{
+		"Brussels": brussels,
+		"Antwerp":  core.NewGeographicPoint(51.2194, 4.4025),
+		"Paris":    core.NewGeographicPoint(48.8566, 2.3522),
+	}
+	for name, location := range cities {
+		vertex := &core.Vertex{Labels: []string{"City"}, Properties: core.KVMap{"name": name, "location": location}}
+		suite.NoError(suite.connection.StoreVertex(context.Background(), vertex))
+	}
+
+	filters := core.KVMap{"location": core.WithinDistance(brussels, 50000)}
+	vertices, err := suite.connection.QueryVertex(context.Background(), "City", nil, filters, nil)
+	suite.NoError(err)
+	names := make([]string, 0, len(vertices))
+	for _, vertex := range vertices {
+		names = append(names, vertex.Properties["name"].(string))
+	}
+	suite.ElementsMatch([]string{"Brussels", "Antwerp"}, names)
+
+	// point properties are read back as core.Point values and can be used as selectors
+	vertices, err = suite.connection.QueryVertex(context.Background(), "City", core.KVMap{"location": brussels}, nil, nil)
+	suite.NoError(err)
+	suite.Equal(1, len(vertices))
+	suite.Equal(brussels, vertices[0].Properties["location"])
+}
+
 func (suite *Neo4JIntegrationTestSuite) TearDownTest() {
 	suite.cleanupDB()
 }
@@ -256,6 +1384,11 @@ func TestNeo4JIntegrationTestSuite(t *testing.T) {
 	suite.Run(t, new(Neo4JIntegrationTestSuite))
 }
 
+type address struct {
+	City string `ogm:"city"`
+	Zip  int64  `ogm:"pinCode,alt=pincode|pin_code"`
+}
+
 type person struct {
 	Name string
 	Age  int64
@@ -298,3 +1431,62 @@ func (lv *livesin) GetLabel() string {
 func (lv *livesin) GetType() omg.GraphObjectType {
 	return omg.Edge
 }
+
+type parentof struct {
+}
+
+// GetLabel returns the label associated with the graph object
+func (po *parentof) GetLabel() string {
+	return "PARENT_OF"
+}
+
+// GraphType() returns the type associated with the Graph object
+func (po *parentof) GetType() omg.GraphObjectType {
+	return omg.Edge
+}
+
+type purchaseOrder struct {
+	Number    string     `ogm:"number"`
+	LineItems []lineItem `ogm:",edge:CONTAINS"`
+}
+
+// GetLabel returns the label associated with the graph object
+func (po *purchaseOrder) GetLabel() string {
+	return "Order"
+}
+
+// GetType returns the type of the graph object
+func (po *purchaseOrder) GetType() omg.GraphObjectType {
+	return omg.Vertex
+}
+
+type lineItem struct {
+	Product  string `ogm:"product"`
+	Quantity int64  `ogm:"quantity"`
+}
+
+// GetLabel returns the label associated with the graph object
+func (li *lineItem) GetLabel() string {
+	return "LineItem"
+}
+
+// GetType returns the type of the graph object
+func (li *lineItem) GetType() omg.GraphObjectType {
+	return omg.Vertex
+}
+
+type book struct {
+	Title    string   `ogm:"title"`
+	Chapters []int64  `ogm:"chapters"`
+	Tags     []string `ogm:"tags"`
+}
+
+// GetLabel returns the label associated with the graph object
+func (b *book) GetLabel() string {
+	return "Book"
+}
+
+// GetType returns the type of the graph object
+func (b *book) GetType() omg.GraphObjectType {
+	return omg.Vertex
+}
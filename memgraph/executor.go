@@ -1,10 +1,192 @@
 package memgraph
 
 import (
+	"context"
+	"errors"
+	"fmt"
+
 	"github.com/prahaladd/gograph/core"
 	"github.com/prahaladd/gograph/neo"
 )
 
+const (
+	// MEMGRAPH_REPLICA_HOST_KEY is the connection option key specifying the host of a replica instance serving the
+	// read queries of the connection
+	MEMGRAPH_REPLICA_HOST_KEY = "replicaHost"
+	// MEMGRAPH_REPLICA_PORT_KEY is the connection option key specifying the Bolt port of the replica instance as an
+	// int32. The port of the main instance is used if not specified.
+	MEMGRAPH_REPLICA_PORT_KEY = "replicaPort"
+)
+
+type memgraphContextKey string
+
+const (
+	// ContextKeyPreferReplica is used in context to specify that read queries should be served by the replica
+	// instance of the connection. The value must be a bool.
+	ContextKeyPreferReplica = memgraphContextKey("preferReplica")
+)
+
+// WithReplicaReads returns a copy of the specified context preferring the replica instance for read queries
+func WithReplicaReads(ctx context.Context) context.Context {
+	return context.WithValue(ctx, ContextKeyPreferReplica, true)
+}
+
+// newNeoConnection creates the connections to the Memgraph instances. It is replaced when unit testing the routing
+// of the queries.
+var newNeoConnection = neo.NewConnection
+
 func init() {
-	core.RegisterConnectorFactory("memgraph", neo.NewConnection)
+	core.RegisterConnectorFactory("memgraph", NewConnection)
+}
+
+// MemgraphConnection is a connection to a replicated Memgraph deployment.
+//
+// Memgraph replicates the data written to the MAIN instance to one or more REPLICA instances, each of which serves
+// reads through its own Bolt endpoint. The Bolt driver does not route queries between the instances, hence the
+// connection holds a separate connection to the main instance and to a replica instance. Writes are always executed
+// against the main instance, while read queries are executed against the replica if preferred within the context
+// using WithReplicaReads.
+//
+// Replicas may lag behind the main instance, hence reads requiring core.ConsistencyStrong are always executed against
+// the main instance. Transactions are started on the main instance, except for snapshots preferring the replica.
+type MemgraphConnection struct {
+	core.Connection
+	replica core.Connection
+}
+
+// reader returns the connection against which the read operations using the specified context are executed
+func (mc *MemgraphConnection) reader(ctx context.Context) core.Connection {
+	if preferReplica, ok := ctx.Value(ContextKeyPreferReplica).(bool); !ok || !preferReplica {
+		return mc.Connection
+	}
+	if core.ConsistencyFromContext(ctx) == core.ConsistencyStrong {
+		return mc.Connection
+	}
+	return mc.replica
+}
+
+// executor returns the connection against which a query of the specified mode is executed
+func (mc *MemgraphConnection) executor(ctx context.Context, mode core.QueryMode) core.Connection {
+	if mode != core.Read {
+		return mc.Connection
+	}
+	return mc.reader(ctx)
+}
+
+func (mc *MemgraphConnection) QueryVertex(ctx context.Context, label string, selectors, filters, queryParams core.KVMap) ([]*core.Vertex, error) {
+	return mc.reader(ctx).QueryVertex(ctx, label, selectors, filters, queryParams)
+}
+
+func (mc *MemgraphConnection) QueryVertexFunc(ctx context.Context, label string, selectors, filters core.KVMap, fn func(*core.Vertex) error) error {
+	return mc.reader(ctx).QueryVertexFunc(ctx, label, selectors, filters, fn)
+}
+
+func (mc *MemgraphConnection) QueryEdge(ctx context.Context, startVertexLabel, endVertexLabel []string, label string, startVertexSelectors, endVertexSelectors, selectors core.KVMap, startVertexFilters, endVertexFilters, filters, queryParams core.KVMap, fetchMode core.EdgeFetchMode) ([]*core.Edge, error) {
+	return mc.reader(ctx).QueryEdge(ctx, startVertexLabel, endVertexLabel, label, startVertexSelectors, endVertexSelectors, selectors, startVertexFilters, endVertexFilters, filters, queryParams, fetchMode)
+}
+
+func (mc *MemgraphConnection) QueryConnectedVertices(ctx context.Context, startVertexLabel, endVertexLabel []string, label string, startVertexSelectors, endVertexSelectors, selectors core.KVMap, startVertexFilters, endVertexFilters, filters core.KVMap, queryParams core.KVMap) ([]*core.Vertex, error) {
+	return mc.reader(ctx).QueryConnectedVertices(ctx, startVertexLabel, endVertexLabel, label, startVertexSelectors, endVertexSelectors, selectors, startVertexFilters, endVertexFilters, filters, queryParams)
+}
+
+func (mc *MemgraphConnection) QueryPaths(ctx context.Context, startVertexLabel, endVertexLabel []string, label string, startVertexSelectors, endVertexSelectors, selectors core.KVMap, minHops, maxHops int) ([]*core.Path, error) {
+	return mc.reader(ctx).QueryPaths(ctx, startVertexLabel, endVertexLabel, label, startVertexSelectors, endVertexSelectors, selectors, minHops, maxHops)
+}
+
+func (mc *MemgraphConnection) QuerySubgraph(ctx context.Context, query string, queryParams core.KVMap) (*core.Subgraph, error) {
+	return mc.reader(ctx).QuerySubgraph(ctx, query, queryParams)
+}
+
+func (mc *MemgraphConnection) ExecuteQuery(ctx context.Context, query string, mode core.QueryMode, queryParams map[string]interface{}) (*core.QueryResult, error) {
+	return mc.executor(ctx, mode).ExecuteQuery(ctx, query, mode, queryParams)
+}
+
+func (mc *MemgraphConnection) ExecuteQueryTyped(ctx context.Context, query string, mode core.QueryMode, queryParams map[string]interface{}, dest ...interface{}) error {
+	return mc.executor(ctx, mode).ExecuteQueryTyped(ctx, query, mode, queryParams, dest...)
+}
+
+func (mc *MemgraphConnection) ExecuteQueryNormalized(ctx context.Context, query string, mode core.QueryMode, queryParams map[string]interface{}) (*core.QueryResult, error) {
+	return mc.executor(ctx, mode).ExecuteQueryNormalized(ctx, query, mode, queryParams)
+}
+
+func (mc *MemgraphConnection) QueryScalarInt(ctx context.Context, query string, queryParams map[string]interface{}) (int64, error) {
+	return mc.reader(ctx).QueryScalarInt(ctx, query, queryParams)
+}
+
+func (mc *MemgraphConnection) QueryScalarFloat(ctx context.Context, query string, queryParams map[string]interface{}) (float64, error) {
+	return mc.reader(ctx).QueryScalarFloat(ctx, query, queryParams)
+}
+
+func (mc *MemgraphConnection) BeginSnapshot(ctx context.Context) (core.Tx, error) {
+	return mc.reader(ctx).BeginSnapshot(ctx)
+}
+
+func (mc *MemgraphConnection) RelationshipTypesBetween(ctx context.Context, startLabels, endLabels []string) ([]string, error) {
+	return mc.reader(ctx).RelationshipTypesBetween(ctx, startLabels, endLabels)
+}
+
+func (mc *MemgraphConnection) LabelCombinations(ctx context.Context) ([][]string, error) {
+	return mc.reader(ctx).LabelCombinations(ctx)
+}
+
+func (mc *MemgraphConnection) Degree(ctx context.Context, id *core.Identifier, direction core.EdgeDirection, edgeLabels []string) (int64, error) {
+	return mc.reader(ctx).Degree(ctx, id, direction, edgeLabels)
+}
+
+func (mc *MemgraphConnection) ExpandNeighbors(ctx context.Context, ids []*core.Identifier, edgeLabels []string, direction core.EdgeDirection) (map[string][]*core.Edge, error) {
+	return mc.reader(ctx).ExpandNeighbors(ctx, ids, edgeLabels, direction)
+}
+
+// Ping verifies that both the main and the replica instances can be reached
+func (mc *MemgraphConnection) Ping(ctx context.Context) error {
+	if err := mc.Connection.Ping(ctx); err != nil {
+		return err
+	}
+	return mc.replica.Ping(ctx)
+}
+
+// Close closes the connections to both the main and the replica instances, returning the first error encountered
+func (mc *MemgraphConnection) Close(ctx context.Context) error {
+	err := mc.Connection.Close(ctx)
+	if replicaErr := mc.replica.Close(ctx); err == nil {
+		err = replicaErr
+	}
+	return err
+}
+
+// NewConnection returns a new connection to the specified Memgraph instance. Memgraph requires the same parameters as
+// Neo4j, see neo.NewConnection, with the exception that the protocol is bolt instead of neo4j(s).
+//
+// A replica instance serving read queries can be specified using the MEMGRAPH_REPLICA_HOST_KEY and
+// MEMGRAPH_REPLICA_PORT_KEY keys within the options map, in which case a MemgraphConnection is returned. The replica
+// is connected to using the same protocol, realm, auth and options as the main instance.
+func NewConnection(protocol, host, realm string, port *int32, auth, options map[string]interface{}) (core.Connection, error) {
+	main, err := newNeoConnection(protocol, host, realm, port, auth, options)
+	if err != nil {
+		return nil, err
+	}
+	replicaHostOption, ok := options[MEMGRAPH_REPLICA_HOST_KEY]
+	if !ok {
+		return main, nil
+	}
+	replicaHost, ok := replicaHostOption.(string)
+	if !ok || replicaHost == "" {
+		main.Close(context.Background())
+		return nil, errors.New("value of the MEMGRAPH_REPLICA_HOST_KEY option must be a non empty string")
+	}
+	replicaPort := port
+	if replicaPortOption, ok := options[MEMGRAPH_REPLICA_PORT_KEY]; ok {
+		p, ok := replicaPortOption.(int32)
+		if !ok {
+			main.Close(context.Background())
+			return nil, errors.New("value of the MEMGRAPH_REPLICA_PORT_KEY option must be an int32")
+		}
+		replicaPort = &p
+	}
+	replica, err := newNeoConnection(protocol, replicaHost, realm, replicaPort, auth, options)
+	if err != nil {
+		main.Close(context.Background())
+		return nil, fmt.Errorf("failed to connect to the replica: %w", err)
+	}
+	return &MemgraphConnection{Connection: main, replica: replica}, nil
 }
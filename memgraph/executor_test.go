@@ -0,0 +1,142 @@
+package memgraph
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/prahaladd/gograph/core"
+	"github.com/stretchr/testify/suite"
+)
+
+// fakeConnection records the queries executed against the Memgraph instance running on host
+type fakeConnection struct {
+	core.Connection
+	host    string
+	queries []string
+	closed  bool
+}
+
+func (fc *fakeConnection) ExecuteQuery(ctx context.Context, query string, mode core.QueryMode, queryParams map[string]interface{}) (*core.QueryResult, error) {
+	fc.queries = append(fc.queries, query)
+	return &core.QueryResult{}, nil
+}
+
+func (fc *fakeConnection) QueryScalarInt(ctx context.Context, query string, queryParams map[string]interface{}) (int64, error) {
+	fc.queries = append(fc.queries, query)
+	return 0, nil
+}
+
+func (fc *fakeConnection) Close(ctx context.Context) error {
+	fc.closed = true
+	return nil
+}
+
+type MemgraphConnectionTestSuite struct {
+	suite.Suite
+	connections map[string]*fakeConnection
+	ports       map[string]int32
+	restore     func()
+}
+
+func (suite *MemgraphConnectionTestSuite) SetupTest() {
+	suite.connections = make(map[string]*fakeConnection)
+	suite.ports = make(map[string]int32)
+	original := newNeoConnection
+	suite.restore = func() { newNeoConnection = original }
+	newNeoConnection = func(protocol, host, realm string, port *int32, auth, options map[string]interface{}) (core.Connection, error) {
+		conn := &fakeConnection{host: host}
+		suite.connections[host] = conn
+		suite.ports[host] = *port
+		return conn, nil
+	}
+}
+
+func (suite *MemgraphConnectionTestSuite) TearDownTest() {
+	suite.restore()
+}
+
+func (suite *MemgraphConnectionTestSuite) connect(options map[string]interface{}) core.Connection {
+	port := int32(7687)
+	conn, err := NewConnection("bolt", "main", "", &port, nil, options)
+	suite.NoError(err)
+	return conn
+}
+
+func (suite *MemgraphConnectionTestSuite) TestNoReplica() {
+	conn := suite.connect(nil)
+	suite.Same(suite.connections["main"], conn)
+	suite.Len(suite.connections, 1)
+}
+
+func (suite *MemgraphConnectionTestSuite) TestReplicaPort() {
+	suite.connect(map[string]interface{}{MEMGRAPH_REPLICA_HOST_KEY: "replica", MEMGRAPH_REPLICA_PORT_KEY: int32(7688)})
+	suite.Equal(int32(7687), suite.ports["main"])
+	suite.Equal(int32(7688), suite.ports["replica"])
+}
+
+func (suite *MemgraphConnectionTestSuite) TestInvalidReplicaOptions() {
+	port := int32(7687)
+	_, err := NewConnection("bolt", "main", "", &port, nil, map[string]interface{}{MEMGRAPH_REPLICA_HOST_KEY: 1})
+	suite.Error(err)
+	suite.True(suite.connections["main"].closed)
+	_, err = NewConnection("bolt", "main", "", &port, nil, map[string]interface{}{MEMGRAPH_REPLICA_HOST_KEY: "replica", MEMGRAPH_REPLICA_PORT_KEY: 7688})
+	suite.Error(err)
+}
+
+func (suite *MemgraphConnectionTestSuite) TestReplicaConnectionFailure() {
+	replicaErr := errors.New("connection refused")
+	newNeoConnection = func(protocol, host, realm string, port *int32, auth, options map[string]interface{}) (core.Connection, error) {
+		if host == "replica" {
+			return nil, replicaErr
+		}
+		conn := &fakeConnection{host: host}
+		suite.connections[host] = conn
+		return conn, nil
+	}
+	port := int32(7687)
+	_, err := NewConnection("bolt", "main", "", &port, nil, map[string]interface{}{MEMGRAPH_REPLICA_HOST_KEY: "replica"})
+	suite.ErrorIs(err, replicaErr)
+	suite.True(suite.connections["main"].closed)
+}
+
+func (suite *MemgraphConnectionTestSuite) TestReadQueriesTargetReplica() {
+	conn := suite.connect(map[string]interface{}{MEMGRAPH_REPLICA_HOST_KEY: "replica"})
+	ctx := WithReplicaReads(context.Background())
+	_, err := conn.ExecuteQuery(ctx, "MATCH (v) RETURN v", core.Read, nil)
+	suite.NoError(err)
+	_, err = conn.QueryScalarInt(ctx, "MATCH (v) RETURN count(v)", nil)
+	suite.NoError(err)
+	suite.Equal([]string{"MATCH (v) RETURN v", "MATCH (v) RETURN count(v)"}, suite.connections["replica"].queries)
+	suite.Empty(suite.connections["main"].queries)
+}
+
+func (suite *MemgraphConnectionTestSuite) TestWriteQueriesTargetMain() {
+	conn := suite.connect(map[string]interface{}{MEMGRAPH_REPLICA_HOST_KEY: "replica"})
+	_, err := conn.ExecuteQuery(WithReplicaReads(context.Background()), "CREATE (v:Person) RETURN v", core.Write, nil)
+	suite.NoError(err)
+	suite.Equal([]string{"CREATE (v:Person) RETURN v"}, suite.connections["main"].queries)
+	suite.Empty(suite.connections["replica"].queries)
+}
+
+func (suite *MemgraphConnectionTestSuite) TestReadQueriesTargetMainByDefault() {
+	conn := suite.connect(map[string]interface{}{MEMGRAPH_REPLICA_HOST_KEY: "replica"})
+	_, err := conn.ExecuteQuery(context.Background(), "MATCH (v) RETURN v", core.Read, nil)
+	suite.NoError(err)
+	strongCtx := core.WithConsistency(WithReplicaReads(context.Background()), core.ConsistencyStrong)
+	_, err = conn.ExecuteQuery(strongCtx, "MATCH (v) RETURN v", core.Read, nil)
+	suite.NoError(err)
+	suite.Len(suite.connections["main"].queries, 2)
+	suite.Empty(suite.connections["replica"].queries)
+}
+
+func (suite *MemgraphConnectionTestSuite) TestCloseClosesReplica() {
+	conn := suite.connect(map[string]interface{}{MEMGRAPH_REPLICA_HOST_KEY: "replica"})
+	suite.NoError(conn.Close(context.Background()))
+	suite.True(suite.connections["main"].closed)
+	suite.True(suite.connections["replica"].closed)
+}
+
+func TestMemgraphConnectionTestSuite(t *testing.T) {
+	suite.Run(t, new(MemgraphConnectionTestSuite))
+}
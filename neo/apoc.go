@@ -0,0 +1,56 @@
+package neo
+
+import (
+	"fmt"
+
+	"github.com/prahaladd/gograph/core"
+)
+
+// names of the query parameters carrying the labels and the properties of vertices in APOC backed queries
+const (
+	apocLabelsParam     = "__labels"
+	apocPropertiesParam = "__properties"
+	apocDataParam       = "__data"
+)
+
+// apocMergeNodeQuery returns a query merging a vertex using apoc.merge.node. The labels and the properties of the
+// vertex are passed as query parameters and hence need not be included within the query text. The merged vertex is
+// bound to the variable sv.
+//
+// If a created timestamp property is specified, the property is set to the server timestamp when the vertex is
+// created. If data properties are set, the data properties passed as a query parameter are set on the vertex only if
+// any of them differs from the properties of the vertex.
+func apocMergeNodeQuery(createdTimestamp string, setData bool) string {
+	onCreateProperties := "{}"
+	if createdTimestamp != "" {
+		onCreateProperties = fmt.Sprintf("{`%s`: timestamp()}", createdTimestamp)
+	}
+	setDataProperties := ""
+	if setData {
+		setDataProperties = fmt.Sprintf(" FOREACH (_ IN CASE WHEN any(k IN keys($%s) WHERE NOT coalesce(node[k] = $%s[k], false)) THEN [1] ELSE [] END | SET node += $%s)", apocDataParam, apocDataParam, apocDataParam)
+	}
+	return fmt.Sprintf("CALL apoc.merge.node($%s, $%s, %s, {}) YIELD node%s RETURN node AS sv", apocLabelsParam, apocPropertiesParam, onCreateProperties, setDataProperties)
+}
+
+// apocCreateNodeQuery returns a query creating a vertex using apoc.create.node. The labels and the properties of the
+// vertex are passed as query parameters using the same parameters as apocMergeNodeQuery. The created vertex is bound
+// to the variable sv.
+//
+// If a created timestamp property is specified, the property is set to the server timestamp.
+func apocCreateNodeQuery(createdTimestamp string) string {
+	setCreatedTimestamp := ""
+	if createdTimestamp != "" {
+		setCreatedTimestamp = fmt.Sprintf(" SET node.`%s` = timestamp()", createdTimestamp)
+	}
+	return fmt.Sprintf("CALL apoc.create.node($%s, $%s) YIELD node%s RETURN node AS sv", apocLabelsParam, apocPropertiesParam, setCreatedTimestamp)
+}
+
+// apocMergeNodeParams returns the query parameters for the queries returned by apocMergeNodeQuery and
+// apocCreateNodeQuery. The data properties are only passed if specified.
+func apocMergeNodeParams(labels []string, properties, dataProperties core.KVMap) core.KVMap {
+	params := core.KVMap{apocLabelsParam: labels, apocPropertiesParam: map[string]any(properties)}
+	if len(dataProperties) > 0 {
+		params[apocDataParam] = map[string]any(dataProperties)
+	}
+	return params
+}
@@ -0,0 +1,32 @@
+package neo
+
+import (
+	"testing"
+
+	"github.com/prahaladd/gograph/core"
+	"github.com/stretchr/testify/suite"
+)
+
+type ApocTestSuite struct {
+	suite.Suite
+}
+
+func (suite *ApocTestSuite) TestMergeNodeQuery() {
+	suite.Equal("CALL apoc.merge.node($__labels, $__properties, {}, {}) YIELD node RETURN node AS sv", apocMergeNodeQuery("", false))
+	suite.Equal("CALL apoc.merge.node($__labels, $__properties, {`createdAt`: timestamp()}, {}) YIELD node RETURN node AS sv", apocMergeNodeQuery("createdAt", false))
+	suite.Equal("CALL apoc.merge.node($__labels, $__properties, {}, {}) YIELD node FOREACH (_ IN CASE WHEN any(k IN keys($__data) WHERE NOT coalesce(node[k] = $__data[k], false)) THEN [1] ELSE [] END | SET node += $__data) RETURN node AS sv", apocMergeNodeQuery("", true))
+}
+
+func (suite *ApocTestSuite) TestCreateNodeQuery() {
+	suite.Equal("CALL apoc.create.node($__labels, $__properties) YIELD node RETURN node AS sv", apocCreateNodeQuery(""))
+	suite.Equal("CALL apoc.create.node($__labels, $__properties) YIELD node SET node.`createdAt` = timestamp() RETURN node AS sv", apocCreateNodeQuery("createdAt"))
+}
+
+func (suite *ApocTestSuite) TestMergeNodeParams() {
+	suite.Equal(core.KVMap{"__labels": []string{"Person"}, "__properties": map[string]any{"name": "Tintin"}}, apocMergeNodeParams([]string{"Person"}, core.KVMap{"name": "Tintin"}, nil))
+	suite.Equal(core.KVMap{"__labels": []string{"Person"}, "__properties": map[string]any{"name": "Tintin"}, "__data": map[string]any{"age": 17}}, apocMergeNodeParams([]string{"Person"}, core.KVMap{"name": "Tintin"}, core.KVMap{"age": 17}))
+}
+
+func TestApocTestSuite(t *testing.T) {
+	suite.Run(t, new(ApocTestSuite))
+}
@@ -0,0 +1,34 @@
+package neo
+
+import (
+	"context"
+
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+)
+
+// neo4jDriver is the subset of neo4j.DriverWithContext used by the connection. It allows the driver to be
+// substituted when unit testing the connection.
+type neo4jDriver interface {
+	NewSession(ctx context.Context, config neo4j.SessionConfig) neo4jSession
+	GetServerInfo(ctx context.Context) (neo4j.ServerInfo, error)
+	VerifyConnectivity(ctx context.Context) error
+	Close(ctx context.Context) error
+}
+
+// neo4jSession is the subset of neo4j.SessionWithContext used by the connection.
+type neo4jSession interface {
+	BeginTransaction(ctx context.Context, configurers ...func(*neo4j.TransactionConfig)) (neo4j.ExplicitTransaction, error)
+	ExecuteRead(ctx context.Context, work neo4j.ManagedTransactionWork, configurers ...func(*neo4j.TransactionConfig)) (any, error)
+	ExecuteWrite(ctx context.Context, work neo4j.ManagedTransactionWork, configurers ...func(*neo4j.TransactionConfig)) (any, error)
+	Run(ctx context.Context, cypher string, params map[string]any, configurers ...func(*neo4j.TransactionConfig)) (neo4j.ResultWithContext, error)
+	Close(ctx context.Context) error
+}
+
+// driverWithContext adapts a neo4j.DriverWithContext to the neo4jDriver interface
+type driverWithContext struct {
+	neo4j.DriverWithContext
+}
+
+func (d driverWithContext) NewSession(ctx context.Context, config neo4j.SessionConfig) neo4jSession {
+	return d.DriverWithContext.NewSession(ctx, config)
+}
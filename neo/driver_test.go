@@ -0,0 +1,231 @@
+package neo
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+)
+
+// mockDriver is a neo4jDriver recording the configuration of the sessions created through it. All the sessions
+// return the same records and summary for every query. Connectivity is verified unless connectivityErr is set.
+type mockDriver struct {
+	sessionConfigs  []neo4j.SessionConfig
+	session         *mockSession
+	connectivityErr error
+}
+
+func newMockDriver(records ...*neo4j.Record) *mockDriver {
+	return &mockDriver{session: &mockSession{records: records}}
+}
+
+func (md *mockDriver) NewSession(ctx context.Context, config neo4j.SessionConfig) neo4jSession {
+	md.sessionConfigs = append(md.sessionConfigs, config)
+	return md.session
+}
+
+func (md *mockDriver) GetServerInfo(ctx context.Context) (neo4j.ServerInfo, error) {
+	return nil, errors.New("server info not available")
+}
+
+func (md *mockDriver) VerifyConnectivity(ctx context.Context) error {
+	return md.connectivityErr
+}
+
+func (md *mockDriver) Close(ctx context.Context) error {
+	return nil
+}
+
+// mockSession records the kind of managed transactions executed, their timeouts and the queries run along with
+// their parameters, as well as the outcome of the explicit transactions. The records of every result are followed by
+// streamErr if set, while consuming the results fails with consumeErr if set.
+type mockSession struct {
+	records      []*neo4j.Record
+	summary      neo4j.ResultSummary
+	streamErr    error
+	consumeErr   error
+	transactions []neo4j.AccessMode
+	timeouts     []time.Duration
+	queries      []string
+	params       []map[string]any
+	committed    bool
+	rolledBack   bool
+	closed       bool
+}
+
+func (ms *mockSession) recordTimeout(configurers []func(*neo4j.TransactionConfig)) {
+	config := neo4j.TransactionConfig{}
+	for _, configurer := range configurers {
+		configurer(&config)
+	}
+	ms.timeouts = append(ms.timeouts, config.Timeout)
+}
+
+func (ms *mockSession) BeginTransaction(ctx context.Context, configurers ...func(*neo4j.TransactionConfig)) (neo4j.ExplicitTransaction, error) {
+	ms.recordTimeout(configurers)
+	return &mockExplicitTransaction{session: ms}, nil
+}
+
+func (ms *mockSession) ExecuteRead(ctx context.Context, work neo4j.ManagedTransactionWork, configurers ...func(*neo4j.TransactionConfig)) (any, error) {
+	ms.transactions = append(ms.transactions, neo4j.AccessModeRead)
+	ms.recordTimeout(configurers)
+	return work(&mockTransaction{session: ms})
+}
+
+func (ms *mockSession) ExecuteWrite(ctx context.Context, work neo4j.ManagedTransactionWork, configurers ...func(*neo4j.TransactionConfig)) (any, error) {
+	ms.transactions = append(ms.transactions, neo4j.AccessModeWrite)
+	ms.recordTimeout(configurers)
+	return work(&mockTransaction{session: ms})
+}
+
+func (ms *mockSession) Run(ctx context.Context, cypher string, params map[string]any, configurers ...func(*neo4j.TransactionConfig)) (neo4j.ResultWithContext, error) {
+	ms.recordTimeout(configurers)
+	return ms.run(cypher, params), nil
+}
+
+func (ms *mockSession) run(cypher string, params map[string]any) neo4j.ResultWithContext {
+	ms.queries = append(ms.queries, cypher)
+	ms.params = append(ms.params, params)
+	result := newMockResult(ms.records)
+	result.summary = ms.summary
+	result.streamErr = ms.streamErr
+	result.consumeErr = ms.consumeErr
+	return result
+}
+
+func (ms *mockSession) Close(ctx context.Context) error {
+	ms.closed = true
+	return nil
+}
+
+// mockTransaction is a managed transaction running queries through the session. The embedded interface is never
+// set and only satisfies the unexported methods of neo4j.ManagedTransaction.
+type mockTransaction struct {
+	neo4j.ManagedTransaction
+	session *mockSession
+}
+
+func (mt *mockTransaction) Run(ctx context.Context, cypher string, params map[string]any) (neo4j.ResultWithContext, error) {
+	return mt.session.run(cypher, params), nil
+}
+
+// mockExplicitTransaction is an explicit transaction running queries through the session and recording its outcome
+// on the session
+type mockExplicitTransaction struct {
+	neo4j.ExplicitTransaction
+	session *mockSession
+}
+
+func (mt *mockExplicitTransaction) Run(ctx context.Context, cypher string, params map[string]any) (neo4j.ResultWithContext, error) {
+	return mt.session.run(cypher, params), nil
+}
+
+func (mt *mockExplicitTransaction) Commit(ctx context.Context) error {
+	mt.session.committed = true
+	return nil
+}
+
+func (mt *mockExplicitTransaction) Rollback(ctx context.Context) error {
+	mt.session.rolledBack = true
+	return nil
+}
+
+func (mt *mockExplicitTransaction) Close(ctx context.Context) error {
+	return nil
+}
+
+// mockResult iterates over a fixed set of records. An empty result summary is returned unless summary is set.
+type mockResult struct {
+	neo4j.ResultWithContext
+	records    []*neo4j.Record
+	index      int
+	summary    neo4j.ResultSummary
+	streamErr  error
+	consumeErr error
+}
+
+func newMockResult(records []*neo4j.Record) *mockResult {
+	return &mockResult{records: records, index: -1}
+}
+
+func (mr *mockResult) Keys() ([]string, error) {
+	if len(mr.records) == 0 {
+		return nil, nil
+	}
+	return mr.records[0].Keys, nil
+}
+
+func (mr *mockResult) Next(ctx context.Context) bool {
+	mr.index++
+	return mr.index < len(mr.records)
+}
+
+func (mr *mockResult) Record() *neo4j.Record {
+	return mr.records[mr.index]
+}
+
+func (mr *mockResult) Err() error {
+	if mr.index < len(mr.records) {
+		return nil
+	}
+	return mr.streamErr
+}
+
+func (mr *mockResult) Consume(ctx context.Context) (neo4j.ResultSummary, error) {
+	if mr.consumeErr != nil {
+		return nil, mr.consumeErr
+	}
+	if mr.summary == nil {
+		return &mockSummary{}, nil
+	}
+	return mr.summary, nil
+}
+
+// mockSummary is a result summary reporting fixed counters, timings and statement type
+type mockSummary struct {
+	neo4j.ResultSummary
+	counters             mockCounters
+	statementType        neo4j.StatementType
+	resultAvailableAfter time.Duration
+	resultConsumedAfter  time.Duration
+}
+
+func (ms *mockSummary) Counters() neo4j.Counters {
+	return ms.counters
+}
+
+func (ms *mockSummary) StatementType() neo4j.StatementType {
+	return ms.statementType
+}
+
+func (ms *mockSummary) Notifications() []neo4j.Notification {
+	return nil
+}
+
+func (ms *mockSummary) ResultAvailableAfter() time.Duration {
+	return ms.resultAvailableAfter
+}
+
+func (ms *mockSummary) ResultConsumedAfter() time.Duration {
+	return ms.resultConsumedAfter
+}
+
+type mockCounters struct {
+	neo4j.Counters
+	nodesCreated         int
+	nodesDeleted         int
+	relationshipsCreated int
+	relationshipsDeleted int
+	propertiesSet        int
+	labelsAdded          int
+	labelsRemoved        int
+}
+
+func (mc mockCounters) NodesCreated() int         { return mc.nodesCreated }
+func (mc mockCounters) NodesDeleted() int         { return mc.nodesDeleted }
+func (mc mockCounters) RelationshipsCreated() int { return mc.relationshipsCreated }
+func (mc mockCounters) RelationshipsDeleted() int { return mc.relationshipsDeleted }
+func (mc mockCounters) PropertiesSet() int        { return mc.propertiesSet }
+func (mc mockCounters) LabelsAdded() int          { return mc.labelsAdded }
+func (mc mockCounters) LabelsRemoved() int        { return mc.labelsRemoved }
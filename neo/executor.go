@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
@@ -15,28 +16,52 @@ const (
 	NEO4J_USER_KEY       = "username"
 	NEO4J_PWD_KEY        = "password"
 	NEO4J_AUTH_TOKEN_KEY = "auth-token"
-	defaultTimeout       = 5 * time.Second
+	NEO4J_APOC_KEY       = "apoc"
+	// NEO4J_NUMERIC_IDS_KEY is the connection option key used to populate the legacy numeric ids of the nodes and
+	// relationships read from the database within their identifiers, alongside the element ids
+	NEO4J_NUMERIC_IDS_KEY = "numericIds"
+	// defaultTimeout is the transaction timeout used when the connection options do not specify
+	// core.DEFAULT_QUERY_TIMEOUT_KEY
+	defaultTimeout = 5 * time.Second
 )
 
 type neo4jContextKey string
 
 const (
 	ContextKeyDbName = neo4jContextKey("dbname")
+	// ContextKeyWriteModeCreate is used to specify that StoreVertex and StoreEdge must use CREATE instead of MERGE.
+	// Enabled by specifying a boolean value of true against the key.
+	ContextKeyWriteModeCreate = neo4jContextKey("writeModeCreate")
 )
 
 type Neo4jConnection struct {
-	driver neo4j.DriverWithContext
+	driver    neo4jDriver
+	labelCase core.LabelCase
+	// apoc is set when the APOC procedures are available on the server
+	apoc bool
+	// bookmarkManager chains the sessions requiring strong consistency
+	bookmarkManager neo4j.BookmarkManager
+	// maxRows is the maximum number of rows a query may return. Any number of rows is allowed if 0
+	maxRows int
+	// numericIds is set when the legacy numeric ids are populated within the identifiers
+	numericIds bool
+	// queryTimeout is the default transaction timeout of the queries. defaultTimeout is used if 0
+	queryTimeout time.Duration
+	// tx is set when the connection is bound to an explicit transaction
+	tx neo4j.ExplicitTransaction
 }
 
 func (neo *Neo4jConnection) QueryVertex(ctx context.Context, label string, selectors, filters, queryParams core.KVMap) ([]*core.Vertex, error) {
 
-	vqb := cypher.NewVertexQueryBuilder()
-	vqb.SetQueryMode(core.Read)
-	vqb.SetLabel([]string{label})
-	vqb.SetSelector(selectors)
-	vqb.SetFilters(filters)
-	vqb.SetVarName("v")
-	query, err := vqb.Build()
+	limit, queryParams, err := core.SplitLimit(queryParams)
+	if err != nil {
+		return nil, err
+	}
+	query, generatedParams, err := neo.buildVertexQuery(label, selectors, filters, limit)
+	if err != nil {
+		return nil, err
+	}
+	queryParams, err = core.MergeQueryParams(generatedParams, queryParams)
 	if err != nil {
 		return nil, err
 	}
@@ -52,24 +77,91 @@ func (neo *Neo4jConnection) QueryVertex(ctx context.Context, label string, selec
 	return vertices, nil
 }
 
+// QueryVertexFunc invokes the specified function for each of the vertices with the specified label matching the
+// selectors and filters. The records are streamed from the server as the function consumes them, hence the vertices
+// are never held in memory together.
+//
+// The iteration stops at the first error returned by the function, which is returned as is. The query is run using
+// an auto-commit transaction unless the connection is bound to a transaction, hence the query is not retried on
+// transient errors since the vertices passed to the function till then would be passed again. The number of vertices
+// is not limited by the maximum number of rows configured on the connection.
+func (neo *Neo4jConnection) QueryVertexFunc(ctx context.Context, label string, selectors, filters core.KVMap, fn func(*core.Vertex) error) error {
+	query, queryParams, err := neo.buildVertexQuery(label, selectors, filters, 0)
+	if err != nil {
+		return err
+	}
+	if err = core.ValidateQueryParamsFromContext(ctx, query, queryParams); err != nil {
+		return err
+	}
+	var response neo4j.ResultWithContext
+	if neo.tx != nil {
+		response, err = neo.tx.Run(ctx, query, toDriverParams(queryParams))
+	} else {
+		session := neo.driver.NewSession(ctx, neo.sessionConfig(ctx, core.Read))
+		defer session.Close(ctx)
+		response, err = session.Run(ctx, query, toDriverParams(queryParams), neo4j.WithTxTimeout(neo.txTimeout(ctx)))
+	}
+	if err != nil {
+		return err
+	}
+	for response.Next(ctx) {
+		node, ok := response.Record().Values[0].(neo4j.Node)
+		if !ok {
+			return fmt.Errorf("unexpected value %v returned for vertex", response.Record().Values[0])
+		}
+		if err = fn(neo.nodeToVertex(node)); err != nil {
+			return err
+		}
+	}
+	return response.Err()
+}
+
+// buildVertexQuery builds the query reading the vertices with the specified label matching the selectors and filters
+// along with the parameters generated for the query. Labels cannot be passed as query parameters when matching
+// vertices, hence if dynamic labels are supported, the label is quoted instead. Empty labels are left unquoted so
+// that the builder rejects them.
+func (neo *Neo4jConnection) buildVertexQuery(label string, selectors, filters core.KVMap, limit int64) (string, core.KVMap, error) {
+	if neo.apoc && strings.TrimSpace(label) != "" {
+		label = cypher.QuoteName(label)
+	}
+	vqb := cypher.NewVertexQueryBuilder()
+	vqb.SetQueryMode(core.Read)
+	vqb.SetLabel([]string{label})
+	vqb.SetSelector(selectors)
+	vqb.SetFilters(filters)
+	vqb.SetVarName("v")
+	vqb.SetLimit(limit)
+	vqb.SetParameterized(true)
+	return vqb.BuildWithParams()
+}
+
 func (neo *Neo4jConnection) nodeToVertex(node neo4j.Node) *core.Vertex {
 	v := core.Vertex{}
 	v.Properties = make(core.KVMap)
-	v.Labels = append(v.Labels, node.Labels...)
-	v.ID = core.NewId(node.ElementId)
+	v.Labels = neo.labelCase.ApplyAll(node.Labels)
+	v.ID = neo.newId(node.ElementId, node.Id)
 	for key, val := range node.Props {
-		v.Properties[key] = val
+		v.Properties[key] = fromDriverValue(val)
 	}
 	return &v
 }
 
 func (neo *Neo4jConnection) QueryEdge(ctx context.Context, startVertexLabel, endVertexLabel []string, label string, startVertexSelectors, endVertexSelectors, selectors core.KVMap, startVertexFilters, endVertexFilters, filters, queryParams core.KVMap, fetchMode core.EdgeFetchMode) ([]*core.Edge, error) {
 
+	paging, queryParams, err := core.SplitPaging(queryParams)
+	if err != nil {
+		return nil, err
+	}
 	edgeQueryBuilder := cypher.NewEdgeQueryBuilder()
 	edgeQueryBuilder.SetEdgeFetchMode(fetchMode)
 	edgeQueryBuilder.SetStartVertexLabels(startVertexLabel)
 	edgeQueryBuilder.SetEndVertexLabels(endVertexLabel)
-	edgeQueryBuilder.SetLabel([]string{label})
+	if len(label) > 0 {
+		edgeQueryBuilder.SetLabel([]string{label})
+	} else {
+		// an empty label selects relationships of all types
+		edgeQueryBuilder.SetAnyLabel(true)
+	}
 	edgeQueryBuilder.SetStartVertexSelector(startVertexSelectors)
 	edgeQueryBuilder.SetEndVertexSelector(endVertexSelectors)
 	edgeQueryBuilder.SetSelector(selectors)
@@ -77,45 +169,280 @@ func (neo *Neo4jConnection) QueryEdge(ctx context.Context, startVertexLabel, end
 	edgeQueryBuilder.SetEndVertexFilters(endVertexFilters)
 	edgeQueryBuilder.SetFilters(filters)
 	edgeQueryBuilder.SetVariableName("r")
+	edgeQueryBuilder.SetLimit(paging.Limit)
+	edgeQueryBuilder.SetSkip(paging.Skip)
+	edgeQueryBuilder.SetOrderByID(paging.OrderByID)
+	edgeQueryBuilder.SetOrderBy(paging.OrderBy...)
+	edgeQueryBuilder.SetParameterized(true)
 	if fetchMode == core.EdgeWithCompleteVertex {
 		edgeQueryBuilder.SetStartVertexVariableName("sv")
 		edgeQueryBuilder.SetEndVertexVariableName("ev")
 	}
 
-	query, err := edgeQueryBuilder.Build()
+	query, generatedParams, err := edgeQueryBuilder.BuildWithParams()
 
 	if err != nil {
 		return nil, err
 	}
-	qr, err := neo.ExecuteQuery(ctx, query, core.Read, filters)
+	queryParams, err = core.MergeQueryParams(generatedParams, queryParams)
+	if err != nil {
+		return nil, err
+	}
+	qr, err := neo.ExecuteQuery(ctx, query, core.Read, queryParams)
 	if err != nil {
 		return nil, err
 	}
 	edges := make([]*core.Edge, 0)
 	for _, row := range qr.Rows {
-		e := core.Edge{}
-		e.Properties = make(core.KVMap)
-		relationship := row["r"].(neo4j.Relationship)
-		e.Type = relationship.Type
-		e.ID = core.NewId(relationship.ElementId)
-		for key, val := range relationship.Props {
-			e.Properties[key] = val
-		}
-		e.SourceVertexID = core.NewId(relationship.StartElementId)
-		e.DestinationVertexID = core.NewId(relationship.EndElementId)
+		e := neo.relationshipToEdge(row["r"].(neo4j.Relationship))
 		if fetchMode == core.EdgeWithCompleteVertex {
-			e.SourceVertex = neo.nodeToVertex(row["sv"].(neo4j.Node))
-			e.DestinationVertex = neo.nodeToVertex(row["ev"].(neo4j.Node))
+			e.SetEndpoints(neo.nodeToVertex(row["sv"].(neo4j.Node)), neo.nodeToVertex(row["ev"].(neo4j.Node)))
 		}
-		edges = append(edges, &e)
+		edges = append(edges, e)
 	}
 	return edges, nil
 }
 
+func (neo *Neo4jConnection) relationshipToEdge(relationship neo4j.Relationship) *core.Edge {
+	e := core.Edge{}
+	e.Properties = make(core.KVMap)
+	e.Type = neo.labelCase.Apply(relationship.Type)
+	e.ID = neo.newId(relationship.ElementId, relationship.Id)
+	for key, val := range relationship.Props {
+		e.Properties[key] = fromDriverValue(val)
+	}
+	e.SourceVertexID = neo.newId(relationship.StartElementId, relationship.StartId)
+	e.DestinationVertexID = neo.newId(relationship.EndElementId, relationship.EndId)
+	return &e
+}
+
+// newId returns the identifier of a node or relationship with the specified element id. The legacy numeric id is
+// included if the connection populates numeric ids.
+func (neo *Neo4jConnection) newId(elementId string, numericId int64) *core.Identifier {
+	if neo.numericIds {
+		return core.NewIdWithInt64(elementId, numericId)
+	}
+	return core.NewId(elementId)
+}
+
+// QueryConnectedVertices returns the distinct start and end vertices of the edges selected using the specified labels,
+// selectors and filters.
+func (neo *Neo4jConnection) QueryConnectedVertices(ctx context.Context, startVertexLabel, endVertexLabel []string, label string, startVertexSelectors, endVertexSelectors, selectors core.KVMap, startVertexFilters, endVertexFilters, filters core.KVMap, queryParams core.KVMap) ([]*core.Vertex, error) {
+	edges, err := neo.QueryEdge(ctx, startVertexLabel, endVertexLabel, label, startVertexSelectors, endVertexSelectors, selectors, startVertexFilters, endVertexFilters, filters, queryParams, core.EdgeWithCompleteVertex)
+	if err != nil {
+		return nil, err
+	}
+	return core.DistinctEndpoints(edges), nil
+}
+
+// QuerySubgraph executes the specified read query and returns the distinct nodes and relationships returned by the
+// query, including the nodes and relationships of the returned paths.
+func (neo *Neo4jConnection) QuerySubgraph(ctx context.Context, query string, queryParams core.KVMap) (*core.Subgraph, error) {
+	qr, err := neo.ExecuteQuery(ctx, query, core.Read, queryParams)
+	if err != nil {
+		return nil, err
+	}
+	vertices := make([]*core.Vertex, 0)
+	edges := make([]*core.Edge, 0)
+	var collect func(value any)
+	collect = func(value any) {
+		switch v := value.(type) {
+		case neo4j.Node:
+			vertices = append(vertices, neo.nodeToVertex(v))
+		case neo4j.Relationship:
+			edges = append(edges, neo.relationshipToEdge(v))
+		case neo4j.Path:
+			for _, node := range v.Nodes {
+				collect(node)
+			}
+			for _, relationship := range v.Relationships {
+				collect(relationship)
+			}
+		case []any:
+			for _, elem := range v {
+				collect(elem)
+			}
+		}
+	}
+	for _, row := range qr.Rows {
+		for _, column := range qr.Columns {
+			collect(row[column])
+		}
+	}
+	return core.NewSubgraph(vertices, edges), nil
+}
+
+// QueryPaths returns the distinct pairs of start and end vertices connected by a path of edges with the specified label
+//
+// The path traverses at least minHops and at most maxHops edges. A maxHops of 0 traverses any number of edges.
+func (neo *Neo4jConnection) QueryPaths(ctx context.Context, startVertexLabel, endVertexLabel []string, label string, startVertexSelectors, endVertexSelectors, selectors core.KVMap, minHops, maxHops int) ([]*core.Path, error) {
+	edgeQueryBuilder := cypher.NewEdgeQueryBuilder()
+	edgeQueryBuilder.SetEdgeFetchMode(core.EdgeWithCompleteVertex)
+	edgeQueryBuilder.SetStartVertexLabels(startVertexLabel)
+	edgeQueryBuilder.SetEndVertexLabels(endVertexLabel)
+	edgeQueryBuilder.SetLabel([]string{label})
+	edgeQueryBuilder.SetStartVertexSelector(startVertexSelectors)
+	edgeQueryBuilder.SetEndVertexSelector(endVertexSelectors)
+	edgeQueryBuilder.SetSelector(selectors)
+	edgeQueryBuilder.SetHops(minHops, maxHops)
+	edgeQueryBuilder.SetVariableName("r")
+	edgeQueryBuilder.SetStartVertexVariableName("sv")
+	edgeQueryBuilder.SetEndVertexVariableName("ev")
+	edgeQueryBuilder.SetParameterized(true)
+
+	query, queryParams, err := edgeQueryBuilder.BuildWithParams()
+	if err != nil {
+		return nil, err
+	}
+	qr, err := neo.ExecuteQuery(ctx, query, core.Read, queryParams)
+	if err != nil {
+		return nil, err
+	}
+	paths := make([]*core.Path, 0)
+	for _, row := range qr.Rows {
+		paths = append(paths, &core.Path{
+			StartVertex: neo.nodeToVertex(row["sv"].(neo4j.Node)),
+			EndVertex:   neo.nodeToVertex(row["ev"].(neo4j.Node)),
+		})
+	}
+	return paths, nil
+}
+
+// ExecuteQuery executes the specified query using the specified mode. If query debugging is enabled within the
+// context using core.WithDebugQueries, errors are returned wrapped within a core.QueryError identifying the query.
 func (neo *Neo4jConnection) ExecuteQuery(ctx context.Context, query string, mode core.QueryMode, queryParams map[string]interface{}) (*core.QueryResult, error) {
-	var sessionConfig neo4j.SessionConfig
+	result, err := neo.executeQuery(ctx, query, mode, queryParams)
+	if err != nil {
+		return nil, core.WrapQueryError(ctx, err, query, mode)
+	}
+	return result, nil
+}
+
+func (neo *Neo4jConnection) executeQuery(ctx context.Context, query string, mode core.QueryMode, queryParams map[string]interface{}) (*core.QueryResult, error) {
+	err := core.ValidateQueryParamsFromContext(ctx, query, queryParams)
+	if err != nil {
+		return nil, err
+	}
+	driverParams := toDriverParams(queryParams)
+	if neo.tx != nil {
+		response, err := neo.tx.Run(ctx, query, driverParams)
+		if err != nil {
+			return nil, err
+		}
+		return collectResult(ctx, response, neo.maxRows)
+	}
 
 	var queryExecuteFn func(context.Context, neo4j.ManagedTransactionWork, ...func(*neo4j.TransactionConfig)) (any, error)
+	session := neo.driver.NewSession(ctx, neo.sessionConfig(ctx, mode))
+	defer session.Close(ctx)
+	if mode == core.Read {
+		queryExecuteFn = session.ExecuteRead
+	} else {
+		queryExecuteFn = session.ExecuteWrite
+	}
+	result, err := queryExecuteFn(ctx, func(tx neo4j.ManagedTransaction) (any, error) {
+		response, err := tx.Run(ctx, query, driverParams)
+		if err != nil {
+			return nil, err
+		}
+		return collectResult(ctx, response, neo.maxRows)
+	}, neo4j.WithTxTimeout(neo.txTimeout(ctx)))
+
+	if err != nil {
+		return nil, err
+	}
+	return result.(*core.QueryResult), nil
+}
+
+// ExecuteQueryTyped executes a query returning a single row and scans the columns of the row into dest.
+func (neo *Neo4jConnection) ExecuteQueryTyped(ctx context.Context, query string, mode core.QueryMode, queryParams map[string]interface{}, dest ...interface{}) error {
+	result, err := neo.ExecuteQuery(ctx, query, mode, queryParams)
+	if err != nil {
+		return err
+	}
+	return core.ScanRow(result, dest...)
+}
+
+// QueryScalarInt executes a read query returning a single integer value.
+func (neo *Neo4jConnection) QueryScalarInt(ctx context.Context, query string, queryParams map[string]interface{}) (int64, error) {
+	result, err := neo.ExecuteQuery(ctx, query, core.Read, queryParams)
+	if err != nil {
+		return 0, err
+	}
+	return core.ScanInt64(result)
+}
+
+// QueryScalarFloat executes a read query returning a single numeric value.
+func (neo *Neo4jConnection) QueryScalarFloat(ctx context.Context, query string, queryParams map[string]interface{}) (float64, error) {
+	result, err := neo.ExecuteQuery(ctx, query, core.Read, queryParams)
+	if err != nil {
+		return 0, err
+	}
+	return core.ScanFloat64(result)
+}
+
+// ExecuteQueryNormalized executes a query and returns the result with the values of the rows normalized to plain Go
+// values. Temporal values are normalized to time.Time values, durations to their ISO 8601 representation and points
+// to maps containing the srid and the coordinates of the point.
+func (neo *Neo4jConnection) ExecuteQueryNormalized(ctx context.Context, query string, mode core.QueryMode, queryParams map[string]interface{}) (*core.QueryResult, error) {
+	result, err := neo.ExecuteQuery(ctx, query, mode, queryParams)
+	if err != nil {
+		return nil, err
+	}
+	return result.Normalized(neo.ConvertValue), nil
+}
+
+// ConvertValue converts the driver specific values returned by a query to core values which can be normalized. Nodes
+// and relationships are converted to vertices and edges.
+func (neo *Neo4jConnection) ConvertValue(value interface{}) (interface{}, bool) {
+	switch v := value.(type) {
+	case neo4j.Node:
+		return neo.nodeToVertex(v), true
+	case neo4j.Relationship:
+		return neo.relationshipToEdge(v), true
+	case neo4j.Path:
+		vertices := make([]*core.Vertex, 0, len(v.Nodes))
+		for _, node := range v.Nodes {
+			vertices = append(vertices, neo.nodeToVertex(node))
+		}
+		edges := make([]*core.Edge, 0, len(v.Relationships))
+		for _, relationship := range v.Relationships {
+			edges = append(edges, neo.relationshipToEdge(relationship))
+		}
+		return map[string]interface{}{"vertices": vertices, "edges": edges}, true
+	case neo4j.Date:
+		return v.Time(), true
+	case neo4j.LocalTime:
+		return v.Time(), true
+	case neo4j.LocalDateTime:
+		return v.Time(), true
+	case neo4j.Time:
+		return v.Time(), true
+	case neo4j.Duration:
+		return v.String(), true
+	case neo4j.Point2D:
+		return map[string]interface{}{"srid": v.SpatialRefId, "x": v.X, "y": v.Y}, true
+	case neo4j.Point3D:
+		return map[string]interface{}{"srid": v.SpatialRefId, "x": v.X, "y": v.Y, "z": v.Z}, true
+	default:
+		return value, false
+	}
+}
+
+// writeModeFromContext returns the write mode specified within the context using core.WithWriteMode or
+// ContextKeyWriteModeCreate
+func writeModeFromContext(ctx context.Context) core.WriteMode {
+	if writeMode, ok := core.WriteModeFromContext(ctx); ok {
+		return writeMode
+	}
+	if create, ok := ctx.Value(ContextKeyWriteModeCreate).(bool); ok && create {
+		return core.Create
+	}
+	return core.Merge
+}
+
+func (neo *Neo4jConnection) sessionConfig(ctx context.Context, mode core.QueryMode) neo4j.SessionConfig {
+	var sessionConfig neo4j.SessionConfig
 	if mode == core.Read {
 		sessionConfig = neo4j.SessionConfig{AccessMode: neo4j.AccessModeRead}
 	} else {
@@ -129,36 +456,89 @@ func (neo *Neo4jConnection) ExecuteQuery(ctx context.Context, query string, mode
 			sessionConfig.DatabaseName = graphDbName
 		}
 	}
-	session := neo.driver.NewSession(ctx, sessionConfig)
-	defer session.Close(ctx)
-	if mode == core.Read {
-		queryExecuteFn = session.ExecuteRead
-	} else {
-		queryExecuteFn = session.ExecuteWrite
+	// sessions sharing a bookmark manager are causally chained, hence every session observes the writes of the
+	// sessions completed before it, even on a different member of a cluster
+	if core.ConsistencyFromContext(ctx) == core.ConsistencyStrong {
+		sessionConfig.BookmarkManager = neo.bookmarkManager
 	}
-	result, err := queryExecuteFn(ctx, func(tx neo4j.ManagedTransaction) (any, error) {
-		response, err := tx.Run(ctx, query, queryParams)
-		if err != nil {
+	return sessionConfig
+}
+
+func collectResult(ctx context.Context, response neo4j.ResultWithContext, maxRows int) (*core.QueryResult, error) {
+	queryResult := core.QueryResult{}
+	queryResult.Columns, _ = response.Keys()
+	for response.Next(ctx) {
+		if err := core.CheckMaxRows(len(queryResult.Rows)+1, maxRows); err != nil {
 			return nil, err
 		}
-		queryResult := core.QueryResult{}
-		for response.Next(ctx) {
-			m := make(core.Row)
-			values := response.Record().Values
-			keys := response.Record().Keys
-			for i := 0; i < len(keys); i++ {
-				m[keys[i]] = values[i]
-			}
-			queryResult.Rows = append(queryResult.Rows, m)
+		m := make(core.Row)
+		values := response.Record().Values
+		keys := response.Record().Keys
+		for i := 0; i < len(keys); i++ {
+			m[keys[i]] = values[i]
 		}
-		return queryResult, nil
-	}, neo4j.WithTxTimeout(defaultTimeout))
-
+		queryResult.Rows = append(queryResult.Rows, m)
+	}
+	// the records stop early if the query fails while the records are streamed, hence the error of the stream takes
+	// precedence over the error returned when consuming the summary
+	if err := response.Err(); err != nil {
+		return nil, err
+	}
+	summary, err := response.Consume(ctx)
 	if err != nil {
 		return nil, err
 	}
-	qr := result.(core.QueryResult)
-	return &qr, err
+	counters := summary.Counters()
+	queryResult.Counters = &core.WriteCounters{
+		NodesCreated:         counters.NodesCreated(),
+		RelationshipsCreated: counters.RelationshipsCreated(),
+		PropertiesSet:        counters.PropertiesSet(),
+		LabelsAdded:          counters.LabelsAdded(),
+	}
+	for _, notification := range summary.Notifications() {
+		queryResult.Notifications = append(queryResult.Notifications, formatNotification(notification))
+	}
+	queryResult.Summary = &core.QuerySummary{
+		WriteCounters:        *queryResult.Counters,
+		NodesDeleted:         counters.NodesDeleted(),
+		RelationshipsDeleted: counters.RelationshipsDeleted(),
+		LabelsRemoved:        counters.LabelsRemoved(),
+		QueryType:            queryTypes[summary.StatementType()],
+		ResultAvailableAfter: summary.ResultAvailableAfter(),
+		ResultConsumedAfter:  summary.ResultConsumedAfter(),
+	}
+	return &queryResult, nil
+}
+
+// queryTypes maps the statement types reported by Neo4j to the query types
+var queryTypes = map[neo4j.StatementType]core.QueryType{
+	neo4j.StatementTypeReadOnly:    core.QueryTypeReadOnly,
+	neo4j.StatementTypeReadWrite:   core.QueryTypeReadWrite,
+	neo4j.StatementTypeWriteOnly:   core.QueryTypeWriteOnly,
+	neo4j.StatementTypeSchemaWrite: core.QueryTypeSchemaWrite,
+}
+
+// formatNotification formats the notification as <code>: <title> - <description>
+func formatNotification(notification neo4j.Notification) string {
+	return fmt.Sprintf("%s: %s - %s", notification.Code(), notification.Title(), notification.Description())
+}
+
+// Capabilities returns the features supported by Neo4j connections. Multiple databases are supported only by the
+// enterprise editions of Neo4j.
+func (neo *Neo4jConnection) Capabilities() core.Capabilities {
+	return core.Capabilities{
+		DirectedEdges:     true,
+		MultipleDatabases: true,
+		FullTextSearch:    true,
+		BatchImport:       true,
+		Transactions:      true,
+		DynamicLabels:     neo.apoc,
+	}
+}
+
+// Ping verifies that the driver can connect to the Neo4j server or cluster.
+func (neo *Neo4jConnection) Ping(ctx context.Context) error {
+	return neo.driver.VerifyConnectivity(ctx)
 }
 
 func (neo *Neo4jConnection) Close(ctx context.Context) error {
@@ -166,18 +546,42 @@ func (neo *Neo4jConnection) Close(ctx context.Context) error {
 }
 
 func (neo *Neo4jConnection) StoreVertex(ctx context.Context, vertex *core.Vertex) error {
-	vqb := cypher.NewVertexQueryBuilder()
-	vqb.SetQueryMode(core.Write)
-	vqb.SetLabel(vertex.Labels)
-	vqb.SetSelector(vertex.Properties)
-	vqb.SetVarName("sv")
-
-	query, err := vqb.Build()
+	createdTimestamp, setCreatedTimestamp := core.CreatedTimestampProperty(ctx)
+	var query string
+	var queryParams core.KVMap
+	var err error
+	writeMode := writeModeFromContext(ctx)
+	// the vertex is merged on all of its properties unless identity properties are specified
+	identityProperties, dataProperties := vertex.Properties, core.KVMap(nil)
+	if identity, ok := core.VertexIdentityPropertiesFromContext(ctx); ok {
+		identityProperties, dataProperties = core.SplitIdentityProperties(vertex.Properties, identity)
+	}
+	if neo.apoc && writeMode == core.Create && len(vertex.Labels) > 0 {
+		query = apocCreateNodeQuery(createdTimestamp)
+		queryParams = apocMergeNodeParams(vertex.Labels, vertex.Properties, nil)
+	} else if neo.apoc && len(vertex.Labels) > 0 && len(identityProperties) > 0 {
+		// apoc.merge.node requires at least one identifying property
+		query = apocMergeNodeQuery(createdTimestamp, len(dataProperties) > 0)
+		queryParams = apocMergeNodeParams(vertex.Labels, identityProperties, dataProperties)
+	} else {
+		vqb := cypher.NewVertexQueryBuilder()
+		vqb.SetQueryMode(core.Write)
+		vqb.SetWriteMode(writeMode)
+		vqb.SetLabel(vertex.Labels)
+		vqb.SetSelector(identityProperties)
+		vqb.SetDataProperties(dataProperties)
+		vqb.SetVarName("sv")
+		vqb.SetParameterized(true)
+		if setCreatedTimestamp {
+			vqb.SetCreatedTimestamp(createdTimestamp)
+		}
+		query, queryParams, err = vqb.BuildWithParams()
+	}
 	if err != nil {
 		return err
 	}
 
-	qr, err := neo.ExecuteQuery(ctx, query, core.Write, nil)
+	qr, err := neo.ExecuteQuery(ctx, query, core.Write, queryParams)
 	if err != nil {
 		return err
 	}
@@ -187,8 +591,89 @@ func (neo *Neo4jConnection) StoreVertex(ctx context.Context, vertex *core.Vertex
 	// support only a single vertex store at a time. hence consider only the first returned row
 	row := qr.Rows[0]
 	node := row["sv"].(neo4j.Node)
-	vertex.ID = core.NewId(node.ElementId)
+	vertex.ID = neo.newId(node.ElementId, node.Id)
+	if setCreatedTimestamp {
+		if vertex.Properties == nil {
+			vertex.Properties = make(core.KVMap)
+		}
+		vertex.Properties[createdTimestamp] = node.Props[createdTimestamp]
+	}
+	if writeOutcomes, ok := core.WriteOutcomesFromContext(ctx); ok && qr.Counters != nil {
+		writeOutcomes.Record(qr.Counters.Outcome())
+	}
+
+	return nil
+}
+
+// StoreVerticesDistinct stores the specified vertices within a single transaction.
+func (neo *Neo4jConnection) StoreVerticesDistinct(ctx context.Context, vertices []*core.Vertex) error {
+	if neo.tx != nil {
+		return core.StoreEachVertex(ctx, neo, vertices)
+	}
+	return core.StoreVerticesInTx(ctx, neo, vertices)
+}
 
+// StoreVertices creates the specified vertices within a single transaction. The vertices sharing the same labels are
+// created using a single UNWIND query, hence the number of queries run is the number of distinct label sets instead
+// of the number of vertices.
+func (neo *Neo4jConnection) StoreVertices(ctx context.Context, vertices []*core.Vertex) error {
+	if neo.tx != nil {
+		return neo.createVertices(ctx, vertices)
+	}
+	tx, err := neo.beginTx(ctx, core.Write)
+	if err != nil {
+		return err
+	}
+	defer tx.Close(ctx)
+	if err = tx.(*neo4jTransaction).createVertices(ctx, vertices); err != nil {
+		tx.Rollback(ctx)
+		for _, vertex := range vertices {
+			vertex.ID = nil
+		}
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+// createVertices creates the specified vertices using a query for each of the distinct label sets of the vertices
+func (neo *Neo4jConnection) createVertices(ctx context.Context, vertices []*core.Vertex) error {
+	batches := make(map[string][]*core.Vertex)
+	// the label sets in the order of their first occurrence, so that the queries are run in a deterministic order
+	labelSets := make([]string, 0)
+	for _, vertex := range vertices {
+		key := core.LabelSetKey(vertex.Labels)
+		if _, ok := batches[key]; !ok {
+			labelSets = append(labelSets, key)
+		}
+		batches[key] = append(batches[key], vertex)
+	}
+	for _, labelSet := range labelSets {
+		batch := batches[labelSet]
+		query, err := cypher.BuildCreateVerticesQuery(batch[0].Labels)
+		if err != nil {
+			return err
+		}
+		rows := make([]any, 0, len(batch))
+		for _, vertex := range batch {
+			properties := map[string]any{}
+			for k, v := range vertex.Properties {
+				properties[k] = v
+			}
+			rows = append(rows, properties)
+		}
+		qr, err := neo.ExecuteQuery(ctx, query, core.Write, map[string]interface{}{cypher.BatchRowsParam: rows})
+		if err != nil {
+			return err
+		}
+		if len(qr.Rows) != len(batch) {
+			return fmt.Errorf("unexpected number of vertices created. expected %d but %d were created", len(batch), len(qr.Rows))
+		}
+		// UNWIND retains the order of the rows, hence the created nodes are returned in the order of the vertices
+		for i, row := range qr.Rows {
+			node := row["v"].(neo4j.Node)
+			batch[i].ID = neo.newId(node.ElementId, node.Id)
+		}
+	}
 	return nil
 }
 
@@ -198,8 +683,11 @@ func (neo *Neo4jConnection) StoreEdge(ctx context.Context, edge *core.Edge) erro
 		return errors.New("source node must be specified for vertex connectivity")
 	}
 
+	startVertexMode, endVertexMode := core.EndpointModesFromContext(ctx)
+
 	eqb := cypher.NewEdgeQueryBuilder()
 	eqb.SetQueryMode(core.Write)
+	eqb.SetWriteMode(writeModeFromContext(ctx))
 	eqb.SetStartVertexSelector(edge.SourceVertex.Properties)
 	eqb.SetStartVertexVariableName("sv")
 	eqb.SetStartVertexLabels(edge.SourceVertex.Labels)
@@ -213,33 +701,346 @@ func (neo *Neo4jConnection) StoreEdge(ctx context.Context, edge *core.Edge) erro
 	eqb.SetEdgeFetchMode(core.EdgeWithCompleteVertex)
 	eqb.SetLabel([]string{edge.Type})
 	eqb.SetVariableName("rel")
-	eqb.SetSelector(edge.Properties)
+	eqb.SetStartVertexMode(startVertexMode)
+	eqb.SetEndVertexMode(endVertexMode)
+	if key, ok := core.IdempotencyKeyFromContext(ctx); ok {
+		eqb.SetSelector(core.KVMap{core.IdempotencyKeyProperty: key})
+		eqb.SetOnCreateProperties(edge.Properties)
+	} else if identity, ok := core.EdgeIdentityPropertiesFromContext(ctx); ok {
+		identityProperties, dataProperties := core.SplitEdgeProperties(edge.Properties, identity)
+		eqb.SetSelector(identityProperties)
+		eqb.SetDataProperties(dataProperties)
+	} else {
+		eqb.SetSelector(edge.Properties)
+	}
+	eqb.SetParameterized(true)
 
-	query, err := eqb.Build()
+	query, queryParams, err := eqb.BuildWithParams()
 
 	if err != nil {
 		return err
 	}
 
-	qr, err := neo.ExecuteQuery(ctx, query, core.Write, nil)
+	qr, err := neo.ExecuteQuery(ctx, query, core.Write, queryParams)
 
 	if err != nil {
 		return err
 	}
 
 	if len(qr.Rows) == 0 {
+		if startVertexMode == core.EndpointMatch || endVertexMode == core.EndpointMatch {
+			return core.ErrEndpointNotFound
+		}
 		return errors.New("unexpected error. failed to store vertex connectivity")
 	}
 
 	row := qr.Rows[0]
 
-	edge.SourceVertex.ID = core.NewId(row["sv"].(neo4j.Node).ElementId)
-	edge.ID = core.NewId(row["rel"].(neo4j.Relationship).ElementId)
-	edge.DestinationVertex.ID = core.NewId(row["ev"].(neo4j.Node).ElementId)
+	startNode := row["sv"].(neo4j.Node)
+	relationship := row["rel"].(neo4j.Relationship)
+	endNode := row["ev"].(neo4j.Node)
+	edge.SourceVertex.ID = neo.newId(startNode.ElementId, startNode.Id)
+	edge.ID = neo.newId(relationship.ElementId, relationship.Id)
+	edge.DestinationVertex.ID = neo.newId(endNode.ElementId, endNode.Id)
+
+	return nil
+}
 
+// AddLabels adds the specified labels to an existing vertex identified by the specified element id.
+func (neo *Neo4jConnection) AddLabels(ctx context.Context, id *core.Identifier, labels []string) error {
+	return neo.updateLabels(ctx, "SET", id, labels)
+}
+
+// RemoveLabels removes the specified labels from an existing vertex identified by the specified element id.
+func (neo *Neo4jConnection) RemoveLabels(ctx context.Context, id *core.Identifier, labels []string) error {
+	return neo.updateLabels(ctx, "REMOVE", id, labels)
+}
+
+func (neo *Neo4jConnection) updateLabels(ctx context.Context, operation string, id *core.Identifier, labels []string) error {
+	if id == nil {
+		return errors.New("vertex id must be specified")
+	}
+	if len(labels) == 0 {
+		return errors.New("at least one label must be specified")
+	}
+	labelFragment := strings.Builder{}
+	for _, label := range labels {
+		if len(label) == 0 {
+			return errors.New("labels cannot be empty")
+		}
+		labelFragment.WriteString(fmt.Sprintf(":%s", cypher.EscapeName(label)))
+	}
+	query := fmt.Sprintf("MATCH (v) WHERE elementId(v)=$id %s v%s return v", operation, labelFragment.String())
+	qr, err := neo.ExecuteQuery(ctx, query, core.Write, map[string]interface{}{"id": id.Value()})
+	if err != nil {
+		return err
+	}
+	if len(qr.Rows) == 0 {
+		return fmt.Errorf("vertex with id %s not found", id)
+	}
 	return nil
 }
 
+// RelationshipTypesBetween returns the distinct types of the relationships from vertices having the specified
+// start labels to vertices having the specified end labels.
+func (neo *Neo4jConnection) RelationshipTypesBetween(ctx context.Context, startLabels, endLabels []string) ([]string, error) {
+	query, err := cypher.BuildRelationshipTypesQuery(startLabels, endLabels)
+	if err != nil {
+		return nil, err
+	}
+	qr, err := neo.ExecuteQuery(ctx, query, core.Read, nil)
+	if err != nil {
+		return nil, err
+	}
+	relationshipTypes := make([]string, 0, len(qr.Rows))
+	for _, row := range qr.Rows {
+		relationshipTypes = append(relationshipTypes, neo.labelCase.Apply(row[cypher.RelationshipTypeVar].(string)))
+	}
+	return relationshipTypes, nil
+}
+
+// DeleteEdge deletes the relationships with the specified type between nodes having the specified start and end
+// labels, selected by the specified selectors, and returns the number of deleted relationships. The start and end
+// nodes are not deleted.
+//
+// The element ids of the deleted relationships are added to the DeletedIds specified within the context.
+func (neo *Neo4jConnection) DeleteEdge(ctx context.Context, startLabels, endLabels []string, edgeLabel string, startSelectors, endSelectors, edgeSelectors core.KVMap) (int, error) {
+	if err := core.ValidateDeleteSelector(ctx, startSelectors, endSelectors, edgeSelectors); err != nil {
+		return 0, err
+	}
+	deletedIds, collectIds := core.DeletedIdsFromContext(ctx)
+	eqb := cypher.NewEdgeQueryBuilder()
+	eqb.SetQueryMode(core.Delete)
+	eqb.SetStartVertexLabels(startLabels)
+	eqb.SetStartVertexVariableName("sv")
+	eqb.SetStartVertexSelector(startSelectors)
+	eqb.SetEndVertexLabels(endLabels)
+	eqb.SetEndVertexVariableName("ev")
+	eqb.SetEndVertexSelector(endSelectors)
+	if len(edgeLabel) > 0 {
+		eqb.SetLabel([]string{edgeLabel})
+	} else {
+		// an empty label selects relationships of all types
+		eqb.SetAnyLabel(true)
+	}
+	eqb.SetVariableName("r")
+	eqb.SetSelector(edgeSelectors)
+	eqb.SetParameterized(true)
+	if collectIds {
+		eqb.SetDeletedIdFunction("elementId")
+	}
+	query, queryParams, err := eqb.BuildWithParams()
+	if err != nil {
+		return 0, err
+	}
+	qr, err := neo.ExecuteQuery(ctx, query, core.Write, queryParams)
+	if err != nil {
+		return 0, err
+	}
+	if collectIds {
+		for _, row := range qr.Rows {
+			deletedIds.Add(core.NewId(row[cypher.DeletedEdgeIdVar].(string)))
+		}
+		return len(qr.Rows), nil
+	}
+	if len(qr.Rows) == 0 {
+		return 0, nil
+	}
+	return int(qr.Rows[0][cypher.DeletedCountVar].(int64)), nil
+}
+
+// LabelCombinations returns the distinct combinations of labels present on the nodes of the graph
+func (neo *Neo4jConnection) LabelCombinations(ctx context.Context) ([][]string, error) {
+	qr, err := neo.ExecuteQuery(ctx, cypher.BuildLabelCombinationsQuery("labels"), core.Read, nil)
+	if err != nil {
+		return nil, err
+	}
+	combinations := make([][]string, 0, len(qr.Rows))
+	for _, row := range qr.Rows {
+		labels := row[cypher.LabelsVar].([]any)
+		combination := make([]string, 0, len(labels))
+		for _, label := range labels {
+			combination = append(combination, neo.labelCase.Apply(label.(string)))
+		}
+		combinations = append(combinations, combination)
+	}
+	return core.DistinctLabelCombinations(combinations), nil
+}
+
+// Degree returns the number of relationships of the specified direction and types connected to the node identified
+// by the specified element id.
+func (neo *Neo4jConnection) Degree(ctx context.Context, id *core.Identifier, direction core.EdgeDirection, edgeLabels []string) (int64, error) {
+	if id == nil {
+		return 0, errors.New("vertex id must be specified")
+	}
+	query, err := cypher.BuildDegreeQuery("elementId(v) = $id", direction, edgeLabels)
+	if err != nil {
+		return 0, err
+	}
+	qr, err := neo.ExecuteQuery(ctx, query, core.Read, map[string]interface{}{"id": id.Value()})
+	if err != nil {
+		return 0, err
+	}
+	if len(qr.Rows) == 0 {
+		return 0, nil
+	}
+	return qr.Rows[0][cypher.DegreeVar].(int64), nil
+}
+
+// ExpandNeighbors reads the relationships of the specified direction and types connecting each of the nodes identified
+// by the specified element ids to their neighbors. The neighbors of all the nodes are read using a single query.
+func (neo *Neo4jConnection) ExpandNeighbors(ctx context.Context, ids []*core.Identifier, edgeLabels []string, direction core.EdgeDirection) (map[string][]*core.Edge, error) {
+	neighbors := make(map[string][]*core.Edge, len(ids))
+	idValues := make([]any, 0, len(ids))
+	for _, id := range ids {
+		if id == nil {
+			return nil, errors.New("vertex id must be specified")
+		}
+		neighbors[id.String()] = make([]*core.Edge, 0)
+		idValues = append(idValues, id.Value())
+	}
+	if len(ids) == 0 {
+		return neighbors, nil
+	}
+	query, err := cypher.BuildExpandNeighborsQuery("elementId", direction, edgeLabels)
+	if err != nil {
+		return nil, err
+	}
+	qr, err := neo.ExecuteQuery(ctx, query, core.Read, map[string]interface{}{cypher.ExpandIdsParam: idValues})
+	if err != nil {
+		return nil, err
+	}
+	for _, row := range qr.Rows {
+		id := fmt.Sprint(row[cypher.ExpandIdVar])
+		e := neo.relationshipToEdge(row[cypher.NeighborEdgeVar].(neo4j.Relationship))
+		e.SetEndpoint(neo.nodeToVertex(row[cypher.NeighborVar].(neo4j.Node)))
+		neighbors[id] = append(neighbors[id], e)
+	}
+	return neighbors, nil
+}
+
+// DeleteVertex detaches and deletes the nodes with the specified label selected by the specified selectors and returns
+// the number of deleted nodes.
+//
+// The element ids of the deleted nodes and relationships are added to the DeletedIds specified within the context.
+func (neo *Neo4jConnection) DeleteVertex(ctx context.Context, label string, selectors core.KVMap) (int, error) {
+	if err := core.ValidateDeleteSelector(ctx, selectors); err != nil {
+		return 0, err
+	}
+	deletedIds, collectIds := core.DeletedIdsFromContext(ctx)
+	idFunction := ""
+	if collectIds {
+		idFunction = "elementId"
+	}
+	query, queryParams, err := cypher.BuildDeleteVertexQuery(label, selectors, idFunction, true)
+	if err != nil {
+		return 0, err
+	}
+	qr, err := neo.ExecuteQuery(ctx, query, core.Write, queryParams)
+	if err != nil {
+		return 0, err
+	}
+	if !collectIds {
+		if len(qr.Rows) == 0 {
+			return 0, nil
+		}
+		return int(qr.Rows[0][cypher.DeletedCountVar].(int64)), nil
+	}
+	// a row is returned for each of the edges of a vertex, and an edge between two deleted vertices is returned for
+	// both the vertices
+	vertexIds := make(map[string]struct{})
+	edgeIds := make(map[string]struct{})
+	for _, row := range qr.Rows {
+		vertexId := row[cypher.DeletedVertexIdVar].(string)
+		if _, ok := vertexIds[vertexId]; !ok {
+			vertexIds[vertexId] = struct{}{}
+			deletedIds.Add(core.NewId(vertexId))
+		}
+		edgeId, ok := row[cypher.DeletedEdgeIdVar].(string)
+		if !ok {
+			continue
+		}
+		if _, ok := edgeIds[edgeId]; !ok {
+			edgeIds[edgeId] = struct{}{}
+			deletedIds.Add(core.NewId(edgeId))
+		}
+	}
+	return len(vertexIds), nil
+}
+
+// BeginTx starts an explicit transaction within a new write session.
+//
+// The database against which the transaction is executed can be specified using the ContextKeyDbName context key.
+func (neo *Neo4jConnection) BeginTx(ctx context.Context) (core.Tx, error) {
+	return neo.beginTx(ctx, core.Write)
+}
+
+// BeginSnapshot starts an explicit transaction within a new read session. Read sessions are routed to the
+// read replicas of a cluster.
+//
+// Neo4j executes transactions with the read committed isolation level. Hence the queries executed through the
+// transaction are not guaranteed to be isolated from the writes committed by concurrent transactions.
+func (neo *Neo4jConnection) BeginSnapshot(ctx context.Context) (core.Tx, error) {
+	return neo.beginTx(ctx, core.Read)
+}
+
+// txTimeout returns the timeout of the transactions started using the specified context. A timeout specified within
+// the context using core.WithQueryTimeout takes precedence over the default query timeout of the connection.
+func (neo *Neo4jConnection) txTimeout(ctx context.Context) time.Duration {
+	if timeout, ok := core.QueryTimeoutFromContext(ctx); ok {
+		return timeout
+	}
+	if neo.queryTimeout > 0 {
+		return neo.queryTimeout
+	}
+	return defaultTimeout
+}
+
+func (neo *Neo4jConnection) beginTx(ctx context.Context, mode core.QueryMode) (core.Tx, error) {
+	session := neo.driver.NewSession(ctx, neo.sessionConfig(ctx, mode))
+	tx, err := session.BeginTransaction(ctx, neo4j.WithTxTimeout(neo.txTimeout(ctx)))
+	if err != nil {
+		session.Close(ctx)
+		return nil, err
+	}
+	txConnection := *neo
+	txConnection.tx = tx
+	return &neo4jTransaction{Neo4jConnection: txConnection, session: session}, nil
+}
+
+// neo4jTransaction is a Neo4j connection bound to an explicit transaction.
+type neo4jTransaction struct {
+	Neo4jConnection
+	session neo4jSession
+}
+
+func (ntx *neo4jTransaction) Commit(ctx context.Context) error {
+	err := ntx.tx.Commit(ctx)
+	ntx.session.Close(ctx)
+	return err
+}
+
+func (ntx *neo4jTransaction) Rollback(ctx context.Context) error {
+	err := ntx.tx.Rollback(ctx)
+	ntx.session.Close(ctx)
+	return err
+}
+
+// Close rolls back the transaction if it has not been completed and releases the underlying session.
+// The driver shared with the parent connection is not closed.
+func (ntx *neo4jTransaction) Close(ctx context.Context) error {
+	ntx.tx.Close(ctx)
+	return ntx.session.Close(ctx)
+}
+
+func (ntx *neo4jTransaction) BeginTx(ctx context.Context) (core.Tx, error) {
+	return nil, errors.New("nested transactions are not supported")
+}
+
+func (ntx *neo4jTransaction) BeginSnapshot(ctx context.Context) (core.Tx, error) {
+	return nil, errors.New("nested transactions are not supported")
+}
+
 // NewConnection constructs a Neo4j driver connected to a Neo4j instance using the specified auth and config options
 //
 // # For Neo4j connection auth options must contain either of the following
@@ -250,10 +1051,84 @@ func (neo *Neo4jConnection) StoreEdge(ctx context.Context, edge *core.Edge) erro
 // # Absence of any of the required keys would result in an error
 //
 // Additional options can be configured using the options by providing a KV pair as the options paramater.
+//
+// Neo4j retains the case of vertex labels and edge types. A core.LabelCase value can be specified against the
+// core.LABEL_CASE_KEY key within the options map to normalize the labels read from the database.
+//
+// StoreVertex and StoreEdge write using MERGE by default, matching existing vertices and edges before creating them.
+// Specifying a boolean value of true against the ContextKeyWriteModeCreate context key, or core.Create using
+// core.WithWriteMode, writes using CREATE instead.
+// CREATE skips the match, which is considerably faster for bulk loads of vertices and edges known to be new since the
+// cost of the match grows with the number of vertices with the label unless the properties are indexed. However,
+// CREATE always creates new vertices and edges and hence results in duplicates if they already exist. StoreEdge
+// creates the start and end vertices as well unless the endpoints are required to exist.
+//
+// A core.Consistency value can be specified against the core.ContextKeyConsistency context key. Sessions of
+// operations requiring core.ConsistencyStrong share the bookmarks of the connection, hence each such operation
+// observes the writes of the operations completed before it through the connection.
+//
+// If the APOC procedures are installed on the server, a boolean value of true can be specified against the
+// NEO4J_APOC_KEY key within the options map. StoreVertex and QueryVertex then pass the labels of the vertices as
+// query parameters to APOC procedures and functions instead of including the labels within the query text. This
+// allows labels chosen at runtime to be used safely.
+//
+// The number of rows returned by a query can be capped by specifying an int value against the core.MAX_ROWS_KEY key
+// within the options map. ExecuteQuery then returns core.ErrMaxRowsExceeded for queries returning more rows than the
+// cap, guarding the application against runaway queries.
+//
+// Transactions time out after 5 seconds by default. A different timeout can be specified as a time.Duration value
+// against the core.DEFAULT_QUERY_TIMEOUT_KEY key within the options map, and overridden for individual operations
+// using core.WithQueryTimeout.
+//
+// The identifiers of the nodes and relationships read from the database contain their element ids. Applications
+// integrating with systems storing the legacy numeric ids can specify a boolean value of true against the
+// NEO4J_NUMERIC_IDS_KEY key within the options map to also populate the numeric ids, which are then returned by
+// core.Identifier.Int64.
+//
+// Errors constructing the connection identify the Neo4j server targeted by the connection. The credentials are never
+// included in the errors.
 func NewConnection(protocol, host, realm string, port *int32, auth, options map[string]interface{}) (core.Connection, error) {
+	var target string
+	if port != nil {
+		target = fmt.Sprintf("%s://%s:%d", protocol, host, *port)
+	} else {
+		target = fmt.Sprintf("%s://%s", protocol, host)
+	}
+	conn, err := newConnection(target, realm, auth, options)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create neo4j connection to %s: %w", target, err)
+	}
+	return conn, nil
+}
+
+func newConnection(target, realm string, auth, options map[string]interface{}) (*Neo4jConnection, error) {
 	if !validateAuthData(auth) {
 		return nil, errors.New("specify a valid NEO4J_USER_KEY and NEO4J_PWD_KEY or a NEO4J_AUTH_TOKEN_KEY")
 	}
+	labelCase, err := core.LabelCaseFromOptions(options)
+	if err != nil {
+		return nil, err
+	}
+	maxRows, err := core.MaxRowsFromOptions(options)
+	if err != nil {
+		return nil, err
+	}
+	queryTimeout, err := core.DefaultQueryTimeoutFromOptions(options)
+	if err != nil {
+		return nil, err
+	}
+	apoc := false
+	if apocOption, ok := options[NEO4J_APOC_KEY]; ok {
+		if apoc, ok = apocOption.(bool); !ok {
+			return nil, errors.New("value of the NEO4J_APOC_KEY option must be a bool")
+		}
+	}
+	numericIds := false
+	if numericIdsOption, ok := options[NEO4J_NUMERIC_IDS_KEY]; ok {
+		if numericIds, ok = numericIdsOption.(bool); !ok {
+			return nil, errors.New("value of the NEO4J_NUMERIC_IDS_KEY option must be a bool")
+		}
+	}
 	var token neo4j.AuthToken
 
 	if _, ok := auth[NEO4J_AUTH_TOKEN_KEY]; !ok {
@@ -261,18 +1136,12 @@ func NewConnection(protocol, host, realm string, port *int32, auth, options map[
 	} else {
 		token = auth[NEO4J_AUTH_TOKEN_KEY].(neo4j.AuthToken)
 	}
-	var target string
-	if port != nil {
-		target = fmt.Sprintf("%s://%s:%d", protocol, host, *port)
-	} else {
-		target = fmt.Sprintf("%s://%s", protocol, host)
-	}
 
 	driver, err := neo4j.NewDriverWithContext(target, token)
 	if err != nil {
 		return nil, err
 	}
-	return &Neo4jConnection{driver: driver}, nil
+	return &Neo4jConnection{driver: driverWithContext{driver}, labelCase: labelCase, apoc: apoc, maxRows: maxRows, numericIds: numericIds, queryTimeout: queryTimeout, bookmarkManager: neo4j.NewBookmarkManager(neo4j.BookmarkManagerConfig{})}, nil
 
 }
 
@@ -0,0 +1,822 @@
+package neo
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+	"github.com/prahaladd/gograph/core"
+	"github.com/stretchr/testify/suite"
+)
+
+type ExecutorTestSuite struct {
+	suite.Suite
+}
+
+func (suite *ExecutorTestSuite) TestNodeToVertex() {
+	neo := Neo4jConnection{}
+	node := neo4j.Node{ElementId: "4:abc:1", Labels: []string{"Person"}, Props: map[string]any{"name": "Tintin"}}
+	v := neo.nodeToVertex(node)
+	suite.Equal(core.NewId("4:abc:1"), v.ID)
+	suite.Equal([]string{"Person"}, v.Labels)
+	suite.Equal(core.KVMap{"name": "Tintin"}, v.Properties)
+}
+
+func (suite *ExecutorTestSuite) TestNumericIdsPopulatedOnRead() {
+	neo := Neo4jConnection{numericIds: true}
+	node := neo4j.Node{Id: 42, ElementId: "4:abc:42"}
+	numericId, ok := neo.nodeToVertex(node).ID.Int64()
+	suite.True(ok)
+	suite.Equal(int64(42), numericId)
+
+	relationship := neo4j.Relationship{Id: 7, ElementId: "5:abc:7", StartId: 42, StartElementId: "4:abc:42", EndId: 43, EndElementId: "4:abc:43"}
+	e := neo.relationshipToEdge(relationship)
+	suite.Equal("5:abc:7", e.ID.Value())
+	numericId, _ = e.ID.Int64()
+	suite.Equal(int64(7), numericId)
+	numericId, _ = e.DestinationVertexID.Int64()
+	suite.Equal(int64(43), numericId)
+
+	// the numeric ids are not populated by default
+	neo = Neo4jConnection{}
+	suite.Equal(core.NewId("4:abc:42"), neo.nodeToVertex(node).ID)
+}
+
+func (suite *ExecutorTestSuite) TestLabelCaseAppliedOnRead() {
+	neo := Neo4jConnection{labelCase: core.LabelCaseLower}
+	node := neo4j.Node{ElementId: "4:abc:1", Labels: []string{"Person", "Employee"}}
+	v := neo.nodeToVertex(node)
+	suite.Equal([]string{"person", "employee"}, v.Labels)
+}
+
+func (suite *ExecutorTestSuite) TestCapabilities() {
+	neo := Neo4jConnection{}
+	capabilities := neo.Capabilities()
+	suite.True(capabilities.DirectedEdges)
+	suite.True(capabilities.MultipleDatabases)
+	suite.True(capabilities.FullTextSearch)
+	suite.True(capabilities.BatchImport)
+	suite.True(capabilities.Transactions)
+	suite.False(capabilities.DynamicLabels)
+}
+
+func (suite *ExecutorTestSuite) TestCapabilitiesWithApoc() {
+	neo := Neo4jConnection{apoc: true}
+	suite.True(neo.Capabilities().DynamicLabels)
+}
+
+func (suite *ExecutorTestSuite) TestReadQueryUsesReadSession() {
+	driver := newMockDriver()
+	neo := Neo4jConnection{driver: driver}
+	_, err := neo.ExecuteQuery(context.Background(), "MATCH (v) RETURN v", core.Read, nil)
+	suite.NoError(err)
+	suite.Equal([]neo4j.SessionConfig{{AccessMode: neo4j.AccessModeRead}}, driver.sessionConfigs)
+	suite.Equal([]neo4j.AccessMode{neo4j.AccessModeRead}, driver.session.transactions)
+	suite.Equal([]string{"MATCH (v) RETURN v"}, driver.session.queries)
+	suite.True(driver.session.closed)
+}
+
+func (suite *ExecutorTestSuite) TestWriteQueryUsesWriteSession() {
+	driver := newMockDriver()
+	neo := Neo4jConnection{driver: driver}
+	_, err := neo.ExecuteQuery(context.Background(), "CREATE (v) RETURN v", core.Write, nil)
+	suite.NoError(err)
+	suite.Equal([]neo4j.SessionConfig{{AccessMode: neo4j.AccessModeWrite}}, driver.sessionConfigs)
+	suite.Equal([]neo4j.AccessMode{neo4j.AccessModeWrite}, driver.session.transactions)
+}
+
+func (suite *ExecutorTestSuite) TestSessionDatabaseName() {
+	driver := newMockDriver()
+	neo := Neo4jConnection{driver: driver}
+	ctx := context.WithValue(context.Background(), ContextKeyDbName, "movies")
+	_, err := neo.ExecuteQuery(ctx, "MATCH (v) RETURN v", core.Read, nil)
+	suite.NoError(err)
+	suite.Equal("movies", driver.sessionConfigs[0].DatabaseName)
+
+	// an empty database name selects the default database
+	ctx = context.WithValue(context.Background(), ContextKeyDbName, "")
+	_, err = neo.ExecuteQuery(ctx, "MATCH (v) RETURN v", core.Read, nil)
+	suite.NoError(err)
+	suite.Equal("", driver.sessionConfigs[1].DatabaseName)
+}
+
+func (suite *ExecutorTestSuite) TestStrongConsistencySharesBookmarks() {
+	driver := newMockDriver()
+	bookmarkManager := neo4j.NewBookmarkManager(neo4j.BookmarkManagerConfig{})
+	neo := Neo4jConnection{driver: driver, bookmarkManager: bookmarkManager}
+	_, err := neo.ExecuteQuery(context.Background(), "MATCH (v) RETURN v", core.Read, nil)
+	suite.NoError(err)
+	suite.Nil(driver.sessionConfigs[0].BookmarkManager)
+
+	ctx := core.WithConsistency(context.Background(), core.ConsistencyStrong)
+	_, err = neo.ExecuteQuery(ctx, "CREATE (v) RETURN v", core.Write, nil)
+	suite.NoError(err)
+	_, err = neo.ExecuteQuery(ctx, "MATCH (v) RETURN v", core.Read, nil)
+	suite.NoError(err)
+	suite.Equal(bookmarkManager, driver.sessionConfigs[1].BookmarkManager)
+	suite.Equal(bookmarkManager, driver.sessionConfigs[2].BookmarkManager)
+}
+
+func (suite *ExecutorTestSuite) TestStoreVertexWithCoreWriteMode() {
+	driver := newMockDriver(&neo4j.Record{Keys: []string{"sv"}, Values: []any{neo4j.Node{ElementId: "4:abc:1"}}})
+	neo := Neo4jConnection{driver: driver}
+	ctx := core.WithWriteMode(context.Background(), core.Create)
+	suite.NoError(neo.StoreVertex(ctx, &core.Vertex{Labels: []string{"Person"}, Properties: core.KVMap{"name": "Tintin"}}))
+	suite.Equal([]string{"CREATE (sv:Person{name: $p0})  return sv"}, driver.session.queries)
+	suite.Equal([]map[string]any{{"p0": "Tintin"}}, driver.session.params)
+
+	// the core write mode takes precedence over ContextKeyWriteModeCreate
+	ctx = core.WithWriteMode(context.WithValue(context.Background(), ContextKeyWriteModeCreate, true), core.Merge)
+	suite.Equal(core.Merge, writeModeFromContext(ctx))
+}
+
+func (suite *ExecutorTestSuite) TestQueryVertexMapsRows() {
+	node := neo4j.Node{ElementId: "4:abc:1", Labels: []string{"Person"}, Props: map[string]any{"name": "Tintin"}}
+	driver := newMockDriver(&neo4j.Record{Keys: []string{"v"}, Values: []any{node}})
+	neo := Neo4jConnection{driver: driver}
+	vertices, err := neo.QueryVertex(context.Background(), "Person", core.KVMap{"name": "Tintin"}, nil, nil)
+	suite.NoError(err)
+	suite.Equal(1, len(vertices))
+	suite.Equal(core.NewId("4:abc:1"), vertices[0].ID)
+	suite.Equal(core.KVMap{"name": "Tintin"}, vertices[0].Properties)
+	suite.Equal([]string{"MATCH (v:Person{name: $p0})  return v"}, driver.session.queries)
+	suite.Equal([]map[string]any{{"p0": "Tintin"}}, driver.session.params)
+}
+
+func (suite *ExecutorTestSuite) TestQueryVertexEmptyLabel() {
+	driver := newMockDriver()
+	neo := Neo4jConnection{driver: driver}
+	_, err := neo.QueryVertex(context.Background(), "", core.KVMap{"name": "Tintin"}, nil, nil)
+	suite.EqualError(err, `invalid vertex label "": labels cannot be empty`)
+	suite.Empty(driver.session.queries)
+}
+
+func (suite *ExecutorTestSuite) TestExecuteQuerySummary() {
+	driver := newMockDriver()
+	driver.session.summary = &mockSummary{
+		counters:             mockCounters{nodesCreated: 1, propertiesSet: 2, labelsAdded: 1, relationshipsDeleted: 3},
+		statementType:        neo4j.StatementTypeWriteOnly,
+		resultAvailableAfter: 5 * time.Millisecond,
+		resultConsumedAfter:  2 * time.Millisecond,
+	}
+	neo := Neo4jConnection{driver: driver}
+	result, err := neo.ExecuteQuery(context.Background(), "CREATE (v:Person{name: 'Tintin', age: 18})", core.Write, nil)
+	suite.NoError(err)
+	suite.Equal(&core.QuerySummary{
+		WriteCounters:        core.WriteCounters{NodesCreated: 1, PropertiesSet: 2, LabelsAdded: 1},
+		RelationshipsDeleted: 3,
+		QueryType:            core.QueryTypeWriteOnly,
+		ResultAvailableAfter: 5 * time.Millisecond,
+		ResultConsumedAfter:  2 * time.Millisecond,
+	}, result.Summary)
+	suite.Equal(&result.Summary.WriteCounters, result.Counters)
+
+	// the summary is retained by the normalized result
+	normalized, err := neo.ExecuteQueryNormalized(context.Background(), "CREATE (v:Person{name: 'Tintin', age: 18})", core.Write, nil)
+	suite.NoError(err)
+	suite.Equal(result.Summary, normalized.Summary)
+}
+
+func (suite *ExecutorTestSuite) TestQueryEdgePassesGeneratedParams() {
+	driver := newMockDriver()
+	neo := Neo4jConnection{driver: driver}
+	_, err := neo.QueryEdge(context.Background(), []string{"Person"}, []string{"Person"}, "KNOWS", core.KVMap{"name": "Tintin"}, nil, nil, nil, nil, core.KVMap{"since": 1941}, core.KVMap{"tenant": "acme"}, core.EdgeWithVertexIds)
+	suite.NoError(err)
+	suite.Equal([]string{"MATCH (person0:Person{name: $p0})-[r:KNOWS]->(person2:Person)  WHERE r.since=$p1 return r"}, driver.session.queries)
+	suite.Equal([]map[string]any{{"p0": "Tintin", "p1": 1941, "tenant": "acme"}}, driver.session.params)
+
+	// caller specified parameters cannot replace the generated parameters
+	_, err = neo.QueryEdge(context.Background(), []string{"Person"}, []string{"Person"}, "KNOWS", core.KVMap{"name": "Tintin"}, nil, nil, nil, nil, nil, core.KVMap{"p0": "Haddock"}, core.EdgeWithVertexIds)
+	suite.ErrorContains(err, "query parameters collide with generated parameters: p0")
+}
+
+func (suite *ExecutorTestSuite) TestQueryEdgeOrderedByProperty() {
+	driver := newMockDriver()
+	neo := Neo4jConnection{driver: driver}
+	queryParams := core.KVMap{core.QueryParamOrderBy: core.OrderBy{Property: "since", Descending: true}, core.QueryParamLimit: 10}
+	_, err := neo.QueryEdge(context.Background(), []string{"Person"}, []string{"Person"}, "KNOWS", nil, nil, nil, nil, nil, nil, queryParams, core.EdgeWithCompleteVertex)
+	suite.NoError(err)
+	suite.Equal([]string{"MATCH (sv:Person)-[r:KNOWS]->(ev:Person)  return sv, r, ev ORDER BY r.since DESC LIMIT 10"}, driver.session.queries)
+	// the ordering is not passed to the driver as a query parameter
+	suite.Equal([]map[string]any{{}}, driver.session.params)
+}
+
+func (suite *ExecutorTestSuite) TestExecuteQueryNormalized() {
+	node := neo4j.Node{ElementId: "4:abc:1", Labels: []string{"Person"}, Props: map[string]any{"name": "Tintin", "age": int64(18)}}
+	driver := newMockDriver(&neo4j.Record{Keys: []string{"v", "count", "tags"}, Values: []any{node, int64(2), []any{"reporter"}}})
+	neo := Neo4jConnection{driver: driver}
+	result, err := neo.ExecuteQueryNormalized(context.Background(), "MATCH (v) RETURN v, 2 AS count, ['reporter'] AS tags", core.Read, nil)
+	suite.NoError(err)
+	suite.Equal([]core.Row{{
+		"v": map[string]interface{}{
+			"id":         "4:abc:1",
+			"labels":     []interface{}{"Person"},
+			"properties": map[string]interface{}{"name": "Tintin", "age": int64(18)},
+		},
+		"count": int64(2),
+		"tags":  []interface{}{"reporter"},
+	}}, result.Rows)
+}
+
+func (suite *ExecutorTestSuite) TestQueryVertexFuncStopsOnError() {
+	records := make([]*neo4j.Record, 0)
+	for _, name := range []string{"Tintin", "Haddock", "Calculus"} {
+		node := neo4j.Node{ElementId: "4:abc:" + name, Labels: []string{"Person"}, Props: map[string]any{"name": name}}
+		records = append(records, &neo4j.Record{Keys: []string{"v"}, Values: []any{node}})
+	}
+	driver := newMockDriver(records...)
+	neo := Neo4jConnection{driver: driver}
+	errStop := errors.New("stop")
+	names := make([]string, 0)
+	err := neo.QueryVertexFunc(context.Background(), "Person", nil, nil, func(v *core.Vertex) error {
+		names = append(names, v.Properties["name"].(string))
+		if len(names) == 2 {
+			return errStop
+		}
+		return nil
+	})
+	suite.ErrorIs(err, errStop)
+	suite.Equal([]string{"Tintin", "Haddock"}, names)
+	suite.Equal([]string{"MATCH (v:Person)  return v"}, driver.session.queries)
+	suite.True(driver.session.closed)
+}
+
+func (suite *ExecutorTestSuite) TestQueryVertexFunc() {
+	node := neo4j.Node{ElementId: "4:abc:1", Labels: []string{"Person"}, Props: map[string]any{"name": "Tintin"}}
+	driver := newMockDriver(&neo4j.Record{Keys: []string{"v"}, Values: []any{node}})
+	neo := Neo4jConnection{driver: driver}
+	vertices := make([]*core.Vertex, 0)
+	err := neo.QueryVertexFunc(context.Background(), "Person", core.KVMap{"name": "Tintin"}, nil, func(v *core.Vertex) error {
+		vertices = append(vertices, v)
+		return nil
+	})
+	suite.NoError(err)
+	suite.Equal(1, len(vertices))
+	suite.Equal(core.NewId("4:abc:1"), vertices[0].ID)
+	suite.Equal(neo4j.AccessModeRead, driver.sessionConfigs[0].AccessMode)
+}
+
+func (suite *ExecutorTestSuite) TestQueryVertexWithFiltersOnly() {
+	node := neo4j.Node{ElementId: "4:abc:1", Labels: []string{"Person"}, Props: map[string]any{"name": "Tintin", "age": int64(18)}}
+	driver := newMockDriver(&neo4j.Record{Keys: []string{"v"}, Values: []any{node}})
+	neo := Neo4jConnection{driver: driver}
+	vertices, err := neo.QueryVertex(context.Background(), "Person", nil, core.KVMap{"age": 18}, nil)
+	suite.NoError(err)
+	suite.Equal(1, len(vertices))
+	suite.Equal([]string{"MATCH (v:Person)  WHERE v.age=$p0 return v"}, driver.session.queries)
+}
+
+func (suite *ExecutorTestSuite) TestQueryVertexWithComparisonFilter() {
+	driver := newMockDriver()
+	neo := Neo4jConnection{driver: driver}
+	_, err := neo.QueryVertex(context.Background(), "Person", nil, core.KVMap{"age": core.Filter{Operator: core.Gt, Value: 18}}, nil)
+	suite.NoError(err)
+	suite.Equal([]string{"MATCH (v:Person)  WHERE v.age>$p0 return v"}, driver.session.queries)
+	suite.Equal([]map[string]any{{"p0": 18}}, driver.session.params)
+}
+
+func (suite *ExecutorTestSuite) TestQueryVertexWithinDistance() {
+	location := neo4j.Point2D{SpatialRefId: 4326, X: 4.35, Y: 50.85}
+	node := neo4j.Node{ElementId: "4:abc:1", Labels: []string{"City"}, Props: map[string]any{"name": "Brussels", "location": location}}
+	driver := newMockDriver(&neo4j.Record{Keys: []string{"v"}, Values: []any{node}})
+	neo := Neo4jConnection{driver: driver}
+	brussels := core.NewGeographicPoint(50.85, 4.35)
+	vertices, err := neo.QueryVertex(context.Background(), "City", nil, core.KVMap{"location": core.WithinDistance(brussels, 1000)}, nil)
+	suite.NoError(err)
+	suite.Equal([]string{"MATCH (v:City)  WHERE point.distance(v.location, $p0) < $p1 return v"}, driver.session.queries)
+	// points are passed to the driver as neo4j points and read back as core points
+	suite.Equal([]map[string]any{{"p0": location, "p1": 1000.0}}, driver.session.params)
+	suite.Equal(core.KVMap{"name": "Brussels", "location": brussels}, vertices[0].Properties)
+}
+
+func (suite *ExecutorTestSuite) TestToDriverParamsConvertsNestedPoints() {
+	point := core.Point{SRID: core.SRIDCartesian, X: 1, Y: 2}
+	params := toDriverParams(map[string]any{
+		"point":  &point,
+		"rows":   []any{map[string]any{"location": point}},
+		"points": []core.Point{point},
+		"name":   "Tintin",
+	})
+	driverPoint := neo4j.Point2D{SpatialRefId: 7203, X: 1, Y: 2}
+	suite.Equal(map[string]any{
+		"point":  driverPoint,
+		"rows":   []any{map[string]any{"location": driverPoint}},
+		"points": []any{driverPoint},
+		"name":   "Tintin",
+	}, params)
+	suite.Nil(toDriverParams(nil))
+}
+
+func (suite *ExecutorTestSuite) TestQueryVertexWithApoc() {
+	node := neo4j.Node{ElementId: "4:abc:1", Labels: []string{"Runtime Label"}, Props: map[string]any{"name": "Tintin"}}
+	driver := newMockDriver(&neo4j.Record{Keys: []string{"v"}, Values: []any{node}})
+	neo := Neo4jConnection{driver: driver, apoc: true}
+	// reads use the query builder regardless of APOC, hence filters using any operator are supported
+	vertices, err := neo.QueryVertex(context.Background(), "Runtime Label", core.KVMap{"name": "Tintin"}, core.KVMap{"age": core.Filter{Operator: core.Gt, Value: 17}}, nil)
+	suite.NoError(err)
+	suite.Equal(1, len(vertices))
+	suite.Equal([]string{"Runtime Label"}, vertices[0].Labels)
+	query, _, err := neo.buildVertexQuery("Runtime Label", core.KVMap{"name": "Tintin"}, core.KVMap{"age": core.Filter{Operator: core.Gt, Value: 17}}, 0)
+	suite.NoError(err)
+	suite.Equal([]string{query}, driver.session.queries)
+	suite.NotContains(query, "apoc")
+	suite.Contains(query, "(v:`Runtime Label`")
+}
+
+func (suite *ExecutorTestSuite) TestQueryVertexWithApocRejectsEmptyLabel() {
+	driver := newMockDriver()
+	neo := Neo4jConnection{driver: driver, apoc: true}
+	_, err := neo.QueryVertex(context.Background(), "", core.KVMap{"name": "Tintin"}, nil, nil)
+	suite.EqualError(err, `invalid vertex label "": labels cannot be empty`)
+	_, err = neo.QueryVertex(context.Background(), "  ", core.KVMap{"name": "Tintin"}, nil, nil)
+	suite.EqualError(err, `invalid vertex label "  ": labels cannot be empty`)
+	suite.Empty(driver.session.queries)
+}
+
+func (suite *ExecutorTestSuite) TestQueryEdgeReportsStoredDirection() {
+	tintin := neo4j.Node{ElementId: "4:abc:1", Labels: []string{"Person"}, Props: map[string]any{"name": "Tintin"}}
+	snowy := neo4j.Node{ElementId: "4:abc:2", Labels: []string{"Dog"}, Props: map[string]any{"name": "Snowy"}}
+	owns := neo4j.Relationship{ElementId: "5:abc:3", StartElementId: "4:abc:1", EndElementId: "4:abc:2", Type: "OWNS"}
+	// the vertices are bound in the opposite order of the stored direction
+	driver := newMockDriver(&neo4j.Record{Keys: []string{"sv", "r", "ev"}, Values: []any{snowy, owns, tintin}})
+	neo := Neo4jConnection{driver: driver}
+	edges, err := neo.QueryEdge(context.Background(), []string{"Person"}, []string{"Dog"}, "OWNS", nil, nil, nil, nil, nil, nil, nil, core.EdgeWithCompleteVertex)
+	suite.NoError(err)
+	suite.Equal(1, len(edges))
+	suite.Equal(core.NewId("4:abc:1"), edges[0].SourceVertexID)
+	suite.Equal(core.NewId("4:abc:2"), edges[0].DestinationVertexID)
+	suite.Equal(edges[0].SourceVertexID, edges[0].SourceVertex.ID)
+	suite.Equal(edges[0].DestinationVertexID, edges[0].DestinationVertex.ID)
+}
+
+func (suite *ExecutorTestSuite) TestExecuteQueryWithinMaxRows() {
+	driver := newMockDriver(
+		&neo4j.Record{Keys: []string{"n"}, Values: []any{int64(1)}},
+		&neo4j.Record{Keys: []string{"n"}, Values: []any{int64(2)}},
+	)
+	neo := Neo4jConnection{driver: driver, maxRows: 2}
+	result, err := neo.ExecuteQuery(context.Background(), "UNWIND [1, 2] AS n RETURN n", core.Read, nil)
+	suite.NoError(err)
+	suite.Equal(2, len(result.Rows))
+}
+
+func (suite *ExecutorTestSuite) TestExecuteQueryExceedingMaxRows() {
+	driver := newMockDriver(
+		&neo4j.Record{Keys: []string{"n"}, Values: []any{int64(1)}},
+		&neo4j.Record{Keys: []string{"n"}, Values: []any{int64(2)}},
+		&neo4j.Record{Keys: []string{"n"}, Values: []any{int64(3)}},
+	)
+	neo := Neo4jConnection{driver: driver, maxRows: 2}
+	_, err := neo.ExecuteQuery(context.Background(), "UNWIND [1, 2, 3] AS n RETURN n", core.Read, nil)
+	suite.ErrorIs(err, core.ErrMaxRowsExceeded)
+
+	// the query is identified within the error when debugging
+	_, err = neo.ExecuteQuery(core.WithDebugQueries(context.Background()), "UNWIND [1, 2, 3] AS n RETURN n", core.Read, nil)
+	suite.ErrorIs(err, core.ErrMaxRowsExceeded)
+	suite.ErrorContains(err, `failed to execute read query "UNWIND [1, 2, 3] AS n RETURN n"`)
+}
+
+func (suite *ExecutorTestSuite) TestNewConnectionMaxRowsOption() {
+	auth := core.KVMap{NEO4J_USER_KEY: "neo4j", NEO4J_PWD_KEY: "pwd"}
+	conn, err := NewConnection("neo4j", "localhost", "", nil, auth, core.KVMap{core.MAX_ROWS_KEY: 1000})
+	suite.NoError(err)
+	suite.Equal(1000, conn.(*Neo4jConnection).maxRows)
+
+	_, err = NewConnection("neo4j", "localhost", "", nil, auth, core.KVMap{core.MAX_ROWS_KEY: "1000"})
+	suite.Error(err)
+}
+
+func (suite *ExecutorTestSuite) TestNewConnectionNumericIdsOption() {
+	auth := core.KVMap{NEO4J_USER_KEY: "neo4j", NEO4J_PWD_KEY: "pwd"}
+	conn, err := NewConnection("neo4j", "localhost", "", nil, auth, core.KVMap{NEO4J_NUMERIC_IDS_KEY: true})
+	suite.NoError(err)
+	suite.True(conn.(*Neo4jConnection).numericIds)
+
+	_, err = NewConnection("neo4j", "localhost", "", nil, auth, core.KVMap{NEO4J_NUMERIC_IDS_KEY: "true"})
+	suite.ErrorContains(err, "value of the NEO4J_NUMERIC_IDS_KEY option must be a bool")
+}
+
+func (suite *ExecutorTestSuite) TestNewConnectionDefaultQueryTimeoutOption() {
+	auth := core.KVMap{NEO4J_USER_KEY: "neo4j", NEO4J_PWD_KEY: "pwd"}
+	conn, err := NewConnection("neo4j", "localhost", "", nil, auth, core.KVMap{core.DEFAULT_QUERY_TIMEOUT_KEY: 30 * time.Second})
+	suite.NoError(err)
+	suite.Equal(30*time.Second, conn.(*Neo4jConnection).queryTimeout)
+
+	_, err = NewConnection("neo4j", "localhost", "", nil, auth, core.KVMap{core.DEFAULT_QUERY_TIMEOUT_KEY: 30})
+	suite.ErrorContains(err, "value of the DEFAULT_QUERY_TIMEOUT_KEY option must be a positive time.Duration")
+}
+
+func (suite *ExecutorTestSuite) TestQueryTimeout() {
+	driver := newMockDriver()
+	neo := Neo4jConnection{driver: driver}
+	_, err := neo.ExecuteQuery(context.Background(), "MATCH (v) RETURN v", core.Read, nil)
+	suite.NoError(err)
+
+	neo.queryTimeout = 30 * time.Second
+	_, err = neo.ExecuteQuery(context.Background(), "MATCH (v) RETURN v", core.Read, nil)
+	suite.NoError(err)
+
+	// a timeout specified within the context overrides the default of the connection
+	ctx := core.WithQueryTimeout(context.Background(), 200*time.Millisecond)
+	_, err = neo.ExecuteQuery(ctx, "CREATE (v) RETURN v", core.Write, nil)
+	suite.NoError(err)
+	err = neo.QueryVertexFunc(ctx, "Person", nil, nil, func(v *core.Vertex) error { return nil })
+	suite.NoError(err)
+
+	suite.Equal([]time.Duration{defaultTimeout, 30 * time.Second, 200 * time.Millisecond, 200 * time.Millisecond}, driver.session.timeouts)
+}
+
+func (suite *ExecutorTestSuite) TestNewConnectionErrorIdentifiesTarget() {
+	port := int32(7688)
+	auth := core.KVMap{NEO4J_USER_KEY: "neo4j", NEO4J_PWD_KEY: "secret"}
+	_, err := NewConnection("ftp", "graphhost", "", &port, auth, nil)
+	suite.ErrorContains(err, "failed to create neo4j connection to ftp://graphhost:7688: ")
+	suite.NotContains(err.Error(), "secret")
+
+	_, err = NewConnection("neo4j", "graphhost", "", nil, core.KVMap{}, nil)
+	suite.EqualError(err, "failed to create neo4j connection to neo4j://graphhost: specify a valid NEO4J_USER_KEY and NEO4J_PWD_KEY or a NEO4J_AUTH_TOKEN_KEY")
+}
+
+func (suite *ExecutorTestSuite) TestQuerySubgraph() {
+	tintin := neo4j.Node{ElementId: "4:abc:1", Labels: []string{"Person"}, Props: map[string]any{"name": "Tintin"}}
+	snowy := neo4j.Node{ElementId: "4:abc:2", Labels: []string{"Dog"}, Props: map[string]any{"name": "Snowy"}}
+	owns := neo4j.Relationship{ElementId: "5:abc:3", StartElementId: "4:abc:1", EndElementId: "4:abc:2", Type: "OWNS"}
+	path := neo4j.Path{Nodes: []neo4j.Node{tintin, snowy}, Relationships: []neo4j.Relationship{owns}}
+	driver := newMockDriver(
+		&neo4j.Record{Keys: []string{"p", "v"}, Values: []any{path, tintin}},
+		&neo4j.Record{Keys: []string{"p", "v"}, Values: []any{path, []any{snowy, "ignored"}}},
+	)
+	neo := Neo4jConnection{driver: driver}
+	subgraph, err := neo.QuerySubgraph(context.Background(), "MATCH p = (v)-[]->() RETURN p, v", nil)
+	suite.NoError(err)
+	suite.Equal(2, len(subgraph.Vertices))
+	suite.Equal(1, len(subgraph.Edges))
+	suite.Equal("OWNS", subgraph.Edges[0].Type)
+	suite.Same(subgraph.Vertices[0], subgraph.Edges[0].SourceVertex)
+	suite.Same(subgraph.Vertices[1], subgraph.Edges[0].DestinationVertex)
+}
+
+func (suite *ExecutorTestSuite) TestStoreVertexWriteModeCreate() {
+	node := neo4j.Node{ElementId: "4:abc:1", Labels: []string{"Person"}, Props: map[string]any{"name": "Tintin"}}
+	driver := newMockDriver(&neo4j.Record{Keys: []string{"sv"}, Values: []any{node}})
+	neo := Neo4jConnection{driver: driver}
+	ctx := context.WithValue(context.Background(), ContextKeyWriteModeCreate, true)
+	vertex := core.Vertex{Labels: []string{"Person"}, Properties: core.KVMap{"name": "Tintin"}}
+	suite.NoError(neo.StoreVertex(ctx, &vertex))
+	suite.Equal(core.NewId("4:abc:1"), vertex.ID)
+	suite.True(strings.HasPrefix(driver.session.queries[0], "CREATE (sv:Person"))
+}
+
+func (suite *ExecutorTestSuite) TestStoreVertexWriteModeMergeByDefault() {
+	node := neo4j.Node{ElementId: "4:abc:1", Labels: []string{"Person"}, Props: map[string]any{"name": "Tintin"}}
+	driver := newMockDriver(&neo4j.Record{Keys: []string{"sv"}, Values: []any{node}})
+	neo := Neo4jConnection{driver: driver}
+	vertex := core.Vertex{Labels: []string{"Person"}, Properties: core.KVMap{"name": "Tintin"}}
+	suite.NoError(neo.StoreVertex(context.Background(), &vertex))
+	suite.True(strings.HasPrefix(driver.session.queries[0], "MERGE (sv:Person"))
+}
+
+func (suite *ExecutorTestSuite) TestStoreVertexIgnoresPartitionKey() {
+	node := neo4j.Node{ElementId: "4:abc:1", Labels: []string{"Person"}, Props: map[string]any{"name": "Tintin"}}
+	driver := newMockDriver(&neo4j.Record{Keys: []string{"sv"}, Values: []any{node}})
+	neo := Neo4jConnection{driver: driver}
+	ctx := core.WithPartitionKey(context.Background(), "tenant", "acme")
+	vertex := core.Vertex{Labels: []string{"Person"}, Properties: core.KVMap{"name": "Tintin"}}
+	suite.NoError(neo.StoreVertex(ctx, &vertex))
+	suite.NotContains(driver.session.queries[0], "tenant")
+	suite.Equal(core.KVMap{"name": "Tintin"}, vertex.Properties)
+}
+
+func (suite *ExecutorTestSuite) TestStoreVertexWithIdentityPropertiesRecordsOutcomes() {
+	node := neo4j.Node{ElementId: "4:abc:1", Labels: []string{"Person"}, Props: map[string]any{"name": "Tintin", "age": int64(17)}}
+	driver := newMockDriver(&neo4j.Record{Keys: []string{"sv"}, Values: []any{node}})
+	neo := Neo4jConnection{driver: driver}
+	ctx, writeOutcomes := core.WithWriteOutcomes(core.WithVertexIdentityProperties(context.Background(), "name"))
+	vertex := core.Vertex{Labels: []string{"Person"}, Properties: core.KVMap{"name": "Tintin", "age": 17}}
+
+	driver.session.summary = &mockSummary{counters: mockCounters{nodesCreated: 1, labelsAdded: 1, propertiesSet: 2}}
+	suite.NoError(neo.StoreVertex(ctx, &vertex))
+	// the vertex is merged on the identity properties while the remaining properties are set if they differ
+	suite.Equal("MERGE (sv:Person{name: $p0})  FOREACH (_ IN CASE WHEN NOT coalesce(sv.age = $p1, false) THEN [1] ELSE [] END | SET sv.age = $p1) return sv", driver.session.queries[0])
+	suite.Equal(map[string]any{"p0": "Tintin", "p1": 17}, driver.session.params[0])
+
+	driver.session.summary = &mockSummary{counters: mockCounters{propertiesSet: 1}}
+	suite.NoError(neo.StoreVertex(ctx, &vertex))
+	driver.session.summary = &mockSummary{}
+	suite.NoError(neo.StoreVertex(ctx, &vertex))
+	suite.Equal(core.WriteOutcomes{Created: 1, Updated: 1, Unchanged: 1}, *writeOutcomes)
+}
+
+func (suite *ExecutorTestSuite) TestStoreVertexWithIdentityPropertiesUsingApoc() {
+	node := neo4j.Node{ElementId: "4:abc:1", Labels: []string{"Person"}, Props: map[string]any{"name": "Tintin", "age": int64(17)}}
+	driver := newMockDriver(&neo4j.Record{Keys: []string{"sv"}, Values: []any{node}})
+	neo := Neo4jConnection{driver: driver, apoc: true}
+	ctx := core.WithVertexIdentityProperties(context.Background(), "name")
+	vertex := core.Vertex{Labels: []string{"Person"}, Properties: core.KVMap{"name": "Tintin", "age": 17}}
+	suite.NoError(neo.StoreVertex(ctx, &vertex))
+	suite.Equal([]string{apocMergeNodeQuery("", true)}, driver.session.queries)
+	suite.Equal(map[string]any{"__labels": []string{"Person"}, "__properties": map[string]any{"name": "Tintin"}, "__data": map[string]any{"age": 17}}, driver.session.params[0])
+}
+
+func (suite *ExecutorTestSuite) TestStoreEdgeWithIdempotencyKey() {
+	tintin := neo4j.Node{ElementId: "4:abc:1", Labels: []string{"Person"}}
+	snowy := neo4j.Node{ElementId: "4:abc:2", Labels: []string{"Dog"}}
+	owns := neo4j.Relationship{ElementId: "5:abc:3", StartElementId: "4:abc:1", EndElementId: "4:abc:2", Type: "OWNS"}
+	driver := newMockDriver(&neo4j.Record{Keys: []string{"sv", "rel", "ev"}, Values: []any{tintin, owns, snowy}})
+	neo := Neo4jConnection{driver: driver}
+	edge := core.Edge{
+		Type:              "OWNS",
+		SourceVertex:      &core.Vertex{Labels: []string{"Person"}, Properties: core.KVMap{"name": "Tintin"}},
+		DestinationVertex: &core.Vertex{Labels: []string{"Dog"}, Properties: core.KVMap{"name": "Snowy"}},
+		Properties:        core.KVMap{"since": 1929},
+	}
+	ctx := core.WithIdempotencyKey(context.Background(), "request-1")
+	suite.NoError(neo.StoreEdge(ctx, &edge))
+	suite.Equal(core.NewId("5:abc:3"), edge.ID)
+	suite.Equal([]string{"MERGE (sv:Person{name: $p0})-[rel:OWNS{idempotencyKey: $p2}]->(ev:Dog{name: $p1})  ON CREATE SET rel.since = $p3 return sv, rel, ev"}, driver.session.queries)
+	suite.Equal([]map[string]any{{"p0": "Tintin", "p1": "Snowy", "p2": "request-1", "p3": 1929}}, driver.session.params)
+}
+
+func (suite *ExecutorTestSuite) TestStoreEdgeWithIdentityProperties() {
+	tintin := neo4j.Node{ElementId: "4:abc:1", Labels: []string{"Person"}}
+	snowy := neo4j.Node{ElementId: "4:abc:2", Labels: []string{"Dog"}}
+	owns := neo4j.Relationship{ElementId: "5:abc:3", StartElementId: "4:abc:1", EndElementId: "4:abc:2", Type: "OWNS"}
+	driver := newMockDriver(&neo4j.Record{Keys: []string{"sv", "rel", "ev"}, Values: []any{tintin, owns, snowy}})
+	neo := Neo4jConnection{driver: driver}
+	edge := core.Edge{
+		Type:              "OWNS",
+		SourceVertex:      &core.Vertex{Labels: []string{"Person"}, Properties: core.KVMap{"name": "Tintin"}},
+		DestinationVertex: &core.Vertex{Labels: []string{"Dog"}, Properties: core.KVMap{"name": "Snowy"}},
+		Properties:        core.KVMap{"since": 1929, "weight": 0.5},
+	}
+	ctx := core.WithEdgeIdentityProperties(context.Background(), "since")
+	suite.NoError(neo.StoreEdge(ctx, &edge))
+	suite.Equal(core.NewId("5:abc:3"), edge.ID)
+	suite.Equal([]string{"MERGE (sv:Person{name: $p0})-[rel:OWNS{since: $p2}]->(ev:Dog{name: $p1})  SET rel.weight = $p3 return sv, rel, ev"}, driver.session.queries)
+	suite.Equal([]map[string]any{{"p0": "Tintin", "p1": "Snowy", "p2": 1929, "p3": 0.5}}, driver.session.params)
+}
+
+func (suite *ExecutorTestSuite) TestDeleteVertex() {
+	driver := newMockDriver(&neo4j.Record{Keys: []string{"deleted"}, Values: []any{int64(2)}})
+	neo := Neo4jConnection{driver: driver}
+	deleted, err := neo.DeleteVertex(context.Background(), "Person", core.KVMap{"name": "Tintin"})
+	suite.NoError(err)
+	suite.Equal(2, deleted)
+	suite.Equal([]string{"MATCH (v:Person{name: $p0}) DETACH DELETE v RETURN count(v) AS deleted"}, driver.session.queries)
+	suite.Equal([]map[string]any{{"p0": "Tintin"}}, driver.session.params)
+}
+
+func (suite *ExecutorTestSuite) TestDeleteVertexWithEmptySelector() {
+	driver := newMockDriver(&neo4j.Record{Keys: []string{"deleted"}, Values: []any{int64(3)}})
+	neo := Neo4jConnection{driver: driver}
+	_, err := neo.DeleteVertex(context.Background(), "Person", core.KVMap{})
+	suite.ErrorIs(err, core.ErrEmptySelector)
+	suite.Empty(driver.session.queries)
+
+	deleted, err := neo.DeleteVertex(core.WithAllowEmptySelector(context.Background()), "Person", nil)
+	suite.NoError(err)
+	suite.Equal(3, deleted)
+	suite.Equal([]string{"MATCH (v:Person) DETACH DELETE v RETURN count(v) AS deleted"}, driver.session.queries)
+}
+
+func (suite *ExecutorTestSuite) TestDeleteVertexCollectsDeletedIds() {
+	driver := newMockDriver(
+		&neo4j.Record{Keys: []string{"vertexId", "edgeId"}, Values: []any{"4:abc:1", "5:abc:3"}},
+		&neo4j.Record{Keys: []string{"vertexId", "edgeId"}, Values: []any{"4:abc:2", "5:abc:3"}},
+		&neo4j.Record{Keys: []string{"vertexId", "edgeId"}, Values: []any{"4:abc:2", "5:abc:4"}},
+		&neo4j.Record{Keys: []string{"vertexId", "edgeId"}, Values: []any{"4:abc:5", nil}},
+	)
+	neo := Neo4jConnection{driver: driver}
+	ctx, deletedIds := core.WithDeletedIds(context.Background())
+	deleted, err := neo.DeleteVertex(ctx, "Person", core.KVMap{"name": "Tintin"})
+	suite.NoError(err)
+	suite.Equal(3, deleted)
+	suite.Equal([]*core.Identifier{core.NewId("4:abc:1"), core.NewId("5:abc:3"), core.NewId("4:abc:2"), core.NewId("5:abc:4"), core.NewId("4:abc:5")}, deletedIds.IDs)
+	suite.Equal([]string{"MATCH (v:Person{name: $p0}) OPTIONAL MATCH (v)-[r]-() WITH v, elementId(v) AS vertexId, elementId(r) AS edgeId DETACH DELETE v RETURN vertexId, edgeId"}, driver.session.queries)
+}
+
+func (suite *ExecutorTestSuite) TestLabelCombinations() {
+	driver := newMockDriver(
+		&neo4j.Record{Keys: []string{"labels"}, Values: []any{[]any{"Person"}}},
+		&neo4j.Record{Keys: []string{"labels"}, Values: []any{[]any{"Person", "Employee"}}},
+		&neo4j.Record{Keys: []string{"labels"}, Values: []any{[]any{"Employee", "Person"}}},
+	)
+	neo := Neo4jConnection{driver: driver, labelCase: core.LabelCaseLower}
+	combinations, err := neo.LabelCombinations(context.Background())
+	suite.NoError(err)
+	suite.Equal([][]string{{"person"}, {"employee", "person"}}, combinations)
+	suite.Equal([]string{"MATCH (n) RETURN DISTINCT labels(n) AS labels"}, driver.session.queries)
+}
+
+func (suite *ExecutorTestSuite) TestDeleteEdge() {
+	driver := newMockDriver(&neo4j.Record{Keys: []string{"deleted"}, Values: []any{int64(1)}})
+	neo := Neo4jConnection{driver: driver}
+	deleted, err := neo.DeleteEdge(context.Background(), []string{"Person"}, []string{"Dog"}, "OWNS", core.KVMap{"name": "Tintin"}, nil, nil)
+	suite.NoError(err)
+	suite.Equal(1, deleted)
+	suite.Equal([]string{"MATCH (sv:Person{name: $p0})-[r:OWNS]->(ev:Dog)  DELETE r RETURN count(r) AS deleted"}, driver.session.queries)
+	suite.Equal([]map[string]any{{"p0": "Tintin"}}, driver.session.params)
+
+	_, err = neo.DeleteEdge(context.Background(), []string{"Person"}, []string{"Dog"}, "OWNS", nil, core.KVMap{}, nil)
+	suite.ErrorIs(err, core.ErrEmptySelector)
+}
+
+func (suite *ExecutorTestSuite) TestDeleteEdgeCollectsDeletedIds() {
+	driver := newMockDriver(
+		&neo4j.Record{Keys: []string{"edgeId"}, Values: []any{"5:abc:3"}},
+		&neo4j.Record{Keys: []string{"edgeId"}, Values: []any{"5:abc:4"}},
+	)
+	neo := Neo4jConnection{driver: driver}
+	ctx, deletedIds := core.WithDeletedIds(core.WithAllowEmptySelector(context.Background()))
+	deleted, err := neo.DeleteEdge(ctx, nil, nil, "", nil, nil, nil)
+	suite.NoError(err)
+	suite.Equal(2, deleted)
+	suite.Equal([]*core.Identifier{core.NewId("5:abc:3"), core.NewId("5:abc:4")}, deletedIds.IDs)
+	suite.Equal([]string{"MATCH (sv)-[r]->(ev)  WITH r, elementId(r) AS edgeId DELETE r RETURN edgeId"}, driver.session.queries)
+}
+
+func (suite *ExecutorTestSuite) TestRowToVertexAndEdge() {
+	tintin := neo4j.Node{ElementId: "4:abc:1", Labels: []string{"Person"}, Props: map[string]any{"name": "Tintin"}}
+	owns := neo4j.Relationship{ElementId: "5:abc:3", StartElementId: "4:abc:1", EndElementId: "4:abc:2", Type: "OWNS", Props: map[string]any{"since": int64(1929)}}
+	driver := newMockDriver(&neo4j.Record{Keys: []string{"p", "r"}, Values: []any{tintin, owns}})
+	neo := Neo4jConnection{driver: driver}
+	result, err := neo.ExecuteQuery(context.Background(), "MATCH (p:Person)-[r]->() RETURN p, r", core.Read, nil)
+	suite.NoError(err)
+
+	vertex, err := core.RowToVertex(result.Rows[0], "p", neo.ConvertValue)
+	suite.NoError(err)
+	suite.Equal(core.NewId("4:abc:1"), vertex.ID)
+	suite.Equal([]string{"Person"}, vertex.Labels)
+	suite.Equal(core.KVMap{"name": "Tintin"}, vertex.Properties)
+
+	edge, err := core.RowToEdge(result.Rows[0], "r", neo.ConvertValue)
+	suite.NoError(err)
+	suite.Equal(core.NewId("5:abc:3"), edge.ID)
+	suite.Equal("OWNS", edge.Type)
+	suite.Equal(core.NewId("4:abc:1"), edge.SourceVertexID)
+	suite.Equal(core.NewId("4:abc:2"), edge.DestinationVertexID)
+	suite.Equal(core.KVMap{"since": int64(1929)}, edge.Properties)
+
+	_, err = core.RowToEdge(result.Rows[0], "p", neo.ConvertValue)
+	suite.EqualError(err, "value of the column p is not an edge")
+}
+
+func (suite *ExecutorTestSuite) TestDegree() {
+	driver := newMockDriver(&neo4j.Record{Keys: []string{"degree"}, Values: []any{int64(3)}})
+	neo := Neo4jConnection{driver: driver}
+	degree, err := neo.Degree(context.Background(), core.NewId("4:abc:1"), core.DirectionOutgoing, []string{"KNOWS"})
+	suite.NoError(err)
+	suite.Equal(int64(3), degree)
+	suite.Equal([]string{"MATCH (v)-[r:KNOWS]->() WHERE elementId(v) = $id RETURN count(r) AS degree"}, driver.session.queries)
+}
+
+func (suite *ExecutorTestSuite) TestExpandNeighbors() {
+	relationship := neo4j.Relationship{ElementId: "5:abc:7", StartElementId: "4:abc:1", EndElementId: "4:abc:2", Type: "KNOWS"}
+	neighbor := neo4j.Node{ElementId: "4:abc:2", Labels: []string{"Person"}, Props: map[string]any{"name": "Haddock"}}
+	driver := newMockDriver(&neo4j.Record{Keys: []string{"id", "r", "n"}, Values: []any{"4:abc:1", relationship, neighbor}})
+	neo := Neo4jConnection{driver: driver}
+	neighbors, err := neo.ExpandNeighbors(context.Background(), []*core.Identifier{core.NewId("4:abc:1"), core.NewId("4:abc:3")}, []string{"KNOWS"}, core.DirectionOutgoing)
+	suite.NoError(err)
+	suite.Equal([]string{"UNWIND $ids AS id MATCH (v)-[r:KNOWS]->(n) WHERE elementId(v) = id RETURN id, r, n"}, driver.session.queries)
+	suite.Equal([]any{"4:abc:1", "4:abc:3"}, driver.session.params[0]["ids"])
+	suite.Equal(2, len(neighbors))
+	suite.Empty(neighbors["4:abc:3"])
+	suite.Equal(1, len(neighbors["4:abc:1"]))
+	edge := neighbors["4:abc:1"][0]
+	suite.Equal(core.NewId("5:abc:7"), edge.ID)
+	suite.Nil(edge.SourceVertex)
+	suite.Equal(core.KVMap{"name": "Haddock"}, edge.DestinationVertex.Properties)
+}
+
+func (suite *ExecutorTestSuite) TestExpandNeighborsWithoutIds() {
+	driver := newMockDriver()
+	neo := Neo4jConnection{driver: driver}
+	neighbors, err := neo.ExpandNeighbors(context.Background(), nil, nil, core.DirectionBoth)
+	suite.NoError(err)
+	suite.Empty(neighbors)
+	suite.Empty(driver.session.queries)
+
+	_, err = neo.ExpandNeighbors(context.Background(), []*core.Identifier{nil}, nil, core.DirectionBoth)
+	suite.EqualError(err, "vertex id must be specified")
+}
+
+func (suite *ExecutorTestSuite) TestBeginTxRunsQueriesWithinExplicitTransaction() {
+	driver := newMockDriver(&neo4j.Record{Keys: []string{"sv"}, Values: []any{neo4j.Node{ElementId: "4:abc:1"}}})
+	neo := &Neo4jConnection{driver: driver}
+	tx, err := neo.BeginTx(context.Background())
+	suite.NoError(err)
+	_, err = tx.ExecuteQuery(context.Background(), "MATCH (sv) SET sv.visited = true RETURN sv", core.Write, nil)
+	suite.NoError(err)
+	suite.NoError(tx.StoreVertex(context.Background(), &core.Vertex{Labels: []string{"Person"}, Properties: core.KVMap{"name": "Tintin"}}))
+	suite.NoError(tx.Commit(context.Background()))
+
+	// the queries are run through the explicit transaction instead of managed transactions
+	suite.Equal(2, len(driver.session.queries))
+	suite.Empty(driver.session.transactions)
+	suite.True(driver.session.committed)
+	suite.False(driver.session.rolledBack)
+	suite.True(driver.session.closed)
+	suite.Equal(neo4j.AccessModeWrite, driver.sessionConfigs[0].AccessMode)
+}
+
+func (suite *ExecutorTestSuite) TestBeginTxRollback() {
+	driver := newMockDriver()
+	neo := &Neo4jConnection{driver: driver}
+	tx, err := neo.BeginTx(context.Background())
+	suite.NoError(err)
+	_, err = tx.ExecuteQuery(context.Background(), "CREATE (v:Person)", core.Write, nil)
+	suite.NoError(err)
+	suite.NoError(tx.Rollback(context.Background()))
+	suite.True(driver.session.rolledBack)
+	suite.False(driver.session.committed)
+	suite.True(driver.session.closed)
+
+	_, err = tx.BeginTx(context.Background())
+	suite.EqualError(err, "nested transactions are not supported")
+}
+
+func (suite *ExecutorTestSuite) TestExecuteQueryWithinTransactionReportsStreamErrors() {
+	driver := newMockDriver(&neo4j.Record{Keys: []string{"n"}, Values: []any{int64(1)}})
+	neo := &Neo4jConnection{driver: driver}
+	tx, err := neo.BeginTx(context.Background())
+	suite.NoError(err)
+
+	// the stream fails after the first record, hence the partial result is not returned
+	driver.session.streamErr = errors.New("connection reset")
+	driver.session.consumeErr = errors.New("result failed")
+	result, err := tx.ExecuteQuery(context.Background(), "UNWIND [1, 2] AS n RETURN n", core.Read, nil)
+	suite.EqualError(err, "connection reset")
+	suite.Nil(result)
+
+	driver.session.streamErr = nil
+	result, err = tx.ExecuteQuery(context.Background(), "UNWIND [1, 2] AS n RETURN n", core.Read, nil)
+	suite.EqualError(err, "result failed")
+	suite.Nil(result)
+
+	driver.session.consumeErr = nil
+	result, err = tx.ExecuteQuery(context.Background(), "UNWIND [1, 2] AS n RETURN n", core.Read, nil)
+	suite.NoError(err)
+	suite.Equal([]core.Row{{"n": int64(1)}}, result.Rows)
+	suite.NotNil(result.Summary)
+	suite.NoError(tx.Rollback(context.Background()))
+}
+
+func (suite *ExecutorTestSuite) TestPing() {
+	driver := newMockDriver()
+	neo := Neo4jConnection{driver: driver}
+	suite.NoError(neo.Ping(context.Background()))
+
+	driver.connectivityErr = errors.New("connection refused")
+	err := neo.Ping(context.Background())
+	suite.Same(driver.connectivityErr, err)
+}
+
+func (suite *ExecutorTestSuite) TestStoreVerticesBatchesByLabels() {
+	driver := newMockDriver(
+		&neo4j.Record{Keys: []string{"v"}, Values: []any{neo4j.Node{ElementId: "4:abc:1"}}},
+		&neo4j.Record{Keys: []string{"v"}, Values: []any{neo4j.Node{ElementId: "4:abc:2"}}},
+	)
+	neo := &Neo4jConnection{driver: driver}
+	vertices := []*core.Vertex{
+		{Labels: []string{"Person"}, Properties: core.KVMap{"name": "Tintin"}},
+		{Labels: []string{"Person", "Employee"}, Properties: core.KVMap{"name": "Haddock"}},
+		{Labels: []string{"Person"}, Properties: core.KVMap{"name": "Calculus"}},
+		{Labels: []string{"Employee", "Person"}, Properties: core.KVMap{"name": "Nestor"}},
+	}
+	suite.Nil(neo.StoreVertices(context.Background(), vertices))
+	suite.Equal([]string{
+		"UNWIND $rows AS row CREATE (v:Person) SET v = row RETURN v",
+		"UNWIND $rows AS row CREATE (v:Person:Employee) SET v = row RETURN v",
+	}, driver.session.queries)
+	suite.Equal([]any{map[string]any{"name": "Tintin"}, map[string]any{"name": "Calculus"}}, driver.session.params[0]["rows"])
+	suite.Equal([]any{map[string]any{"name": "Haddock"}, map[string]any{"name": "Nestor"}}, driver.session.params[1]["rows"])
+	suite.True(driver.session.committed)
+	suite.Equal(core.NewId("4:abc:1"), vertices[0].ID)
+	suite.Equal(core.NewId("4:abc:2"), vertices[2].ID)
+	suite.Equal(core.NewId("4:abc:1"), vertices[1].ID)
+	suite.Equal(core.NewId("4:abc:2"), vertices[3].ID)
+}
+
+func (suite *ExecutorTestSuite) TestStoreVerticesRollsBackOnFailure() {
+	driver := newMockDriver(&neo4j.Record{Keys: []string{"v"}, Values: []any{neo4j.Node{ElementId: "4:abc:1"}}})
+	neo := &Neo4jConnection{driver: driver}
+	vertices := []*core.Vertex{
+		{Labels: []string{"Person"}, Properties: core.KVMap{"name": "Tintin"}},
+		{Labels: []string{"Employee"}, Properties: core.KVMap{"name": "Haddock"}},
+		{Labels: []string{"Employee"}, Properties: core.KVMap{"name": "Nestor"}},
+	}
+	err := neo.StoreVertices(context.Background(), vertices)
+	suite.EqualError(err, "unexpected number of vertices created. expected 2 but 1 were created")
+	suite.True(driver.session.rolledBack)
+	suite.False(driver.session.committed)
+	for _, vertex := range vertices {
+		suite.Nil(vertex.ID)
+	}
+}
+
+func TestExecutorTestSuite(t *testing.T) {
+	suite.Run(t, new(ExecutorTestSuite))
+}
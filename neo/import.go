@@ -0,0 +1,108 @@
+package neo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/prahaladd/gograph/core"
+)
+
+// DefaultImportBatchSize is the number of rows committed per transaction by Import when no batch size is specified
+const DefaultImportBatchSize = 1000
+
+// Import runs the specified query once for every one of the specified rows, committing after every batchSize rows.
+// The query refers to the row being imported using the variable row, for e.g.
+//
+//	MERGE (p:Person{name: row.name}) SET p.age = row.age
+//
+// and must not return any results. A batchSize of 0 uses DefaultImportBatchSize.
+//
+// Against Neo4j 4.4 and later the import is performed as a single CALL { ... } IN TRANSACTIONS OF batchSize ROWS
+// query. Older servers do not support the syntax and the rows are imported in batches of batchSize rows, each within
+// its own transaction. In both cases a failure leaves the batches committed till then in place.
+//
+// Import cannot be performed within an explicit transaction.
+func (neo *Neo4jConnection) Import(ctx context.Context, query string, rows []core.KVMap, batchSize int) error {
+	if neo.tx != nil {
+		return errors.New("import cannot be performed within an explicit transaction")
+	}
+	if batchSize < 0 {
+		return fmt.Errorf("invalid import batch size %d", batchSize)
+	}
+	if batchSize == 0 {
+		batchSize = DefaultImportBatchSize
+	}
+	if len(rows) == 0 {
+		return nil
+	}
+	serverInfo, err := neo.driver.GetServerInfo(ctx)
+	if err != nil {
+		return err
+	}
+	if supportsCallInTransactions(serverInfo.Agent()) {
+		return neo.importInTransactions(ctx, query, rows, batchSize)
+	}
+	return neo.importInBatches(ctx, query, rows, batchSize)
+}
+
+// importInTransactions imports the rows using a CALL { ... } IN TRANSACTIONS query. Such queries can only be run in
+// an auto-commit transaction and hence are run directly on the session.
+func (neo *Neo4jConnection) importInTransactions(ctx context.Context, query string, rows []core.KVMap, batchSize int) error {
+	session := neo.driver.NewSession(ctx, neo.sessionConfig(ctx, core.Write))
+	defer session.Close(ctx)
+	importQuery := fmt.Sprintf("UNWIND $rows AS row CALL { WITH row %s } IN TRANSACTIONS OF %d ROWS", query, batchSize)
+	result, err := session.Run(ctx, importQuery, toDriverParams(map[string]any{"rows": importRows(rows)}))
+	if err != nil {
+		return err
+	}
+	_, err = result.Consume(ctx)
+	return err
+}
+
+func (neo *Neo4jConnection) importInBatches(ctx context.Context, query string, rows []core.KVMap, batchSize int) error {
+	importQuery := "UNWIND $rows AS row " + query
+	for start := 0; start < len(rows); start += batchSize {
+		end := start + batchSize
+		if end > len(rows) {
+			end = len(rows)
+		}
+		_, err := neo.ExecuteQuery(ctx, importQuery, core.Write, map[string]any{"rows": importRows(rows[start:end])})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// importRows converts the rows to plain maps that can be passed as query parameters to the driver
+func importRows(rows []core.KVMap) []any {
+	converted := make([]any, 0, len(rows))
+	for _, row := range rows {
+		converted = append(converted, map[string]any(row))
+	}
+	return converted
+}
+
+// supportsCallInTransactions reports whether the Neo4j server identified by the specified agent string
+// (for e.g. Neo4j/4.4.5) supports CALL { ... } IN TRANSACTIONS, which was introduced in Neo4j 4.4.
+func supportsCallInTransactions(agent string) bool {
+	version := strings.TrimPrefix(strings.ToLower(agent), "neo4j/")
+	// Aura servers report versions such as 5.3-aura
+	version = strings.SplitN(version, "-", 2)[0]
+	components := strings.Split(version, ".")
+	if len(components) < 2 {
+		return false
+	}
+	major, err := strconv.Atoi(components[0])
+	if err != nil {
+		return false
+	}
+	minor, err := strconv.Atoi(components[1])
+	if err != nil {
+		return false
+	}
+	return major > 4 || (major == 4 && minor >= 4)
+}
@@ -0,0 +1,31 @@
+package neo
+
+import (
+	"testing"
+
+	"github.com/prahaladd/gograph/core"
+	"github.com/stretchr/testify/suite"
+)
+
+type ImportTestSuite struct {
+	suite.Suite
+}
+
+func (suite *ImportTestSuite) TestSupportsCallInTransactions() {
+	suite.True(supportsCallInTransactions("Neo4j/4.4.5"))
+	suite.True(supportsCallInTransactions("Neo4j/5.3.0"))
+	suite.True(supportsCallInTransactions("Neo4j/5.3-aura"))
+	suite.False(supportsCallInTransactions("Neo4j/4.3.10"))
+	suite.False(supportsCallInTransactions("Neo4j/3.5.0"))
+	suite.False(supportsCallInTransactions("Neo4j"))
+	suite.False(supportsCallInTransactions(""))
+}
+
+func (suite *ImportTestSuite) TestImportRows() {
+	rows := importRows([]core.KVMap{{"name": "Tom"}, {"name": "Jerry"}})
+	suite.Equal([]any{map[string]any{"name": "Tom"}, map[string]any{"name": "Jerry"}}, rows)
+}
+
+func TestImportTestSuite(t *testing.T) {
+	suite.Run(t, new(ImportTestSuite))
+}
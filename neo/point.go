@@ -0,0 +1,67 @@
+package neo
+
+import (
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+	"github.com/prahaladd/gograph/core"
+)
+
+// toDriverParams returns a copy of the query parameters in which the core.Point values are converted to the
+// neo4j.Point2D values sent by the driver. Points nested within maps and lists, for e.g. the properties of the rows
+// of a batch, are converted as well.
+func toDriverParams(params map[string]any) map[string]any {
+	if params == nil {
+		return nil
+	}
+	converted := make(map[string]any, len(params))
+	for k, v := range params {
+		converted[k] = toDriverValue(v)
+	}
+	return converted
+}
+
+func toDriverValue(value any) any {
+	switch v := value.(type) {
+	case core.Point:
+		return neo4j.Point2D{SpatialRefId: v.SRID, X: v.X, Y: v.Y}
+	case *core.Point:
+		if v == nil {
+			return nil
+		}
+		return toDriverValue(*v)
+	case []core.Point:
+		points := make([]any, 0, len(v))
+		for _, point := range v {
+			points = append(points, toDriverValue(point))
+		}
+		return points
+	case map[string]any:
+		return toDriverParams(v)
+	case core.KVMap:
+		return toDriverParams(v)
+	case []any:
+		list := make([]any, 0, len(v))
+		for _, elem := range v {
+			list = append(list, toDriverValue(elem))
+		}
+		return list
+	default:
+		return value
+	}
+}
+
+// fromDriverValue converts the neo4j.Point2D property values returned by the driver, including the points within
+// lists, to core.Point values. Other values are returned as is.
+func fromDriverValue(value any) any {
+	switch v := value.(type) {
+	case neo4j.Point2D:
+		return core.Point{SRID: v.SpatialRefId, X: v.X, Y: v.Y}
+	case []any:
+		list := make([]any, 0, len(v))
+		for _, elem := range v {
+			list = append(list, fromDriverValue(elem))
+		}
+		return list
+	default:
+		return value
+	}
+}
@@ -0,0 +1,124 @@
+package neptune
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+	"github.com/prahaladd/gograph/core"
+	"github.com/prahaladd/gograph/neo"
+)
+
+const (
+	// NEPTUNE_REGION_KEY is the auth key specifying the AWS region of the Neptune cluster, for e.g. us-east-1
+	NEPTUNE_REGION_KEY = "region"
+	// NEPTUNE_ACCESS_KEY_ID_KEY is the auth key specifying the AWS access key id used to sign the connection requests
+	NEPTUNE_ACCESS_KEY_ID_KEY = "accessKeyId"
+	// NEPTUNE_SECRET_ACCESS_KEY_KEY is the auth key specifying the AWS secret access key used to sign the connection
+	// requests
+	NEPTUNE_SECRET_ACCESS_KEY_KEY = "secretAccessKey"
+	// NEPTUNE_SESSION_TOKEN_KEY is the auth key specifying the session token of temporary AWS credentials
+	NEPTUNE_SESSION_TOKEN_KEY = "sessionToken"
+)
+
+const (
+	// neptuneService is the name of the service against which the connection requests are signed
+	neptuneService = "neptune-db"
+	// defaultPort is the port on which Neptune listens for Bolt connections
+	defaultPort = int32(8182)
+	// neptuneUser is the user name sent along with the signed headers. Neptune identifies the caller using the
+	// signature and hence ignores the user name.
+	neptuneUser = "username"
+)
+
+// newNeoConnection creates the Bolt connection to the Neptune cluster. It is replaced when unit testing the auth
+// token passed to the connection.
+var newNeoConnection = neo.NewConnection
+
+// clock provides the time at which the connection requests are signed
+var clock core.Clock = core.SystemClock
+
+func init() {
+	core.RegisterConnectorFactory("neptune", NewConnection)
+}
+
+// NewConnection returns a new connection to the specified Amazon Neptune cluster over the Bolt protocol. Neptune
+// requires TLS, hence the protocol is typically bolt+s. The port defaults to 8182 if not specified.
+//
+// Clusters with IAM database authentication enabled require the connection to be authenticated using a request
+// signed with AWS Signature Version 4. The auth map must then contain:
+//
+// - NEPTUNE_REGION_KEY key with the AWS region of the cluster
+// - NEPTUNE_ACCESS_KEY_ID_KEY and NEPTUNE_SECRET_ACCESS_KEY_KEY keys with the AWS credentials
+// - NEPTUNE_SESSION_TOKEN_KEY key with the session token if the credentials are temporary
+//
+// The signed headers are passed to the neo connection as an auth token using neo.NEO4J_AUTH_TOKEN_KEY. Neptune only
+// accepts signatures created within the last 5 minutes, and the neo4j driver reuses the auth token for the
+// connections it opens later on. Hence, connections should be created close to their use and recreated when the
+// driver fails to authenticate new connections.
+//
+// Clusters without IAM database authentication do not verify the auth data, and the auth map is passed unchanged
+// to the neo connection if it does not contain the NEPTUNE_REGION_KEY key.
+//
+// All the other options are the same as the options of a Neo4j connection, see neo.NewConnection.
+func NewConnection(protocol, host, realm string, port *int32, auth, options map[string]interface{}) (core.Connection, error) {
+	if port == nil {
+		p := defaultPort
+		port = &p
+	}
+	if _, ok := auth[NEPTUNE_REGION_KEY]; !ok {
+		return newNeoConnection(protocol, host, realm, port, auth, options)
+	}
+	region, creds, err := credentialsFromAuth(auth)
+	if err != nil {
+		return nil, err
+	}
+	token, err := signedAuthToken(fmt.Sprintf("%s:%d", host, *port), region, creds)
+	if err != nil {
+		return nil, err
+	}
+	return newNeoConnection(protocol, host, realm, port, map[string]interface{}{neo.NEO4J_AUTH_TOKEN_KEY: token}, options)
+}
+
+// credentialsFromAuth reads the region and the AWS credentials from the specified auth map
+func credentialsFromAuth(auth map[string]interface{}) (string, credentials, error) {
+	keyNames := map[string]string{
+		NEPTUNE_REGION_KEY:            "NEPTUNE_REGION_KEY",
+		NEPTUNE_ACCESS_KEY_ID_KEY:     "NEPTUNE_ACCESS_KEY_ID_KEY",
+		NEPTUNE_SECRET_ACCESS_KEY_KEY: "NEPTUNE_SECRET_ACCESS_KEY_KEY",
+		NEPTUNE_SESSION_TOKEN_KEY:     "NEPTUNE_SESSION_TOKEN_KEY",
+	}
+	values := make(map[string]string)
+	for key, name := range keyNames {
+		value, ok := auth[key]
+		if !ok {
+			continue
+		}
+		if values[key], ok = value.(string); !ok {
+			return "", credentials{}, fmt.Errorf("value of the %s auth key must be a string", name)
+		}
+	}
+	if values[NEPTUNE_REGION_KEY] == "" || values[NEPTUNE_ACCESS_KEY_ID_KEY] == "" || values[NEPTUNE_SECRET_ACCESS_KEY_KEY] == "" {
+		return "", credentials{}, errors.New("specify a valid NEPTUNE_REGION_KEY, NEPTUNE_ACCESS_KEY_ID_KEY and NEPTUNE_SECRET_ACCESS_KEY_KEY")
+	}
+	creds := credentials{
+		accessKeyID:     values[NEPTUNE_ACCESS_KEY_ID_KEY],
+		secretAccessKey: values[NEPTUNE_SECRET_ACCESS_KEY_KEY],
+		sessionToken:    values[NEPTUNE_SESSION_TOKEN_KEY],
+	}
+	return values[NEPTUNE_REGION_KEY], creds, nil
+}
+
+// signedAuthToken returns the auth token authenticating a Bolt connection to the Neptune endpoint running on the
+// specified host and port. The credentials of the token are the JSON encoded headers of a signed request to the
+// openCypher HTTP endpoint, along with the HTTP method of the request.
+func signedAuthToken(hostPort, region string, creds credentials) (neo4j.AuthToken, error) {
+	headers := signRequest("GET", hostPort, "/opencypher", neptuneService, region, creds, clock.Now())
+	headers["HttpMethod"] = "GET"
+	encoded, err := json.Marshal(headers)
+	if err != nil {
+		return neo4j.AuthToken{}, err
+	}
+	return neo4j.BasicAuth(neptuneUser, string(encoded), ""), nil
+}
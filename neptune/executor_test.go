@@ -0,0 +1,88 @@
+package neptune
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+	"github.com/prahaladd/gograph/core"
+	"github.com/prahaladd/gograph/neo"
+	"github.com/stretchr/testify/suite"
+)
+
+type fixedClock struct {
+	now time.Time
+}
+
+func (fc fixedClock) Now() time.Time {
+	return fc.now
+}
+
+func (fc fixedClock) After(d time.Duration) <-chan time.Time {
+	return make(chan time.Time)
+}
+
+type ExecutorTestSuite struct {
+	suite.Suite
+	port    int32
+	auth    map[string]interface{}
+	restore func()
+}
+
+func (suite *ExecutorTestSuite) SetupTest() {
+	originalConnection, originalClock := newNeoConnection, clock
+	suite.restore = func() { newNeoConnection, clock = originalConnection, originalClock }
+	clock = fixedClock{now: time.Date(2023, 3, 1, 10, 0, 0, 0, time.UTC)}
+	newNeoConnection = func(protocol, host, realm string, port *int32, auth, options map[string]interface{}) (core.Connection, error) {
+		suite.port = *port
+		suite.auth = auth
+		return nil, nil
+	}
+}
+
+func (suite *ExecutorTestSuite) TearDownTest() {
+	suite.restore()
+}
+
+func (suite *ExecutorTestSuite) TestSignedAuthToken() {
+	auth := map[string]interface{}{
+		NEPTUNE_REGION_KEY:            "us-east-1",
+		NEPTUNE_ACCESS_KEY_ID_KEY:     "AKIDEXAMPLE",
+		NEPTUNE_SECRET_ACCESS_KEY_KEY: "wJalrXUtnFEMI/K7MDENG+bPxRfiCYEXAMPLEKEY",
+		NEPTUNE_SESSION_TOKEN_KEY:     "token",
+	}
+	_, err := NewConnection("bolt+s", "cluster.neptune.amazonaws.com", "", nil, auth, nil)
+	suite.NoError(err)
+	suite.Equal(int32(8182), suite.port)
+
+	expectedHeaders := signRequest("GET", "cluster.neptune.amazonaws.com:8182", "/opencypher", "neptune-db", "us-east-1",
+		credentials{accessKeyID: "AKIDEXAMPLE", secretAccessKey: "wJalrXUtnFEMI/K7MDENG+bPxRfiCYEXAMPLEKEY", sessionToken: "token"},
+		time.Date(2023, 3, 1, 10, 0, 0, 0, time.UTC))
+	suite.Equal("20230301T100000Z", expectedHeaders["X-Amz-Date"])
+	expectedHeaders["HttpMethod"] = "GET"
+	encoded, err := json.Marshal(expectedHeaders)
+	suite.NoError(err)
+	suite.Equal(map[string]interface{}{neo.NEO4J_AUTH_TOKEN_KEY: neo4j.BasicAuth("username", string(encoded), "")}, suite.auth)
+}
+
+func (suite *ExecutorTestSuite) TestInvalidCredentials() {
+	_, err := NewConnection("bolt+s", "cluster.neptune.amazonaws.com", "", nil, map[string]interface{}{NEPTUNE_REGION_KEY: "us-east-1"}, nil)
+	suite.EqualError(err, "specify a valid NEPTUNE_REGION_KEY, NEPTUNE_ACCESS_KEY_ID_KEY and NEPTUNE_SECRET_ACCESS_KEY_KEY")
+	_, err = NewConnection("bolt+s", "cluster.neptune.amazonaws.com", "", nil, map[string]interface{}{NEPTUNE_REGION_KEY: 1}, nil)
+	suite.EqualError(err, "value of the NEPTUNE_REGION_KEY auth key must be a string")
+	suite.Nil(suite.auth)
+}
+
+func (suite *ExecutorTestSuite) TestWithoutIAMAuth() {
+	port := int32(8183)
+	auth := map[string]interface{}{neo.NEO4J_USER_KEY: "user", neo.NEO4J_PWD_KEY: "pwd"}
+	_, err := NewConnection("bolt+s", "cluster.neptune.amazonaws.com", "", &port, auth, nil)
+	suite.NoError(err)
+	suite.Equal(int32(8183), suite.port)
+	suite.Equal(auth, suite.auth)
+}
+
+func TestExecutorTestSuite(t *testing.T) {
+	suite.Run(t, new(ExecutorTestSuite))
+}
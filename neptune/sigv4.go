@@ -0,0 +1,80 @@
+package neptune
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	sigV4Algorithm  = "AWS4-HMAC-SHA256"
+	sigV4TimeFormat = "20060102T150405Z"
+	sigV4DateFormat = "20060102"
+)
+
+// credentials are the AWS credentials used to sign the requests
+type credentials struct {
+	accessKeyID     string
+	secretAccessKey string
+	// sessionToken is only set for temporary credentials
+	sessionToken string
+}
+
+// signRequest signs a request without a query string or a body using AWS Signature Version 4 and returns the headers
+// to be sent along with the request, including the Host header. The path must already be URI encoded.
+func signRequest(method, host, path, service, region string, creds credentials, t time.Time) map[string]string {
+	t = t.UTC()
+	headers := map[string]string{
+		"host":       host,
+		"x-amz-date": t.Format(sigV4TimeFormat),
+	}
+	if creds.sessionToken != "" {
+		headers["x-amz-security-token"] = creds.sessionToken
+	}
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	canonicalHeaders := strings.Builder{}
+	for _, name := range names {
+		canonicalHeaders.WriteString(fmt.Sprintf("%s:%s\n", name, strings.TrimSpace(headers[name])))
+	}
+	signedHeaders := strings.Join(names, ";")
+	emptyPayloadHash := sha256Hex("")
+	canonicalRequest := strings.Join([]string{method, path, "", canonicalHeaders.String(), signedHeaders, emptyPayloadHash}, "\n")
+
+	scope := fmt.Sprintf("%s/%s/%s/aws4_request", t.Format(sigV4DateFormat), region, service)
+	stringToSign := strings.Join([]string{sigV4Algorithm, headers["x-amz-date"], scope, sha256Hex(canonicalRequest)}, "\n")
+
+	signingKey := hmacSHA256([]byte("AWS4"+creds.secretAccessKey), t.Format(sigV4DateFormat))
+	for _, part := range []string{region, service, "aws4_request"} {
+		signingKey = hmacSHA256(signingKey, part)
+	}
+	signature := hex.EncodeToString(hmacSHA256(signingKey, stringToSign))
+
+	signed := map[string]string{
+		"Host":          host,
+		"X-Amz-Date":    headers["x-amz-date"],
+		"Authorization": fmt.Sprintf("%s Credential=%s/%s, SignedHeaders=%s, Signature=%s", sigV4Algorithm, creds.accessKeyID, scope, signedHeaders, signature),
+	}
+	if creds.sessionToken != "" {
+		signed["X-Amz-Security-Token"] = creds.sessionToken
+	}
+	return signed
+}
+
+func sha256Hex(s string) string {
+	sum := sha256.Sum256([]byte(s))
+	return hex.EncodeToString(sum[:])
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
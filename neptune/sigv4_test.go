@@ -0,0 +1,34 @@
+package neptune
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type SigV4TestSuite struct {
+	suite.Suite
+}
+
+// TestSignRequest verifies the signature against the get-vanilla case of the AWS Signature Version 4 test suite
+func (suite *SigV4TestSuite) TestSignRequest() {
+	creds := credentials{accessKeyID: "AKIDEXAMPLE", secretAccessKey: "wJalrXUtnFEMI/K7MDENG+bPxRfiCYEXAMPLEKEY"}
+	headers := signRequest("GET", "example.amazonaws.com", "/", "service", "us-east-1", creds, time.Date(2015, 8, 30, 12, 36, 0, 0, time.UTC))
+	suite.Equal(map[string]string{
+		"Host":          "example.amazonaws.com",
+		"X-Amz-Date":    "20150830T123600Z",
+		"Authorization": "AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/20150830/us-east-1/service/aws4_request, SignedHeaders=host;x-amz-date, Signature=5fa00fa31553b73ebf1942676e86291e8372ff2a2260956d9b8aae1d763fbf31",
+	}, headers)
+}
+
+func (suite *SigV4TestSuite) TestSignRequestWithSessionToken() {
+	creds := credentials{accessKeyID: "AKIDEXAMPLE", secretAccessKey: "wJalrXUtnFEMI/K7MDENG+bPxRfiCYEXAMPLEKEY", sessionToken: "token"}
+	headers := signRequest("GET", "example.amazonaws.com", "/", "service", "us-east-1", creds, time.Date(2015, 8, 30, 12, 36, 0, 0, time.UTC))
+	suite.Equal("token", headers["X-Amz-Security-Token"])
+	suite.Contains(headers["Authorization"], "SignedHeaders=host;x-amz-date;x-amz-security-token,")
+}
+
+func TestSigV4TestSuite(t *testing.T) {
+	suite.Run(t, new(SigV4TestSuite))
+}
@@ -0,0 +1,185 @@
+package omg
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"github.com/prahaladd/gograph/core"
+)
+
+// adjacentField is a struct field mapped to the vertices adjacent to the vertex mapped from the struct. Such fields
+// are slices of structs or of pointers to structs, tagged with the label of the connecting edges using the edge option
+// of the ogm tag. For e.g.
+//
+//	LineItems []LineItem `ogm:",edge:CONTAINS"`
+type adjacentField struct {
+	// index is the index sequence of the field within the struct, which includes the indices of the embedded structs
+	// the field is promoted from
+	index []int
+	// edgeLabel is the label of the edges from the vertex to the adjacent vertices
+	edgeLabel string
+	// elemType is the struct type of the elements of the field
+	elemType reflect.Type
+}
+
+// adjacentFields returns the fields of the specified struct type mapped to adjacent vertices, including the fields
+// promoted from embedded structs
+func adjacentFields(t reflect.Type) ([]adjacentField, error) {
+	fields := make([]adjacentField, 0)
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		tag := parseOgmTag(field)
+		if embedded, ok := embeddedStruct(field); ok && tag.edge == "" && !tag.skip {
+			promoted, err := adjacentFields(embedded)
+			if err != nil {
+				return nil, err
+			}
+			for _, af := range promoted {
+				af.index = append([]int{i}, af.index...)
+				fields = append(fields, af)
+			}
+			continue
+		}
+		if tag.edge == "" {
+			continue
+		}
+		if field.Type.Kind() != reflect.Slice {
+			return nil, fmt.Errorf("field %s mapped to adjacent vertices must be a slice of structs", field.Name)
+		}
+		elemType := field.Type.Elem()
+		if elemType.Kind() == reflect.Pointer {
+			elemType = elemType.Elem()
+		}
+		if elemType.Kind() != reflect.Struct {
+			return nil, fmt.Errorf("field %s mapped to adjacent vertices must be a slice of structs", field.Name)
+		}
+		fields = append(fields, adjacentField{index: []int{i}, edgeLabel: tag.edge, elemType: elemType})
+	}
+	return fields, nil
+}
+
+// fieldByIndex returns the field of the specified struct value with the specified index sequence, allocating the nil
+// pointers to embedded structs along the way
+func fieldByIndex(val reflect.Value, index []int) reflect.Value {
+	for i, x := range index {
+		if i > 0 && val.Kind() == reflect.Pointer {
+			if val.IsNil() {
+				val.Set(reflect.New(val.Type().Elem()))
+			}
+			val = val.Elem()
+		}
+		val = val.Field(x)
+	}
+	return val
+}
+
+// label returns the label of the adjacent vertices. The label returned by GetLabel is used if the element type
+// implements GraphObject, otherwise the name of the element type is used.
+func (af adjacentField) label() string {
+	if obj, ok := reflect.New(af.elemType).Interface().(GraphObject); ok {
+		return obj.GetLabel()
+	}
+	return af.elemType.Name()
+}
+
+// persistAdjacentVertices persists the elements of the adjacent fields of the specified struct as vertices along
+// with the edges from the stored vertex to the adjacent vertices. Adjacent fields of the elements are persisted
+// recursively.
+func (gs *GenericStore) persistAdjacentVertices(ctx context.Context, vertex *core.Vertex, obj any) error {
+	val := reflect.Indirect(reflect.ValueOf(obj))
+	fields, err := adjacentFields(val.Type())
+	if err != nil {
+		return err
+	}
+	// both the vertices have been stored by the time the edge is stored
+	edgeCtx := context.WithValue(ctx, core.ContextKeyRequireExistingEndpoints, true)
+	for _, field := range fields {
+		elems, err := val.FieldByIndexErr(field.index)
+		// a nil pointer to an embedded struct has no adjacent vertices
+		if err != nil {
+			continue
+		}
+		for i := 0; i < elems.Len(); i++ {
+			elem := elems.Index(i)
+			if elem.Kind() == reflect.Pointer && elem.IsNil() {
+				continue
+			}
+			adjacentVertex, err := gs.mapper.ToVertex(elem.Interface(), []string{field.label()})
+			if err != nil {
+				return err
+			}
+			if err = gs.connection.StoreVertex(ctx, adjacentVertex); err != nil {
+				return err
+			}
+			edge := core.Edge{Type: field.edgeLabel, SourceVertex: vertex, DestinationVertex: adjacentVertex, Properties: core.KVMap{}}
+			if err = gs.connection.StoreEdge(edgeCtx, &edge); err != nil {
+				return err
+			}
+			if err = gs.persistAdjacentVertices(ctx, adjacentVertex, elem.Interface()); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// readAdjacentVertices populates the adjacent fields of the struct pointed to by the specified value with the
+// vertices adjacent to the specified vertex, matched using the specified labels and the properties of the vertex.
+// Adjacent fields of the elements are populated recursively.
+//
+// The path holds the vertices being read on the way to the vertex. The adjacent fields of an element already on the
+// path are left empty, which ends the recursion when the vertices form a cycle.
+//
+// The order of the elements is not guaranteed to be the order in which they were persisted.
+func (gs *GenericStore) readAdjacentVertices(ctx context.Context, labels []string, vertex *core.Vertex, obj reflect.Value, path map[string]struct{}) error {
+	val := obj.Elem()
+	fields, err := adjacentFields(val.Type())
+	if err != nil {
+		return err
+	}
+	key := vertexKey(labels, vertex)
+	path[key] = struct{}{}
+	defer delete(path, key)
+	for _, field := range fields {
+		edges, err := gs.connection.QueryEdge(ctx, labels, []string{field.label()}, field.edgeLabel, vertex.Properties, nil, nil, nil, nil, nil, nil, core.EdgeWithCompleteVertex)
+		if err != nil {
+			return err
+		}
+		// the embedded struct the field is promoted from is only allocated if there are adjacent vertices
+		if _, err = val.FieldByIndexErr(field.index); err != nil && len(edges) == 0 {
+			continue
+		}
+		fieldValue := fieldByIndex(val, field.index)
+		elems := reflect.MakeSlice(fieldValue.Type(), 0, len(edges))
+		for _, edge := range edges {
+			elem := reflect.New(field.elemType)
+			if err = gs.mapper.FromVertex(edge.DestinationVertex, elem.Interface()); err != nil {
+				return err
+			}
+			if _, onPath := path[vertexKey([]string{field.label()}, edge.DestinationVertex)]; !onPath {
+				if err = gs.readAdjacentVertices(ctx, []string{field.label()}, edge.DestinationVertex, elem, path); err != nil {
+					return err
+				}
+			}
+			if fieldValue.Type().Elem().Kind() != reflect.Pointer {
+				elem = elem.Elem()
+			}
+			elems = reflect.Append(elems, elem)
+		}
+		fieldValue.Set(elems)
+	}
+	return nil
+}
+
+// vertexKey returns the key identifying the specified vertex while reading adjacent vertices. Vertices are identified
+// by their ids if returned by the connection, otherwise by the labels and the properties matching the vertex.
+func vertexKey(labels []string, vertex *core.Vertex) string {
+	if vertex.ID != nil {
+		return fmt.Sprintf("id:%v", vertex.ID)
+	}
+	return fmt.Sprintf("%v%v", labels, map[string]any(vertex.Properties))
+}
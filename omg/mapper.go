@@ -3,8 +3,12 @@ package omg
 import (
 	"errors"
 	"fmt"
+	"math"
 	"reflect"
+	"sort"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/mitchellh/mapstructure"
 	"github.com/prahaladd/gograph/core"
@@ -12,37 +16,75 @@ import (
 
 const ogmTagSuffix = "ogm"
 
+// ogmEdgeOption is the prefix of the ogm tag option specifying the label of the edges connecting a vertex to the
+// adjacent vertices mapped to a field
+const ogmEdgeOption = "edge:"
+
+// ogmAltOption is the prefix of the ogm tag option specifying the alternate names, separated by |, under which the
+// property mapped to a field may be read back from the database (for e.g. `ogm:"pinCode,alt=pincode|pin_code"`)
+const ogmAltOption = "alt="
+
+// ogmIdentityOption is the ogm tag option designating the property mapped to a field as an identity property of a
+// vertex (for e.g. `ogm:"email,identity"`). Example based reads match vertices using only the identity properties.
+const ogmIdentityOption = "identity"
+
+// ogmSkipTag is the ogm tag of the fields which are never mapped to properties, for e.g. caches or computed values.
+// Similar to encoding/json, a field can be mapped to a property named - using the tag `ogm:"-,"`.
+const ogmSkipTag = "-"
+
+// maxExactFloatInt is the largest integer that can be represented exactly by a float64
+const maxExactFloatInt = 1 << 53
+
 // Mapper interface defines a contract for implementations that map arbitrary structs to
 // graph entities (vertices and structs)
 type Mapper interface {
 
 	// ToVertex maps a specified struct to a graph vertex with the specified labels.
 	//
-	// The properties of the vertex are populated from the fields of the struct.
+	// The properties of the vertex are populated from the exported fields of the struct. Unexported fields and fields
+	// tagged `ogm:"-"` are ignored. An error is returned if more than one field maps to the same property.
 	//
 	// Implementations must not expect the label to be always specified. In cases when label
 	// is not specified the type of the value serves as the vertex label.
 	//
 	// The passed in value to be mapped must be a struct or a pointer to a struct.
-	// Nested structs are not currently supported
+	// Nested structs are not currently supported, with the exception of time.Time fields which are mapped to
+	// RFC 3339 strings and core.Point fields which are mapped to point properties. The fields of embedded structs
+	// are mapped to properties of the vertex itself, similar to Go embedding.
+	//
+	// Interface fields, for e.g. interface{} fields, are mapped to properties holding their dynamic values. The
+	// dynamic value must be a scalar, a time.Time, a core.Point or a slice or array of scalars, an error is returned
+	// for other dynamic types such as structs and maps. A nil interface is mapped to a nil property value.
 	ToVertex(v any, labels []string) (*core.Vertex, error)
 
 	// ToEdge maps a specified struct to a graph edge with the specified labels.
 	//
-	// The properties of the edge are populated from the fields of the struct
+	// The properties of the edge are populated from the fields of the struct, ignoring the fields tagged `ogm:"-"`.
+	// An error is returned if more than one field maps to the same property.
 	//
 	// Implementations must not expect the label to be always specified. In cases when label
 	// is not specified the type of the struct serves as the edge label.
 	//
 	// The passed in value to be mapped must be a struct or a pointer to a struct.
-	// Nested structs are not currently supported
+	// Nested structs are not currently supported, while the fields of embedded structs are mapped to properties of the
+	// edge itself.
 	ToEdge(v any, label *string) (*core.Edge, error)
 
 	// FromVertex maps a vertex properties to a user-defined struct.
 	//
+	// Properties decoded into interface fields are stored as returned by the graph database, for e.g. an integer
+	// property decoded into an interface{} field holds an int64. An error is returned if the property value does not
+	// implement the interface of the field.
+	//
+	// Backends may rename properties, for e.g. by lower casing them. Alternate names under which a property may be
+	// read back can be listed within the ogm tag of a field using the alt option (for e.g.
+	// `ogm:"pinCode,alt=pincode|pin_code"`).
+	//
 	// Vertex label information is not retained as a part of the conversion process.
 	// Hence, if the vertex node has a label other than the struct type name, then the label information is lost.
-
+	//
+	// Implementations may either overwrite the whole struct or merge the properties into the struct, leaving the
+	// fields without a corresponding property untouched. See NewMergingReflectionMapper.
 	FromVertex(vertex *core.Vertex, v any) error
 
 	// FromEdge maps an edge properties to a user-defined struct.
@@ -53,6 +95,9 @@ type Mapper interface {
 }
 
 type ReflectionMapper struct {
+	// merge leaves the fields without a corresponding property untouched when mapping properties to a struct, instead
+	// of resetting the fields to their zero values
+	merge bool
 }
 
 func (rm *ReflectionMapper) ToVertex(v any, labels []string) (*core.Vertex, error) {
@@ -73,7 +118,11 @@ func (rm *ReflectionMapper) ToVertex(v any, labels []string) (*core.Vertex, erro
 		} else {
 			vertex.Labels = []string{typeNameOfV}
 		}
-		vertex.Properties = rm.performMap(typeOfV, reflect.ValueOf(v))
+		properties, err := rm.performMap(typeOfV, reflect.ValueOf(v))
+		if err != nil {
+			return nil, err
+		}
+		vertex.Properties = properties
 		return &vertex, nil
 
 	case reflect.Ptr:
@@ -84,7 +133,11 @@ func (rm *ReflectionMapper) ToVertex(v any, labels []string) (*core.Vertex, erro
 		} else {
 			vertex.Labels = []string{typeNameOfV}
 		}
-		vertex.Properties = rm.performMap(typeOfV.Elem(), reflect.Indirect(reflect.ValueOf(v)))
+		properties, err := rm.performMap(typeOfV.Elem(), reflect.Indirect(reflect.ValueOf(v)))
+		if err != nil {
+			return nil, err
+		}
+		vertex.Properties = properties
 		return &vertex, nil
 	default:
 		return nil, errors.New("passed in value must be a struct or pointer to a struct")
@@ -114,7 +167,11 @@ func (rm *ReflectionMapper) ToEdge(v interface{}, label *string) (*core.Edge, er
 			}
 		}
 
-		edge.Properties = rm.performMap(typeOfV, reflect.ValueOf(v))
+		properties, err := rm.performMap(typeOfV, reflect.ValueOf(v))
+		if err != nil {
+			return nil, err
+		}
+		edge.Properties = properties
 		return &edge, nil
 
 	case reflect.Ptr:
@@ -129,7 +186,11 @@ func (rm *ReflectionMapper) ToEdge(v interface{}, label *string) (*core.Edge, er
 				edge.Type = typeNameOfV
 			}
 		}
-		edge.Properties = rm.performMap(typeOfV.Elem(), reflect.Indirect(reflect.ValueOf(v)))
+		properties, err := rm.performMap(typeOfV.Elem(), reflect.Indirect(reflect.ValueOf(v)))
+		if err != nil {
+			return nil, err
+		}
+		edge.Properties = properties
 		return &edge, nil
 	default:
 		return nil, errors.New("passed in value must be a struct or pointer to a struct")
@@ -184,24 +245,290 @@ func (rm *ReflectionMapper) FromEdge(edge *core.Edge, v any) error {
 	}
 }
 
-func (rm *ReflectionMapper) performMap(t reflect.Type, val reflect.Value) core.KVMap {
+// performMap maps the fields of the struct to properties. An error is returned if more than one field maps to the
+// same property, see propertyFields, since the value of one of the fields would otherwise be silently lost.
+func (rm *ReflectionMapper) performMap(t reflect.Type, val reflect.Value) (core.KVMap, error) {
 
 	props := core.KVMap{}
-	for i := 0; i < val.NumField(); i++ {
-		var key string
-		if t.Field(i).Tag != "" && t.Field(i).Tag.Get(ogmTagSuffix) != "" {
-			key = t.Field(i).Tag.Get(ogmTagSuffix)
-		} else {
-			key = t.Field(i).Name
+	fields, err := propertyFields(t)
+	if err != nil {
+		return nil, err
+	}
+	for _, field := range fields {
+		tag := parseOgmTag(field)
+		fieldValue, err := val.FieldByIndexErr(field.Index)
+		if err != nil {
+			// the field is promoted from a nil embedded struct pointer and hence unset
+			props[tag.name] = nil
+			continue
+		}
+		if fieldValue.Kind() == reflect.Interface {
+			if props[tag.name], err = interfacePropertyValue(fieldValue); err != nil {
+				return nil, fmt.Errorf("field %s of %s: %w", field.Name, t.Name(), err)
+			}
+			continue
+		}
+		props[tag.name] = toPropertyValue(fieldValue)
+	}
+	return props, nil
+}
+
+// propertyFields returns the fields of the struct type mapped to properties. Unexported fields, fields tagged
+// `ogm:"-"` and fields mapped to adjacent vertices are excluded.
+//
+// Embedded structs, and pointers to structs, are flattened similar to Go embedding, hence the fields of an embedded
+// struct are mapped to properties of the vertex or edge itself. The index of a promoted field is the index sequence
+// of the field within the struct type. An embedded struct whose ogm tag specifies a property name is mapped to that
+// property as is.
+//
+// As with Go embedding, a field shadows the fields mapped to the same property at a greater depth of embedding. An
+// error is returned if more than one field maps to the same property at the shallowest depth. The fields are
+// returned in the order of their depth.
+func propertyFields(t reflect.Type) ([]reflect.StructField, error) {
+	fields := flattenedFields(t)
+	sort.SliceStable(fields, func(i, j int) bool {
+		return len(fields[i].Index) < len(fields[j].Index)
+	})
+	resolved := make([]reflect.StructField, 0, len(fields))
+	// fieldsByProperty maps the name of each property to the shallowest field mapped to the property
+	fieldsByProperty := make(map[string]reflect.StructField)
+	for _, field := range fields {
+		name := parseOgmTag(field).name
+		if shallowest, ok := fieldsByProperty[name]; ok {
+			if len(shallowest.Index) == len(field.Index) {
+				return nil, fmt.Errorf("fields %s and %s of %s map to the same property %s", shallowest.Name, field.Name, t.Name(), name)
+			}
+			continue
+		}
+		fieldsByProperty[name] = field
+		resolved = append(resolved, field)
+	}
+	return resolved, nil
+}
+
+// flattenedFields returns the fields of the struct type mapped to properties along with the fields promoted from
+// embedded structs, in the order of their declaration
+func flattenedFields(t reflect.Type) []reflect.StructField {
+	fields := make([]reflect.StructField, 0, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		// unexported fields cannot be read through reflection and are not mapped to properties
+		if !field.IsExported() {
+			continue
+		}
+		tag := parseOgmTag(field)
+		// adjacent vertices are not properties of the vertex
+		if tag.edge != "" || tag.skip {
+			continue
+		}
+		if embedded, ok := embeddedStruct(field); ok {
+			for _, promoted := range flattenedFields(embedded) {
+				promoted.Index = append([]int{i}, promoted.Index...)
+				fields = append(fields, promoted)
+			}
+			continue
+		}
+		fields = append(fields, field)
+	}
+	return fields
+}
+
+// skippedFieldNames returns the names of the fields of the specified struct type, including the fields promoted from
+// embedded structs, which are skipped using the `ogm:"-"` tag. Each name is returned in its original, lower and upper
+// case.
+func skippedFieldNames(t reflect.Type) map[string]struct{} {
+	names := make(map[string]struct{})
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		tag := parseOgmTag(field)
+		if tag.skip {
+			names[field.Name] = struct{}{}
+			names[strings.ToLower(field.Name)] = struct{}{}
+			names[strings.ToUpper(field.Name)] = struct{}{}
+			continue
+		}
+		if embedded, ok := embeddedStruct(field); ok && tag.edge == "" {
+			for name := range skippedFieldNames(embedded) {
+				names[name] = struct{}{}
+			}
+		}
+	}
+	return names
+}
+
+// embeddedStruct returns the struct type of an embedded field which is flattened into the fields of the embedding
+// struct. Embedded fields whose ogm tag specifies a property name are not flattened.
+func embeddedStruct(field reflect.StructField) (reflect.Type, bool) {
+	if !field.Anonymous || strings.Split(field.Tag.Get(ogmTagSuffix), ",")[0] != "" {
+		return nil, false
+	}
+	t := field.Type
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return t, t.Kind() == reflect.Struct && t != timeType && t != pointType
+}
+
+// ogmTag represents the ogm tag of a struct field
+type ogmTag struct {
+	// name is the name of the property mapped to the field
+	name string
+	// edge is the label of the edges connecting a vertex to the adjacent vertices mapped to the field
+	edge string
+	// alternates are the alternate names of the property which are decoded into the field
+	alternates []string
+	// identity is true if the property mapped to the field identifies the vertex
+	identity bool
+	// skip is true if the field is never mapped to a property
+	skip bool
+}
+
+// parseOgmTag parses the ogm tag of the specified field. The tag contains the name of the property followed by
+// comma separated options. The name of the field is used as the name of the property if the tag does not
+// specify a name. Fields tagged `ogm:"-"` are skipped.
+func parseOgmTag(field reflect.StructField) ogmTag {
+	value := field.Tag.Get(ogmTagSuffix)
+	if value == ogmSkipTag {
+		return ogmTag{skip: true}
+	}
+	parts := strings.Split(value, ",")
+	tag := ogmTag{name: parts[0]}
+	if tag.name == "" {
+		tag.name = field.Name
+	}
+	for _, option := range parts[1:] {
+		if strings.HasPrefix(option, ogmEdgeOption) {
+			tag.edge = strings.TrimPrefix(option, ogmEdgeOption)
+		}
+		if strings.HasPrefix(option, ogmAltOption) {
+			tag.alternates = strings.Split(strings.TrimPrefix(option, ogmAltOption), "|")
+		}
+		if option == ogmIdentityOption {
+			tag.identity = true
+		}
+	}
+	return tag
+}
+
+// builtinScalarTypes maps the kinds of the scalar types to the corresponding builtin types
+var builtinScalarTypes = map[reflect.Kind]reflect.Type{
+	reflect.Bool:    reflect.TypeOf(false),
+	reflect.String:  reflect.TypeOf(""),
+	reflect.Int:     reflect.TypeOf(int(0)),
+	reflect.Int8:    reflect.TypeOf(int8(0)),
+	reflect.Int16:   reflect.TypeOf(int16(0)),
+	reflect.Int32:   reflect.TypeOf(int32(0)),
+	reflect.Int64:   reflect.TypeOf(int64(0)),
+	reflect.Uint:    reflect.TypeOf(uint(0)),
+	reflect.Uint8:   reflect.TypeOf(uint8(0)),
+	reflect.Uint16:  reflect.TypeOf(uint16(0)),
+	reflect.Uint32:  reflect.TypeOf(uint32(0)),
+	reflect.Uint64:  reflect.TypeOf(uint64(0)),
+	reflect.Float32: reflect.TypeOf(float32(0)),
+	reflect.Float64: reflect.TypeOf(float64(0)),
+}
+
+// timeType is the type of the time.Time fields, which are mapped to RFC 3339 strings
+var timeType = reflect.TypeOf(time.Time{})
+
+// pointType is the type of the core.Point fields, which are mapped to point properties as is
+var pointType = reflect.TypeOf(core.Point{})
+
+// dateLayout is the layout of the dates which are decoded into time.Time fields along with RFC 3339 times
+const dateLayout = "2006-01-02"
+
+// toPropertyValue converts the value of a field to the value of the property mapped to the field. Times are
+// converted to RFC 3339 strings since graph databases differ in their support for temporal values, while the strings
+// are supported by all of them and sort chronologically for times in the same time zone. Zero times and nil time
+// pointers are unset and hence mapped to nil property values, which are not used as selectors by example based reads.
+// The same applies to core.Point fields, a zero point having no coordinate reference system.
+func toPropertyValue(val reflect.Value) any {
+	if val.Kind() == reflect.Ptr && (val.Type().Elem() == timeType || val.Type().Elem() == pointType) {
+		if val.IsNil() {
+			return nil
+		}
+		val = val.Elem()
+	}
+	if val.Type() == pointType {
+		if val.IsZero() {
+			return nil
 		}
-		props[key] = val.Field(i).Interface()
+		return val.Interface()
+	}
+	if val.Kind() == reflect.Slice || val.Kind() == reflect.Array {
+		return toBuiltinSlice(val)
+	}
+	if val.Type() != timeType {
+		return toBuiltinScalar(val)
+	}
+	if t := val.Interface().(time.Time); !t.IsZero() {
+		return t.Format(time.RFC3339Nano)
+	}
+	return nil
+}
+
+// interfacePropertyValue converts the dynamic value of an interface field to the value of the property mapped to the
+// field. Dynamic values which are scalars, times, points or slices and arrays of scalars are converted as the values
+// of fields of their dynamic types would be, while a nil interface is mapped to a nil property value. Other dynamic
+// values, for e.g. structs and maps, cannot be stored as property values by all the graph databases and an error is
+// returned instead.
+func interfacePropertyValue(val reflect.Value) (any, error) {
+	if val.IsNil() {
+		return nil, nil
+	}
+	dynamic := val.Elem()
+	t := dynamic.Type()
+	if t.Kind() == reflect.Ptr && (t.Elem() == timeType || t.Elem() == pointType) {
+		t = t.Elem()
+	}
+	if t.Kind() == reflect.Slice || t.Kind() == reflect.Array {
+		t = t.Elem()
+	}
+	if _, ok := builtinScalarTypes[t.Kind()]; !ok && t != timeType && t != pointType {
+		return nil, fmt.Errorf("unsupported dynamic type %s", dynamic.Type())
 	}
-	return props
+	return toPropertyValue(dynamic), nil
+}
+
+// toBuiltinScalar converts values of user defined scalar types (for e.g. type Status string) to the
+// underlying builtin type. Graph database drivers and the query builders recognize only the builtin types.
+//
+// The values are restored to the user defined types by kind when decoding the properties.
+func toBuiltinScalar(val reflect.Value) any {
+	builtinType, ok := builtinScalarTypes[val.Kind()]
+	if !ok || val.Type() == builtinType {
+		return val.Interface()
+	}
+	return val.Convert(builtinType).Interface()
+}
+
+// toBuiltinSlice converts slices and arrays of scalar types to slices of the underlying builtin type, for e.g. an
+// [2]int64 or a []Status to an []int64 or a []string, since graph database drivers support lists only as slices.
+// Slices of other element types are returned as is. A nil slice is mapped to a nil property value.
+func toBuiltinSlice(val reflect.Value) any {
+	if val.Kind() == reflect.Slice && val.IsNil() {
+		return nil
+	}
+	builtinType, ok := builtinScalarTypes[val.Type().Elem().Kind()]
+	if !ok || (val.Kind() == reflect.Slice && val.Type().Elem() == builtinType) {
+		return val.Interface()
+	}
+	converted := reflect.MakeSlice(reflect.SliceOf(builtinType), val.Len(), val.Len())
+	for i := 0; i < val.Len(); i++ {
+		converted.Index(i).Set(val.Index(i).Convert(builtinType))
+	}
+	return converted.Interface()
 }
 
 func (rm *ReflectionMapper) performReverseMap(properties core.KVMap, t reflect.Type, val reflect.Value) {
 	t = t.Elem()
 	for i := 0; i < val.NumField(); i++ {
+		if !t.Field(i).IsExported() {
+			continue
+		}
 		if t.Field(i).Tag != "" && t.Field(i).Tag.Get(ogmTagSuffix) != "" {
 			val.Field(i).Set(reflect.ValueOf(properties[t.Field(i).Tag.Get(ogmTagSuffix)]))
 		} else {
@@ -225,38 +552,180 @@ func (rm *ReflectionMapper) performReverseMap(properties core.KVMap, t reflect.T
 	}
 }
 
+// performDecode decodes the properties into the fields of the struct. A property is decoded into the field with the
+// same name in its original, lower or upper case, the field whose tag specifies the property name or the field whose
+// tag lists the property name as an alternate name. A property under an alternate name is ignored if the property
+// is also present under the name specified by the tag. Properties named after skipped fields are ignored as well.
+//
+// The fields mapped to properties are reset to their zero values before decoding unless the mapper merges the
+// properties into the struct. A decoded property always replaces the value of the field, hence slices and maps
+// already held by the field are not merged with the decoded values.
 func (rm *ReflectionMapper) performDecode(properties core.KVMap, t reflect.Type, val reflect.Value, v any) error {
-	fieldTagMapping := make(map[string]string)
+	// fieldTagMapping maps the name of each property to the field mapped to the property
+	fieldTagMapping := make(map[string]reflect.StructField)
 	fieldMappingByName := make(map[string]reflect.StructField)
+	// alternateNames maps each alternate property name to the name specified by the tag of the field
+	alternateNames := make(map[string]string)
 
 	t = t.Elem()
-	for i := 0; i < val.NumField(); i++ {
-		if t.Field(i).Tag != "" && t.Field(i).Tag.Get(ogmTagSuffix) != "" {
-			fieldTagMapping[t.Field(i).Tag.Get(ogmTagSuffix)] = t.Field(i).Name
-
+	// unexported fields cannot be set through reflection, hence properties are never decoded into them. Skipped fields
+	// are left untouched, similar to the fields mapped to adjacent vertices.
+	fields, err := propertyFields(t)
+	if err != nil {
+		return err
+	}
+	// the fields are ordered by depth, hence a field name shared with a shallower field resolves to the shallower one
+	setFieldMapping := func(name string, field reflect.StructField) {
+		if _, ok := fieldMappingByName[name]; !ok {
+			fieldMappingByName[name] = field
 		}
-		fieldMappingByName[strings.ToLower(t.Field(i).Name)] = t.Field(i)
-		fieldMappingByName[strings.ToUpper(t.Field(i).Name)] = t.Field(i)
-		fieldMappingByName[t.Field(i).Name] = t.Field(i)
 	}
+	for _, field := range fields {
+		tag := parseOgmTag(field)
+		if fieldValue, err := val.FieldByIndexErr(field.Index); !rm.merge && err == nil {
+			fieldValue.Set(reflect.Zero(field.Type))
+		}
+		fieldTagMapping[tag.name] = field
+		setFieldMapping(strings.ToLower(field.Name), field)
+		setFieldMapping(strings.ToUpper(field.Name), field)
+		setFieldMapping(field.Name, field)
+		for _, alternate := range tag.alternates {
+			alternateNames[alternate] = tag.name
+		}
+	}
+	skippedNames := skippedFieldNames(t)
 	mapToDecode := make(map[string]interface{})
 	for k, v := range properties {
 		fieldToDecode, ok := fieldMappingByName[k]
 		// if the field is not found by name, then check if the key is a tag on a field
 		if !ok {
-			originalFieldName, ok := fieldTagMapping[k]
+			fieldToDecode, ok = fieldTagMapping[k]
 			if !ok {
-				return fmt.Errorf("unknown field %s", k)
+				name, isAlternate := alternateNames[k]
+				if !isAlternate {
+					if _, skipped := skippedNames[k]; skipped {
+						continue
+					}
+					return fmt.Errorf("unknown field %s", k)
+				}
+				if _, ok = properties[name]; ok {
+					continue
+				}
+				fieldToDecode = fieldTagMapping[name]
 			}
-			fieldToDecode = fieldMappingByName[originalFieldName]
 		}
 
-		mapToDecode[fieldToDecode.Name] = v
+		if err := checkIntegerPrecision(k, v, fieldToDecode); err != nil {
+			return err
+		}
+		setDecodedValue(mapToDecode, t, fieldToDecode.Index, v)
 	}
-	err := mapstructure.Decode(mapToDecode, v)
-	return err
+	decodeHook := mapstructure.ComposeDecodeHookFunc(decodeBoolText, decodeTimeText)
+	decoder, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{DecodeHook: decodeHook, ZeroFields: true, Result: v})
+	if err != nil {
+		return err
+	}
+	return decoder.Decode(mapToDecode)
+}
+
+// setDecodedValue sets the value of the field with the specified index within the map decoded into the struct type.
+// The values of the fields promoted from embedded structs are nested within maps keyed by the names of the embedded
+// fields, which are decoded into the embedded structs, allocating nil embedded struct pointers.
+func setDecodedValue(mapToDecode map[string]interface{}, t reflect.Type, index []int, value interface{}) {
+	for _, i := range index[:len(index)-1] {
+		field := t.Field(i)
+		nested, ok := mapToDecode[field.Name].(map[string]interface{})
+		if !ok {
+			nested = make(map[string]interface{})
+			mapToDecode[field.Name] = nested
+		}
+		mapToDecode = nested
+		if t = field.Type; t.Kind() == reflect.Pointer {
+			t = t.Elem()
+		}
+	}
+	mapToDecode[t.Field(index[len(index)-1]).Name] = value
+}
+
+// decodeBoolText decodes boolean values returned as text into bool fields. For e.g. PostgreSQL based graph
+// databases such as Agensgraph return booleans as the text t or f.
+func decodeBoolText(from reflect.Type, to reflect.Type, data interface{}) (interface{}, error) {
+	if from.Kind() != reflect.String || to.Kind() != reflect.Bool {
+		return data, nil
+	}
+	b, err := strconv.ParseBool(reflect.ValueOf(data).String())
+	if err != nil {
+		return data, nil
+	}
+	return b, nil
+}
+
+// decodeTimeText decodes times stored as RFC 3339 strings, or dates stored as yyyy-mm-dd strings, into time.Time
+// fields. Graph database drivers returning temporal values as time.Time values, for e.g. Neo4j, are decoded as is.
+func decodeTimeText(from reflect.Type, to reflect.Type, data interface{}) (interface{}, error) {
+	if from.Kind() != reflect.String || to != timeType {
+		return data, nil
+	}
+	text := reflect.ValueOf(data).String()
+	if t, err := time.Parse(time.RFC3339Nano, text); err == nil {
+		return t, nil
+	}
+	t, err := time.Parse(dateLayout, text)
+	if err != nil {
+		return nil, fmt.Errorf("cannot decode %q into a time: %w", text, err)
+	}
+	return t, nil
+}
+
+// checkIntegerPrecision guards against a floating point property value being silently truncated
+// when decoded into an integer field. Values outside the range of integers that can be exactly
+// represented as a float64 would have lost precision before reaching the mapper.
+//
+// Graph databases return list properties as []interface{}, which are decoded into slice and array fields element by
+// element. Hence each element of a list decoded into a slice or an array of integers is checked as well.
+func checkIntegerPrecision(key string, value any, field reflect.StructField) error {
+	if elems, ok := value.([]interface{}); ok && (field.Type.Kind() == reflect.Slice || field.Type.Kind() == reflect.Array) {
+		for i, elem := range elems {
+			if losesIntegerPrecision(elem, field.Type.Elem()) {
+				return fmt.Errorf("property %s with element %v at index %d cannot be decoded into integer slice field %s without loss of precision", key, elem, i, field.Name)
+			}
+		}
+		return nil
+	}
+	if losesIntegerPrecision(value, field.Type) {
+		return fmt.Errorf("property %s with value %v cannot be decoded into integer field %s without loss of precision", key, value, field.Name)
+	}
+	return nil
+}
+
+// losesIntegerPrecision returns true if the specified floating point value cannot be decoded into the specified
+// integer type without loss of precision
+func losesIntegerPrecision(value any, t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+	default:
+		return false
+	}
+	var f float64
+	switch fv := value.(type) {
+	case float64:
+		f = fv
+	case float32:
+		f = float64(fv)
+	default:
+		return false
+	}
+	return f != math.Trunc(f) || math.Abs(f) > maxExactFloatInt
 }
 
 func NewReflectionMapper() *ReflectionMapper {
 	return &ReflectionMapper{}
 }
+
+// NewMergingReflectionMapper returns a reflection mapper which merges the properties of vertices and edges into the
+// structs passed to FromVertex and FromEdge. Only the fields with a corresponding property are set, hence fields
+// populated beforehand, for e.g. computed fields, survive the mapping.
+func NewMergingReflectionMapper() *ReflectionMapper {
+	return &ReflectionMapper{merge: true}
+}
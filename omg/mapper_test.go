@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/prahaladd/gograph/core"
 	"github.com/stretchr/testify/suite"
@@ -256,6 +257,444 @@ func (suite *MapperTestSuite) TestMapVertexToComplexStruct() {
 
 }
 
+func (suite *MapperTestSuite) TestMapVertexLargeIntegerPreserved() {
+	suite.mapper = NewReflectionMapper()
+	ts := snowflake{ID: 1541815603606036480, Name: "Tom"}
+	v, err := suite.mapper.ToVertex(ts, []string{})
+	suite.NoError(err)
+	suite.Equal(int64(1541815603606036480), v.Properties["ID"])
+
+	var ts2 snowflake
+	err = suite.mapper.FromVertex(v, &ts2)
+	suite.NoError(err)
+	suite.Equal(ts, ts2)
+}
+
+func (suite *MapperTestSuite) TestMapVertexLargeIntegerCoercedToFloat() {
+	suite.mapper = NewReflectionMapper()
+	v := &core.Vertex{Labels: []string{"snowflake"}, Properties: core.KVMap{"ID": float64(1541815603606036480), "Name": "Tom"}}
+	var ts snowflake
+	err := suite.mapper.FromVertex(v, &ts)
+	suite.Error(err)
+
+	// floats representing small integers exactly can still be decoded
+	v.Properties["ID"] = float64(1990)
+	err = suite.mapper.FromVertex(v, &ts)
+	suite.NoError(err)
+	suite.Equal(int64(1990), ts.ID)
+}
+
+func (suite *MapperTestSuite) TestMapVertexWithCustomScalarTypes() {
+	suite.mapper = NewReflectionMapper()
+	ts := ticket{Title: "Fix mapper", Status: Active, Priority: High}
+	v, err := suite.mapper.ToVertex(ts, []string{})
+	suite.NoError(err)
+	// the properties carry the builtin types understood by the drivers
+	suite.Equal("Active", v.Properties["Status"])
+	suite.Equal(int(2), v.Properties["Priority"])
+
+	var ts2 ticket
+	err = suite.mapper.FromVertex(v, &ts2)
+	suite.NoError(err)
+	suite.Equal(ts, ts2)
+	suite.True(ts2.Status == Active)
+}
+
+func (suite *MapperTestSuite) TestMapVertexToStructWithCustomScalarTypes() {
+	suite.mapper = NewReflectionMapper()
+	// properties as returned by the graph database drivers
+	v := &core.Vertex{Labels: []string{"ticket"}, Properties: core.KVMap{"Title": "Fix mapper", "Status": "Inactive", "Priority": int64(1)}}
+	var ts ticket
+	err := suite.mapper.FromVertex(v, &ts)
+	suite.NoError(err)
+	suite.Equal(ticket{Title: "Fix mapper", Status: Inactive, Priority: Low}, ts)
+}
+
+func (suite *MapperTestSuite) TestMapVertexToStructWithBoolText() {
+	suite.mapper = NewReflectionMapper()
+	for text, expected := range map[string]bool{"t": true, "f": false, "true": true, "false": false} {
+		v := &core.Vertex{Labels: []string{"subscriber"}, Properties: core.KVMap{"Name": "Tom", "Active": text}}
+		var s subscriber
+		err := suite.mapper.FromVertex(v, &s)
+		suite.NoError(err)
+		suite.Equal(subscriber{Name: "Tom", Active: expected}, s)
+	}
+}
+
+func (suite *MapperTestSuite) TestMapVertexToStructWithBool() {
+	suite.mapper = NewReflectionMapper()
+	v := &core.Vertex{Labels: []string{"subscriber"}, Properties: core.KVMap{"Name": "Tom", "Active": true}}
+	var s subscriber
+	suite.NoError(suite.mapper.FromVertex(v, &s))
+	suite.Equal(subscriber{Name: "Tom", Active: true}, s)
+
+	v.Properties["Active"] = "yes"
+	suite.Error(suite.mapper.FromVertex(v, &s))
+}
+
+func (suite *MapperTestSuite) TestMapVertexSkipsAdjacentFields() {
+	o := order{Number: "A-1", LineItems: []lineItem{{Product: "Pen", Quantity: 2}}}
+	v, err := suite.mapper.ToVertex(&o, nil)
+	suite.NoError(err)
+	suite.Equal(core.KVMap{"number": "A-1"}, v.Properties)
+
+	var decoded order
+	suite.NoError(suite.mapper.FromVertex(v, &decoded))
+	suite.Equal(order{Number: "A-1"}, decoded)
+}
+
+func (suite *MapperTestSuite) TestParseOgmTag() {
+	fields := reflect.TypeOf(order{})
+	suite.Equal(ogmTag{name: "number"}, parseOgmTag(fields.Field(0)))
+	suite.Equal(ogmTag{name: "LineItems", edge: "CONTAINS"}, parseOgmTag(fields.Field(1)))
+	suite.Equal(ogmTag{name: "email", identity: true}, parseOgmTag(reflect.TypeOf(member{}).Field(0)))
+	fields = reflect.TypeOf(profile{})
+	suite.Equal(ogmTag{skip: true}, parseOgmTag(fields.Field(1)))
+	suite.Equal(ogmTag{name: "-"}, parseOgmTag(fields.Field(2)))
+}
+
+func (suite *MapperTestSuite) TestMapVertexToStructWithListProperties() {
+	v := &core.Vertex{Properties: core.KVMap{
+		"ids":     []interface{}{int64(1541815603606036480), int64(2)},
+		"tags":    []interface{}{"comics", "adventure"},
+		"ratings": []interface{}{int64(4), 4.5},
+	}}
+	var p publication
+	suite.NoError(suite.mapper.FromVertex(v, &p))
+	suite.Equal(publication{Ids: []int64{1541815603606036480, 2}, Tags: []string{"comics", "adventure"}, Ratings: []float64{4, 4.5}}, p)
+}
+
+func (suite *MapperTestSuite) TestMapStructWithListsRoundTrip() {
+	pub := publication{Ids: []int64{1541815603606036480, 2}, Tags: []string{"comics", "adventure"}, Ratings: []float64{4, 4.5}}
+	v, err := suite.mapper.ToVertex(pub, nil)
+	suite.NoError(err)
+	suite.Equal(core.KVMap{"ids": []int64{1541815603606036480, 2}, "tags": []string{"comics", "adventure"}, "ratings": []float64{4, 4.5}}, v.Properties)
+
+	// graph database drivers return the lists as []interface{}
+	for k, list := range v.Properties {
+		elems := reflect.ValueOf(list)
+		returned := make([]interface{}, elems.Len())
+		for i := range returned {
+			returned[i] = elems.Index(i).Interface()
+		}
+		v.Properties[k] = returned
+	}
+	var pub2 publication
+	suite.NoError(suite.mapper.FromVertex(v, &pub2))
+	suite.Equal(pub, pub2)
+}
+
+func (suite *MapperTestSuite) TestMapStructWithCustomScalarListsToVertex() {
+	r := release{Statuses: []Status{Active, Inactive}, Levels: [2]Priority{Low, High}}
+	v, err := suite.mapper.ToVertex(r, nil)
+	suite.NoError(err)
+	// the lists carry the builtin types understood by the drivers
+	suite.Equal(core.KVMap{"Statuses": []string{"Active", "Inactive"}, "Levels": []int{1, 2}, "Notes": nil}, v.Properties)
+
+	v.Properties = core.KVMap{"Statuses": []interface{}{"Inactive"}, "Levels": []interface{}{int64(2), int64(1)}, "Notes": []interface{}{}}
+	var r2 release
+	suite.NoError(suite.mapper.FromVertex(v, &r2))
+	suite.Equal(release{Statuses: []Status{Inactive}, Levels: [2]Priority{High, Low}, Notes: []string{}}, r2)
+}
+
+func (suite *MapperTestSuite) TestMapVertexToStructWithEmptyListProperty() {
+	v := &core.Vertex{Properties: core.KVMap{"tags": []interface{}{}}}
+	var p publication
+	suite.NoError(suite.mapper.FromVertex(v, &p))
+	suite.Equal([]string{}, p.Tags)
+}
+
+func (suite *MapperTestSuite) TestMapVertexToStructWithLossyListElement() {
+	v := &core.Vertex{Properties: core.KVMap{"ids": []interface{}{int64(1), 2.5}}}
+	var p publication
+	err := suite.mapper.FromVertex(v, &p)
+	suite.EqualError(err, "property ids with element 2.5 at index 1 cannot be decoded into integer slice field Ids without loss of precision")
+}
+
+func (suite *MapperTestSuite) TestMapStructWithCollidingPropertiesToVertex() {
+	_, err := suite.mapper.ToVertex(&account{Name: "Tintin", DisplayName: "tintin"}, nil)
+	suite.EqualError(err, "fields Name and DisplayName of account map to the same property Name")
+}
+
+func (suite *MapperTestSuite) TestMapStructWithCollidingPropertiesToEdge() {
+	_, err := suite.mapper.ToEdge(account{Name: "Tintin", DisplayName: "tintin"}, nil)
+	suite.EqualError(err, "fields Name and DisplayName of account map to the same property Name")
+}
+
+func (suite *MapperTestSuite) TestMapVertexToStructWithAlternatePropertyNames() {
+	for _, name := range []string{"pinCode", "pincode", "pin_code"} {
+		var a address
+		err := suite.mapper.FromVertex(&core.Vertex{Properties: core.KVMap{"city": "Mumbai", name: int64(400001)}}, &a)
+		suite.NoError(err)
+		suite.Equal(address{City: "Mumbai", Zip: 400001}, a)
+	}
+}
+
+func (suite *MapperTestSuite) TestMapVertexToStructPrefersTaggedPropertyName() {
+	var a address
+	err := suite.mapper.FromVertex(&core.Vertex{Properties: core.KVMap{"pinCode": int64(400001), "pin_code": int64(110001)}}, &a)
+	suite.NoError(err)
+	suite.Equal(int64(400001), a.Zip)
+}
+
+func (suite *MapperTestSuite) TestMapStructWithAlternatePropertyNamesToVertex() {
+	// the alternate names are used only when reading the properties
+	v, err := suite.mapper.ToVertex(&address{City: "Mumbai", Zip: 400001}, nil)
+	suite.NoError(err)
+	suite.Equal(core.KVMap{"city": "Mumbai", "pinCode": int64(400001)}, v.Properties)
+}
+
+func (suite *MapperTestSuite) TestMapStructWithUnexportedFieldToVertex() {
+	suite.mapper = NewReflectionMapper()
+	v, err := suite.mapper.ToVertex(&credential{User: "tintin", secret: "snowy"}, nil)
+	suite.NoError(err)
+	suite.Equal(core.KVMap{"user": "tintin"}, v.Properties)
+}
+
+func (suite *MapperTestSuite) TestMapStructWithSkippedFieldToVertex() {
+	v, err := NewReflectionMapper().ToVertex(profile{Name: "Tintin", Cache: "cached", Dash: "dash"}, nil)
+	suite.NoError(err)
+	suite.Equal(core.KVMap{"Name": "Tintin", "-": "dash"}, v.Properties)
+	suite.NotContains(v.Properties, "Cache")
+
+	e, err := NewReflectionMapper().ToEdge(profile{Name: "Tintin", Cache: "cached"}, nil)
+	suite.NoError(err)
+	suite.NotContains(e.Properties, "Cache")
+}
+
+func (suite *MapperTestSuite) TestMapVertexToStructWithSkippedField() {
+	p := profile{Cache: "cached"}
+	suite.NoError(NewReflectionMapper().FromVertex(&core.Vertex{Properties: core.KVMap{"Name": "Tintin", "-": "dash"}}, &p))
+	// skipped fields are left untouched
+	suite.Equal(profile{Name: "Tintin", Cache: "cached", Dash: "dash"}, p)
+
+	// properties named after skipped fields are ignored and never decoded into the fields
+	suite.NoError(NewReflectionMapper().FromVertex(&core.Vertex{Properties: core.KVMap{"Name": "Haddock", "Cache": "stale", "cache": "stale"}}, &p))
+	suite.Equal(profile{Name: "Haddock", Cache: "cached"}, p)
+
+	err := NewReflectionMapper().FromVertex(&core.Vertex{Properties: core.KVMap{"Name": "Tintin", "Stale": "stale"}}, &p)
+	suite.EqualError(err, "unknown field Stale")
+}
+
+func (suite *MapperTestSuite) TestMapVertexToStructWithUnexportedField() {
+	suite.mapper = NewReflectionMapper()
+	var c credential
+	err := suite.mapper.FromVertex(&core.Vertex{Properties: core.KVMap{"user": "tintin"}}, &c)
+	suite.NoError(err)
+	suite.Equal(credential{User: "tintin"}, c)
+
+	// properties are never decoded into unexported fields
+	err = suite.mapper.FromVertex(&core.Vertex{Properties: core.KVMap{"user": "tintin", "secret": "snowy"}}, &c)
+	suite.EqualError(err, "unknown field secret")
+}
+
+func (suite *MapperTestSuite) TestMapVertexToStructOverwritesFields() {
+	p := person{Name: "Jerry", Age: 8, Department: "Sales"}
+	suite.NoError(NewReflectionMapper().FromVertex(&core.Vertex{Properties: core.KVMap{"name": "Tom"}}, &p))
+	suite.Equal(person{Name: "Tom"}, p)
+}
+
+func (suite *MapperTestSuite) TestMapVertexToStructMergesFields() {
+	p := person{Name: "Jerry", Age: 8, Department: "Sales"}
+	suite.NoError(NewMergingReflectionMapper().FromVertex(&core.Vertex{Properties: core.KVMap{"name": "Tom"}}, &p))
+	suite.Equal(person{Name: "Tom", Age: 8, Department: "Sales"}, p)
+
+	// a property set to nil is present and hence resets the field
+	suite.NoError(NewMergingReflectionMapper().FromVertex(&core.Vertex{Properties: core.KVMap{"dept": nil}}, &p))
+	suite.Equal(person{Name: "Tom", Age: 8}, p)
+}
+
+func (suite *MapperTestSuite) TestMapVertexToStructMergeReplacesLists() {
+	pub := publication{Ids: []int64{7}, Tags: []string{"comics", "adventure", "classic"}}
+	suite.NoError(NewMergingReflectionMapper().FromVertex(&core.Vertex{Properties: core.KVMap{"tags": []any{"travel"}}}, &pub))
+	suite.Equal(publication{Ids: []int64{7}, Tags: []string{"travel"}}, pub)
+}
+
+func (suite *MapperTestSuite) TestMapEdgeToStructMergesFields() {
+	l := livesin{Since: 1982}
+	suite.NoError(NewMergingReflectionMapper().FromEdge(&core.Edge{Properties: core.KVMap{}}, &l))
+	suite.Equal(livesin{Since: 1982}, l)
+}
+
+func (suite *MapperTestSuite) TestMapStructWithTimeToVertex() {
+	createdAt := time.Date(2023, 3, 1, 10, 30, 0, 500, time.FixedZone("IST", 19800))
+	e := event{Name: "launch", CreatedAt: createdAt}
+	v, err := NewReflectionMapper().ToVertex(e, nil)
+	suite.NoError(err)
+	suite.Equal(core.KVMap{"Name": "launch", "CreatedAt": "2023-03-01T10:30:00.0000005+05:30", "ClosedAt": nil}, v.Properties)
+
+	var e2 event
+	suite.NoError(NewReflectionMapper().FromVertex(v, &e2))
+	suite.Equal("launch", e2.Name)
+	suite.True(createdAt.Equal(e2.CreatedAt))
+	suite.Nil(e2.ClosedAt)
+
+	e.ClosedAt = &createdAt
+	v, err = NewReflectionMapper().ToVertex(e, nil)
+	suite.NoError(err)
+	suite.Equal("2023-03-01T10:30:00.0000005+05:30", v.Properties["ClosedAt"])
+	suite.NoError(NewReflectionMapper().FromVertex(v, &e2))
+	suite.True(createdAt.Equal(*e2.ClosedAt))
+
+	// zero times are unset
+	v, err = NewReflectionMapper().ToVertex(event{Name: "launch"}, nil)
+	suite.NoError(err)
+	suite.Equal(core.KVMap{"Name": "launch", "CreatedAt": nil, "ClosedAt": nil}, v.Properties)
+	suite.NoError(NewReflectionMapper().FromVertex(v, &e2))
+	suite.Equal(event{Name: "launch"}, e2)
+}
+
+func (suite *MapperTestSuite) TestMapVertexToStructWithTime() {
+	createdAt := time.Date(2023, 3, 1, 10, 30, 0, 0, time.UTC)
+	// temporal values returned as time.Time values by the graph database drivers are decoded as is
+	var e event
+	suite.NoError(NewReflectionMapper().FromVertex(&core.Vertex{Properties: core.KVMap{"CreatedAt": createdAt}}, &e))
+	suite.Equal(createdAt, e.CreatedAt)
+
+	suite.NoError(NewReflectionMapper().FromVertex(&core.Vertex{Properties: core.KVMap{"CreatedAt": "2023-03-01"}}, &e))
+	suite.Equal(time.Date(2023, 3, 1, 0, 0, 0, 0, time.UTC), e.CreatedAt)
+
+	err := NewReflectionMapper().FromVertex(&core.Vertex{Properties: core.KVMap{"CreatedAt": "yesterday"}}, &e)
+	suite.ErrorContains(err, `cannot decode "yesterday" into a time`)
+
+	// strings decoded into non time fields are unaffected
+	suite.NoError(NewReflectionMapper().FromVertex(&core.Vertex{Properties: core.KVMap{"Name": "2023-03-01"}}, &e))
+	suite.Equal("2023-03-01", e.Name)
+}
+
+func (suite *MapperTestSuite) TestMapStructWithPointToVertex() {
+	location := core.NewGeographicPoint(50.85, 4.35)
+	v, err := NewReflectionMapper().ToVertex(venue{Name: "Moulinsart", Location: location, Entrance: &location}, nil)
+	suite.NoError(err)
+	suite.Equal(core.KVMap{"Name": "Moulinsart", "Location": location, "Entrance": location}, v.Properties)
+
+	var decoded venue
+	suite.NoError(NewReflectionMapper().FromVertex(v, &decoded))
+	suite.Equal(venue{Name: "Moulinsart", Location: location, Entrance: &location}, decoded)
+
+	// zero points and nil point pointers are unset
+	v, err = NewReflectionMapper().ToVertex(venue{Name: "Moulinsart"}, nil)
+	suite.NoError(err)
+	suite.Equal(core.KVMap{"Name": "Moulinsart", "Location": nil, "Entrance": nil}, v.Properties)
+	suite.NoError(NewReflectionMapper().FromVertex(v, &decoded))
+	suite.Equal(venue{Name: "Moulinsart"}, decoded)
+}
+
+func (suite *MapperTestSuite) TestMapVertexToStructWithNormalizedPoint() {
+	// points normalized by core.NormalizeValue are decoded back into core.Point fields
+	var decoded venue
+	properties := core.KVMap{"Location": map[string]interface{}{"srid": int64(4326), "x": 4.35, "y": 50.85}}
+	suite.NoError(NewReflectionMapper().FromVertex(&core.Vertex{Properties: properties}, &decoded))
+	suite.Equal(core.NewGeographicPoint(50.85, 4.35), decoded.Location)
+}
+
+func (suite *MapperTestSuite) TestMapStructWithEmbeddedStructToVertex() {
+	u := user{Base: Base{ID: "u-1"}, Name: "Tintin"}
+	v, err := NewReflectionMapper().ToVertex(u, nil)
+	suite.NoError(err)
+	suite.Equal([]string{"user"}, v.Labels)
+	suite.Equal(core.KVMap{"id": "u-1", "name": "Tintin"}, v.Properties)
+
+	var decoded user
+	suite.NoError(NewReflectionMapper().FromVertex(v, &decoded))
+	suite.Equal(u, decoded)
+
+	e, err := NewReflectionMapper().ToEdge(&u, nil)
+	suite.NoError(err)
+	suite.Equal(core.KVMap{"id": "u-1", "name": "Tintin"}, e.Properties)
+}
+
+func (suite *MapperTestSuite) TestMapStructWithEmbeddedStructPointerToVertex() {
+	a := auditedUser{user: user{Name: "Haddock"}, Audit: &Audit{CreatedBy: "Tintin"}}
+	v, err := NewReflectionMapper().ToVertex(a, nil)
+	suite.NoError(err)
+	// embedded structs are flattened recursively, the unexported embedded struct is ignored
+	suite.Equal(core.KVMap{"CreatedBy": "Tintin"}, v.Properties)
+
+	var decoded auditedUser
+	suite.NoError(NewReflectionMapper().FromVertex(v, &decoded))
+	suite.Equal(&Audit{CreatedBy: "Tintin"}, decoded.Audit)
+
+	// the properties of a nil embedded struct pointer are unset
+	v, err = NewReflectionMapper().ToVertex(auditedUser{}, nil)
+	suite.NoError(err)
+	suite.Equal(core.KVMap{"CreatedBy": nil}, v.Properties)
+}
+
+func (suite *MapperTestSuite) TestMapStructWithNamedEmbeddedStructToVertex() {
+	// an embedded struct tagged with a property name is mapped to the property as is
+	t := tagged{Base: Base{ID: "t-1"}}
+	v, err := NewReflectionMapper().ToVertex(t, nil)
+	suite.NoError(err)
+	suite.Equal(core.KVMap{"base": Base{ID: "t-1"}}, v.Properties)
+}
+
+func (suite *MapperTestSuite) TestMapStructWithShadowedEmbeddedField() {
+	// as with Go embedding, the shallower field shadows the embedded field mapped to the same property
+	s := shadowing{Base: Base{ID: "base"}, Key: "outer"}
+	v, err := NewReflectionMapper().ToVertex(s, nil)
+	suite.NoError(err)
+	suite.Equal(core.KVMap{"id": "outer"}, v.Properties)
+
+	var decoded shadowing
+	suite.NoError(NewReflectionMapper().FromVertex(v, &decoded))
+	suite.Equal(shadowing{Key: "outer"}, decoded)
+
+	n := namedUser{Named: Named{Name: "base"}, Name: "Tintin"}
+	v, err = NewReflectionMapper().ToVertex(n, nil)
+	suite.NoError(err)
+	suite.Equal(core.KVMap{"Name": "Tintin"}, v.Properties)
+
+	var decodedUser namedUser
+	suite.NoError(NewReflectionMapper().FromVertex(v, &decodedUser))
+	suite.Equal(namedUser{Name: "Tintin"}, decodedUser)
+}
+
+func (suite *MapperTestSuite) TestMapStructWithAmbiguousEmbeddedField() {
+	// fields at the same depth of embedding mapped to the same property are ambiguous
+	_, err := NewReflectionMapper().ToVertex(ambiguous{}, nil)
+	suite.EqualError(err, "fields ID and Key of ambiguous map to the same property id")
+	err = NewReflectionMapper().FromVertex(&core.Vertex{Properties: core.KVMap{"id": "a-1"}}, &ambiguous{})
+	suite.EqualError(err, "fields ID and Key of ambiguous map to the same property id")
+}
+
+func (suite *MapperTestSuite) TestMapStructWithInterfaceFieldToVertex() {
+	v, err := NewReflectionMapper().ToVertex(setting{Name: "theme", Value: "dark"}, nil)
+	suite.NoError(err)
+	suite.Equal(core.KVMap{"Name": "theme", "Value": "dark"}, v.Properties)
+
+	var decoded setting
+	suite.NoError(NewReflectionMapper().FromVertex(v, &decoded))
+	suite.Equal(setting{Name: "theme", Value: "dark"}, decoded)
+
+	// dynamic values of user defined scalar types and lists are converted to builtin types
+	v, err = NewReflectionMapper().ToVertex(setting{Name: "levels", Value: []Priority{1, 2}}, nil)
+	suite.NoError(err)
+	suite.Equal([]int{1, 2}, v.Properties["Value"])
+
+	v, err = NewReflectionMapper().ToVertex(setting{Name: "unset"}, nil)
+	suite.NoError(err)
+	suite.Equal(core.KVMap{"Name": "unset", "Value": nil}, v.Properties)
+}
+
+func (suite *MapperTestSuite) TestMapStructWithInterfaceFieldHoldingStruct() {
+	_, err := NewReflectionMapper().ToVertex(setting{Name: "pair", Value: tuple{Field1: "Tom and Jerry"}}, nil)
+	suite.EqualError(err, "field Value of setting: unsupported dynamic type omg.tuple")
+	_, err = NewReflectionMapper().ToEdge(setting{Name: "pair", Value: map[string]int{"a": 1}}, nil)
+	suite.EqualError(err, "field Value of setting: unsupported dynamic type map[string]int")
+}
+
+func (suite *MapperTestSuite) TestMapVertexToStructWithInterfaceField() {
+	// properties are decoded into interface fields as returned by the graph database
+	var decoded setting
+	suite.NoError(NewReflectionMapper().FromVertex(&core.Vertex{Properties: core.KVMap{"Value": int64(42)}}, &decoded))
+	suite.Equal(int64(42), decoded.Value)
+
+	var described describedSetting
+	err := NewReflectionMapper().FromVertex(&core.Vertex{Properties: core.KVMap{"Value": int64(42)}}, &described)
+	suite.ErrorContains(err, "expected type 'fmt.Stringer', got 'int64'")
+}
+
 func TestMapperTestSuite(t *testing.T) {
 	suite.Run(t, new(MapperTestSuite))
 }
@@ -284,8 +723,206 @@ type tuple struct {
 	Field2 int32
 }
 
+type snowflake struct {
+	ID   int64
+	Name string
+}
+
 type nested struct {
 	Field1       string
 	Field2       int32
 	ComplexField tuple
 }
+
+type Status string
+
+const (
+	Active   Status = "Active"
+	Inactive Status = "Inactive"
+)
+
+type Priority int
+
+const (
+	Low Priority = iota + 1
+	High
+)
+
+type ticket struct {
+	Title    string
+	Status   Status
+	Priority Priority
+}
+
+type subscriber struct {
+	Name   string
+	Active bool
+}
+
+type order struct {
+	Number    string     `ogm:"number"`
+	LineItems []lineItem `ogm:",edge:CONTAINS"`
+}
+
+func (o *order) GetLabel() string {
+	return "Order"
+}
+
+func (o *order) GetType() GraphObjectType {
+	return Vertex
+}
+
+type node struct {
+	Name     string `ogm:"name"`
+	Children []node `ogm:",edge:CHILD"`
+}
+
+func (n *node) GetLabel() string {
+	return "Node"
+}
+
+func (n *node) GetType() GraphObjectType {
+	return Vertex
+}
+
+type Contents struct {
+	LineItems []lineItem `ogm:",edge:CONTAINS"`
+}
+
+type bundle struct {
+	Number string `ogm:"number"`
+	Contents
+}
+
+func (b *bundle) GetLabel() string {
+	return "Bundle"
+}
+
+func (b *bundle) GetType() GraphObjectType {
+	return Vertex
+}
+
+type parcel struct {
+	Number string `ogm:"number"`
+	*Contents
+}
+
+func (p *parcel) GetLabel() string {
+	return "Parcel"
+}
+
+func (p *parcel) GetType() GraphObjectType {
+	return Vertex
+}
+
+type lineItem struct {
+	Product  string `ogm:"product"`
+	Quantity int32  `ogm:"quantity"`
+}
+
+func (li *lineItem) GetLabel() string {
+	return "LineItem"
+}
+
+func (li *lineItem) GetType() GraphObjectType {
+	return Vertex
+}
+
+type publication struct {
+	Ids     []int64   `ogm:"ids"`
+	Tags    []string  `ogm:"tags"`
+	Ratings []float64 `ogm:"ratings"`
+}
+
+type address struct {
+	City string `ogm:"city"`
+	Zip  int64  `ogm:"pinCode,alt=pincode|pin_code"`
+}
+
+type account struct {
+	Name        string
+	DisplayName string `ogm:"Name"`
+}
+
+type credential struct {
+	User   string `ogm:"user"`
+	secret string
+}
+
+type event struct {
+	Name      string
+	CreatedAt time.Time
+	ClosedAt  *time.Time
+}
+
+type venue struct {
+	Name     string
+	Location core.Point
+	Entrance *core.Point
+}
+
+type Base struct {
+	ID string `ogm:"id"`
+}
+
+type user struct {
+	Base
+	Name string `ogm:"name"`
+}
+
+type Audit struct {
+	CreatedBy string
+}
+
+type auditedUser struct {
+	user
+	*Audit
+}
+
+type tagged struct {
+	Base `ogm:"base"`
+}
+
+type shadowing struct {
+	Base
+	Key string `ogm:"id"`
+}
+
+type Named struct {
+	Name string
+}
+
+type namedUser struct {
+	Named
+	Name string
+}
+
+type Keyed struct {
+	Key string `ogm:"id"`
+}
+
+type ambiguous struct {
+	Base
+	Keyed
+}
+
+type setting struct {
+	Name  string
+	Value interface{}
+}
+
+type describedSetting struct {
+	Value fmt.Stringer
+}
+
+type release struct {
+	Statuses []Status
+	Levels   [2]Priority
+	Notes    []string
+}
+
+type profile struct {
+	Name  string
+	Cache string `ogm:"-"`
+	Dash  string `ogm:"-,"`
+}
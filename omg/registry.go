@@ -0,0 +1,47 @@
+package omg
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+type graphObjectKey struct {
+	objectType GraphObjectType
+	label      string
+}
+
+var graphObjectRegistry map[graphObjectKey]reflect.Type = make(map[graphObjectKey]reflect.Type)
+
+// RegisterGraphObject registers the struct type of the specified graph object against its label and type.
+//
+// Registered types are used to map the vertices and edges read from the graph database back to structs
+// when the struct type cannot be inferred from the example passed to a store operation.
+func RegisterGraphObject(obj GraphObject) {
+	t := reflect.TypeOf(obj)
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	graphObjectRegistry[graphObjectKey{objectType: obj.GetType(), label: obj.GetLabel()}] = t
+}
+
+// newGraphObject returns a pointer to a new instance of the struct type registered against any of the
+// specified labels.
+//
+// Labels are matched case insensitively if no type is registered against the exact label since some
+// graph databases do not retain the case of the labels.
+func newGraphObject(objectType GraphObjectType, labels []string) (GraphObject, error) {
+	for _, label := range labels {
+		if t, ok := graphObjectRegistry[graphObjectKey{objectType: objectType, label: label}]; ok {
+			return reflect.New(t).Interface().(GraphObject), nil
+		}
+	}
+	for _, label := range labels {
+		for key, t := range graphObjectRegistry {
+			if key.objectType == objectType && strings.EqualFold(key.label, label) {
+				return reflect.New(t).Interface().(GraphObject), nil
+			}
+		}
+	}
+	return nil, fmt.Errorf("no graph object registered for labels %v", labels)
+}
@@ -0,0 +1,47 @@
+package omg
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type RegistryTestSuite struct {
+	suite.Suite
+}
+
+func (suite *RegistryTestSuite) TestNewRegisteredGraphObject() {
+	RegisterGraphObject(&registeredVertex{})
+	obj, err := newGraphObject(Vertex, []string{"Other", "Registered"})
+	suite.NoError(err)
+	suite.IsType(&registeredVertex{}, obj)
+}
+
+func (suite *RegistryTestSuite) TestNewRegisteredGraphObjectIgnoresLabelCase() {
+	RegisterGraphObject(&registeredVertex{})
+	obj, err := newGraphObject(Vertex, []string{"registered"})
+	suite.NoError(err)
+	suite.IsType(&registeredVertex{}, obj)
+}
+
+func (suite *RegistryTestSuite) TestNewUnregisteredGraphObject() {
+	RegisterGraphObject(&registeredVertex{})
+	_, err := newGraphObject(Edge, []string{"Registered"})
+	suite.Error(err)
+}
+
+func TestRegistryTestSuite(t *testing.T) {
+	suite.Run(t, new(RegistryTestSuite))
+}
+
+type registeredVertex struct {
+	Name string
+}
+
+func (rv *registeredVertex) GetLabel() string {
+	return "Registered"
+}
+
+func (rv *registeredVertex) GetType() GraphObjectType {
+	return Vertex
+}
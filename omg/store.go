@@ -3,6 +3,7 @@ package omg
 import (
 	"context"
 	"errors"
+	"fmt"
 	"reflect"
 
 	"github.com/prahaladd/gograph/core"
@@ -15,6 +16,10 @@ type Store interface {
 	// PersistVertex persists a struct implementing the GraphObject interface to
 	// the underlying graph database.
 	//
+	// Slices of structs tagged with an edge label using the ogm tag (for e.g. `ogm:",edge:CONTAINS"`) are not
+	// persisted as properties. Instead each element is persisted as a vertex adjacent to the persisted vertex,
+	// connected by an edge with the tagged label.
+	//
 	// Returns errors encountered during persistence.
 	PersistVertex(context.Context, GraphObject) error
 
@@ -22,8 +27,37 @@ type Store interface {
 	// within the example vertex. The example vertex need not be a fully formed
 	// entity, the query generated to read the vertex would consider all non empty
 	// fields from the struct to generate the vertex selectors
+	//
+	// Structs may designate identity fields using the identity option of the ogm tag (for e.g.
+	// `ogm:"email,identity"`), in which case only the non empty identity fields are used as selectors.
+	//
+	// Fields holding zero values (for e.g. false, 0 or "") cannot be told apart from unpopulated fields and are
+	// never used as selectors, hence an example such as Active: false matches vertices irrespective of the value of
+	// Active. Use ReadVertexBy to match on zero values.
+	//
+	// Slices tagged with an edge label are populated with the adjacent vertices connected by edges with the label.
 	ReadVertex(context.Context, GraphObject) ([]GraphObject, error)
 
+	// ReadVertexBy reads vertices from the graph database using only the specified fields of the example vertex as
+	// selectors. The fields can be specified using either the struct field names or the property names specified
+	// using the ogm tag. The named fields are used as selectors even if they are empty.
+	ReadVertexBy(ctx context.Context, exampleVertex GraphObject, selectorFields []string) ([]GraphObject, error)
+
+	// ReadOrCreateVertex reads a vertex matching the selectors specified within the example vertex and
+	// creates the vertex if no such vertex exists.
+	//
+	// Returns the hydrated vertex along with a flag indicating whether the vertex was created.
+	ReadOrCreateVertex(context.Context, GraphObject) (GraphObject, bool, error)
+
+	// ReadVertexWithNeighbors reads the vertices matching the selectors specified within the example vertex
+	// along with the edges connecting the vertices to their immediate neighbors.
+	//
+	// Only edges with the specified labels are read. Edges of all labels are read if no edge labels are specified.
+	// The neighboring vertices and the edges are mapped to the struct types registered using RegisterGraphObject.
+	//
+	// A vertex relation containing only the source vertex is returned for a matched vertex without any edges.
+	ReadVertexWithNeighbors(ctx context.Context, exampleVertex GraphObject, edgeLabels []string) ([]*VertexRelation, error)
+
 	// PersistEdge persists a vertex relation to the underlying graph database
 	// A vertex relation is a concise mechanism to declare a relationship.
 	//
@@ -43,6 +77,9 @@ type Store interface {
 	//
 	// Returns a list of all vertex relations satisfying the example on sucess, an error other wise.
 	//
+	// As with ReadVertex, fields of the example vertices and relationship holding zero values are not used as
+	// selectors.
+	//
 	// A vertex relation is a concise mechanism to declare a relationship.
 	//
 	// The returned result depends upon the contents of the passed in example relation:
@@ -60,34 +97,88 @@ type Store interface {
 	// The use case where-in only the source vertex is specified, but the relationship and the
 	// destination vertex is nil is equivalent to  querying for a single isolated vertex
 	// from the graph database
+	//
+	// If the example relation specifies a hop range using MinHops and MaxHops, then the source and destination
+	// vertices connected by a path of edges of the relationship type are returned. The returned relations
+	// do not contain the relationship since the path traverses multiple edges.
+	//
+	// The vertices are read using core.EdgeWithCompleteVertex unless a different fetch mode is specified within
+	// the context using core.WithEdgeFetchMode.
 	ReadEdge(context.Context, *VertexRelation) ([]*VertexRelation, error)
+
+	// RunInTransaction executes the specified function as a single unit of work.
+	//
+	// All operations performed through the store passed to the function are routed through a single
+	// transaction on the underlying connection. The transaction is committed if the function returns
+	// successfully and rolled back if the function returns an error.
+	RunInTransaction(ctx context.Context, fn func(txStore Store) error) error
 }
 
 type GenericStore struct {
 	connection core.Connection
 	mapper     Mapper
+	// writeMode is the write mode used by PersistVertex and PersistEdge unless the context specifies one. The write
+	// mode of the connection is used if nil.
+	writeMode *core.WriteMode
 }
 
 // PersistVertex persists a struct implementing the GraphObject interface to
 // the underlying graph database.
 //
+// Elements of slices tagged with an edge label are persisted as adjacent vertices. The adjacent vertices are
+// stored using the MERGE semantics of the underlying connection, hence identical elements are persisted as a
+// single vertex. Use RunInTransaction to persist the vertex and the adjacent vertices atomically.
+//
+// The vertex is written using the write mode specified within the context using core.WithWriteMode, falling back to
+// the write mode of the store. For e.g. core.Create must be used to store vertices with new labels to Agensgraph.
+//
 // Returns errors encountered during persistence.
 func (gs *GenericStore) PersistVertex(ctx context.Context, vertex GraphObject) error {
+	ctx = gs.writeContext(ctx)
 	if vertex.GetType() != Vertex {
 		return errors.New("specified value must be of graph object type vertex")
 	}
+	// validate the adjacent fields before anything is stored
+	if _, err := adjacentFields(reflect.Indirect(reflect.ValueOf(vertex)).Type()); err != nil {
+		return err
+	}
 	v, err := gs.mapper.ToVertex(vertex, []string{vertex.GetLabel()})
 	if err != nil {
 		return err
 	}
-	return gs.connection.StoreVertex(ctx, v)
+	if err = gs.connection.StoreVertex(ctx, v); err != nil {
+		return err
+	}
+	return gs.persistAdjacentVertices(ctx, v, vertex)
 }
 
 // ReadVertex reads a vertex from the graph database using the selectors specified
 // within the example vertex. The example vertex need not be a fully formed
 // entity, the query generated to read the vertex would consider all non empty
 // fields from the struct to generate the vertex selectors
+//
+// If the struct designates identity fields using the identity option of the ogm tag, then only the non empty
+// identity fields are used as selectors. All the non empty fields are used if none of the identity fields are
+// populated.
+//
+// Fields holding zero values are never used as selectors since they cannot be told apart from unpopulated fields.
+// ReadVertexBy must be used to match on zero values.
 func (gs *GenericStore) ReadVertex(ctx context.Context, exampleVertex GraphObject) ([]GraphObject, error) {
+	return gs.readVertices(ctx, exampleVertex, func(properties core.KVMap) (core.KVMap, error) {
+		return exampleSelectors(exampleVertex, properties), nil
+	})
+}
+
+// ReadVertexBy reads vertices from the graph database using only the specified fields of the example vertex as
+// selectors.
+func (gs *GenericStore) ReadVertexBy(ctx context.Context, exampleVertex GraphObject, selectorFields []string) ([]GraphObject, error) {
+	return gs.readVertices(ctx, exampleVertex, func(properties core.KVMap) (core.KVMap, error) {
+		return namedSelectorProperties(exampleVertex, properties, selectorFields)
+	})
+}
+
+// readVertices reads the vertices matching the selectors derived from the properties of the example vertex
+func (gs *GenericStore) readVertices(ctx context.Context, exampleVertex GraphObject, selectorsFn func(core.KVMap) (core.KVMap, error)) ([]GraphObject, error) {
 	if exampleVertex.GetType() != Vertex {
 		return nil, errors.New("specified value must be of graph object type vertex")
 	}
@@ -95,8 +186,12 @@ func (gs *GenericStore) ReadVertex(ctx context.Context, exampleVertex GraphObjec
 	if err != nil {
 		return nil, err
 	}
+	selectors, err := selectorsFn(v.GetProperties())
+	if err != nil {
+		return nil, err
+	}
 
-	resultVertices, err := gs.connection.QueryVertex(ctx, v.GetLabel()[0], v.GetProperties(), nil, nil)
+	resultVertices, err := gs.connection.QueryVertex(ctx, v.GetLabel()[0], selectors, nil, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -105,12 +200,157 @@ func (gs *GenericStore) ReadVertex(ctx context.Context, exampleVertex GraphObjec
 	for _, rv := range resultVertices {
 		graphObj := reflect.New(reflect.TypeOf(exampleVertex).Elem())
 		gs.mapper.FromVertex(rv, graphObj.Interface())
+		if err = gs.readAdjacentVertices(ctx, v.Labels, rv, graphObj, make(map[string]struct{})); err != nil {
+			return nil, err
+		}
 		toRet = append(toRet, graphObj.Interface().(GraphObject))
 	}
 
 	return toRet, nil
 }
 
+// ReadOrCreateVertex reads a vertex matching the selectors specified within the example vertex and
+// creates the vertex if no such vertex exists.
+//
+// Returns the hydrated vertex along with a flag indicating whether the vertex was created. If more than
+// one vertex matches the example, then the first matching vertex is returned.
+//
+// The vertex is created using the MERGE semantics of the underlying connection. Hence concurrent
+// invocations with the same example would not create duplicate vertices, however more than one
+// invocation may report the vertex as created.
+func (gs *GenericStore) ReadOrCreateVertex(ctx context.Context, exampleVertex GraphObject) (GraphObject, bool, error) {
+	existing, err := gs.ReadVertex(ctx, exampleVertex)
+	if err != nil {
+		return nil, false, err
+	}
+	if len(existing) > 0 {
+		return existing[0], false, nil
+	}
+	v, err := gs.mapper.ToVertex(exampleVertex, []string{exampleVertex.GetLabel()})
+	if err != nil {
+		return nil, false, err
+	}
+	err = gs.connection.StoreVertex(gs.writeContext(ctx), v)
+	if err != nil {
+		return nil, false, err
+	}
+	graphObj := reflect.New(reflect.TypeOf(exampleVertex).Elem())
+	err = gs.mapper.FromVertex(v, graphObj.Interface())
+	if err != nil {
+		return nil, false, err
+	}
+	return graphObj.Interface().(GraphObject), true, nil
+}
+
+// ReadVertexWithNeighbors reads the vertices matching the selectors specified within the example vertex
+// along with the edges connecting the vertices to their immediate neighbors. Only the non empty fields of the
+// example vertex are used as selectors.
+//
+// Only edges with the specified labels are read. Edges of all labels are read if no edge labels are specified.
+// The matched vertices are mapped to the type of the example vertex, while the neighboring vertices and the
+// edges are mapped to the struct types registered using RegisterGraphObject. An error is returned if no type
+// is registered for a neighboring vertex or an edge.
+//
+// Relations are returned for both incoming and outgoing edges, with the source and destination vertices
+// reflecting the direction of the edge. A vertex relation containing only the source vertex is returned
+// for a matched vertex without any edges.
+func (gs *GenericStore) ReadVertexWithNeighbors(ctx context.Context, exampleVertex GraphObject, edgeLabels []string) ([]*VertexRelation, error) {
+	if exampleVertex.GetType() != Vertex {
+		return nil, errors.New("specified value must be of graph object type vertex")
+	}
+	v, err := gs.mapper.ToVertex(exampleVertex, []string{exampleVertex.GetLabel()})
+	if err != nil {
+		return nil, err
+	}
+	v.Properties = exampleSelectors(exampleVertex, v.Properties)
+	matchedVertices, err := gs.connection.QueryVertex(ctx, v.GetLabel()[0], v.GetProperties(), nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	if len(edgeLabels) == 0 {
+		// an empty label selects edges of all types
+		edgeLabels = []string{""}
+	}
+	edges := make([]*core.Edge, 0)
+	seenEdges := make(map[string]bool)
+	for _, edgeLabel := range edgeLabels {
+		outgoing, err := gs.connection.QueryEdge(ctx, v.Labels, nil, edgeLabel, v.Properties, nil, nil, nil, nil, nil, nil, core.EdgeWithCompleteVertex)
+		if err != nil {
+			return nil, err
+		}
+		incoming, err := gs.connection.QueryEdge(ctx, nil, v.Labels, edgeLabel, nil, v.Properties, nil, nil, nil, nil, nil, core.EdgeWithCompleteVertex)
+		if err != nil {
+			return nil, err
+		}
+		for _, edge := range append(outgoing, incoming...) {
+			// self loops are returned as both an incoming and an outgoing edge
+			if seenEdges[edge.ID.String()] {
+				continue
+			}
+			seenEdges[edge.ID.String()] = true
+			edges = append(edges, edge)
+		}
+	}
+
+	vrs := make([]*VertexRelation, 0)
+	for _, matchedVertex := range matchedVertices {
+		vertexObj := reflect.New(reflect.TypeOf(exampleVertex).Elem())
+		err = gs.mapper.FromVertex(matchedVertex, vertexObj.Interface())
+		if err != nil {
+			return nil, err
+		}
+		matchedObj := vertexObj.Interface().(GraphObject)
+		hasEdges := false
+		for _, edge := range edges {
+			isSource := edge.SourceVertex.ID.String() == matchedVertex.ID.String()
+			isDestination := edge.DestinationVertex.ID.String() == matchedVertex.ID.String()
+			if !isSource && !isDestination {
+				continue
+			}
+			hasEdges = true
+			vr := VertexRelation{SourceVertex: matchedObj, DestinationVertex: matchedObj}
+			if !isSource {
+				vr.SourceVertex, err = gs.mapRegisteredVertex(edge.SourceVertex)
+				if err != nil {
+					return nil, err
+				}
+			}
+			if !isDestination {
+				vr.DestinationVertex, err = gs.mapRegisteredVertex(edge.DestinationVertex)
+				if err != nil {
+					return nil, err
+				}
+			}
+			vr.Relationship, err = newGraphObject(Edge, []string{edge.Type})
+			if err != nil {
+				return nil, err
+			}
+			err = gs.mapper.FromEdge(edge, vr.Relationship)
+			if err != nil {
+				return nil, err
+			}
+			vrs = append(vrs, &vr)
+		}
+		if !hasEdges {
+			vrs = append(vrs, &VertexRelation{SourceVertex: matchedObj})
+		}
+	}
+	return vrs, nil
+}
+
+// mapRegisteredVertex maps the specified vertex to the struct type registered against the labels of the vertex
+func (gs *GenericStore) mapRegisteredVertex(vertex *core.Vertex) (GraphObject, error) {
+	obj, err := newGraphObject(Vertex, vertex.Labels)
+	if err != nil {
+		return nil, err
+	}
+	err = gs.mapper.FromVertex(vertex, obj)
+	if err != nil {
+		return nil, err
+	}
+	return obj, nil
+}
+
 // PersistEdge persists a vertex relation to the underlying graph database
 // A vertex relation is a concise mechanism to declare a relationship.
 //
@@ -123,14 +363,22 @@ func (gs *GenericStore) ReadVertex(ctx context.Context, exampleVertex GraphObjec
 // The use case where-in only the source vertex is specified, but the relationship and the
 // destination vertex is nil is equivalent to  creating a single isolated vertex
 // from the graph database
+//
+// The edge is written using the write mode specified within the context using core.WithWriteMode, falling back to
+// the write mode of the store.
 func (gs *GenericStore) PersistEdge(ctx context.Context, edge *VertexRelation) error {
+	ctx = gs.writeContext(ctx)
 	if edge.SourceVertex == nil {
 		return errors.New("source vertex cannot be nil")
 	}
-	if edge.SourceVertex == nil && edge.DestinationVertex == nil {
-		return errors.New("source vertex and destination vertex cannot be nil")
+	if edge.DestinationVertex == nil {
+		if edge.Relationship != nil {
+			return errors.New("destination vertex cannot be nil when edge is specified")
+		}
+		// a relation with only the source vertex represents an isolated vertex
+		return gs.PersistVertex(ctx, edge.SourceVertex)
 	}
-	if edge.SourceVertex != nil && edge.DestinationVertex != nil && edge.Relationship == nil {
+	if edge.Relationship == nil {
 		return errors.New("edge cannot be nil when source and destination vertices are specified")
 	}
 	// validate that the types are correct
@@ -168,7 +416,15 @@ func (gs *GenericStore) PersistEdge(ctx context.Context, edge *VertexRelation) e
 // Returns a list of all vertex relations satisfying the example on sucess, an error other wise.
 //
 // A vertex relation is a concise mechanism to declare a relationship.
-
+//
+// Fields of the example vertices and relationship holding zero values are not used as selectors.
+//
+// If the example relation specifies a hop range using MinHops and MaxHops, then the source and destination
+// vertices connected by a path of edges of the relationship type are returned without the relationship.
+//
+// The source and destination vertices are read along with the relationship unless core.EdgeWithVertexIds is specified
+// as the fetch mode within the context using core.WithEdgeFetchMode, in which case only the relationships are
+// returned. Skipping the vertices reduces the data read for callers interested only in the relationships.
 func (gs *GenericStore) ReadEdge(ctx context.Context, exampleEdge *VertexRelation) ([]*VertexRelation, error) {
 	emptyExample := VertexRelation{}
 	if exampleEdge == &emptyExample {
@@ -201,9 +457,16 @@ func (gs *GenericStore) ReadEdge(ctx context.Context, exampleEdge *VertexRelatio
 		}
 		relLabel = rel.Type
 	}
+	srcVertex.Properties = selectorProperties(srcVertex.Properties)
+	destVertex.Properties = selectorProperties(destVertex.Properties)
+	rel.Properties = selectorProperties(rel.Properties)
 	rel.SourceVertex = srcVertex
 	rel.DestinationVertex = destVertex
-	edges, err := gs.connection.QueryEdge(ctx, srcVertex.Labels, destVertex.Labels, rel.Type, srcVertex.Properties, destVertex.Properties, rel.Properties, nil, nil, nil, nil, core.EdgeWithCompleteVertex)
+	if exampleEdge.IsVariableLength() {
+		return gs.readPaths(ctx, exampleEdge, srcVertex, destVertex, rel)
+	}
+	fetchMode := core.EdgeFetchModeFromContext(ctx, core.EdgeWithCompleteVertex)
+	edges, err := gs.connection.QueryEdge(ctx, srcVertex.Labels, destVertex.Labels, rel.Type, srcVertex.Properties, destVertex.Properties, rel.Properties, nil, nil, nil, nil, fetchMode)
 	if err != nil {
 		return nil, err
 	}
@@ -211,20 +474,147 @@ func (gs *GenericStore) ReadEdge(ctx context.Context, exampleEdge *VertexRelatio
 	vrs := make([]*VertexRelation, 0)
 	for _, edge := range edges {
 		vr := VertexRelation{}
-		srcVertexObj := reflect.New(reflect.TypeOf(exampleEdge.SourceVertex).Elem())
-		gs.mapper.FromVertex(edge.SourceVertex, srcVertexObj.Interface())
-		destVertexObj := reflect.New(reflect.TypeOf(exampleEdge.DestinationVertex).Elem())
-		gs.mapper.FromVertex(edge.DestinationVertex, destVertexObj.Interface())
+		if fetchMode == core.EdgeWithCompleteVertex {
+			srcVertexObj := reflect.New(reflect.TypeOf(exampleEdge.SourceVertex).Elem())
+			gs.mapper.FromVertex(edge.SourceVertex, srcVertexObj.Interface())
+			destVertexObj := reflect.New(reflect.TypeOf(exampleEdge.DestinationVertex).Elem())
+			gs.mapper.FromVertex(edge.DestinationVertex, destVertexObj.Interface())
+			vr.SourceVertex = srcVertexObj.Interface().(GraphObject)
+			vr.DestinationVertex = destVertexObj.Interface().(GraphObject)
+		}
 		relObj := reflect.New(reflect.TypeOf(exampleEdge.Relationship).Elem())
 		gs.mapper.FromEdge(edge, relObj.Interface())
+		vr.Relationship = relObj.Interface().(GraphObject)
+		vrs = append(vrs, &vr)
+	}
+	return vrs, nil
+}
+
+// readPaths reads the source and destination vertices connected by a variable length relationship
+func (gs *GenericStore) readPaths(ctx context.Context, exampleEdge *VertexRelation, srcVertex, destVertex *core.Vertex, rel *core.Edge) ([]*VertexRelation, error) {
+	paths, err := gs.connection.QueryPaths(ctx, srcVertex.Labels, destVertex.Labels, rel.Type, srcVertex.Properties, destVertex.Properties, rel.Properties, exampleEdge.MinHops, exampleEdge.MaxHops)
+	if err != nil {
+		return nil, err
+	}
+	vrs := make([]*VertexRelation, 0)
+	for _, path := range paths {
+		vr := VertexRelation{MinHops: exampleEdge.MinHops, MaxHops: exampleEdge.MaxHops}
+		srcVertexObj := reflect.New(reflect.TypeOf(exampleEdge.SourceVertex).Elem())
+		err = gs.mapper.FromVertex(path.StartVertex, srcVertexObj.Interface())
+		if err != nil {
+			return nil, err
+		}
+		destVertexObj := reflect.New(reflect.TypeOf(exampleEdge.DestinationVertex).Elem())
+		err = gs.mapper.FromVertex(path.EndVertex, destVertexObj.Interface())
+		if err != nil {
+			return nil, err
+		}
 		vr.SourceVertex = srcVertexObj.Interface().(GraphObject)
 		vr.DestinationVertex = destVertexObj.Interface().(GraphObject)
-		vr.Relationship = relObj.Interface().(GraphObject)
 		vrs = append(vrs, &vr)
 	}
 	return vrs, nil
 }
 
+// RunInTransaction executes the specified function as a single unit of work.
+//
+// All operations performed through the store passed to the function are routed through a single
+// transaction on the underlying connection. The transaction is committed if the function returns
+// successfully and rolled back if the function returns an error.
+func (gs *GenericStore) RunInTransaction(ctx context.Context, fn func(txStore Store) error) error {
+	tx, err := gs.connection.BeginTx(ctx)
+	if err != nil {
+		return err
+	}
+	err = fn(&GenericStore{connection: tx, mapper: gs.mapper, writeMode: gs.writeMode})
+	if err != nil {
+		if rollbackErr := tx.Rollback(ctx); rollbackErr != nil {
+			return fmt.Errorf("%w (rollback failed: %v)", err, rollbackErr)
+		}
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+// writeContext returns a copy of the specified context carrying the write mode of the store, unless the context
+// already specifies a write mode.
+func (gs *GenericStore) writeContext(ctx context.Context) context.Context {
+	if gs.writeMode == nil {
+		return ctx
+	}
+	if _, ok := core.WriteModeFromContext(ctx); ok {
+		return ctx
+	}
+	return core.WithWriteMode(ctx, *gs.writeMode)
+}
+
+// selectorProperties returns the properties of an example graph object to be used as selectors. Properties
+// with zero values are not considered since they correspond to fields which are not populated in the example.
+func selectorProperties(properties core.KVMap) core.KVMap {
+	selectors := make(core.KVMap)
+	for k, v := range properties {
+		if v == nil || reflect.ValueOf(v).IsZero() {
+			continue
+		}
+		selectors[k] = v
+	}
+	return selectors
+}
+
+// exampleSelectors returns the properties of an example vertex to be used as selectors. Only the populated identity
+// properties are used if the struct designates identity fields, falling back to all the populated properties.
+func exampleSelectors(example GraphObject, properties core.KVMap) core.KVMap {
+	t := reflect.TypeOf(example)
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	identity := make(core.KVMap)
+	// the fields have been validated when mapping the example to properties, hence the error is not checked
+	fields, _ := propertyFields(t)
+	for _, field := range fields {
+		if tag := parseOgmTag(field); tag.identity {
+			identity[tag.name] = properties[tag.name]
+		}
+	}
+	if selectors := selectorProperties(identity); len(selectors) > 0 {
+		return selectors
+	}
+	return selectorProperties(properties)
+}
+
+// namedSelectorProperties returns the properties of the example graph object corresponding to the specified fields.
+// Fields are specified either using the property names or the struct field names.
+func namedSelectorProperties(example GraphObject, properties core.KVMap, fields []string) (core.KVMap, error) {
+	t := reflect.TypeOf(example)
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	selectors := make(core.KVMap)
+	for _, field := range fields {
+		key := field
+		if _, ok := properties[key]; !ok {
+			structField, found := t.FieldByName(field)
+			if !found {
+				return nil, fmt.Errorf("unknown selector field %s", field)
+			}
+			key = parseOgmTag(structField).name
+		}
+		value, ok := properties[key]
+		if !ok {
+			return nil, fmt.Errorf("unknown selector field %s", field)
+		}
+		selectors[key] = value
+	}
+	return selectors, nil
+}
+
 func NewGenericStore(connection core.Connection, mapper Mapper) Store {
 	return &GenericStore{connection: connection, mapper: mapper}
 }
+
+// NewGenericStoreWithWriteMode creates a store which persists vertices and edges using the specified write mode,
+// irrespective of the default write mode of the connection. A write mode specified within the context using
+// core.WithWriteMode takes precedence over the write mode of the store.
+func NewGenericStoreWithWriteMode(connection core.Connection, mapper Mapper, writeMode core.WriteMode) Store {
+	return &GenericStore{connection: connection, mapper: mapper, writeMode: &writeMode}
+}
@@ -0,0 +1,398 @@
+package omg
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/prahaladd/gograph/core"
+	"github.com/stretchr/testify/suite"
+)
+
+// queryRecordingConnection is a connection recording the selectors used to query vertices. Only the methods used
+// by the tests are implemented.
+type queryRecordingConnection struct {
+	core.Connection
+	label     string
+	selectors core.KVMap
+}
+
+func (qc *queryRecordingConnection) QueryVertex(ctx context.Context, label string, selectors, filters, queryParams core.KVMap) ([]*core.Vertex, error) {
+	qc.label = label
+	qc.selectors = selectors
+	return []*core.Vertex{{Labels: []string{label}, Properties: core.KVMap{"name": "Tom", "age": int64(10), "dept": "Sales"}}}, nil
+}
+
+// memoryConnection is a connection storing vertices and edges in memory. Only the methods used by the tests are
+// implemented.
+type memoryConnection struct {
+	core.Connection
+	vertices []*core.Vertex
+	edges    []*core.Edge
+	// existingEndpoints records whether existing endpoints were required when storing each edge
+	existingEndpoints []bool
+	// fetchModes records the fetch mode of each edge query
+	fetchModes []core.EdgeFetchMode
+	// edgeSelectors records the source vertex, destination vertex and edge selectors of each edge query
+	edgeSelectors [][]core.KVMap
+	// writeModes records the write mode specified within the context of each vertex and edge write. Merge is
+	// recorded if no write mode is specified.
+	writeModes []core.WriteMode
+}
+
+func (mc *memoryConnection) StoreVertex(ctx context.Context, vertex *core.Vertex) error {
+	mc.vertices = append(mc.vertices, vertex)
+	mc.recordWriteMode(ctx)
+	return nil
+}
+
+func (mc *memoryConnection) StoreEdge(ctx context.Context, edge *core.Edge) error {
+	mc.edges = append(mc.edges, edge)
+	mc.recordWriteMode(ctx)
+	mc.existingEndpoints = append(mc.existingEndpoints, core.RequireExistingEndpoints(ctx))
+	return nil
+}
+
+func (mc *memoryConnection) BeginTx(ctx context.Context) (core.Tx, error) {
+	return &memoryTx{memoryConnection: mc}, nil
+}
+
+// memoryTx is a transaction writing directly to the memory connection it was started on
+type memoryTx struct {
+	*memoryConnection
+}
+
+func (mt *memoryTx) Commit(ctx context.Context) error {
+	return nil
+}
+
+func (mt *memoryTx) Rollback(ctx context.Context) error {
+	return nil
+}
+
+func (mc *memoryConnection) recordWriteMode(ctx context.Context) {
+	writeMode, _ := core.WriteModeFromContext(ctx)
+	mc.writeModes = append(mc.writeModes, writeMode)
+}
+
+func (mc *memoryConnection) QueryVertex(ctx context.Context, label string, selectors, filters, queryParams core.KVMap) ([]*core.Vertex, error) {
+	vertices := make([]*core.Vertex, 0)
+	for _, vertex := range mc.vertices {
+		if vertex.Labels[0] == label && reflect.DeepEqual(vertex.Properties, selectors) {
+			vertices = append(vertices, vertex)
+		}
+	}
+	return vertices, nil
+}
+
+func (mc *memoryConnection) QueryEdge(ctx context.Context, startVertexLabels, endVertexLabels []string, label string, startVertexSelectors, endVertexSelectors, selectors, startVertexFilters, endVertexFilters, filters, queryParams core.KVMap, edgeFetchMode core.EdgeFetchMode) ([]*core.Edge, error) {
+	mc.fetchModes = append(mc.fetchModes, edgeFetchMode)
+	mc.edgeSelectors = append(mc.edgeSelectors, []core.KVMap{startVertexSelectors, endVertexSelectors, selectors})
+	edges := make([]*core.Edge, 0)
+	for _, edge := range mc.edges {
+		if edge.Type == label && reflect.DeepEqual(edge.SourceVertex.Labels, startVertexLabels) &&
+			reflect.DeepEqual(edge.DestinationVertex.Labels, endVertexLabels) &&
+			reflect.DeepEqual(edge.SourceVertex.Properties, startVertexSelectors) {
+			if edgeFetchMode == core.EdgeWithVertexIds {
+				edges = append(edges, &core.Edge{Type: edge.Type, Properties: edge.Properties})
+				continue
+			}
+			edges = append(edges, edge)
+		}
+	}
+	return edges, nil
+}
+
+type StoreTestSuite struct {
+	suite.Suite
+}
+
+func (suite *StoreTestSuite) TestSelectorPropertiesSkipsEmptyFields() {
+	v, err := NewReflectionMapper().ToVertex(&nested{Field1: "value"}, nil)
+	suite.NoError(err)
+	suite.Equal(core.KVMap{"Field1": "value"}, selectorProperties(v.Properties))
+}
+
+func (suite *StoreTestSuite) TestSelectorPropertiesRetainsPopulatedFields() {
+	v, err := NewReflectionMapper().ToVertex(&nested{Field1: "value", Field2: 10, ComplexField: tuple{Field1: "nested"}}, nil)
+	suite.NoError(err)
+	suite.Equal(v.Properties, selectorProperties(v.Properties))
+}
+
+func (suite *StoreTestSuite) TestReadVertexUsesAllPopulatedFields() {
+	conn := &queryRecordingConnection{}
+	store := NewGenericStore(conn, NewReflectionMapper())
+	_, err := store.ReadVertex(context.Background(), &employee{Name: "Tom", Age: 10})
+	suite.NoError(err)
+	suite.Equal(core.KVMap{"name": "Tom", "age": int32(10)}, conn.selectors)
+}
+
+func (suite *StoreTestSuite) TestReadVertexUsesIdentityFields() {
+	conn := &queryRecordingConnection{}
+	store := NewGenericStore(conn, NewReflectionMapper())
+	_, err := store.ReadVertex(context.Background(), &member{Email: "tom@example.com", Name: "Tom", Age: 10})
+	suite.NoError(err)
+	suite.Equal("Member", conn.label)
+	suite.Equal(core.KVMap{"email": "tom@example.com"}, conn.selectors)
+}
+
+func (suite *StoreTestSuite) TestReadVertexWithoutIdentityUsesPopulatedFields() {
+	conn := &queryRecordingConnection{}
+	store := NewGenericStore(conn, NewReflectionMapper())
+	_, err := store.ReadVertex(context.Background(), &member{Name: "Tom"})
+	suite.NoError(err)
+	suite.Equal(core.KVMap{"name": "Tom"}, conn.selectors)
+}
+
+func (suite *StoreTestSuite) TestReadVertexByField() {
+	conn := &queryRecordingConnection{}
+	store := NewGenericStore(conn, NewReflectionMapper())
+	vertices, err := store.ReadVertexBy(context.Background(), &employee{Name: "Tom", Age: 11, Department: "Marketing"}, []string{"Name"})
+	suite.NoError(err)
+	suite.Equal("Employee", conn.label)
+	suite.Equal(core.KVMap{"name": "Tom"}, conn.selectors)
+	suite.Equal(1, len(vertices))
+	suite.Equal(employee{Name: "Tom", Age: 10, Department: "Sales"}, *(vertices[0].(*employee)))
+}
+
+func (suite *StoreTestSuite) TestReadVertexByPropertyName() {
+	conn := &queryRecordingConnection{}
+	store := NewGenericStore(conn, NewReflectionMapper())
+	_, err := store.ReadVertexBy(context.Background(), &employee{Name: "Tom", Department: "Marketing"}, []string{"dept", "Age"})
+	suite.NoError(err)
+	// named fields are used as selectors even if empty
+	suite.Equal(core.KVMap{"dept": "Marketing", "age": int32(0)}, conn.selectors)
+}
+
+func (suite *StoreTestSuite) TestReadVertexByUnknownField() {
+	store := NewGenericStore(&queryRecordingConnection{}, NewReflectionMapper())
+	_, err := store.ReadVertexBy(context.Background(), &employee{Name: "Tom"}, []string{"Salary"})
+	suite.EqualError(err, "unknown selector field Salary")
+}
+
+func (suite *StoreTestSuite) TestPersistVertexWithAdjacentVertices() {
+	conn := &memoryConnection{}
+	store := NewGenericStore(conn, NewReflectionMapper())
+	o := order{Number: "A-1", LineItems: []lineItem{{Product: "Pen", Quantity: 2}, {Product: "Ink", Quantity: 1}}}
+	suite.NoError(store.PersistVertex(context.Background(), &o))
+
+	suite.Equal(3, len(conn.vertices))
+	suite.Equal(core.KVMap{"number": "A-1"}, conn.vertices[0].Properties)
+	suite.Equal(2, len(conn.edges))
+	for _, edge := range conn.edges {
+		suite.Equal("CONTAINS", edge.Type)
+		suite.Equal([]string{"Order"}, edge.SourceVertex.Labels)
+		suite.Equal([]string{"LineItem"}, edge.DestinationVertex.Labels)
+	}
+	suite.Equal(core.KVMap{"product": "Ink", "quantity": int32(1)}, conn.edges[1].DestinationVertex.Properties)
+	suite.Equal([]bool{true, true}, conn.existingEndpoints)
+}
+
+func (suite *StoreTestSuite) TestReadVertexWithAdjacentVertices() {
+	conn := &memoryConnection{}
+	store := NewGenericStore(conn, NewReflectionMapper())
+	o := order{Number: "A-1", LineItems: []lineItem{{Product: "Pen", Quantity: 2}, {Product: "Ink", Quantity: 1}}}
+	suite.NoError(store.PersistVertex(context.Background(), &o))
+
+	orders, err := store.ReadVertex(context.Background(), &order{Number: "A-1"})
+	suite.NoError(err)
+	suite.Equal(1, len(orders))
+	suite.Equal(o, *(orders[0].(*order)))
+}
+
+func (suite *StoreTestSuite) TestPersistVertexWithAdjacentVerticesInEmbeddedStruct() {
+	conn := &memoryConnection{}
+	store := NewGenericStore(conn, NewReflectionMapper())
+	b := bundle{Number: "B-1", Contents: Contents{LineItems: []lineItem{{Product: "Pen", Quantity: 2}}}}
+	suite.NoError(store.PersistVertex(context.Background(), &b))
+
+	suite.Equal(2, len(conn.vertices))
+	suite.Equal(1, len(conn.edges))
+	suite.Equal("CONTAINS", conn.edges[0].Type)
+	suite.Equal(core.KVMap{"product": "Pen", "quantity": int32(2)}, conn.edges[0].DestinationVertex.Properties)
+
+	bundles, err := store.ReadVertex(context.Background(), &bundle{Number: "B-1"})
+	suite.NoError(err)
+	suite.Equal(1, len(bundles))
+	suite.Equal(b, *(bundles[0].(*bundle)))
+}
+
+func (suite *StoreTestSuite) TestPersistVertexWithAdjacentVerticesInEmbeddedPointer() {
+	conn := &memoryConnection{}
+	store := NewGenericStore(conn, NewReflectionMapper())
+	// a nil embedded pointer has no adjacent vertices
+	suite.NoError(store.PersistVertex(context.Background(), &parcel{Number: "P-1"}))
+	suite.Equal(0, len(conn.edges))
+
+	p := parcel{Number: "P-2", Contents: &Contents{LineItems: []lineItem{{Product: "Ink", Quantity: 1}}}}
+	suite.NoError(store.PersistVertex(context.Background(), &p))
+	suite.Equal(1, len(conn.edges))
+
+	parcels, err := store.ReadVertex(context.Background(), &parcel{Number: "P-2"})
+	suite.NoError(err)
+	suite.Equal(1, len(parcels))
+	suite.Equal(p, *(parcels[0].(*parcel)))
+
+	parcels, err = store.ReadVertex(context.Background(), &parcel{Number: "P-1"})
+	suite.NoError(err)
+	suite.Equal(1, len(parcels))
+	suite.Nil(parcels[0].(*parcel).Contents)
+}
+
+func (suite *StoreTestSuite) TestReadVertexWithCyclicAdjacentVertices() {
+	a := &core.Vertex{Labels: []string{"Node"}, Properties: core.KVMap{"name": "A"}}
+	b := &core.Vertex{Labels: []string{"Node"}, Properties: core.KVMap{"name": "B"}}
+	conn := &memoryConnection{vertices: []*core.Vertex{a, b}, edges: []*core.Edge{
+		{Type: "CHILD", SourceVertex: a, DestinationVertex: b},
+		{Type: "CHILD", SourceVertex: b, DestinationVertex: a},
+	}}
+	store := NewGenericStore(conn, NewReflectionMapper())
+
+	nodes, err := store.ReadVertex(context.Background(), &node{Name: "A"})
+	suite.NoError(err)
+	suite.Equal(1, len(nodes))
+	// the children of A are not read again once the cycle leads back to A
+	suite.Equal(node{Name: "A", Children: []node{{Name: "B", Children: []node{{Name: "A"}}}}}, *(nodes[0].(*node)))
+}
+
+func (suite *StoreTestSuite) TestPersistVertexWithInvalidAdjacentField() {
+	conn := &memoryConnection{}
+	store := NewGenericStore(conn, NewReflectionMapper())
+	err := store.PersistVertex(context.Background(), &invoice{Number: "I-1", Amounts: []int{10}})
+	suite.EqualError(err, "field Amounts mapped to adjacent vertices must be a slice of structs")
+	suite.Equal(0, len(conn.vertices))
+}
+
+func (suite *StoreTestSuite) TestReadEdgeReadsCompleteVerticesByDefault() {
+	conn := &memoryConnection{}
+	store := NewGenericStore(conn, NewReflectionMapper())
+	o := order{Number: "A-1", LineItems: []lineItem{{Product: "Pen", Quantity: 2}}}
+	suite.NoError(store.PersistVertex(context.Background(), &o))
+
+	vrs, err := store.ReadEdge(context.Background(), &VertexRelation{SourceVertex: &order{Number: "A-1"}, DestinationVertex: &lineItem{}, Relationship: &contains{}})
+	suite.NoError(err)
+	suite.Equal([]core.EdgeFetchMode{core.EdgeWithCompleteVertex}, conn.fetchModes)
+	suite.Equal(1, len(vrs))
+	suite.Equal(&lineItem{Product: "Pen", Quantity: 2}, vrs[0].DestinationVertex)
+}
+
+func (suite *StoreTestSuite) TestReadEdgeSkipsZeroValuedFields() {
+	conn := &memoryConnection{}
+	store := NewGenericStore(conn, NewReflectionMapper())
+	_, err := store.ReadEdge(context.Background(), &VertexRelation{SourceVertex: &order{Number: "A-1"}, DestinationVertex: &lineItem{Quantity: 0}, Relationship: &contains{}})
+	suite.NoError(err)
+	suite.Equal([][]core.KVMap{{{"number": "A-1"}, {}, {}}}, conn.edgeSelectors)
+}
+
+func (suite *StoreTestSuite) TestReadEdgeWithFetchModeFromContext() {
+	conn := &memoryConnection{}
+	store := NewGenericStore(conn, NewReflectionMapper())
+	o := order{Number: "A-1", LineItems: []lineItem{{Product: "Pen", Quantity: 2}}}
+	suite.NoError(store.PersistVertex(context.Background(), &o))
+
+	ctx := core.WithEdgeFetchMode(context.Background(), core.EdgeWithVertexIds)
+	vrs, err := store.ReadEdge(ctx, &VertexRelation{SourceVertex: &order{Number: "A-1"}, DestinationVertex: &lineItem{}, Relationship: &contains{}})
+	suite.NoError(err)
+	suite.Equal([]core.EdgeFetchMode{core.EdgeWithVertexIds}, conn.fetchModes)
+	suite.Equal(1, len(vrs))
+	suite.Nil(vrs[0].SourceVertex)
+	suite.Nil(vrs[0].DestinationVertex)
+	suite.Equal(&contains{}, vrs[0].Relationship)
+}
+
+func (suite *StoreTestSuite) TestPersistWithStoreWriteMode() {
+	conn := &memoryConnection{}
+	store := NewGenericStoreWithWriteMode(conn, NewReflectionMapper(), core.Create)
+	o := order{Number: "A-1", LineItems: []lineItem{{Product: "Pen", Quantity: 2}}}
+	suite.NoError(store.PersistVertex(context.Background(), &o))
+	suite.NoError(store.PersistEdge(context.Background(), &VertexRelation{SourceVertex: &order{Number: "A-2"}, DestinationVertex: &lineItem{Product: "Ink"}, Relationship: &contains{}}))
+
+	// the order, the line item and the edge connecting them followed by the persisted edge
+	suite.Equal([]core.WriteMode{core.Create, core.Create, core.Create, core.Create}, conn.writeModes)
+}
+
+func (suite *StoreTestSuite) TestReadOrCreateVertexWithStoreWriteMode() {
+	conn := &memoryConnection{}
+	store := NewGenericStoreWithWriteMode(conn, NewReflectionMapper(), core.Create)
+	_, created, err := store.ReadOrCreateVertex(context.Background(), &employee{Name: "Tom", Age: 10})
+	suite.NoError(err)
+	suite.True(created)
+	suite.Equal([]core.WriteMode{core.Create}, conn.writeModes)
+}
+
+func (suite *StoreTestSuite) TestPersistWithContextWriteMode() {
+	conn := &memoryConnection{}
+	store := NewGenericStoreWithWriteMode(conn, NewReflectionMapper(), core.Create)
+	ctx := core.WithWriteMode(context.Background(), core.Merge)
+	suite.NoError(store.PersistVertex(ctx, &order{Number: "A-1"}))
+	suite.NoError(store.PersistEdge(ctx, &VertexRelation{SourceVertex: &order{Number: "A-2"}, DestinationVertex: &lineItem{Product: "Ink"}, Relationship: &contains{}}))
+
+	// the write mode specified within the context takes precedence over the write mode of the store
+	suite.Equal([]core.WriteMode{core.Merge, core.Merge}, conn.writeModes)
+}
+
+func (suite *StoreTestSuite) TestPersistInTransactionRetainsStoreWriteMode() {
+	conn := &memoryConnection{}
+	store := NewGenericStoreWithWriteMode(conn, NewReflectionMapper(), core.Create)
+	err := store.RunInTransaction(context.Background(), func(txStore Store) error {
+		return txStore.PersistVertex(context.Background(), &order{Number: "A-1"})
+	})
+	suite.NoError(err)
+	suite.Equal([]core.WriteMode{core.Create}, conn.writeModes)
+}
+
+func TestStoreTestSuite(t *testing.T) {
+	suite.Run(t, new(StoreTestSuite))
+}
+
+type employee struct {
+	Name       string `ogm:"name"`
+	Age        int32  `ogm:"age"`
+	Department string `ogm:"dept"`
+}
+
+func (e *employee) GetLabel() string {
+	return "Employee"
+}
+
+func (e *employee) GetType() GraphObjectType {
+	return Vertex
+}
+
+type member struct {
+	Email string `ogm:"email,identity"`
+	Name  string `ogm:"name"`
+	Age   int32  `ogm:"age"`
+}
+
+func (m *member) GetLabel() string {
+	return "Member"
+}
+
+func (m *member) GetType() GraphObjectType {
+	return Vertex
+}
+
+type invoice struct {
+	Number  string `ogm:"number"`
+	Amounts []int  `ogm:",edge:INCLUDES"`
+}
+
+func (i *invoice) GetLabel() string {
+	return "Invoice"
+}
+
+func (i *invoice) GetType() GraphObjectType {
+	return Vertex
+}
+
+type contains struct {
+}
+
+func (c *contains) GetLabel() string {
+	return "CONTAINS"
+}
+
+func (c *contains) GetType() GraphObjectType {
+	return Edge
+}
@@ -29,4 +29,16 @@ type VertexRelation struct {
 	Relationship GraphObject
 	// DestinationVertex represents the destination node of a relation
 	DestinationVertex GraphObject
+	// MinHops is the minimum number of edges of the relationship type traversed between the source and destination vertices
+	MinHops int
+	// MaxHops is the maximum number of edges of the relationship type traversed between the source and destination vertices.
+	// A MaxHops of 0 along with a non-zero MinHops traverses any number of edges.
+	//
+	// Relations with a zero MinHops and MaxHops represent a single edge between the source and destination vertices.
+	MaxHops int
+}
+
+// IsVariableLength returns true if the relation traverses a variable number of edges
+func (vr *VertexRelation) IsVariableLength() bool {
+	return vr.MinHops > 0 || vr.MaxHops > 0
 }
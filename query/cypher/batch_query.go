@@ -0,0 +1,24 @@
+package cypher
+
+import (
+	"errors"
+	"fmt"
+)
+
+// BatchRowsParam is the name of the parameter holding the properties of the vertices created by the query generated
+// using BuildCreateVerticesQuery
+const BatchRowsParam = "rows"
+
+// BuildCreateVerticesQuery builds a cypher query creating a vertex with the specified labels for each of the property
+// maps within the list bound to the BatchRowsParam parameter. The created vertices are returned bound to the variable
+// v, in the order of the property maps.
+func BuildCreateVerticesQuery(labels []string) (string, error) {
+	if len(labels) == 0 {
+		return "", errors.New("no vertex labels specified in the query")
+	}
+	labelFragment, err := buildLabelFragment(labels)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("UNWIND $%s AS row CREATE (v%s) SET v = row RETURN v", BatchRowsParam, labelFragment), nil
+}
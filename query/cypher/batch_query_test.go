@@ -0,0 +1,28 @@
+package cypher
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type BatchQueryTestSuite struct {
+	suite.Suite
+}
+
+func (suite *BatchQueryTestSuite) TestBuildCreateVerticesQuery() {
+	query, err := BuildCreateVerticesQuery([]string{"Person", "Order"})
+	suite.NoError(err)
+	suite.Equal("UNWIND $rows AS row CREATE (v:Person:`Order`) SET v = row RETURN v", query)
+}
+
+func (suite *BatchQueryTestSuite) TestBuildCreateVerticesQueryInvalidLabels() {
+	_, err := BuildCreateVerticesQuery(nil)
+	suite.EqualError(err, "no vertex labels specified in the query")
+	_, err = BuildCreateVerticesQuery([]string{"Person", ""})
+	suite.EqualError(err, "labels cannot be empty")
+}
+
+func TestBatchQueryTestSuite(t *testing.T) {
+	suite.Run(t, new(BatchQueryTestSuite))
+}
@@ -0,0 +1,46 @@
+package cypher
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/prahaladd/gograph/core"
+)
+
+// DegreeVar is the variable name to which the degree is bound by the query generated using BuildDegreeQuery
+const DegreeVar = "degree"
+
+// BuildDegreeQuery builds a cypher query returning the number of edges of the specified direction and labels
+// connected to the vertex bound to the variable v. The vertex is selected using the specified predicate, for e.g.
+// elementId(v) = $id.
+//
+// Edges of all labels are counted if no edge labels are specified.
+func BuildDegreeQuery(vertexPredicate string, direction core.EdgeDirection, edgeLabels []string) (string, error) {
+	if vertexPredicate == "" {
+		return "", errors.New("vertex predicate must be specified")
+	}
+	pattern, err := buildEdgesOfVertexPattern(direction, edgeLabels, "()")
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("MATCH %s WHERE %s RETURN count(r) AS %s", pattern, vertexPredicate, DegreeVar), nil
+}
+
+// buildEdgesOfVertexPattern builds the pattern matching the edges, bound to the variable r, of the specified
+// direction and labels connecting the vertex bound to the variable v to the specified node fragment. Edges of all
+// labels are matched if no edge labels are specified.
+func buildEdgesOfVertexPattern(direction core.EdgeDirection, edgeLabels []string, node string) (string, error) {
+	escapedLabels := make([]string, 0, len(edgeLabels))
+	for _, label := range edgeLabels {
+		if len(label) == 0 {
+			return "", errors.New("labels cannot be empty")
+		}
+		escapedLabels = append(escapedLabels, EscapeName(label))
+	}
+	edgeFragment := "r"
+	if len(escapedLabels) > 0 {
+		edgeFragment = fmt.Sprintf("r:%s", strings.Join(escapedLabels, "|"))
+	}
+	return buildDirectedPattern("(v)", edgeFragment, node, direction)
+}
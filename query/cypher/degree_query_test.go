@@ -0,0 +1,41 @@
+package cypher
+
+import (
+	"testing"
+
+	"github.com/prahaladd/gograph/core"
+	"github.com/stretchr/testify/suite"
+)
+
+type DegreeQueryTestSuite struct {
+	suite.Suite
+}
+
+func (suite *DegreeQueryTestSuite) TestBuildDegreeQuery() {
+	query, err := BuildDegreeQuery("elementId(v) = $id", core.DirectionBoth, nil)
+	suite.NoError(err)
+	suite.Equal("MATCH (v)-[r]-() WHERE elementId(v) = $id RETURN count(r) AS degree", query)
+}
+
+func (suite *DegreeQueryTestSuite) TestBuildDegreeQueryWithDirection() {
+	query, err := BuildDegreeQuery("elementId(v) = $id", core.DirectionOutgoing, []string{"KNOWS"})
+	suite.NoError(err)
+	suite.Equal("MATCH (v)-[r:KNOWS]->() WHERE elementId(v) = $id RETURN count(r) AS degree", query)
+
+	query, err = BuildDegreeQuery("elementId(v) = $id", core.DirectionIncoming, []string{"KNOWS", "LIKES"})
+	suite.NoError(err)
+	suite.Equal("MATCH (v)<-[r:KNOWS|LIKES]-() WHERE elementId(v) = $id RETURN count(r) AS degree", query)
+}
+
+func (suite *DegreeQueryTestSuite) TestBuildDegreeQueryInvalid() {
+	_, err := BuildDegreeQuery("", core.DirectionBoth, nil)
+	suite.Error(err)
+	_, err = BuildDegreeQuery("elementId(v) = $id", core.DirectionBoth, []string{""})
+	suite.Error(err)
+	_, err = BuildDegreeQuery("elementId(v) = $id", core.EdgeDirection(7), nil)
+	suite.EqualError(err, "invalid edge direction 7")
+}
+
+func TestDegreeQueryTestSuite(t *testing.T) {
+	suite.Run(t, new(DegreeQueryTestSuite))
+}
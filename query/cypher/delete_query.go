@@ -0,0 +1,41 @@
+package cypher
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/prahaladd/gograph/core"
+)
+
+// variable names to which the results of the query generated using BuildDeleteVertexQuery are bound
+const (
+	// DeletedCountVar is the variable name to which the number of deleted vertices is bound
+	DeletedCountVar = "deleted"
+	// DeletedVertexIdVar is the variable name to which the identifier of a deleted vertex is bound
+	DeletedVertexIdVar = "vertexId"
+	// DeletedEdgeIdVar is the variable name to which the identifier of an edge removed along with a deleted vertex
+	// is bound
+	DeletedEdgeIdVar = "edgeId"
+)
+
+// BuildDeleteVertexQuery builds a cypher query deleting the vertices with the specified label selected by the
+// specified selector, along with the edges connected to the vertices. All the vertices with the label are deleted if
+// no selector is specified. The query returns the number of deleted vertices bound to DeletedCountVar.
+//
+// If an identity function, for e.g. elementId, is specified the query instead returns a row for each of the edges
+// removed along with the deleted vertices. The identifiers of the vertex and the edge are bound to DeletedVertexIdVar
+// and DeletedEdgeIdVar respectively. Vertices without edges are returned in a single row with a null edge identifier.
+//
+// The values of the selector are passed as parameters if the query is parameterized.
+func BuildDeleteVertexQuery(label string, selector core.KVMap, idFunction string, parameterized bool) (string, map[string]interface{}, error) {
+	if len(label) == 0 {
+		return "", nil, errors.New("vertex label must be specified")
+	}
+	params := newParameters(parameterized)
+	match := fmt.Sprintf("MATCH (v:%s%s)", EscapeName(label), buildSelector(selector, params))
+	if idFunction == "" {
+		return fmt.Sprintf("%s DETACH DELETE v RETURN count(v) AS %s", match, DeletedCountVar), params, nil
+	}
+	return fmt.Sprintf("%s OPTIONAL MATCH (v)-[r]-() WITH v, %s(v) AS %s, %s(r) AS %s DETACH DELETE v RETURN %s, %s",
+		match, idFunction, DeletedVertexIdVar, idFunction, DeletedEdgeIdVar, DeletedVertexIdVar, DeletedEdgeIdVar), params, nil
+}
@@ -0,0 +1,46 @@
+package cypher
+
+import (
+	"testing"
+
+	"github.com/prahaladd/gograph/core"
+	"github.com/stretchr/testify/suite"
+)
+
+type DeleteQueryTestSuite struct {
+	suite.Suite
+}
+
+func (suite *DeleteQueryTestSuite) TestBuildDeleteVertexQuery() {
+	query, params, err := BuildDeleteVertexQuery("Person", core.KVMap{"name": "Tintin"}, "", false)
+	suite.NoError(err)
+	suite.Equal("MATCH (v:Person{name:'Tintin'}) DETACH DELETE v RETURN count(v) AS deleted", query)
+	suite.Nil(params)
+
+	query, params, err = BuildDeleteVertexQuery("Order", nil, "", true)
+	suite.NoError(err)
+	suite.Equal("MATCH (v:`Order`) DETACH DELETE v RETURN count(v) AS deleted", query)
+	suite.Empty(params)
+}
+
+func (suite *DeleteQueryTestSuite) TestBuildDeleteVertexQueryParameterized() {
+	query, params, err := BuildDeleteVertexQuery("Person", core.KVMap{"name": "Tintin", "age": 17}, "", true)
+	suite.NoError(err)
+	suite.Equal("MATCH (v:Person{age: $p0,name: $p1}) DETACH DELETE v RETURN count(v) AS deleted", query)
+	suite.Equal(map[string]interface{}{"p0": 17, "p1": "Tintin"}, params)
+}
+
+func (suite *DeleteQueryTestSuite) TestBuildDeleteVertexQueryReturningIds() {
+	query, _, err := BuildDeleteVertexQuery("Person", core.KVMap{"name": "Tintin"}, "elementId", false)
+	suite.NoError(err)
+	suite.Equal("MATCH (v:Person{name:'Tintin'}) OPTIONAL MATCH (v)-[r]-() WITH v, elementId(v) AS vertexId, elementId(r) AS edgeId DETACH DELETE v RETURN vertexId, edgeId", query)
+}
+
+func (suite *DeleteQueryTestSuite) TestBuildDeleteVertexQueryWithoutLabel() {
+	_, _, err := BuildDeleteVertexQuery("", core.KVMap{"name": "Tintin"}, "", false)
+	suite.EqualError(err, "vertex label must be specified")
+}
+
+func TestDeleteQueryTestSuite(t *testing.T) {
+	suite.Run(t, new(DeleteQueryTestSuite))
+}
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"errors"
 	"fmt"
+	"reflect"
 	"strings"
 
 	"github.com/prahaladd/gograph/core"
@@ -13,6 +14,9 @@ import (
 //
 // # The mode of the query builder can be used to control the type of cypher query - mutating or querying
 //
+// Queries built using the core.Delete mode delete the selected edges, without deleting the start and end vertices,
+// and return the number of deleted edges bound to DeletedCountVar.
+//
 // The fetch mode can be used to control whether the returned edge information contains only ids of the start and end vertices or the complete
 // representations of start and end vertices
 //
@@ -39,6 +43,26 @@ type EdgeQueryBuilder struct {
 	startVertexFilters  core.KVMap
 	endVertexFilters    core.KVMap
 	writeMode           core.WriteMode
+	anyLabel            bool
+	rawWhere            string
+	matchEndpoints      bool
+	startVertexMode     core.EndpointMode
+	endVertexMode       core.EndpointMode
+	limit               int64
+	skip                int64
+	orderByID           bool
+	variableLength      bool
+	minHops             int
+	maxHops             int
+	parameterized       bool
+	// onCreateProperties are the properties set on the edge only when the edge is written
+	onCreateProperties core.KVMap
+	// dataProperties are the properties set on the edge whenever the edge is written, whether created or matched
+	dataProperties core.KVMap
+	// deletedIdFunction is the function returning the identifiers of the edges deleted by delete queries
+	deletedIdFunction string
+	// orderBy are the properties of the edge ordering the results, prior to the identity of the edge
+	orderBy []core.OrderBy
 }
 
 func NewEdgeQueryBuilder() *EdgeQueryBuilder {
@@ -48,6 +72,8 @@ func NewEdgeQueryBuilder() *EdgeQueryBuilder {
 		endVertexSelector:   make(core.KVMap),
 		startVertexFilters:  make(core.KVMap),
 		endVertexFilters:    make(core.KVMap),
+		onCreateProperties:  make(core.KVMap),
+		dataProperties:      make(core.KVMap),
 		writeMode:           core.Merge,
 	}
 }
@@ -63,7 +89,7 @@ func (eqb *EdgeQueryBuilder) SetEdgeFetchMode(edgeFetchMode core.EdgeFetchMode)
 }
 
 func (eqb *EdgeQueryBuilder) SetStartVertexLabels(labels []string) *EdgeQueryBuilder {
-	eqb.startVertexLabels = append(eqb.startVertexLabels, labels...)
+	eqb.startVertexLabels = append([]string(nil), labels...)
 	return eqb
 }
 
@@ -73,7 +99,7 @@ func (eqb *EdgeQueryBuilder) SetStartVertexVariableName(varName string) *EdgeQue
 }
 
 func (eqb *EdgeQueryBuilder) SetEndVertexLabels(labels []string) *EdgeQueryBuilder {
-	eqb.endVertexLabels = append(eqb.endVertexLabels, labels...)
+	eqb.endVertexLabels = append([]string(nil), labels...)
 	return eqb
 }
 
@@ -83,7 +109,16 @@ func (eqb *EdgeQueryBuilder) SetEndVertexVariableName(varName string) *EdgeQuery
 }
 
 func (eqb *EdgeQueryBuilder) SetLabel(labels []string) *EdgeQueryBuilder {
-	eqb.labels = append(eqb.labels, labels...)
+	eqb.labels = append([]string(nil), labels...)
+	return eqb
+}
+
+// SetAnyLabel controls whether the edge label is optional. When set and no edge label is specified, the
+// generated pattern matches relationships of all types between the start and end vertices.
+//
+// Relationships cannot be written without a type. Hence the edge label is still required for write queries.
+func (eqb *EdgeQueryBuilder) SetAnyLabel(anyLabel bool) *EdgeQueryBuilder {
+	eqb.anyLabel = anyLabel
 	return eqb
 }
 
@@ -140,11 +175,147 @@ func (eqb *EdgeQueryBuilder) SetWriteMode(writeMode core.WriteMode) *EdgeQueryBu
 	return eqb
 }
 
+// SetOnCreateProperties sets properties of the edge which are not part of the pattern of the edge and are set only
+// when the edge is written by the query. Merged edges are hence matched on the selector alone and an existing edge
+// retains the values of these properties.
+func (eqb *EdgeQueryBuilder) SetOnCreateProperties(properties core.KVMap) *EdgeQueryBuilder {
+	for k, v := range properties {
+		eqb.onCreateProperties[k] = v
+	}
+	return eqb
+}
+
+// SetDataProperties sets properties of the edge which are not part of the pattern of the edge and are set whenever the
+// edge is written by the query. Merged edges are hence matched on the selector alone and an existing edge is updated
+// with the values of these properties.
+func (eqb *EdgeQueryBuilder) SetDataProperties(properties core.KVMap) *EdgeQueryBuilder {
+	for k, v := range properties {
+		eqb.dataProperties[k] = v
+	}
+	return eqb
+}
+
+// SetDeletedIdFunction makes delete queries return a row for each of the deleted edges, with the identifier of the
+// edge returned by the specified function, for e.g. elementId, bound to DeletedEdgeIdVar. The number of deleted edges
+// is returned otherwise.
+func (eqb *EdgeQueryBuilder) SetDeletedIdFunction(idFunction string) *EdgeQueryBuilder {
+	eqb.deletedIdFunction = idFunction
+	return eqb
+}
+
+// SetMatchEndpoints controls whether write queries match the start and end vertices instead of merging or
+// creating them along with the edge. When set, only the edge is merged or created and the query returns no
+// rows if either of the vertices does not exist.
+//
+// Filters on the edge cannot be specified when matching the endpoints.
+func (eqb *EdgeQueryBuilder) SetMatchEndpoints(matchEndpoints bool) *EdgeQueryBuilder {
+	eqb.matchEndpoints = matchEndpoints
+	return eqb
+}
+
+// SetStartVertexMode sets the mode used by write queries to write the start vertex. The mode takes precedence over
+// SetMatchEndpoints for the start vertex.
+//
+// Vertices written using EndpointDefault are written as part of the pattern of the edge. Otherwise the vertex is
+// matched, merged or created using a clause of its own before the edge is written. Matched vertices are required to
+// exist and the query returns no rows if the vertex does not exist.
+func (eqb *EdgeQueryBuilder) SetStartVertexMode(mode core.EndpointMode) *EdgeQueryBuilder {
+	eqb.startVertexMode = mode
+	return eqb
+}
+
+// SetEndVertexMode sets the mode used by write queries to write the end vertex. The mode takes precedence over
+// SetMatchEndpoints for the end vertex.
+func (eqb *EdgeQueryBuilder) SetEndVertexMode(mode core.EndpointMode) *EdgeQueryBuilder {
+	eqb.endVertexMode = mode
+	return eqb
+}
+
+// SetHops makes the edge a variable length relationship traversing between minHops and maxHops edges of the edge
+// label. A maxHops of 0 traverses any number of edges.
+//
+// Variable length relationships can only be read. The query returns the distinct pairs of start and end vertices
+// connected by the traversed edges.
+func (eqb *EdgeQueryBuilder) SetHops(minHops, maxHops int) *EdgeQueryBuilder {
+	eqb.variableLength = true
+	eqb.minHops = minHops
+	eqb.maxHops = maxHops
+	return eqb
+}
+
+// SetRawWhere sets a raw condition to be included within the WHERE clause of the generated query.
+// The condition is combined with the conditions generated from the filters using AND.
+//
+// The condition is included in the query verbatim and hence must never be built from untrusted input
+// as doing so would make the query vulnerable to cypher injection.
+func (eqb *EdgeQueryBuilder) SetRawWhere(clause string) *EdgeQueryBuilder {
+	eqb.rawWhere = clause
+	return eqb
+}
+
+// SetLimit sets the maximum number of results returned by the query. A limit of 0 returns all the results.
+func (eqb *EdgeQueryBuilder) SetLimit(limit int64) *EdgeQueryBuilder {
+	eqb.limit = limit
+	return eqb
+}
+
+// SetSkip sets the number of results skipped by the query
+func (eqb *EdgeQueryBuilder) SetSkip(skip int64) *EdgeQueryBuilder {
+	eqb.skip = skip
+	return eqb
+}
+
+// SetOrderByID orders the results of the query by the identity of the edge. Ordering keeps the pages of results
+// read using SetSkip and SetLimit consistent with one another.
+func (eqb *EdgeQueryBuilder) SetOrderByID(orderByID bool) *EdgeQueryBuilder {
+	eqb.orderByID = orderByID
+	return eqb
+}
+
+// SetOrderBy orders the results of the query by the specified properties of the edge, in turn. If the results are
+// also ordered by identity using SetOrderByID, the identity orders the edges with equal properties.
+func (eqb *EdgeQueryBuilder) SetOrderBy(orderBy ...core.OrderBy) *EdgeQueryBuilder {
+	eqb.orderBy = orderBy
+	return eqb
+}
+
+// SetParameterized controls whether the values of the selectors and filters are passed as query parameters named
+// p0, p1 and so on instead of being included within the query as literals. Queries differing only in the values then
+// share the same text, allowing the server to reuse the query plan.
+//
+// The values of the parameters are returned by BuildWithParams.
+func (eqb *EdgeQueryBuilder) SetParameterized(parameterized bool) *EdgeQueryBuilder {
+	eqb.parameterized = parameterized
+	return eqb
+}
+
+// Build builds the cypher query
 func (eqb *EdgeQueryBuilder) Build() (string, error) {
+	query, _, _, err := eqb.build()
+	return query, err
+}
+
+// BuildWithVars builds the cypher query and returns the variable names bound within the query.
+//
+// The variable names bound to the start vertex, end vertex and the edge are available against the
+// StartVertexVar, EndVertexVar and EdgeVar keys respectively.
+func (eqb *EdgeQueryBuilder) BuildWithVars() (string, map[string]string, error) {
+	query, vars, _, err := eqb.build()
+	return query, vars, err
+}
+
+// BuildWithParams builds the cypher query and returns the values of the parameters referenced by the query. No
+// parameters are returned unless the builder is parameterized using SetParameterized.
+func (eqb *EdgeQueryBuilder) BuildWithParams() (string, map[string]interface{}, error) {
+	query, _, params, err := eqb.build()
+	return query, params, err
+}
+
+func (eqb *EdgeQueryBuilder) build() (string, map[string]string, map[string]interface{}, error) {
 
 	err := eqb.validate()
 	if err != nil {
-		return "", err
+		return "", nil, nil, err
 	}
 	operation := "MATCH"
 	if eqb.queryMode == core.Write {
@@ -154,31 +325,254 @@ func (eqb *EdgeQueryBuilder) Build() (string, error) {
 		}
 	}
 
-	startVertexQueryFragment, startVertexVarName := eqb.buildVertexQueryFragment(eqb.startVertexVarName, eqb.startVertexLabels, eqb.startVertexSelector)
-	endVertexQueryFragment, endVertexVarName := eqb.buildVertexQueryFragment(eqb.endVertexVarName, eqb.endVertexLabels, eqb.endVertexSelector)
-	edgeQueryFragment, edgeVarName := eqb.buildEdgeQueryFragment()
-
-	allFilters := map[string]map[string]interface{}{startVertexVarName: eqb.startVertexFilters, endVertexVarName: eqb.endVertexFilters, edgeVarName: eqb.filters}
-
-	filters := buildMultiFilters(allFilters)
+	selfLoop := eqb.isSelfLoop()
+	params := newParameters(eqb.parameterized)
+	endVertexParams := params
+	if selfLoop {
+		// the fragment of the end vertex is replaced by the start vertex, hence no parameters are generated for it
+		endVertexParams = nil
+	}
+	startVertexQueryFragment, startVertexVarName := eqb.buildVertexQueryFragment(eqb.startVertexVarName, eqb.startVertexLabels, eqb.startVertexSelector, startVertexPosition, params)
+	endVertexQueryFragment, endVertexVarName := eqb.buildVertexQueryFragment(eqb.endVertexVarName, eqb.endVertexLabels, eqb.endVertexSelector, endVertexPosition, endVertexParams)
+	edgeQueryFragment, edgeVarName := eqb.buildEdgeQueryFragment(params)
+
+	returnedEndVertex := endVertexVarName
+	if selfLoop {
+		// bind the start vertex at both the ends of the edge and return it as the end vertex as well
+		endVertexQueryFragment = fmt.Sprintf("(%s)", startVertexVarName)
+		if endVertexVarName != startVertexVarName {
+			returnedEndVertex = fmt.Sprintf("%s AS %s", startVertexVarName, endVertexVarName)
+		}
+	}
 
 	returnFragment := fmt.Sprintf("return %s", edgeVarName)
 	if eqb.edgeFetchMode == core.EdgeWithCompleteVertex {
-		returnFragment = fmt.Sprintf("return %s, %s, %s", startVertexVarName, edgeVarName, endVertexVarName)
+		returnFragment = fmt.Sprintf("return %s, %s, %s", startVertexVarName, edgeVarName, returnedEndVertex)
+	}
+	if eqb.variableLength {
+		// the edge variable is bound to a list of edges
+		returnFragment = fmt.Sprintf("return DISTINCT %s, %s", startVertexVarName, endVertexVarName)
+	}
+	if eqb.queryMode == core.Delete {
+		returnFragment = fmt.Sprintf("DELETE %s RETURN count(%s) AS %s", edgeVarName, edgeVarName, DeletedCountVar)
+		if eqb.deletedIdFunction != "" {
+			returnFragment = fmt.Sprintf("WITH %s, %s(%s) AS %s DELETE %s RETURN %s", edgeVarName, eqb.deletedIdFunction, edgeVarName, DeletedEdgeIdVar, edgeVarName, DeletedEdgeIdVar)
+		}
+	}
+	returnFragment += eqb.buildOrderBy(edgeVarName)
+	returnFragment += buildSkip(eqb.skip) + buildLimit(eqb.limit)
+	vars := map[string]string{StartVertexVar: startVertexVarName, EndVertexVar: endVertexVarName, EdgeVar: edgeVarName}
+
+	if eqb.queryMode == core.Write && eqb.writesEndpointsSeparately() {
+		startVertexMode, endVertexMode := eqb.endpointModes()
+		var matchFragments, clauses []string
+		vertexFilters := make(map[string]map[string]interface{})
+		// endpointFragment adds the clause writing the vertex using the mode and returns the fragment binding the
+		// vertex within the pattern of the edge
+		endpointFragment := func(mode core.EndpointMode, vertexFragment, varName string, filters core.KVMap) string {
+			switch mode {
+			case core.EndpointDefault:
+				return vertexFragment
+			case core.EndpointMatch:
+				matchFragments = append(matchFragments, vertexFragment)
+				vertexFilters[varName] = filters
+			case core.EndpointCreate:
+				clauses = append(clauses, fmt.Sprintf("CREATE %s", vertexFragment))
+			default:
+				clauses = append(clauses, fmt.Sprintf("MERGE %s", vertexFragment))
+			}
+			return fmt.Sprintf("(%s)", varName)
+		}
+		edgeStartFragment := endpointFragment(startVertexMode, startVertexQueryFragment, startVertexVarName, eqb.startVertexFilters)
+		edgeEndFragment := endVertexQueryFragment
+		if !selfLoop {
+			edgeEndFragment = endpointFragment(endVertexMode, endVertexQueryFragment, endVertexVarName, eqb.endVertexFilters)
+		}
+		if len(matchFragments) > 0 {
+			filters := appendRawWhere(buildMultiFilters(vertexFilters, params), eqb.rawWhere)
+			clauses = append([]string{fmt.Sprintf("MATCH %s%s", strings.Join(matchFragments, ", "), filters)}, clauses...)
+		}
+		setClauses := eqb.buildOnCreateClause(operation, edgeVarName, params) + eqb.buildDataClause(edgeVarName, params)
+		clauses = append(clauses, fmt.Sprintf("%s %s-[%s]->%s%s", operation, edgeStartFragment, edgeQueryFragment, edgeEndFragment, setClauses), returnFragment)
+		return strings.Join(clauses, " "), vars, params, nil
+	}
+
+	allFilters := map[string]map[string]interface{}{startVertexVarName: eqb.startVertexFilters, edgeVarName: eqb.filters}
+	if !selfLoop {
+		allFilters[endVertexVarName] = eqb.endVertexFilters
+	}
+
+	filters := appendRawWhere(buildMultiFilters(allFilters, params), eqb.rawWhere)
+	filters += eqb.buildOnCreateClause(operation, edgeVarName, params)
+	filters += eqb.buildDataClause(edgeVarName, params)
+
+	return fmt.Sprintf("%s %s-[%s]->%s %s %s", operation, startVertexQueryFragment, edgeQueryFragment, endVertexQueryFragment, filters, returnFragment), vars, params, nil
+
+}
+
+// buildOnCreateClause builds the clause setting the on create properties of the edge bound to the specified variable.
+// Merged edges set the properties only when the edge is created.
+func (eqb *EdgeQueryBuilder) buildOnCreateClause(operation string, varName string, params parameters) string {
+	if len(eqb.onCreateProperties) == 0 {
+		return ""
+	}
+	setClause := "SET"
+	if operation == "MERGE" {
+		setClause = "ON CREATE SET"
+	}
+	return fmt.Sprintf(" %s %s", setClause, buildAssignments(varName, eqb.onCreateProperties, params))
+}
+
+// buildDataClause builds the clause setting the data properties of the edge bound to the specified variable
+func (eqb *EdgeQueryBuilder) buildDataClause(varName string, params parameters) string {
+	if len(eqb.dataProperties) == 0 {
+		return ""
+	}
+	return fmt.Sprintf(" SET %s", buildAssignments(varName, eqb.dataProperties, params))
+}
+
+// buildOrderBy builds the ORDER BY clause ordering the results by the properties and then the identity of the edge
+func (eqb *EdgeQueryBuilder) buildOrderBy(edgeVarName string) string {
+	orderings := make([]string, 0, len(eqb.orderBy)+1)
+	for _, orderBy := range eqb.orderBy {
+		ordering := fmt.Sprintf("%s.%s", edgeVarName, EscapeName(orderBy.Property))
+		if orderBy.Descending {
+			ordering += " DESC"
+		}
+		orderings = append(orderings, ordering)
+	}
+	if eqb.orderByID {
+		orderings = append(orderings, fmt.Sprintf("id(%s)", edgeVarName))
 	}
-	return fmt.Sprintf("%s %s-[%s]->%s %s %s", operation, startVertexQueryFragment, edgeQueryFragment, endVertexQueryFragment, filters, returnFragment), nil
+	if len(orderings) == 0 {
+		return ""
+	}
+	return " ORDER BY " + strings.Join(orderings, ", ")
+}
 
+// buildAssignments builds the comma separated assignments of the specified properties of the variable, in the order
+// of the property names
+func buildAssignments(varName string, properties core.KVMap, params parameters) string {
+	assignments := make([]string, 0, len(properties))
+	for _, k := range sortedKeys(properties) {
+		assignments = append(assignments, fmt.Sprintf("%s.%s = %s", varName, EscapeName(k), params.value(properties[k])))
+	}
+	return strings.Join(assignments, ", ")
+}
+
+// isSelfLoop reports whether the start and end vertices of a write query are specified identically, in which case
+// both identify the same vertex and the edge is written as a self loop binding a single vertex variable. Vertices
+// without selectors are not considered identical as the labels alone may select several vertices.
+func (eqb *EdgeQueryBuilder) isSelfLoop() bool {
+	return eqb.queryMode == core.Write && len(eqb.startVertexLabels) > 0 && len(eqb.startVertexSelector) > 0 &&
+		eqb.startVertexMode == eqb.endVertexMode &&
+		reflect.DeepEqual(eqb.startVertexLabels, eqb.endVertexLabels) &&
+		reflect.DeepEqual(eqb.startVertexSelector, eqb.endVertexSelector) &&
+		reflect.DeepEqual(eqb.startVertexFilters, eqb.endVertexFilters)
+}
+
+// endpointModes returns the modes used to write the start and end vertices. Vertices using EndpointDefault are
+// matched when the endpoints are required to be matched.
+func (eqb *EdgeQueryBuilder) endpointModes() (core.EndpointMode, core.EndpointMode) {
+	startVertexMode, endVertexMode := eqb.startVertexMode, eqb.endVertexMode
+	if eqb.matchEndpoints {
+		if startVertexMode == core.EndpointDefault {
+			startVertexMode = core.EndpointMatch
+		}
+		if endVertexMode == core.EndpointDefault {
+			endVertexMode = core.EndpointMatch
+		}
+	}
+	return startVertexMode, endVertexMode
+}
+
+// writesEndpointsSeparately reports whether either of the vertices is written using a clause of its own instead of
+// as part of the pattern of the edge
+func (eqb *EdgeQueryBuilder) writesEndpointsSeparately() bool {
+	startVertexMode, endVertexMode := eqb.endpointModes()
+	return startVertexMode != core.EndpointDefault || endVertexMode != core.EndpointDefault
 }
 
 func (eqb *EdgeQueryBuilder) validate() error {
-	if eqb.labels == nil || len(eqb.labels) == 0 {
-		return errors.New("no edge labels specified in the query")
+	if len(eqb.labels) == 0 {
+		if !eqb.anyLabel {
+			return errors.New("no edge labels specified in the query")
+		}
+		if eqb.queryMode == core.Write {
+			return errors.New("edge label must be specified for write queries")
+		}
+	}
+
+	if err := validateLabels("edge", eqb.labels); err != nil {
+		return err
+	}
+	if err := validateLabels("start vertex", eqb.startVertexLabels); err != nil {
+		return err
+	}
+	if err := validateLabels("end vertex", eqb.endVertexLabels); err != nil {
+		return err
+	}
+
+	if len(eqb.onCreateProperties) > 0 && eqb.queryMode != core.Write {
+		return errors.New("on create properties can only be set by write queries")
+	}
+
+	if len(eqb.dataProperties) > 0 && eqb.queryMode != core.Write {
+		return errors.New("data properties can only be set by write queries")
+	}
+
+	if eqb.skip < 0 {
+		return fmt.Errorf("invalid skip %d", eqb.skip)
+	}
+
+	if eqb.deletedIdFunction != "" && eqb.queryMode != core.Delete {
+		return errors.New("deleted ids can only be returned by delete queries")
+	}
+
+	if eqb.queryMode == core.Delete && (eqb.orderByID || len(eqb.orderBy) > 0 || eqb.skip > 0 || eqb.limit > 0) {
+		return errors.New("delete queries cannot be ordered or paged")
+	}
+
+	for _, orderBy := range eqb.orderBy {
+		if strings.TrimSpace(orderBy.Property) == "" {
+			return errors.New("order by property cannot be empty")
+		}
+		if !IsIdentifier(orderBy.Property) {
+			return fmt.Errorf("invalid order by property %s", orderBy.Property)
+		}
+	}
+
+	if err := validateFilters(eqb.startVertexFilters, eqb.endVertexFilters, eqb.filters); err != nil {
+		return err
+	}
+
+	if eqb.variableLength {
+		if eqb.orderByID {
+			return errors.New("variable length relationships cannot be ordered by the edge identity")
+		}
+		if len(eqb.orderBy) > 0 {
+			return errors.New("variable length relationships cannot be ordered by the edge properties")
+		}
+		if eqb.queryMode != core.Read {
+			return errors.New("variable length relationships cannot be written")
+		}
+		if eqb.minHops < 0 || eqb.maxHops < 0 || (eqb.maxHops > 0 && eqb.minHops > eqb.maxHops) {
+			return fmt.Errorf("invalid hop range %d..%d", eqb.minHops, eqb.maxHops)
+		}
 	}
 
 	if len(eqb.labels) > 1 {
 		return errors.New("multiple edge labels cannot be specified")
 	}
 
+	if eqb.queryMode == core.Write && eqb.matchEndpoints && len(eqb.filters) > 0 {
+		return errors.New("edge filters cannot be specified when matching the endpoints")
+	}
+
+	if eqb.queryMode == core.Write && eqb.writesEndpointsSeparately() && len(eqb.filters) > 0 {
+		return errors.New("edge filters cannot be specified when writing the endpoints separately")
+	}
+
 	if len(eqb.startVertexLabels) == 0 && eqb.startVertexVarName == "" {
 		return errors.New("either start vertex label or start vertex variable name must be specified")
 	}
@@ -190,35 +584,39 @@ func (eqb *EdgeQueryBuilder) validate() error {
 	return nil
 }
 
-func (eqb *EdgeQueryBuilder) buildEdgeQueryFragment() (string, string) {
-	variableName := strings.ToLower(eqb.labels[0])[0:2]
+func (eqb *EdgeQueryBuilder) buildEdgeQueryFragment(params parameters) (string, string) {
+	variableName := generateVariableName(eqb.labels, "r", edgePosition)
 
 	if eqb.varName != "" {
 		variableName = eqb.varName
 	}
 
-	edgeSelector := buildSelector(eqb.selector)
+	edgeSelector := buildSelector(eqb.selector, params)
 	edgeLabelSelector := bytes.Buffer{}
 	for _, label := range eqb.labels {
-		edgeLabelSelector.WriteString(fmt.Sprintf(":%s", label))
+		edgeLabelSelector.WriteString(fmt.Sprintf(":%s", EscapeName(label)))
+	}
+	hops := ""
+	if eqb.variableLength {
+		hops = fmt.Sprintf("*%d..", eqb.minHops)
+		if eqb.maxHops > 0 {
+			hops = fmt.Sprintf("%s%d", hops, eqb.maxHops)
+		}
 	}
-	return fmt.Sprintf("%s%s%s", variableName, edgeLabelSelector.String(), edgeSelector), variableName
+	return fmt.Sprintf("%s%s%s%s", variableName, edgeLabelSelector.String(), hops, edgeSelector), variableName
 }
 
-func (eqb *EdgeQueryBuilder) buildVertexQueryFragment(vertexVarName string, vertexlabels []string, vertexSelector core.KVMap) (string, string) {
-	var variableName string
-	if len(vertexlabels) > 0 {
-		variableName = strings.ToLower(vertexlabels[0])[0:2]
-	}
+func (eqb *EdgeQueryBuilder) buildVertexQueryFragment(vertexVarName string, vertexlabels []string, vertexSelector core.KVMap, position int, params parameters) (string, string) {
+	variableName := generateVariableName(vertexlabels, "v", position)
 
 	if vertexVarName != "" {
 		variableName = vertexVarName
 	}
 
-	selector := buildSelector(vertexSelector)
+	selector := buildSelector(vertexSelector, params)
 	labelSelectors := bytes.Buffer{}
 	for _, label := range vertexlabels {
-		labelSelectors.WriteString(fmt.Sprintf(":%s", label))
+		labelSelectors.WriteString(fmt.Sprintf(":%s", EscapeName(label)))
 	}
 	return fmt.Sprintf("(%s%s%s)", variableName, labelSelectors.String(), selector), variableName
 }
@@ -29,7 +29,7 @@ func (suite *EdgeQueryBuilderTestSuite) TestBuildWithNoVarNameEdgeFetchModeIds()
 
 	queryString, err := suite.edgeQueryBuilder.Build()
 	suite.NoError(err)
-	expectedQueryString := "MATCH (st:StartVertex{name:'SV1'})-[te:TestEdgeLabel{weight: 10}]->(en:EndVertex{name:'EV1'})  return te"
+	expectedQueryString := "MATCH (startvertex0:StartVertex{name:'SV1'})-[testedgelabel1:TestEdgeLabel{weight: 10}]->(endvertex2:EndVertex{name:'EV1'})  return testedgelabel1"
 	suite.Equal(expectedQueryString, queryString)
 }
 
@@ -45,7 +45,7 @@ func (suite *EdgeQueryBuilderTestSuite) TestBuildWithNoVarNameEdgeFetchModeCompl
 
 	queryString, err := suite.edgeQueryBuilder.Build()
 	suite.NoError(err)
-	expectedQueryString := "MATCH (st:StartVertex{name:'SV1'})-[te:TestEdgeLabel{weight: 10}]->(en:EndVertex{name:'EV1'})  return st, te, en"
+	expectedQueryString := "MATCH (startvertex0:StartVertex{name:'SV1'})-[testedgelabel1:TestEdgeLabel{weight: 10}]->(endvertex2:EndVertex{name:'EV1'})  return startvertex0, testedgelabel1, endvertex2"
 	suite.Equal(expectedQueryString, queryString)
 }
 
@@ -62,7 +62,7 @@ func (suite *EdgeQueryBuilderTestSuite) TestBuildWithVarName() {
 
 	queryString, err := suite.edgeQueryBuilder.Build()
 	suite.NoError(err)
-	expectedQueryString := "MATCH (st:StartVertex{name:'SV1'})-[r:TestEdgeLabel{weight: 10}]->(en:EndVertex{name:'EV1'})  return st, r, en"
+	expectedQueryString := "MATCH (startvertex0:StartVertex{name:'SV1'})-[r:TestEdgeLabel{weight: 10}]->(endvertex2:EndVertex{name:'EV1'})  return startvertex0, r, endvertex2"
 	suite.Equal(expectedQueryString, queryString)
 }
 
@@ -79,7 +79,7 @@ func (suite *EdgeQueryBuilderTestSuite) TestBuildWithMultipleStartVertexLabels()
 
 	queryString, err := suite.edgeQueryBuilder.Build()
 	suite.NoError(err)
-	expectedQueryString := "MATCH (st:StartVertex:StartVertex1{name:'SV1'})-[r:TestEdgeLabel{weight: 10}]->(en:EndVertex{name:'EV1'})  return st, r, en"
+	expectedQueryString := "MATCH (startvertex0:StartVertex:StartVertex1{name:'SV1'})-[r:TestEdgeLabel{weight: 10}]->(endvertex2:EndVertex{name:'EV1'})  return startvertex0, r, endvertex2"
 	suite.Equal(expectedQueryString, queryString)
 }
 
@@ -96,7 +96,7 @@ func (suite *EdgeQueryBuilderTestSuite) TestBuildWithMultipleEndVertexLabels() {
 
 	queryString, err := suite.edgeQueryBuilder.Build()
 	suite.NoError(err)
-	expectedQueryString := "MATCH (st:StartVertex{name:'SV1'})-[r:TestEdgeLabel{weight: 10}]->(en:EndVertex:EndVertex1{name:'EV1'})  return st, r, en"
+	expectedQueryString := "MATCH (startvertex0:StartVertex{name:'SV1'})-[r:TestEdgeLabel{weight: 10}]->(endvertex2:EndVertex:EndVertex1{name:'EV1'})  return startvertex0, r, endvertex2"
 	suite.Equal(expectedQueryString, queryString)
 }
 
@@ -112,7 +112,7 @@ func (suite *EdgeQueryBuilderTestSuite) TestQueryModeWrite() {
 
 	queryString, err := suite.edgeQueryBuilder.Build()
 	suite.NoError(err)
-	expectedQueryString := "MERGE (st:StartVertex{name:'SV1'})-[te:TestEdgeLabel{weight: 10}]->(en:EndVertex{name:'EV1'})  return st, te, en"
+	expectedQueryString := "MERGE (startvertex0:StartVertex{name:'SV1'})-[testedgelabel1:TestEdgeLabel{weight: 10}]->(endvertex2:EndVertex{name:'EV1'})  return startvertex0, testedgelabel1, endvertex2"
 	suite.Equal(expectedQueryString, queryString)
 }
 
@@ -129,9 +129,191 @@ func (suite *EdgeQueryBuilderTestSuite) TestQueryModeWriteWithCreate() {
 
 	queryString, err := suite.edgeQueryBuilder.Build()
 	suite.NoError(err)
-	expectedQueryString := "CREATE (st:StartVertex{name:'SV1'})-[te:TestEdgeLabel{weight: 10}]->(en:EndVertex{name:'EV1'})  return st, te, en"
+	expectedQueryString := "CREATE (startvertex0:StartVertex{name:'SV1'})-[testedgelabel1:TestEdgeLabel{weight: 10}]->(endvertex2:EndVertex{name:'EV1'})  return startvertex0, testedgelabel1, endvertex2"
 	suite.Equal(expectedQueryString, queryString)
 }
+
+func (suite *EdgeQueryBuilderTestSuite) TestQueryModeWriteWithMatchEndpoints() {
+	suite.edgeQueryBuilder.SetLabel([]string{"TestEdgeLabel"})
+	suite.edgeQueryBuilder.SetStartVertexLabels([]string{"StartVertex"})
+	suite.edgeQueryBuilder.SetEndVertexLabels([]string{"EndVertex"})
+	suite.edgeQueryBuilder.SetQueryMode(core.Write)
+	suite.edgeQueryBuilder.SetStartVertexSelector(core.KVMap{"name": "SV1"})
+	suite.edgeQueryBuilder.SetEndVertexSelector(core.KVMap{"name": "EV1"})
+	suite.edgeQueryBuilder.SetSelector(core.KVMap{"weight": 10})
+	suite.edgeQueryBuilder.SetEdgeFetchMode(core.EdgeWithCompleteVertex)
+	suite.edgeQueryBuilder.SetMatchEndpoints(true)
+
+	queryString, err := suite.edgeQueryBuilder.Build()
+	suite.NoError(err)
+	expectedQueryString := "MATCH (startvertex0:StartVertex{name:'SV1'}), (endvertex2:EndVertex{name:'EV1'}) MERGE (startvertex0)-[testedgelabel1:TestEdgeLabel{weight: 10}]->(endvertex2) return startvertex0, testedgelabel1, endvertex2"
+	suite.Equal(expectedQueryString, queryString)
+}
+
+func (suite *EdgeQueryBuilderTestSuite) TestQueryModeWriteWithCreateAndMatchEndpoints() {
+	suite.edgeQueryBuilder.SetLabel([]string{"TestEdgeLabel"})
+	suite.edgeQueryBuilder.SetStartVertexLabels([]string{"StartVertex"})
+	suite.edgeQueryBuilder.SetEndVertexLabels([]string{"EndVertex"})
+	suite.edgeQueryBuilder.SetQueryMode(core.Write)
+	suite.edgeQueryBuilder.SetStartVertexSelector(core.KVMap{"name": "SV1"})
+	suite.edgeQueryBuilder.SetEndVertexFilters(core.KVMap{"age": 10})
+	suite.edgeQueryBuilder.SetEdgeFetchMode(core.EdgeWithVertexIds)
+	suite.edgeQueryBuilder.SetWriteMode(core.Create)
+	suite.edgeQueryBuilder.SetMatchEndpoints(true)
+
+	queryString, err := suite.edgeQueryBuilder.Build()
+	suite.NoError(err)
+	expectedQueryString := "MATCH (startvertex0:StartVertex{name:'SV1'}), (endvertex2:EndVertex) WHERE endvertex2.age=10 CREATE (startvertex0)-[testedgelabel1:TestEdgeLabel]->(endvertex2) return testedgelabel1"
+	suite.Equal(expectedQueryString, queryString)
+}
+
+func (suite *EdgeQueryBuilderTestSuite) TestQueryModeWriteWithMatchedStartAndMergedEndVertex() {
+	suite.edgeQueryBuilder.SetLabel([]string{"TestEdgeLabel"})
+	suite.edgeQueryBuilder.SetStartVertexLabels([]string{"StartVertex"})
+	suite.edgeQueryBuilder.SetEndVertexLabels([]string{"EndVertex"})
+	suite.edgeQueryBuilder.SetQueryMode(core.Write)
+	suite.edgeQueryBuilder.SetStartVertexSelector(core.KVMap{"name": "SV1"})
+	suite.edgeQueryBuilder.SetEndVertexSelector(core.KVMap{"name": "EV1"})
+	suite.edgeQueryBuilder.SetEdgeFetchMode(core.EdgeWithCompleteVertex)
+	suite.edgeQueryBuilder.SetStartVertexMode(core.EndpointMatch)
+	suite.edgeQueryBuilder.SetEndVertexMode(core.EndpointMerge)
+
+	queryString, err := suite.edgeQueryBuilder.Build()
+	suite.NoError(err)
+	expectedQueryString := "MATCH (startvertex0:StartVertex{name:'SV1'}) MERGE (endvertex2:EndVertex{name:'EV1'}) MERGE (startvertex0)-[testedgelabel1:TestEdgeLabel]->(endvertex2) return startvertex0, testedgelabel1, endvertex2"
+	suite.Equal(expectedQueryString, queryString)
+}
+
+func (suite *EdgeQueryBuilderTestSuite) TestQueryModeWriteWithMatchedStartAndCreatedEndVertex() {
+	suite.edgeQueryBuilder.SetLabel([]string{"TestEdgeLabel"})
+	suite.edgeQueryBuilder.SetStartVertexLabels([]string{"StartVertex"})
+	suite.edgeQueryBuilder.SetEndVertexLabels([]string{"EndVertex"})
+	suite.edgeQueryBuilder.SetQueryMode(core.Write)
+	suite.edgeQueryBuilder.SetStartVertexFilters(core.KVMap{"age": 10})
+	suite.edgeQueryBuilder.SetEndVertexSelector(core.KVMap{"name": "EV1"})
+	suite.edgeQueryBuilder.SetWriteMode(core.Create)
+	suite.edgeQueryBuilder.SetStartVertexMode(core.EndpointMatch)
+	suite.edgeQueryBuilder.SetEndVertexMode(core.EndpointCreate)
+
+	queryString, err := suite.edgeQueryBuilder.Build()
+	suite.NoError(err)
+	expectedQueryString := "MATCH (startvertex0:StartVertex) WHERE startvertex0.age=10 CREATE (endvertex2:EndVertex{name:'EV1'}) CREATE (startvertex0)-[testedgelabel1:TestEdgeLabel]->(endvertex2) return testedgelabel1"
+	suite.Equal(expectedQueryString, queryString)
+}
+
+func (suite *EdgeQueryBuilderTestSuite) TestQueryModeWriteWithMatchedStartAndDefaultEndVertex() {
+	suite.edgeQueryBuilder.SetLabel([]string{"TestEdgeLabel"})
+	suite.edgeQueryBuilder.SetStartVertexLabels([]string{"StartVertex"})
+	suite.edgeQueryBuilder.SetEndVertexLabels([]string{"EndVertex"})
+	suite.edgeQueryBuilder.SetQueryMode(core.Write)
+	suite.edgeQueryBuilder.SetStartVertexSelector(core.KVMap{"name": "SV1"})
+	suite.edgeQueryBuilder.SetEndVertexSelector(core.KVMap{"name": "EV1"})
+	suite.edgeQueryBuilder.SetStartVertexMode(core.EndpointMatch)
+
+	queryString, err := suite.edgeQueryBuilder.Build()
+	suite.NoError(err)
+	expectedQueryString := "MATCH (startvertex0:StartVertex{name:'SV1'}) MERGE (startvertex0)-[testedgelabel1:TestEdgeLabel]->(endvertex2:EndVertex{name:'EV1'}) return testedgelabel1"
+	suite.Equal(expectedQueryString, queryString)
+}
+
+func (suite *EdgeQueryBuilderTestSuite) TestQueryModeWriteEndpointModeTakesPrecedenceOverMatchEndpoints() {
+	suite.edgeQueryBuilder.SetLabel([]string{"TestEdgeLabel"})
+	suite.edgeQueryBuilder.SetStartVertexLabels([]string{"StartVertex"})
+	suite.edgeQueryBuilder.SetEndVertexLabels([]string{"EndVertex"})
+	suite.edgeQueryBuilder.SetQueryMode(core.Write)
+	suite.edgeQueryBuilder.SetStartVertexSelector(core.KVMap{"name": "SV1"})
+	suite.edgeQueryBuilder.SetEndVertexSelector(core.KVMap{"name": "EV1"})
+	suite.edgeQueryBuilder.SetMatchEndpoints(true)
+	suite.edgeQueryBuilder.SetEndVertexMode(core.EndpointMerge)
+
+	queryString, err := suite.edgeQueryBuilder.Build()
+	suite.NoError(err)
+	expectedQueryString := "MATCH (startvertex0:StartVertex{name:'SV1'}) MERGE (endvertex2:EndVertex{name:'EV1'}) MERGE (startvertex0)-[testedgelabel1:TestEdgeLabel]->(endvertex2) return testedgelabel1"
+	suite.Equal(expectedQueryString, queryString)
+}
+
+func (suite *EdgeQueryBuilderTestSuite) TestQueryModeWriteWithEndpointModesAndEdgeFilters() {
+	suite.edgeQueryBuilder.SetLabel([]string{"TestEdgeLabel"})
+	suite.edgeQueryBuilder.SetStartVertexLabels([]string{"StartVertex"})
+	suite.edgeQueryBuilder.SetEndVertexLabels([]string{"EndVertex"})
+	suite.edgeQueryBuilder.SetQueryMode(core.Write)
+	suite.edgeQueryBuilder.SetFilters(core.KVMap{"weight": 10})
+	suite.edgeQueryBuilder.SetStartVertexMode(core.EndpointMatch)
+
+	_, err := suite.edgeQueryBuilder.Build()
+	suite.EqualError(err, "edge filters cannot be specified when writing the endpoints separately")
+}
+
+func (suite *EdgeQueryBuilderTestSuite) TestQueryModeWriteSelfLoop() {
+	suite.edgeQueryBuilder.SetLabel([]string{"KNOWS"})
+	suite.edgeQueryBuilder.SetStartVertexLabels([]string{"Person"})
+	suite.edgeQueryBuilder.SetEndVertexLabels([]string{"Person"})
+	suite.edgeQueryBuilder.SetStartVertexVariableName("sv")
+	suite.edgeQueryBuilder.SetEndVertexVariableName("ev")
+	suite.edgeQueryBuilder.SetVariableName("rel")
+	suite.edgeQueryBuilder.SetQueryMode(core.Write)
+	suite.edgeQueryBuilder.SetStartVertexSelector(core.KVMap{"name": "Narcissus"})
+	suite.edgeQueryBuilder.SetEndVertexSelector(core.KVMap{"name": "Narcissus"})
+	suite.edgeQueryBuilder.SetEdgeFetchMode(core.EdgeWithCompleteVertex)
+
+	queryString, vars, err := suite.edgeQueryBuilder.BuildWithVars()
+	suite.NoError(err)
+	expectedQueryString := "MERGE (sv:Person{name:'Narcissus'})-[rel:KNOWS]->(sv)  return sv, rel, sv AS ev"
+	suite.Equal(expectedQueryString, queryString)
+	suite.Equal("ev", vars[EndVertexVar])
+}
+
+func (suite *EdgeQueryBuilderTestSuite) TestQueryModeWriteSelfLoopWithMatchEndpoints() {
+	suite.edgeQueryBuilder.SetLabel([]string{"KNOWS"})
+	suite.edgeQueryBuilder.SetStartVertexLabels([]string{"Person"})
+	suite.edgeQueryBuilder.SetEndVertexLabels([]string{"Person"})
+	suite.edgeQueryBuilder.SetQueryMode(core.Write)
+	suite.edgeQueryBuilder.SetStartVertexSelector(core.KVMap{"name": "Narcissus"})
+	suite.edgeQueryBuilder.SetEndVertexSelector(core.KVMap{"name": "Narcissus"})
+	suite.edgeQueryBuilder.SetMatchEndpoints(true)
+
+	queryString, err := suite.edgeQueryBuilder.Build()
+	suite.NoError(err)
+	expectedQueryString := "MATCH (person0:Person{name:'Narcissus'}) MERGE (person0)-[knows1:KNOWS]->(person0) return knows1"
+	suite.Equal(expectedQueryString, queryString)
+}
+
+func (suite *EdgeQueryBuilderTestSuite) TestQueryModeWriteIdenticalLabelsWithoutSelectorsIsNotSelfLoop() {
+	suite.edgeQueryBuilder.SetLabel([]string{"KNOWS"})
+	suite.edgeQueryBuilder.SetStartVertexLabels([]string{"Person"})
+	suite.edgeQueryBuilder.SetEndVertexLabels([]string{"Person"})
+	suite.edgeQueryBuilder.SetQueryMode(core.Write)
+
+	queryString, err := suite.edgeQueryBuilder.Build()
+	suite.NoError(err)
+	suite.Equal("MERGE (person0:Person)-[knows1:KNOWS]->(person2:Person)  return knows1", queryString)
+}
+
+func (suite *EdgeQueryBuilderTestSuite) TestQueryModeReadIdenticalEndpointsIsNotSelfLoop() {
+	suite.edgeQueryBuilder.SetLabel([]string{"KNOWS"})
+	suite.edgeQueryBuilder.SetStartVertexLabels([]string{"Person"})
+	suite.edgeQueryBuilder.SetEndVertexLabels([]string{"Person"})
+	suite.edgeQueryBuilder.SetQueryMode(core.Read)
+	suite.edgeQueryBuilder.SetStartVertexSelector(core.KVMap{"name": "Narcissus"})
+	suite.edgeQueryBuilder.SetEndVertexSelector(core.KVMap{"name": "Narcissus"})
+
+	queryString, err := suite.edgeQueryBuilder.Build()
+	suite.NoError(err)
+	suite.Equal("MATCH (person0:Person{name:'Narcissus'})-[knows1:KNOWS]->(person2:Person{name:'Narcissus'})  return knows1", queryString)
+}
+
+func (suite *EdgeQueryBuilderTestSuite) TestQueryModeWriteWithMatchEndpointsAndEdgeFilters() {
+	suite.edgeQueryBuilder.SetLabel([]string{"TestEdgeLabel"})
+	suite.edgeQueryBuilder.SetStartVertexLabels([]string{"StartVertex"})
+	suite.edgeQueryBuilder.SetEndVertexLabels([]string{"EndVertex"})
+	suite.edgeQueryBuilder.SetQueryMode(core.Write)
+	suite.edgeQueryBuilder.SetFilters(core.KVMap{"weight": 10})
+	suite.edgeQueryBuilder.SetMatchEndpoints(true)
+
+	_, err := suite.edgeQueryBuilder.Build()
+	suite.Error(err)
+}
+
 func (suite *EdgeQueryBuilderTestSuite) TestBuildWithMultipleStartAndEndVertexLabels() {
 	suite.edgeQueryBuilder.SetLabel([]string{"TestEdgeLabel"})
 	suite.edgeQueryBuilder.SetStartVertexLabels([]string{"StartVertex", "StartVertex1"})
@@ -145,7 +327,7 @@ func (suite *EdgeQueryBuilderTestSuite) TestBuildWithMultipleStartAndEndVertexLa
 
 	queryString, err := suite.edgeQueryBuilder.Build()
 	suite.NoError(err)
-	expectedQueryString := "MATCH (st:StartVertex:StartVertex1{name:'SV1'})-[r:TestEdgeLabel{weight: 10}]->(en:EndVertex:EndVertex1{name:'EV1'})  return st, r, en"
+	expectedQueryString := "MATCH (startvertex0:StartVertex:StartVertex1{name:'SV1'})-[r:TestEdgeLabel{weight: 10}]->(endvertex2:EndVertex:EndVertex1{name:'EV1'})  return startvertex0, r, endvertex2"
 	suite.Equal(expectedQueryString, queryString)
 }
 
@@ -169,9 +351,9 @@ func (suite *EdgeQueryBuilderTestSuite) TestBuildWithFilters() {
 	queryComponents := strings.Split(queryString, " WHERE ")
 	suite.Equal(2, len(queryComponents))
 	filterString := queryComponents[1]
-	filterString = strings.Replace(filterString, "return te", "", -1)
+	filterString = strings.Replace(filterString, "return testedgelabel1", "", -1)
 	filterComponents := strings.Split(filterString, " AND ")
-	expectedFilterCompnents := []string{"st.name='SV1'", "en.name='EV1'", "te.name='TestEdge'", "te.weight=100"}
+	expectedFilterCompnents := []string{"startvertex0.name='SV1'", "endvertex2.name='EV1'", "testedgelabel1.name='TestEdge'", "testedgelabel1.weight=100"}
 	actualFilterComponentsSet := make(map[string]bool)
 	for _, v := range filterComponents {
 		actualFilterComponentsSet[strings.TrimLeft(strings.TrimRight(v, " "), " ")] = true
@@ -212,7 +394,7 @@ func (suite *EdgeQueryBuilderTestSuite) TestBuildWithNoStartVertexSelectorsAndLa
 	suite.edgeQueryBuilder.SetEndVertexSelector(core.KVMap{"TestProperty": "TestValue"})
 	queryString, err := suite.edgeQueryBuilder.Build()
 	suite.NoError(err)
-	expectedQuery := "MATCH (sv)-[la:label1]->(en:EndVertex{TestProperty:'TestValue'})  return la"
+	expectedQuery := "MATCH (sv)-[label11:label1]->(endvertex2:EndVertex{TestProperty:'TestValue'})  return label11"
 	suite.Equal(expectedQuery, queryString)
 }
 
@@ -225,10 +407,487 @@ func (suite *EdgeQueryBuilderTestSuite) TestBuildWithNoEndVertexSelectorsAndLabe
 	suite.edgeQueryBuilder.SetQueryMode(core.Read)
 	queryString, err := suite.edgeQueryBuilder.Build()
 	suite.NoError(err)
-	expectedQuery := "MATCH (sv{TestProperty:'TestValue'})-[la:label1]->(en:EndVertex)  return la"
+	expectedQuery := "MATCH (sv{TestProperty:'TestValue'})-[label11:label1]->(endvertex2:EndVertex)  return label11"
 	suite.Equal(expectedQuery, queryString)
 }
 
+func (suite *EdgeQueryBuilderTestSuite) TestBuildWithAnyLabel() {
+	suite.edgeQueryBuilder.SetAnyLabel(true)
+	suite.edgeQueryBuilder.SetStartVertexLabels([]string{"StartVertex"})
+	suite.edgeQueryBuilder.SetEndVertexLabels([]string{"EndVertex"})
+	suite.edgeQueryBuilder.SetQueryMode(core.Read)
+	suite.edgeQueryBuilder.SetStartVertexSelector(core.KVMap{"name": "SV1"})
+	suite.edgeQueryBuilder.SetEndVertexSelector(core.KVMap{"name": "EV1"})
+	suite.edgeQueryBuilder.SetEdgeFetchMode(core.EdgeWithVertexIds)
+
+	queryString, err := suite.edgeQueryBuilder.Build()
+	suite.NoError(err)
+	expectedQueryString := "MATCH (startvertex0:StartVertex{name:'SV1'})-[r1]->(endvertex2:EndVertex{name:'EV1'})  return r1"
+	suite.Equal(expectedQueryString, queryString)
+}
+
+func (suite *EdgeQueryBuilderTestSuite) TestBuildWithAnyLabelAndVarName() {
+	suite.edgeQueryBuilder.SetAnyLabel(true)
+	suite.edgeQueryBuilder.SetStartVertexLabels([]string{"StartVertex"})
+	suite.edgeQueryBuilder.SetEndVertexLabels([]string{"EndVertex"})
+	suite.edgeQueryBuilder.SetQueryMode(core.Read)
+	suite.edgeQueryBuilder.SetEdgeFetchMode(core.EdgeWithCompleteVertex)
+	suite.edgeQueryBuilder.SetVariableName("rel")
+
+	queryString, err := suite.edgeQueryBuilder.Build()
+	suite.NoError(err)
+	expectedQueryString := "MATCH (startvertex0:StartVertex)-[rel]->(endvertex2:EndVertex)  return startvertex0, rel, endvertex2"
+	suite.Equal(expectedQueryString, queryString)
+}
+
+func (suite *EdgeQueryBuilderTestSuite) TestBuildWithAnyLabelWriteMode() {
+	suite.edgeQueryBuilder.SetAnyLabel(true)
+	suite.edgeQueryBuilder.SetStartVertexLabels([]string{"StartVertex"})
+	suite.edgeQueryBuilder.SetEndVertexLabels([]string{"EndVertex"})
+	suite.edgeQueryBuilder.SetQueryMode(core.Write)
+
+	queryString, err := suite.edgeQueryBuilder.Build()
+	suite.Error(err)
+	suite.Equal("", queryString)
+}
+
+func (suite *EdgeQueryBuilderTestSuite) TestSetLabelsTwiceReplaces() {
+	suite.edgeQueryBuilder.SetLabel([]string{"OldEdgeLabel"})
+	suite.edgeQueryBuilder.SetLabel([]string{"TestEdgeLabel"})
+	suite.edgeQueryBuilder.SetStartVertexLabels([]string{"OldStartVertex"})
+	suite.edgeQueryBuilder.SetStartVertexLabels([]string{"StartVertex"})
+	suite.edgeQueryBuilder.SetEndVertexLabels([]string{"OldEndVertex"})
+	suite.edgeQueryBuilder.SetEndVertexLabels([]string{"EndVertex"})
+	suite.edgeQueryBuilder.SetQueryMode(core.Read)
+	suite.edgeQueryBuilder.SetEdgeFetchMode(core.EdgeWithVertexIds)
+
+	queryString, err := suite.edgeQueryBuilder.Build()
+	suite.NoError(err)
+	expectedQueryString := "MATCH (startvertex0:StartVertex)-[testedgelabel1:TestEdgeLabel]->(endvertex2:EndVertex)  return testedgelabel1"
+	suite.Equal(expectedQueryString, queryString)
+
+	// building again with the same builder must produce the same query
+	queryString, err = suite.edgeQueryBuilder.Build()
+	suite.NoError(err)
+	suite.Equal(expectedQueryString, queryString)
+}
+
+func (suite *EdgeQueryBuilderTestSuite) TestBuildWithVars() {
+	suite.edgeQueryBuilder.SetLabel([]string{"TestEdgeLabel"})
+	suite.edgeQueryBuilder.SetStartVertexLabels([]string{"StartVertex"})
+	suite.edgeQueryBuilder.SetEndVertexLabels([]string{"EndVertex"})
+	suite.edgeQueryBuilder.SetEndVertexVariableName("ev")
+	suite.edgeQueryBuilder.SetQueryMode(core.Read)
+	suite.edgeQueryBuilder.SetEdgeFetchMode(core.EdgeWithCompleteVertex)
+
+	queryString, vars, err := suite.edgeQueryBuilder.BuildWithVars()
+	suite.NoError(err)
+	suite.Equal(map[string]string{StartVertexVar: "startvertex0", EndVertexVar: "ev", EdgeVar: "testedgelabel1"}, vars)
+	suite.Equal("MATCH (startvertex0:StartVertex)-[testedgelabel1:TestEdgeLabel]->(ev:EndVertex)  return startvertex0, testedgelabel1, ev", queryString)
+}
+
+func (suite *EdgeQueryBuilderTestSuite) TestBuildWithVarsInvalid() {
+	queryString, vars, err := suite.edgeQueryBuilder.BuildWithVars()
+	suite.Error(err)
+	suite.Nil(vars)
+	suite.Equal("", queryString)
+}
+
+func (suite *EdgeQueryBuilderTestSuite) TestBuildWithRawWhereAndFilters() {
+	suite.edgeQueryBuilder.SetLabel([]string{"TestEdgeLabel"})
+	suite.edgeQueryBuilder.SetStartVertexLabels([]string{"StartVertex"})
+	suite.edgeQueryBuilder.SetEndVertexLabels([]string{"EndVertex"})
+	suite.edgeQueryBuilder.SetQueryMode(core.Read)
+	suite.edgeQueryBuilder.SetEdgeFetchMode(core.EdgeWithVertexIds)
+	suite.edgeQueryBuilder.SetVariableName("r")
+	suite.edgeQueryBuilder.SetFilters(core.KVMap{"weight": 100})
+	suite.edgeQueryBuilder.SetStartVertexVariableName("st")
+	suite.edgeQueryBuilder.SetEndVertexVariableName("en")
+	suite.edgeQueryBuilder.SetRawWhere("st.age + en.age > r.weight")
+
+	queryString, err := suite.edgeQueryBuilder.Build()
+	suite.NoError(err)
+	expectedQueryString := "MATCH (st:StartVertex)-[r:TestEdgeLabel]->(en:EndVertex)  WHERE r.weight=100 AND (st.age + en.age > r.weight) return r"
+	suite.Equal(expectedQueryString, queryString)
+}
+
+func (suite *EdgeQueryBuilderTestSuite) TestBuildWithIdenticalEndpointLabels() {
+	suite.edgeQueryBuilder.SetLabel([]string{"KNOWS"})
+	suite.edgeQueryBuilder.SetStartVertexLabels([]string{"Person"})
+	suite.edgeQueryBuilder.SetEndVertexLabels([]string{"Person"})
+	suite.edgeQueryBuilder.SetQueryMode(core.Read)
+	suite.edgeQueryBuilder.SetEdgeFetchMode(core.EdgeWithCompleteVertex)
+
+	queryString, err := suite.edgeQueryBuilder.Build()
+	suite.NoError(err)
+	suite.Equal("MATCH (person0:Person)-[knows1:KNOWS]->(person2:Person)  return person0, knows1, person2", queryString)
+}
+
+func (suite *EdgeQueryBuilderTestSuite) TestBuildWithCollidingLabelPrefixes() {
+	defer func(maxLength int) { MaxVariableNameLength = maxLength }(MaxVariableNameLength)
+	MaxVariableNameLength = 2
+	suite.edgeQueryBuilder.SetLabel([]string{"PERFORMED"})
+	suite.edgeQueryBuilder.SetStartVertexLabels([]string{"Person"})
+	suite.edgeQueryBuilder.SetEndVertexLabels([]string{"Performance"})
+	suite.edgeQueryBuilder.SetQueryMode(core.Read)
+	suite.edgeQueryBuilder.SetEdgeFetchMode(core.EdgeWithCompleteVertex)
+
+	queryString, err := suite.edgeQueryBuilder.Build()
+	suite.NoError(err)
+	suite.Equal("MATCH (pe0:Person)-[pe1:PERFORMED]->(pe2:Performance)  return pe0, pe1, pe2", queryString)
+}
+
+func (suite *EdgeQueryBuilderTestSuite) TestBuildWithShortLabels() {
+	suite.edgeQueryBuilder.SetLabel([]string{"R"})
+	suite.edgeQueryBuilder.SetStartVertexLabels([]string{"A"})
+	suite.edgeQueryBuilder.SetEndVertexLabels([]string{"B"})
+	suite.edgeQueryBuilder.SetQueryMode(core.Read)
+	suite.edgeQueryBuilder.SetEdgeFetchMode(core.EdgeWithCompleteVertex)
+
+	queryString, err := suite.edgeQueryBuilder.Build()
+	suite.NoError(err)
+	suite.Equal("MATCH (a0:A)-[r1:R]->(b2:B)  return a0, r1, b2", queryString)
+}
+
+func (suite *EdgeQueryBuilderTestSuite) TestBuildWithLimit() {
+	suite.edgeQueryBuilder.SetLabel([]string{"KNOWS"})
+	suite.edgeQueryBuilder.SetStartVertexLabels([]string{"Person"})
+	suite.edgeQueryBuilder.SetEndVertexLabels([]string{"Person"})
+	suite.edgeQueryBuilder.SetQueryMode(core.Read)
+	suite.edgeQueryBuilder.SetEdgeFetchMode(core.EdgeWithCompleteVertex)
+	suite.edgeQueryBuilder.SetLimit(5)
+
+	queryString, err := suite.edgeQueryBuilder.Build()
+	suite.NoError(err)
+	suite.Equal("MATCH (person0:Person)-[knows1:KNOWS]->(person2:Person)  return person0, knows1, person2 LIMIT 5", queryString)
+}
+
+func (suite *EdgeQueryBuilderTestSuite) TestBuildWithPaging() {
+	suite.edgeQueryBuilder.SetLabel([]string{"KNOWS"})
+	suite.edgeQueryBuilder.SetStartVertexLabels([]string{"Person"})
+	suite.edgeQueryBuilder.SetEndVertexLabels([]string{"Person"})
+	suite.edgeQueryBuilder.SetQueryMode(core.Read)
+	suite.edgeQueryBuilder.SetEdgeFetchMode(core.EdgeWithCompleteVertex)
+	suite.edgeQueryBuilder.SetOrderByID(true)
+	suite.edgeQueryBuilder.SetSkip(10)
+	suite.edgeQueryBuilder.SetLimit(5)
+
+	queryString, err := suite.edgeQueryBuilder.Build()
+	suite.NoError(err)
+	suite.Equal("MATCH (person0:Person)-[knows1:KNOWS]->(person2:Person)  return person0, knows1, person2 ORDER BY id(knows1) SKIP 10 LIMIT 5", queryString)
+}
+
+func (suite *EdgeQueryBuilderTestSuite) TestBuildOrderedByProperties() {
+	suite.edgeQueryBuilder.SetLabel([]string{"KNOWS"})
+	suite.edgeQueryBuilder.SetStartVertexLabels([]string{"Person"})
+	suite.edgeQueryBuilder.SetEndVertexLabels([]string{"Person"})
+	suite.edgeQueryBuilder.SetQueryMode(core.Read)
+	suite.edgeQueryBuilder.SetOrderBy(core.OrderBy{Property: "since"})
+
+	queryString, err := suite.edgeQueryBuilder.Build()
+	suite.NoError(err)
+	suite.Equal("MATCH (person0:Person)-[knows1:KNOWS]->(person2:Person)  return knows1 ORDER BY knows1.since", queryString)
+
+	// the properties order the edges returned along with the complete vertices, followed by the identity
+	suite.edgeQueryBuilder.SetEdgeFetchMode(core.EdgeWithCompleteVertex)
+	suite.edgeQueryBuilder.SetOrderBy(core.OrderBy{Property: "weight", Descending: true}, core.OrderBy{Property: "order"})
+	suite.edgeQueryBuilder.SetOrderByID(true)
+	suite.edgeQueryBuilder.SetLimit(5)
+	queryString, err = suite.edgeQueryBuilder.Build()
+	suite.NoError(err)
+	suite.Equal("MATCH (person0:Person)-[knows1:KNOWS]->(person2:Person)  return person0, knows1, person2 ORDER BY knows1.weight DESC, knows1.`order`, id(knows1) LIMIT 5", queryString)
+}
+
+func (suite *EdgeQueryBuilderTestSuite) TestBuildOrderedByInvalidProperties() {
+	suite.edgeQueryBuilder.SetLabel([]string{"KNOWS"})
+	suite.edgeQueryBuilder.SetQueryMode(core.Read)
+	suite.edgeQueryBuilder.SetOrderBy(core.OrderBy{Property: " "})
+	_, err := suite.edgeQueryBuilder.Build()
+	suite.EqualError(err, "order by property cannot be empty")
+
+	// property names are included within the query text, hence only identifiers are accepted
+	suite.edgeQueryBuilder.SetOrderBy(core.OrderBy{Property: "since DESC, knows0.x"})
+	_, err = suite.edgeQueryBuilder.Build()
+	suite.EqualError(err, "invalid order by property since DESC, knows0.x")
+	suite.edgeQueryBuilder.SetOrderBy(core.OrderBy{Property: "since) DETACH DELETE knows0 //"})
+	_, err = suite.edgeQueryBuilder.Build()
+	suite.EqualError(err, "invalid order by property since) DETACH DELETE knows0 //")
+
+	suite.edgeQueryBuilder.SetOrderBy(core.OrderBy{Property: "since"})
+	suite.edgeQueryBuilder.SetHops(1, 3)
+	_, err = suite.edgeQueryBuilder.Build()
+	suite.EqualError(err, "variable length relationships cannot be ordered by the edge properties")
+
+	suite.edgeQueryBuilder.SetHops(0, 0)
+	suite.edgeQueryBuilder.SetQueryMode(core.Delete)
+	_, err = suite.edgeQueryBuilder.Build()
+	suite.EqualError(err, "delete queries cannot be ordered or paged")
+}
+
+func (suite *EdgeQueryBuilderTestSuite) TestBuildWithInvalidSkip() {
+	suite.edgeQueryBuilder.SetLabel([]string{"KNOWS"})
+	suite.edgeQueryBuilder.SetQueryMode(core.Read)
+	suite.edgeQueryBuilder.SetSkip(-1)
+	_, err := suite.edgeQueryBuilder.Build()
+	suite.EqualError(err, "invalid skip -1")
+}
+
+func (suite *EdgeQueryBuilderTestSuite) TestBuildWithHopsOrderedByID() {
+	suite.edgeQueryBuilder.SetLabel([]string{"PARENT_OF"})
+	suite.edgeQueryBuilder.SetQueryMode(core.Read)
+	suite.edgeQueryBuilder.SetHops(1, 3)
+	suite.edgeQueryBuilder.SetOrderByID(true)
+	_, err := suite.edgeQueryBuilder.Build()
+	suite.EqualError(err, "variable length relationships cannot be ordered by the edge identity")
+}
+
+func (suite *EdgeQueryBuilderTestSuite) TestBuildWithHops() {
+	suite.edgeQueryBuilder.SetLabel([]string{"PARENT_OF"})
+	suite.edgeQueryBuilder.SetStartVertexLabels([]string{"Person"})
+	suite.edgeQueryBuilder.SetEndVertexLabels([]string{"Person"})
+	suite.edgeQueryBuilder.SetEndVertexSelector(core.KVMap{"name": "Tom"})
+	suite.edgeQueryBuilder.SetQueryMode(core.Read)
+	suite.edgeQueryBuilder.SetEdgeFetchMode(core.EdgeWithCompleteVertex)
+	suite.edgeQueryBuilder.SetHops(1, 0)
+
+	queryString, err := suite.edgeQueryBuilder.Build()
+	suite.NoError(err)
+	suite.Equal("MATCH (person0:Person)-[parent_of1:PARENT_OF*1..]->(person2:Person{name:'Tom'})  return DISTINCT person0, person2", queryString)
+
+	suite.edgeQueryBuilder.SetHops(2, 3)
+	queryString, err = suite.edgeQueryBuilder.Build()
+	suite.NoError(err)
+	suite.Equal("MATCH (person0:Person)-[parent_of1:PARENT_OF*2..3]->(person2:Person{name:'Tom'})  return DISTINCT person0, person2", queryString)
+}
+
+func (suite *EdgeQueryBuilderTestSuite) TestBuildWithInvalidHops() {
+	suite.edgeQueryBuilder.SetLabel([]string{"PARENT_OF"})
+	suite.edgeQueryBuilder.SetStartVertexLabels([]string{"Person"})
+	suite.edgeQueryBuilder.SetEndVertexLabels([]string{"Person"})
+	suite.edgeQueryBuilder.SetQueryMode(core.Read)
+	suite.edgeQueryBuilder.SetHops(3, 2)
+	_, err := suite.edgeQueryBuilder.Build()
+	suite.Error(err)
+
+	suite.edgeQueryBuilder.SetHops(1, 2)
+	suite.edgeQueryBuilder.SetQueryMode(core.Write)
+	_, err = suite.edgeQueryBuilder.Build()
+	suite.Error(err)
+}
+
+func (suite *EdgeQueryBuilderTestSuite) TestBuildWithReservedWords() {
+	suite.edgeQueryBuilder.SetLabel([]string{"Order"})
+	suite.edgeQueryBuilder.SetStartVertexLabels([]string{"Match"})
+	suite.edgeQueryBuilder.SetEndVertexLabels([]string{"Person"})
+	suite.edgeQueryBuilder.SetQueryMode(core.Read)
+	suite.edgeQueryBuilder.SetSelector(core.KVMap{"by": "web"})
+	suite.edgeQueryBuilder.SetEdgeFetchMode(core.EdgeWithVertexIds)
+
+	queryString, err := suite.edgeQueryBuilder.Build()
+	suite.NoError(err)
+	suite.Equal("MATCH (match0:`Match`)-[order1:`Order`{`by`:'web'}]->(person2:Person)  return order1", queryString)
+}
+
+func (suite *EdgeQueryBuilderTestSuite) TestBuildParameterized() {
+	suite.edgeQueryBuilder.SetLabel([]string{"KNOWS"})
+	suite.edgeQueryBuilder.SetStartVertexLabels([]string{"Person"})
+	suite.edgeQueryBuilder.SetEndVertexLabels([]string{"Person"})
+	suite.edgeQueryBuilder.SetQueryMode(core.Read)
+	suite.edgeQueryBuilder.SetStartVertexSelector(core.KVMap{"name": "Tintin"})
+	suite.edgeQueryBuilder.SetEndVertexSelector(core.KVMap{"name": "Haddock"})
+	suite.edgeQueryBuilder.SetSelector(core.KVMap{"since": 1941})
+	suite.edgeQueryBuilder.SetFilters(core.KVMap{"strength": 0.5})
+	suite.edgeQueryBuilder.SetEdgeFetchMode(core.EdgeWithVertexIds)
+	suite.edgeQueryBuilder.SetParameterized(true)
+
+	queryString, params, err := suite.edgeQueryBuilder.BuildWithParams()
+	suite.NoError(err)
+	suite.Equal("MATCH (person0:Person{name: $p0})-[knows1:KNOWS{since: $p2}]->(person2:Person{name: $p1})  WHERE knows1.strength=$p3 return knows1", queryString)
+	suite.Equal(map[string]interface{}{"p0": "Tintin", "p1": "Haddock", "p2": 1941, "p3": 0.5}, params)
+}
+
+func (suite *EdgeQueryBuilderTestSuite) TestBuildParameterizedSelfLoop() {
+	suite.edgeQueryBuilder.SetLabel([]string{"KNOWS"})
+	suite.edgeQueryBuilder.SetStartVertexLabels([]string{"Person"})
+	suite.edgeQueryBuilder.SetEndVertexLabels([]string{"Person"})
+	suite.edgeQueryBuilder.SetQueryMode(core.Write)
+	suite.edgeQueryBuilder.SetStartVertexSelector(core.KVMap{"name": "Tintin"})
+	suite.edgeQueryBuilder.SetEndVertexSelector(core.KVMap{"name": "Tintin"})
+	suite.edgeQueryBuilder.SetParameterized(true)
+
+	queryString, params, err := suite.edgeQueryBuilder.BuildWithParams()
+	suite.NoError(err)
+	suite.Equal("MERGE (person0:Person{name: $p0})-[knows1:KNOWS]->(person0)  return knows1", queryString)
+	suite.Equal(map[string]interface{}{"p0": "Tintin"}, params)
+}
+
+func (suite *EdgeQueryBuilderTestSuite) TestBuildWithComparisonFilters() {
+	suite.edgeQueryBuilder.SetLabel([]string{"KNOWS"})
+	suite.edgeQueryBuilder.SetStartVertexLabels([]string{"Person"})
+	suite.edgeQueryBuilder.SetEndVertexLabels([]string{"Person"})
+	suite.edgeQueryBuilder.SetQueryMode(core.Read)
+	suite.edgeQueryBuilder.SetStartVertexFilters(core.KVMap{"age": core.Filter{Operator: core.Gte, Value: 18}})
+	suite.edgeQueryBuilder.SetFilters(core.KVMap{"since": core.Filter{Operator: core.Lt, Value: 2000}})
+	suite.edgeQueryBuilder.SetEdgeFetchMode(core.EdgeWithVertexIds)
+
+	queryString, err := suite.edgeQueryBuilder.Build()
+	suite.NoError(err)
+	suite.Equal("MATCH (person0:Person)-[knows1:KNOWS]->(person2:Person)  WHERE knows1.since<2000 AND person0.age>=18 return knows1", queryString)
+}
+
+func (suite *EdgeQueryBuilderTestSuite) TestQueryModeWriteWithOnCreateProperties() {
+	suite.edgeQueryBuilder.SetLabel([]string{"KNOWS"})
+	suite.edgeQueryBuilder.SetStartVertexLabels([]string{"Person"})
+	suite.edgeQueryBuilder.SetEndVertexLabels([]string{"Person"})
+	suite.edgeQueryBuilder.SetQueryMode(core.Write)
+	suite.edgeQueryBuilder.SetStartVertexSelector(core.KVMap{"name": "Tintin"})
+	suite.edgeQueryBuilder.SetEndVertexSelector(core.KVMap{"name": "Haddock"})
+	suite.edgeQueryBuilder.SetSelector(core.KVMap{"idempotencyKey": "request-1"})
+	suite.edgeQueryBuilder.SetOnCreateProperties(core.KVMap{"since": 1941, "order": "first"})
+	suite.edgeQueryBuilder.SetParameterized(true)
+
+	queryString, params, err := suite.edgeQueryBuilder.BuildWithParams()
+	suite.NoError(err)
+	suite.Equal("MERGE (person0:Person{name: $p0})-[knows1:KNOWS{idempotencyKey: $p2}]->(person2:Person{name: $p1})  ON CREATE SET knows1.`order` = $p3, knows1.since = $p4 return knows1", queryString)
+	suite.Equal(map[string]interface{}{"p0": "Tintin", "p1": "Haddock", "p2": "request-1", "p3": "first", "p4": 1941}, params)
+}
+
+func (suite *EdgeQueryBuilderTestSuite) TestQueryModeWriteWithOnCreatePropertiesAndCreate() {
+	suite.edgeQueryBuilder.SetLabel([]string{"KNOWS"})
+	suite.edgeQueryBuilder.SetStartVertexLabels([]string{"Person"})
+	suite.edgeQueryBuilder.SetEndVertexLabels([]string{"Person"})
+	suite.edgeQueryBuilder.SetQueryMode(core.Write)
+	suite.edgeQueryBuilder.SetWriteMode(core.Create)
+	suite.edgeQueryBuilder.SetStartVertexSelector(core.KVMap{"name": "Tintin"})
+	suite.edgeQueryBuilder.SetEndVertexSelector(core.KVMap{"name": "Haddock"})
+	suite.edgeQueryBuilder.SetOnCreateProperties(core.KVMap{"since": 1941})
+	suite.edgeQueryBuilder.SetMatchEndpoints(true)
+
+	queryString, err := suite.edgeQueryBuilder.Build()
+	suite.NoError(err)
+	suite.Equal("MATCH (person0:Person{name:'Tintin'}), (person2:Person{name:'Haddock'}) CREATE (person0)-[knows1:KNOWS]->(person2) SET knows1.since = 1941 return knows1", queryString)
+}
+
+func (suite *EdgeQueryBuilderTestSuite) TestQueryModeReadWithOnCreateProperties() {
+	suite.edgeQueryBuilder.SetLabel([]string{"KNOWS"})
+	suite.edgeQueryBuilder.SetStartVertexLabels([]string{"Person"})
+	suite.edgeQueryBuilder.SetEndVertexLabels([]string{"Person"})
+	suite.edgeQueryBuilder.SetOnCreateProperties(core.KVMap{"since": 1941})
+
+	_, err := suite.edgeQueryBuilder.Build()
+	suite.EqualError(err, "on create properties can only be set by write queries")
+}
+
+func (suite *EdgeQueryBuilderTestSuite) TestQueryModeWriteWithDataProperties() {
+	suite.edgeQueryBuilder.SetLabel([]string{"KNOWS"})
+	suite.edgeQueryBuilder.SetStartVertexLabels([]string{"Person"})
+	suite.edgeQueryBuilder.SetEndVertexLabels([]string{"Person"})
+	suite.edgeQueryBuilder.SetQueryMode(core.Write)
+	suite.edgeQueryBuilder.SetStartVertexSelector(core.KVMap{"name": "Tintin"})
+	suite.edgeQueryBuilder.SetEndVertexSelector(core.KVMap{"name": "Haddock"})
+	suite.edgeQueryBuilder.SetSelector(core.KVMap{"since": 1941})
+	suite.edgeQueryBuilder.SetDataProperties(core.KVMap{"weight": 0.5, "order": "first"})
+	suite.edgeQueryBuilder.SetParameterized(true)
+
+	queryString, params, err := suite.edgeQueryBuilder.BuildWithParams()
+	suite.NoError(err)
+	suite.Equal("MERGE (person0:Person{name: $p0})-[knows1:KNOWS{since: $p2}]->(person2:Person{name: $p1})  SET knows1.`order` = $p3, knows1.weight = $p4 return knows1", queryString)
+	suite.Equal(map[string]interface{}{"p0": "Tintin", "p1": "Haddock", "p2": 1941, "p3": "first", "p4": 0.5}, params)
+}
+
+func (suite *EdgeQueryBuilderTestSuite) TestQueryModeWriteWithDataPropertiesMatchingEndpoints() {
+	suite.edgeQueryBuilder.SetLabel([]string{"KNOWS"})
+	suite.edgeQueryBuilder.SetStartVertexLabels([]string{"Person"})
+	suite.edgeQueryBuilder.SetEndVertexLabels([]string{"Person"})
+	suite.edgeQueryBuilder.SetQueryMode(core.Write)
+	suite.edgeQueryBuilder.SetStartVertexSelector(core.KVMap{"name": "Tintin"})
+	suite.edgeQueryBuilder.SetEndVertexSelector(core.KVMap{"name": "Haddock"})
+	suite.edgeQueryBuilder.SetSelector(core.KVMap{"idempotencyKey": "request-1"})
+	suite.edgeQueryBuilder.SetOnCreateProperties(core.KVMap{"since": 1941})
+	suite.edgeQueryBuilder.SetDataProperties(core.KVMap{"weight": 0.5})
+	suite.edgeQueryBuilder.SetMatchEndpoints(true)
+
+	queryString, err := suite.edgeQueryBuilder.Build()
+	suite.NoError(err)
+	suite.Equal("MATCH (person0:Person{name:'Tintin'}), (person2:Person{name:'Haddock'}) MERGE (person0)-[knows1:KNOWS{idempotencyKey:'request-1'}]->(person2) ON CREATE SET knows1.since = 1941 SET knows1.weight = 0.5 return knows1", queryString)
+}
+
+func (suite *EdgeQueryBuilderTestSuite) TestQueryModeReadWithDataProperties() {
+	suite.edgeQueryBuilder.SetLabel([]string{"KNOWS"})
+	suite.edgeQueryBuilder.SetStartVertexLabels([]string{"Person"})
+	suite.edgeQueryBuilder.SetEndVertexLabels([]string{"Person"})
+	suite.edgeQueryBuilder.SetDataProperties(core.KVMap{"weight": 0.5})
+
+	_, err := suite.edgeQueryBuilder.Build()
+	suite.EqualError(err, "data properties can only be set by write queries")
+}
+
+func (suite *EdgeQueryBuilderTestSuite) TestQueryModeDelete() {
+	suite.edgeQueryBuilder.SetLabel([]string{"KNOWS"})
+	suite.edgeQueryBuilder.SetStartVertexLabels([]string{"Person"})
+	suite.edgeQueryBuilder.SetEndVertexLabels([]string{"Person"})
+	suite.edgeQueryBuilder.SetQueryMode(core.Delete)
+	suite.edgeQueryBuilder.SetStartVertexSelector(core.KVMap{"name": "Tintin"})
+	suite.edgeQueryBuilder.SetEndVertexSelector(core.KVMap{"name": "Tintin"})
+	suite.edgeQueryBuilder.SetFilters(core.KVMap{"since": core.Filter{Operator: core.Lt, Value: 1950}})
+	suite.edgeQueryBuilder.SetEdgeFetchMode(core.EdgeWithCompleteVertex)
+	suite.edgeQueryBuilder.SetParameterized(true)
+
+	// identical endpoints are matched separately, since the edge is not written as a self loop
+	queryString, params, err := suite.edgeQueryBuilder.BuildWithParams()
+	suite.NoError(err)
+	suite.Equal("MATCH (person0:Person{name: $p0})-[knows1:KNOWS]->(person2:Person{name: $p1})  WHERE knows1.since<$p2 DELETE knows1 RETURN count(knows1) AS deleted", queryString)
+	suite.Equal(map[string]interface{}{"p0": "Tintin", "p1": "Tintin", "p2": 1950}, params)
+}
+
+func (suite *EdgeQueryBuilderTestSuite) TestQueryModeDeleteReturningIds() {
+	suite.edgeQueryBuilder.SetAnyLabel(true)
+	suite.edgeQueryBuilder.SetStartVertexVariableName("sv")
+	suite.edgeQueryBuilder.SetEndVertexVariableName("ev")
+	suite.edgeQueryBuilder.SetVariableName("r")
+	suite.edgeQueryBuilder.SetQueryMode(core.Delete)
+	suite.edgeQueryBuilder.SetSelector(core.KVMap{"since": 1941})
+	suite.edgeQueryBuilder.SetDeletedIdFunction("elementId")
+
+	queryString, err := suite.edgeQueryBuilder.Build()
+	suite.NoError(err)
+	suite.Equal("MATCH (sv)-[r{since: 1941}]->(ev)  WITH r, elementId(r) AS edgeId DELETE r RETURN edgeId", queryString)
+}
+
+func (suite *EdgeQueryBuilderTestSuite) TestQueryModeDeleteInvalid() {
+	suite.edgeQueryBuilder.SetLabel([]string{"KNOWS"})
+	suite.edgeQueryBuilder.SetStartVertexLabels([]string{"Person"})
+	suite.edgeQueryBuilder.SetEndVertexLabels([]string{"Person"})
+	suite.edgeQueryBuilder.SetDeletedIdFunction("elementId")
+	_, err := suite.edgeQueryBuilder.Build()
+	suite.EqualError(err, "deleted ids can only be returned by delete queries")
+
+	suite.edgeQueryBuilder.SetQueryMode(core.Delete)
+	suite.edgeQueryBuilder.SetLimit(10)
+	_, err = suite.edgeQueryBuilder.Build()
+	suite.EqualError(err, "delete queries cannot be ordered or paged")
+
+	suite.edgeQueryBuilder.SetLimit(0)
+	suite.edgeQueryBuilder.SetHops(1, 2)
+	_, err = suite.edgeQueryBuilder.Build()
+	suite.EqualError(err, "variable length relationships cannot be written")
+}
+
+func (suite *EdgeQueryBuilderTestSuite) TestEmptyLabel() {
+	suite.edgeQueryBuilder.SetLabel([]string{"TestEdgeLabel"})
+	suite.edgeQueryBuilder.SetStartVertexLabels([]string{""})
+	suite.edgeQueryBuilder.SetEndVertexLabels([]string{"EndVertex"})
+	suite.edgeQueryBuilder.SetQueryMode(core.Read)
+
+	_, err := suite.edgeQueryBuilder.Build()
+	suite.EqualError(err, `invalid start vertex label "": labels cannot be empty`)
+
+	suite.edgeQueryBuilder.SetStartVertexLabels([]string{"StartVertex"})
+	suite.edgeQueryBuilder.SetLabel([]string{" "})
+	_, err = suite.edgeQueryBuilder.Build()
+	suite.EqualError(err, `invalid edge label " ": labels cannot be empty`)
+}
+
 func TestEdgeQueryBuilderTestSuite(t *testing.T) {
 	suite.Run(t, new(EdgeQueryBuilderTestSuite))
 }
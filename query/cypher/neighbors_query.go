@@ -0,0 +1,59 @@
+package cypher
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/prahaladd/gograph/core"
+)
+
+// variable names to which the results of the queries generated using BuildExpandNeighborsQuery and
+// BuildNeighborsQuery are bound
+const (
+	// ExpandIdVar is the variable name to which the identifier of an expanded vertex is bound
+	ExpandIdVar = "id"
+	// NeighborEdgeVar is the variable name to which an edge connecting a vertex to a neighbor is bound
+	NeighborEdgeVar = "r"
+	// NeighborVar is the variable name to which a neighbor of a vertex is bound
+	NeighborVar = "n"
+)
+
+// ExpandIdsParam is the name of the parameter holding the identifiers of the vertices expanded by the query
+// generated using BuildExpandNeighborsQuery
+const ExpandIdsParam = "ids"
+
+// BuildExpandNeighborsQuery builds a cypher query returning the edges of the specified direction and labels connecting
+// each of the vertices identified by the ExpandIdsParam parameter to their neighbors. The vertices are identified using
+// the specified identity function, for e.g. elementId.
+//
+// A row is returned for each of the edges, with the identifier of the expanded vertex, the edge and the neighbor bound
+// to ExpandIdVar, NeighborEdgeVar and NeighborVar respectively. Edges of all labels are returned if no edge labels are
+// specified.
+func BuildExpandNeighborsQuery(idFunction string, direction core.EdgeDirection, edgeLabels []string) (string, error) {
+	if idFunction == "" {
+		return "", errors.New("identity function must be specified")
+	}
+	pattern, err := buildEdgesOfVertexPattern(direction, edgeLabels, fmt.Sprintf("(%s)", NeighborVar))
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("UNWIND $%s AS %s MATCH %s WHERE %s(v) = %s RETURN %s, %s, %s", ExpandIdsParam, ExpandIdVar, pattern,
+		idFunction, ExpandIdVar, ExpandIdVar, NeighborEdgeVar, NeighborVar), nil
+}
+
+// BuildNeighborsQuery builds a cypher query returning the edges of the specified direction and labels connecting the
+// vertex bound to the variable v to its neighbors. The vertex is selected using the specified predicate, for e.g.
+// id(v) = '3.1'.
+//
+// The edge and the neighbor are bound to NeighborEdgeVar and NeighborVar respectively. Edges of all labels are
+// returned if no edge labels are specified.
+func BuildNeighborsQuery(vertexPredicate string, direction core.EdgeDirection, edgeLabels []string) (string, error) {
+	if vertexPredicate == "" {
+		return "", errors.New("vertex predicate must be specified")
+	}
+	pattern, err := buildEdgesOfVertexPattern(direction, edgeLabels, fmt.Sprintf("(%s)", NeighborVar))
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("MATCH %s WHERE %s RETURN %s, %s", pattern, vertexPredicate, NeighborEdgeVar, NeighborVar), nil
+}
@@ -0,0 +1,44 @@
+package cypher
+
+import (
+	"testing"
+
+	"github.com/prahaladd/gograph/core"
+	"github.com/stretchr/testify/suite"
+)
+
+type NeighborsQueryTestSuite struct {
+	suite.Suite
+}
+
+func (suite *NeighborsQueryTestSuite) TestBuildExpandNeighborsQuery() {
+	query, err := BuildExpandNeighborsQuery("elementId", core.DirectionBoth, nil)
+	suite.NoError(err)
+	suite.Equal("UNWIND $ids AS id MATCH (v)-[r]-(n) WHERE elementId(v) = id RETURN id, r, n", query)
+
+	query, err = BuildExpandNeighborsQuery("elementId", core.DirectionOutgoing, []string{"KNOWS", "LIKES"})
+	suite.NoError(err)
+	suite.Equal("UNWIND $ids AS id MATCH (v)-[r:KNOWS|LIKES]->(n) WHERE elementId(v) = id RETURN id, r, n", query)
+}
+
+func (suite *NeighborsQueryTestSuite) TestBuildExpandNeighborsQueryInvalid() {
+	_, err := BuildExpandNeighborsQuery("", core.DirectionBoth, nil)
+	suite.EqualError(err, "identity function must be specified")
+	_, err = BuildExpandNeighborsQuery("elementId", core.DirectionBoth, []string{""})
+	suite.EqualError(err, "labels cannot be empty")
+	_, err = BuildExpandNeighborsQuery("elementId", core.EdgeDirection(7), nil)
+	suite.EqualError(err, "invalid edge direction 7")
+}
+
+func (suite *NeighborsQueryTestSuite) TestBuildNeighborsQuery() {
+	query, err := BuildNeighborsQuery("id(v) = '3.1'", core.DirectionIncoming, []string{"KNOWS"})
+	suite.NoError(err)
+	suite.Equal("MATCH (v)<-[r:KNOWS]-(n) WHERE id(v) = '3.1' RETURN r, n", query)
+
+	_, err = BuildNeighborsQuery("", core.DirectionBoth, nil)
+	suite.EqualError(err, "vertex predicate must be specified")
+}
+
+func TestNeighborsQueryTestSuite(t *testing.T) {
+	suite.Run(t, new(NeighborsQueryTestSuite))
+}
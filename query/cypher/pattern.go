@@ -0,0 +1,64 @@
+package cypher
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/prahaladd/gograph/core"
+)
+
+// RelationshipPattern describes the relationships connecting a vertex bound within a query to other vertices. For
+// e.g. the orders placed by a person are described by the edge label HAS, the outgoing direction and the end vertex
+// label Order.
+type RelationshipPattern struct {
+	// EdgeLabel is the label of the relationships. Relationships of all labels are described if empty
+	EdgeLabel string
+	// Direction is the direction of the relationships relative to the bound vertex
+	Direction core.EdgeDirection
+	// EndVertexLabels are the labels of the vertices at the other end of the relationships. Vertices of all labels
+	// are described if empty
+	EndVertexLabels []string
+	// EndVertexSelector selects the vertices at the other end of the relationships with the specific set of
+	// properties
+	EndVertexSelector core.KVMap
+}
+
+// build builds the pattern starting at the vertex bound to the specified variable name. The relationships and the
+// end vertices are not bound to any variable.
+func (rp RelationshipPattern) build(varName string, params parameters) (string, error) {
+	endVertex := bytes.Buffer{}
+	endVertex.WriteString("(")
+	for _, label := range rp.EndVertexLabels {
+		if len(label) == 0 {
+			return "", errors.New("labels cannot be empty")
+		}
+		endVertex.WriteString(fmt.Sprintf(":%s", EscapeName(label)))
+	}
+	endVertex.WriteString(buildSelector(rp.EndVertexSelector, params))
+	endVertex.WriteString(")")
+	relationship := ""
+	if rp.EdgeLabel != "" {
+		relationship = fmt.Sprintf(":%s", EscapeName(rp.EdgeLabel))
+	}
+	return buildDirectedPattern(fmt.Sprintf("(%s)", varName), relationship, endVertex.String(), rp.Direction)
+}
+
+// existsCondition is a condition testing the existence of the relationships described by a pattern
+type existsCondition struct {
+	pattern RelationshipPattern
+	negated bool
+}
+
+// build builds the EXISTS subquery for the pattern starting at the vertex bound to the specified variable name
+func (ec existsCondition) build(varName string, params parameters) (string, error) {
+	pattern, err := ec.pattern.build(varName, params)
+	if err != nil {
+		return "", err
+	}
+	condition := fmt.Sprintf("EXISTS { %s }", pattern)
+	if ec.negated {
+		condition = fmt.Sprintf("NOT %s", condition)
+	}
+	return condition, nil
+}
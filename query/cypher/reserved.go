@@ -0,0 +1,48 @@
+package cypher
+
+import (
+	"regexp"
+	"strings"
+)
+
+// identifierRegex matches the names that can be used as unquoted labels or property keys
+var identifierRegex = regexp.MustCompile(`^[\pL_][\pL\pN_]*$`)
+
+// reservedWords are the Cypher keywords that cannot be used as unquoted labels or property keys. The words are
+// matched case insensitively.
+var reservedWords = map[string]struct{}{
+	"ALL": {}, "ASC": {}, "ASCENDING": {}, "BY": {}, "CREATE": {}, "DELETE": {}, "DESC": {}, "DESCENDING": {},
+	"DETACH": {}, "EXISTS": {}, "LIMIT": {}, "MATCH": {}, "MERGE": {}, "ON": {}, "OPTIONAL": {}, "ORDER": {},
+	"REMOVE": {}, "RETURN": {}, "SET": {}, "SKIP": {}, "WHERE": {}, "WITH": {}, "UNION": {}, "UNWIND": {},
+	"AND": {}, "AS": {}, "CONTAINS": {}, "DISTINCT": {}, "ENDS": {}, "IN": {}, "IS": {}, "NOT": {}, "OR": {},
+	"STARTS": {}, "XOR": {}, "CASE": {}, "ELSE": {}, "END": {}, "THEN": {}, "WHEN": {}, "CONSTRAINT": {},
+	"DO": {}, "FOR": {}, "REQUIRE": {}, "UNIQUE": {}, "MANDATORY": {}, "SCALAR": {}, "OF": {}, "ADD": {},
+	"DROP": {}, "FALSE": {}, "NULL": {}, "TRUE": {}, "CALL": {}, "YIELD": {}, "USE": {},
+}
+
+// IsReservedWord returns true if the specified name is a Cypher reserved word
+func IsReservedWord(name string) bool {
+	_, reserved := reservedWords[strings.ToUpper(name)]
+	return reserved
+}
+
+// IsIdentifier returns true if the specified name can be used as a label or property key within a generated query
+// without being quoted, apart from the quoting of reserved words by EscapeName
+func IsIdentifier(name string) bool {
+	return identifierRegex.MatchString(name)
+}
+
+// EscapeName quotes the specified label or property key with backticks if it is a Cypher reserved word, so that
+// the name can be used within a generated query. Other names are returned unchanged.
+func EscapeName(name string) string {
+	if !IsReservedWord(name) {
+		return name
+	}
+	return "`" + name + "`"
+}
+
+// QuoteName always quotes the specified label or property key with backticks, escaping the backticks within the
+// name, so that any name, including names chosen at runtime, can be safely used within a generated query.
+func QuoteName(name string) string {
+	return "`" + strings.ReplaceAll(name, "`", "``") + "`"
+}
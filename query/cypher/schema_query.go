@@ -0,0 +1,49 @@
+package cypher
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// RelationshipTypeVar is the variable name to which the distinct relationship types are bound by the
+// query generated using BuildRelationshipTypesQuery
+const RelationshipTypeVar = "relType"
+
+// LabelsVar is the variable name to which the labels of the vertices are bound by the query generated using
+// BuildLabelCombinationsQuery
+const LabelsVar = "labels"
+
+// BuildRelationshipTypesQuery builds a cypher query returning the distinct types of the relationships
+// from vertices having the specified start labels to vertices having the specified end labels.
+//
+// Empty start or end labels match vertices of any label.
+func BuildRelationshipTypesQuery(startLabels, endLabels []string) (string, error) {
+	startLabelFragment, err := buildLabelFragment(startLabels)
+	if err != nil {
+		return "", err
+	}
+	endLabelFragment, err := buildLabelFragment(endLabels)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("MATCH (a%s)-[r]->(b%s) RETURN DISTINCT type(r) AS %s", startLabelFragment, endLabelFragment, RelationshipTypeVar), nil
+}
+
+func buildLabelFragment(labels []string) (string, error) {
+	builder := strings.Builder{}
+	for _, label := range labels {
+		if len(label) == 0 {
+			return "", errors.New("labels cannot be empty")
+		}
+		builder.WriteString(":")
+		builder.WriteString(EscapeName(label))
+	}
+	return builder.String(), nil
+}
+
+// BuildLabelCombinationsQuery builds a cypher query returning the distinct labels of the vertices within the graph.
+// The labels of a vertex are read using the specified function, for e.g. labels.
+func BuildLabelCombinationsQuery(labelsFunction string) string {
+	return fmt.Sprintf("MATCH (n) RETURN DISTINCT %s(n) AS %s", labelsFunction, LabelsVar)
+}
@@ -0,0 +1,36 @@
+package cypher
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type SchemaQueryTestSuite struct {
+	suite.Suite
+}
+
+func (suite *SchemaQueryTestSuite) TestBuildRelationshipTypesQuery() {
+	query, err := BuildRelationshipTypesQuery([]string{"Person"}, []string{"Company"})
+	suite.NoError(err)
+	suite.Equal("MATCH (a:Person)-[r]->(b:Company) RETURN DISTINCT type(r) AS relType", query)
+}
+
+func (suite *SchemaQueryTestSuite) TestBuildRelationshipTypesQueryWithoutLabels() {
+	query, err := BuildRelationshipTypesQuery(nil, []string{"Company", "Listed"})
+	suite.NoError(err)
+	suite.Equal("MATCH (a)-[r]->(b:Company:Listed) RETURN DISTINCT type(r) AS relType", query)
+}
+
+func (suite *SchemaQueryTestSuite) TestBuildRelationshipTypesQueryWithEmptyLabel() {
+	_, err := BuildRelationshipTypesQuery([]string{""}, []string{"Company"})
+	suite.Error(err)
+}
+
+func (suite *SchemaQueryTestSuite) TestBuildLabelCombinationsQuery() {
+	suite.Equal("MATCH (n) RETURN DISTINCT labels(n) AS labels", BuildLabelCombinationsQuery("labels"))
+}
+
+func TestSchemaQueryTestSuite(t *testing.T) {
+	suite.Run(t, new(SchemaQueryTestSuite))
+}
@@ -3,53 +3,266 @@ package cypher
 import (
 	"bytes"
 	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/prahaladd/gograph/core"
+)
+
+// keys of the variable names returned by the query builders
+const (
+	// VertexVar is the key against which the variable name bound to a vertex is returned
+	VertexVar = "vertex"
+	// StartVertexVar is the key against which the variable name bound to the start vertex of an edge is returned
+	StartVertexVar = "startVertex"
+	// EndVertexVar is the key against which the variable name bound to the end vertex of an edge is returned
+	EndVertexVar = "endVertex"
+	// EdgeVar is the key against which the variable name bound to an edge is returned
+	EdgeVar = "edge"
 )
 
-func buildSelector(selector map[string]interface{}) string {
+// MaxVariableNameLength limits the length of the label derived prefix of the variable names generated by the
+// query builders for vertices and edges without an explicitly specified variable name. A value of 0 or less
+// retains the complete label.
+var MaxVariableNameLength = 0
+
+// positions of the elements within a query pattern used to generate unique variable names
+const (
+	startVertexPosition = iota
+	edgePosition
+	endVertexPosition
+)
+
+// generateVariableName generates a variable name for an element of a query pattern from the first of the specified
+// labels, or the specified default prefix if no labels are specified.
+//
+// The generated name is the lower cased label restricted to identifier characters and suffixed with the position
+// of the element within the pattern. Names are hence unique within a pattern even if the elements share a label.
+func generateVariableName(labels []string, defaultPrefix string, position int) string {
+	prefix := ""
+	if len(labels) > 0 {
+		prefix = strings.Map(func(r rune) rune {
+			if unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' {
+				return unicode.ToLower(r)
+			}
+			return -1
+		}, labels[0])
+	}
+	// identifiers cannot start with a digit
+	if len(prefix) == 0 || unicode.IsDigit([]rune(prefix)[0]) {
+		prefix = defaultPrefix + prefix
+	}
+	if MaxVariableNameLength > 0 && len([]rune(prefix)) > MaxVariableNameLength {
+		prefix = string([]rune(prefix)[:MaxVariableNameLength])
+	}
+	return fmt.Sprintf("%s%d", prefix, position)
+}
+
+// parameters collects the property values of a parameterized query against the names of the parameters generated
+// for them. The values are formatted as literals within the query instead if the parameters are nil.
+type parameters map[string]interface{}
+
+// newParameters returns the parameters collecting the property values of a query if the query is parameterized
+func newParameters(parameterized bool) parameters {
+	if !parameterized {
+		return nil
+	}
+	return make(parameters)
+}
+
+// value returns the reference to a newly generated parameter bound to the specified value, or the value formatted as
+// a literal if the query is not parameterized. The parameters are named p0, p1 and so on in the order the values are
+// added.
+func (params parameters) value(v interface{}) string {
+	if params == nil {
+		return formatLiteral(v)
+	}
+	name := fmt.Sprintf("p%d", len(params))
+	params[name] = v
+	return "$" + name
+}
+
+func buildSelector(selector map[string]interface{}, params parameters) string {
 	if len(selector) == 0 {
 		return ""
 	}
 	buffer := bytes.Buffer{}
 	buffer.WriteString("{")
 	firstFilterProcessed := false
-	for k, v := range selector {
+	// the selector is built in the order of the property names so that the parameters are always generated in the
+	// same order
+	for _, k := range sortedKeys(selector) {
+		v := selector[k]
 		if firstFilterProcessed {
 			buffer.WriteString(",")
 		}
-		switch v.(type) {
-		case string:
-			buffer.WriteString(fmt.Sprintf("%s:'%s'", k, v))
-		default:
-			buffer.WriteString(fmt.Sprintf("%s: %v", k, v))
+		separator := ": "
+		if _, ok := v.(string); ok && params == nil {
+			separator = ":"
 		}
+		buffer.WriteString(EscapeName(k) + separator + params.value(v))
 		firstFilterProcessed = true
 	}
 	buffer.WriteString("}")
 	return buffer.String()
 }
 
-func buildFilterConditions(varName string, filters map[string]interface{}) string {
+// filterOperators are the cypher comparison operators of the filter operators
+var filterOperators = map[core.FilterOperator]string{
+	core.Eq:  "=",
+	core.Gt:  ">",
+	core.Gte: ">=",
+	core.Lt:  "<",
+	core.Lte: "<=",
+	core.Neq: "<>",
+}
+
+// validateLabels returns an error if any of the specified labels of the kind of graph element, for e.g. vertex, is
+// empty or consists only of whitespace as the label would produce an invalid query
+func validateLabels(kind string, labels []string) error {
+	for _, label := range labels {
+		if strings.TrimSpace(label) == "" {
+			return fmt.Errorf("invalid %s label %q: labels cannot be empty", kind, label)
+		}
+	}
+	return nil
+}
+
+// validateFilters returns an error if a core.Filter within the specified filters uses an unknown operator, or if the
+// value of a core.Within filter is not a core.Distance
+func validateFilters(filters ...map[string]interface{}) error {
+	for _, f := range filters {
+		for _, k := range sortedKeys(f) {
+			filter := core.FilterOf(f[k])
+			if filter.Operator == core.Within {
+				if _, ok := distanceOf(filter.Value); !ok {
+					return fmt.Errorf("value of the within filter on %s must be a core.Distance", k)
+				}
+				continue
+			}
+			if filterOperators[filter.Operator] == "" {
+				return fmt.Errorf("invalid operator %d in the filter on %s", filter.Operator, k)
+			}
+		}
+	}
+	return nil
+}
+
+// distanceOf returns the core.Distance specified as the value of a core.Within filter
+func distanceOf(value interface{}) (core.Distance, bool) {
+	switch d := value.(type) {
+	case core.Distance:
+		return d, true
+	case *core.Distance:
+		if d != nil {
+			return *d, true
+		}
+	}
+	return core.Distance{}, false
+}
+
+// buildFilterConditions builds the conditions comparing the properties of the specified variable with the filters.
+// Filter values that are not a core.Filter are compared for equality. core.Within filters compare the distance between
+// the property and the point of the filter with the radius using point.distance.
+func buildFilterConditions(varName string, filters map[string]interface{}, params parameters) string {
 	if len(filters) == 0 {
 		return ""
 	}
 	buffer := bytes.Buffer{}
 	firstFilterProcessed := false
-	for k, v := range filters {
+	// the conditions are built in the order of the property names so that the same filters always build the same
+	// WHERE clause
+	for _, k := range sortedKeys(filters) {
+		filter := core.FilterOf(filters[k])
 		if firstFilterProcessed {
 			buffer.WriteString(" AND ")
 		}
-		switch v.(type) {
-		case string:
-			buffer.WriteString(fmt.Sprintf("%s.%s='%s'", varName, k, v))
-		default:
-			buffer.WriteString(fmt.Sprintf("%s.%s=%v", varName, k, v))
+		if distance, ok := distanceOf(filter.Value); ok && filter.Operator == core.Within {
+			buffer.WriteString(fmt.Sprintf("point.distance(%s.%s, %s) < %s", varName, EscapeName(k), params.value(distance.Point), params.value(distance.Radius)))
+		} else {
+			buffer.WriteString(fmt.Sprintf("%s.%s%s%s", varName, EscapeName(k), filterOperators[filter.Operator], params.value(filter.Value)))
 		}
 		firstFilterProcessed = true
 	}
 	return buffer.String()
 }
 
-func buildMultiFilters(multiFilters map[string]map[string]interface{}) string {
+// stringEscaper escapes the characters of a string value which cannot appear as is within a single quoted cypher
+// string literal
+var stringEscaper = strings.NewReplacer(
+	`\`, `\\`,
+	`'`, `\'`,
+	"\n", `\n`,
+	"\r", `\r`,
+	"\t", `\t`,
+)
+
+// quoteString formats a string value as a single quoted cypher string literal. Backslashes, single quotes and line
+// breaks within the value are escaped so that the value cannot terminate the literal.
+func quoteString(s string) string {
+	return "'" + stringEscaper.Replace(s) + "'"
+}
+
+// formatLiteral formats a property value as a cypher literal, quoting strings
+func formatLiteral(v interface{}) string {
+	if s, ok := v.(string); ok {
+		return quoteString(s)
+	}
+	return formatValue(v)
+}
+
+// formatValue formats a non string property value as a cypher literal.
+//
+// Slices and arrays, other than byte slices, are formatted as lists of the literals of their elements. Points are
+// formatted as calls to the point function.
+//
+// Floating point values are formatted in decimal notation with the minimum number of digits required to represent
+// the value exactly, since the default formatting uses exponents for large and small values and may lose precision.
+// A decimal point is always included so that integral values are not read back as integers. NaN and infinite values
+// have no literal representation and are formatted as expressions evaluating to the value.
+func formatValue(v interface{}) string {
+	switch f := v.(type) {
+	case float64:
+		return formatFloat(f, 64)
+	case float32:
+		return formatFloat(float64(f), 32)
+	case []byte:
+		return fmt.Sprintf("%v", v)
+	case core.Point:
+		return fmt.Sprintf("point({srid: %d, x: %s, y: %s})", f.SRID, formatFloat(f.X, 64), formatFloat(f.Y, 64))
+	}
+	list := reflect.ValueOf(v)
+	if list.Kind() != reflect.Slice && list.Kind() != reflect.Array {
+		return fmt.Sprintf("%v", v)
+	}
+	elems := make([]string, 0, list.Len())
+	for i := 0; i < list.Len(); i++ {
+		elems = append(elems, formatLiteral(list.Index(i).Interface()))
+	}
+	return "[" + strings.Join(elems, ", ") + "]"
+}
+
+func formatFloat(f float64, bitSize int) string {
+	switch {
+	case math.IsNaN(f):
+		return "(0.0/0.0)"
+	case math.IsInf(f, 1):
+		return "(1.0/0.0)"
+	case math.IsInf(f, -1):
+		return "(-1.0/0.0)"
+	}
+	formatted := strconv.FormatFloat(f, 'f', -1, bitSize)
+	if !strings.Contains(formatted, ".") {
+		formatted += ".0"
+	}
+	return formatted
+}
+
+func buildMultiFilters(multiFilters map[string]map[string]interface{}, params parameters) string {
 	if len(multiFilters) == 0 {
 		return ""
 	}
@@ -57,7 +270,8 @@ func buildMultiFilters(multiFilters map[string]map[string]interface{}) string {
 	buffer := bytes.Buffer{}
 
 	firstFilterProcessed := false
-	for k, v := range multiFilters {
+	for _, k := range sortedKeys(multiFilters) {
+		v := multiFilters[k]
 		if len(v) == 0 {
 			continue
 		}
@@ -69,10 +283,69 @@ func buildMultiFilters(multiFilters map[string]map[string]interface{}) string {
 			buffer.WriteString(" AND ")
 		}
 
-		buffer.WriteString(buildFilterConditions(k, v))
+		buffer.WriteString(buildFilterConditions(k, v, params))
 		if !firstFilterProcessed {
 			firstFilterProcessed = true
 		}
 	}
 	return buffer.String()
 }
+
+// appendRawWhere combines the specified raw WHERE clause with the WHERE clause generated from the structured filters
+func appendRawWhere(filters string, rawWhere string) string {
+	if len(rawWhere) == 0 {
+		return filters
+	}
+	return appendCondition(filters, fmt.Sprintf("(%s)", rawWhere))
+}
+
+// sortedKeys returns the keys of the specified map in ascending order
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// appendCondition combines the specified condition with the WHERE clause built for the filters using AND. A WHERE
+// clause is started if no filters were specified.
+func appendCondition(filters string, condition string) string {
+	if len(filters) == 0 {
+		return fmt.Sprintf(" WHERE %s", condition)
+	}
+	return fmt.Sprintf("%s AND %s", filters, condition)
+}
+
+// buildDirectedPattern builds a relationship pattern connecting the specified start and end node fragments with the
+// specified relationship fragment in the specified direction, relative to the start node.
+func buildDirectedPattern(startNode, relationship, endNode string, direction core.EdgeDirection) (string, error) {
+	switch direction {
+	case core.DirectionBoth:
+		return fmt.Sprintf("%s-[%s]-%s", startNode, relationship, endNode), nil
+	case core.DirectionOutgoing:
+		return fmt.Sprintf("%s-[%s]->%s", startNode, relationship, endNode), nil
+	case core.DirectionIncoming:
+		return fmt.Sprintf("%s<-[%s]-%s", startNode, relationship, endNode), nil
+	default:
+		return "", fmt.Errorf("invalid edge direction %d", direction)
+	}
+}
+
+// buildSkip builds the SKIP clause for the specified number of results to be skipped. No clause is built if no
+// results are skipped.
+func buildSkip(skip int64) string {
+	if skip <= 0 {
+		return ""
+	}
+	return fmt.Sprintf(" SKIP %d", skip)
+}
+
+// buildLimit builds the LIMIT clause for the specified limit. No clause is built for a limit of 0.
+func buildLimit(limit int64) string {
+	if limit <= 0 {
+		return ""
+	}
+	return fmt.Sprintf(" LIMIT %d", limit)
+}
@@ -0,0 +1,155 @@
+package cypher
+
+import (
+	"math"
+	"strconv"
+	"testing"
+
+	"github.com/prahaladd/gograph/core"
+	"github.com/stretchr/testify/suite"
+)
+
+type UtilsTestSuite struct {
+	suite.Suite
+}
+
+func (suite *UtilsTestSuite) TestFormatLargeFloat() {
+	suite.Equal("1000000.5", formatValue(1000000.5))
+	suite.Equal("15000000000000000000000.0", formatValue(1.5e22))
+	// the formatted value is parsed back to the same value
+	parsed, err := strconv.ParseFloat(formatValue(1.2345678901234567e22), 64)
+	suite.NoError(err)
+	suite.Equal(1.2345678901234567e22, parsed)
+}
+
+func (suite *UtilsTestSuite) TestFormatSmallFloat() {
+	suite.Equal("0.000001", formatValue(1e-6))
+	suite.Equal("-0.0000000123456789", formatValue(-1.23456789e-8))
+}
+
+func (suite *UtilsTestSuite) TestFormatIntegralFloat() {
+	// integral values retain the decimal point so that they are not read back as integers
+	suite.Equal("1000000.0", formatValue(float64(1e6)))
+	suite.Equal("3.0", formatValue(float32(3)))
+	suite.Equal("1000000", formatValue(1000000))
+}
+
+func (suite *UtilsTestSuite) TestFormatList() {
+	suite.Equal("['comics', 'Tintin\\'s adventures']", formatValue([]string{"comics", "Tintin's adventures"}))
+	suite.Equal("[1, 2]", formatValue([]int64{1, 2}))
+	suite.Equal("[4.0, 4.5]", formatValue([2]float64{4, 4.5}))
+	suite.Equal("[1, 'two', 3.0]", formatValue([]interface{}{1, "two", 3.0}))
+	suite.Equal("[]", formatValue([]string{}))
+}
+
+func (suite *UtilsTestSuite) TestFormatPoint() {
+	suite.Equal("point({srid: 4326, x: -0.12, y: 51.5})", formatValue(core.NewGeographicPoint(51.5, -0.12)))
+	suite.Equal("{location: point({srid: 7203, x: 1.0, y: 2.5})}", buildSelector(map[string]interface{}{"location": core.Point{SRID: core.SRIDCartesian, X: 1, Y: 2.5}}, nil))
+}
+
+func (suite *UtilsTestSuite) TestFormatFloat32() {
+	// float32 values are formatted using the precision of a float32
+	suite.Equal("0.1", formatValue(float32(0.1)))
+}
+
+func (suite *UtilsTestSuite) TestFormatNonFiniteFloat() {
+	suite.Equal("(0.0/0.0)", formatValue(math.NaN()))
+	suite.Equal("(1.0/0.0)", formatValue(math.Inf(1)))
+	suite.Equal("(-1.0/0.0)", formatValue(math.Inf(-1)))
+}
+
+func (suite *UtilsTestSuite) TestBuildSelectorWithFloat() {
+	suite.Equal("{weight: 1000000.0}", buildSelector(map[string]interface{}{"weight": 1e6}, nil))
+	suite.Equal("v.weight=0.000001", buildFilterConditions("v", map[string]interface{}{"weight": 1e-6}, nil))
+}
+
+func (suite *UtilsTestSuite) TestEscapeReservedWords() {
+	suite.Equal("`order`", EscapeName("order"))
+	suite.Equal("`Match`", EscapeName("Match"))
+	suite.Equal("ordered", EscapeName("ordered"))
+	suite.Equal("{`order`: 1}", buildSelector(map[string]interface{}{"order": 1}, nil))
+	suite.Equal("v.name='Tintin' AND v.`where`='here'", buildFilterConditions("v", map[string]interface{}{"where": "here", "name": "Tintin"}, nil))
+}
+
+func (suite *UtilsTestSuite) TestIsIdentifier() {
+	suite.True(IsIdentifier("since"))
+	suite.True(IsIdentifier("_created_at2"))
+	suite.True(IsIdentifier("größe"))
+	suite.False(IsIdentifier(""))
+	suite.False(IsIdentifier("2nd"))
+	suite.False(IsIdentifier("since DESC"))
+	suite.False(IsIdentifier("a`b"))
+}
+
+func (suite *UtilsTestSuite) TestQuoteName() {
+	suite.Equal("`name`", QuoteName("name"))
+	suite.Equal("`Runtime Label`", QuoteName("Runtime Label"))
+	suite.Equal("`a``) DETACH DELETE v //`", QuoteName("a`) DETACH DELETE v //"))
+}
+
+func (suite *UtilsTestSuite) TestBuildSelectorWithApostrophe() {
+	suite.Equal(`{name:'O\'Brien'}`, buildSelector(map[string]interface{}{"name": "O'Brien"}, nil))
+	suite.Equal(`v.name='O\'Brien'`, buildFilterConditions("v", map[string]interface{}{"name": "O'Brien"}, nil))
+}
+
+func (suite *UtilsTestSuite) TestBuildSelectorWithQuotesInFreeText() {
+	text := "He said \"it's done\"\nC:\\temp\ttab"
+	expected := `'He said "it\'s done"\nC:\\temp\ttab'`
+	suite.Equal("{note:"+expected+"}", buildSelector(map[string]interface{}{"note": text}, nil))
+	suite.Equal("v.note="+expected, buildFilterConditions("v", map[string]interface{}{"note": text}, nil))
+}
+
+func (suite *UtilsTestSuite) TestQuoteStringCannotTerminateLiteral() {
+	// a trailing backslash must not escape the closing quote
+	suite.Equal(`'trailing\\'`, quoteString(`trailing\`))
+	suite.Equal(`'\' OR 1=1 //'`, quoteString(`' OR 1=1 //`))
+}
+
+func (suite *UtilsTestSuite) TestBuildFilterConditionsWithOperators() {
+	filters := map[string]interface{}{
+		"a": core.Filter{Operator: core.Eq, Value: 1},
+		"b": core.Filter{Operator: core.Gt, Value: 18},
+		"c": core.Filter{Operator: core.Gte, Value: 1.5},
+		"d": core.Filter{Operator: core.Lt, Value: "m"},
+		"e": &core.Filter{Operator: core.Lte, Value: 65},
+		"f": core.Filter{Operator: core.Neq, Value: "retired"},
+		"g": 7,
+	}
+	suite.Equal("v.a=1 AND v.b>18 AND v.c>=1.5 AND v.d<'m' AND v.e<=65 AND v.f<>'retired' AND v.g=7", buildFilterConditions("v", filters, nil))
+
+	params := newParameters(true)
+	suite.Equal("v.b>$p0", buildFilterConditions("v", map[string]interface{}{"b": core.Filter{Operator: core.Gt, Value: 18}}, params))
+	suite.Equal(parameters{"p0": 18}, params)
+}
+
+func (suite *UtilsTestSuite) TestBuildFilterConditionsWithinDistance() {
+	point := core.NewGeographicPoint(51.5, -0.12)
+	filters := map[string]interface{}{"location": core.WithinDistance(point, 1000), "name": "Tintin"}
+	suite.Equal("point.distance(v.location, point({srid: 4326, x: -0.12, y: 51.5})) < 1000.0 AND v.name='Tintin'", buildFilterConditions("v", filters, nil))
+
+	params := newParameters(true)
+	suite.Equal("point.distance(v.location, $p0) < $p1", buildFilterConditions("v", map[string]interface{}{"location": core.WithinDistance(point, 1000)}, params))
+	suite.Equal(parameters{"p0": point, "p1": 1000.0}, params)
+}
+
+func (suite *UtilsTestSuite) TestValidateLabels() {
+	suite.NoError(validateLabels("vertex", []string{"Person", "Actor"}))
+	suite.EqualError(validateLabels("vertex", []string{"Person", ""}), `invalid vertex label "": labels cannot be empty`)
+	suite.EqualError(validateLabels("edge", []string{" \t"}), `invalid edge label " \t": labels cannot be empty`)
+}
+
+func (suite *UtilsTestSuite) TestValidateFilters() {
+	suite.NoError(validateFilters(map[string]interface{}{"age": core.Filter{Operator: core.Neq, Value: 18}, "name": "Tintin"}))
+	suite.EqualError(validateFilters(nil, map[string]interface{}{"age": core.Filter{Operator: core.FilterOperator(42), Value: 18}}), "invalid operator 42 in the filter on age")
+}
+
+func (suite *UtilsTestSuite) TestValidateWithinFilters() {
+	point := core.NewGeographicPoint(51.5, -0.12)
+	suite.NoError(validateFilters(map[string]interface{}{"location": core.WithinDistance(point, 1000)}))
+	suite.NoError(validateFilters(map[string]interface{}{"location": core.Filter{Operator: core.Within, Value: &core.Distance{Point: point, Radius: 5}}}))
+	suite.EqualError(validateFilters(map[string]interface{}{"location": core.Filter{Operator: core.Within, Value: point}}), "value of the within filter on location must be a core.Distance")
+}
+
+func TestUtilsTestSuite(t *testing.T) {
+	suite.Run(t, new(UtilsTestSuite))
+}
@@ -27,10 +27,21 @@ type VertexQueryBuilder struct {
 	selector  core.KVMap
 	filters   core.KVMap
 	writeMode core.WriteMode
+	rawWhere  string
+	limit     int64
+	// existsConditions test the existence of relationships of the vertex
+	existsConditions []existsCondition
+	// createdTimestamp is the property set to the server timestamp when a vertex is created
+	createdTimestamp string
+	// dataProperties are the properties set on the vertex by write queries whenever they differ from the properties of
+	// the vertex
+	dataProperties core.KVMap
+	// parameterized is set when the property values are passed as query parameters instead of literals
+	parameterized bool
 }
 
 func NewVertexQueryBuilder() *VertexQueryBuilder {
-	return &VertexQueryBuilder{selector: core.KVMap{}, filters: core.KVMap{}, writeMode: core.Merge}
+	return &VertexQueryBuilder{selector: core.KVMap{}, filters: core.KVMap{}, dataProperties: core.KVMap{}, writeMode: core.Merge}
 }
 
 func (vqb *VertexQueryBuilder) SetQueryMode(mode core.QueryMode) *VertexQueryBuilder {
@@ -39,7 +50,7 @@ func (vqb *VertexQueryBuilder) SetQueryMode(mode core.QueryMode) *VertexQueryBui
 }
 
 func (vqb *VertexQueryBuilder) SetLabel(labels []string) *VertexQueryBuilder {
-	vqb.labels = labels
+	vqb.labels = append([]string(nil), labels...)
 	return vqb
 }
 
@@ -68,11 +79,92 @@ func (vqb *VertexQueryBuilder) SetWriteMode(writeMode core.WriteMode) *VertexQue
 	return vqb
 }
 
+// SetRawWhere sets a raw condition to be included within the WHERE clause of the generated query.
+// The condition is combined with the conditions generated from the filters using AND.
+//
+// The condition is included in the query verbatim and hence must never be built from untrusted input
+// as doing so would make the query vulnerable to cypher injection.
+func (vqb *VertexQueryBuilder) SetRawWhere(clause string) *VertexQueryBuilder {
+	vqb.rawWhere = clause
+	return vqb
+}
+
+// AddExistsCondition selects only the vertices with at least one relationship matching the specified pattern, for
+// e.g. persons who have placed orders.
+//
+// The condition is built as an EXISTS subquery, which requires Neo4j 4.0 or later.
+func (vqb *VertexQueryBuilder) AddExistsCondition(pattern RelationshipPattern) *VertexQueryBuilder {
+	vqb.existsConditions = append(vqb.existsConditions, existsCondition{pattern: pattern})
+	return vqb
+}
+
+// AddNotExistsCondition selects only the vertices without any relationship matching the specified pattern, for e.g.
+// persons who have not placed any order.
+func (vqb *VertexQueryBuilder) AddNotExistsCondition(pattern RelationshipPattern) *VertexQueryBuilder {
+	vqb.existsConditions = append(vqb.existsConditions, existsCondition{pattern: pattern, negated: true})
+	return vqb
+}
+
+// SetLimit sets the maximum number of results returned by the query. A limit of 0 returns all the results.
+func (vqb *VertexQueryBuilder) SetLimit(limit int64) *VertexQueryBuilder {
+	vqb.limit = limit
+	return vqb
+}
+
+// SetCreatedTimestamp sets the specified property of the vertex to the server timestamp, in milliseconds since
+// epoch, when the vertex is created by a write query. Vertices matched by a MERGE are not modified.
+func (vqb *VertexQueryBuilder) SetCreatedTimestamp(property string) *VertexQueryBuilder {
+	vqb.createdTimestamp = property
+	return vqb
+}
+
+// SetDataProperties sets properties of the vertex which are not part of the pattern of the vertex and are set by
+// write queries. Merged vertices are hence matched on the selector alone. The properties of a matched vertex are set
+// only if at least one of them differs from the property of the vertex, so that writing the same properties again
+// leaves the vertex unchanged.
+func (vqb *VertexQueryBuilder) SetDataProperties(properties core.KVMap) *VertexQueryBuilder {
+	for k, v := range properties {
+		vqb.dataProperties[k] = v
+	}
+	return vqb
+}
+
+// SetParameterized controls whether the values of the selectors and filters are passed as query parameters named
+// p0, p1 and so on instead of being included within the query as literals. Queries differing only in the values then
+// share the same text, allowing the server to reuse the query plan.
+//
+// The values of the parameters are returned by BuildWithParams.
+func (vqb *VertexQueryBuilder) SetParameterized(parameterized bool) *VertexQueryBuilder {
+	vqb.parameterized = parameterized
+	return vqb
+}
+
+// Build builds the cypher query
 func (vqb *VertexQueryBuilder) Build() (string, error) {
+	query, _, _, err := vqb.build()
+	return query, err
+}
+
+// BuildWithVars builds the cypher query and returns the variable names bound within the query.
+//
+// The variable name bound to the vertex is available against the VertexVar key.
+func (vqb *VertexQueryBuilder) BuildWithVars() (string, map[string]string, error) {
+	query, vars, _, err := vqb.build()
+	return query, vars, err
+}
+
+// BuildWithParams builds the cypher query and returns the values of the parameters referenced by the query. No
+// parameters are returned unless the builder is parameterized using SetParameterized.
+func (vqb *VertexQueryBuilder) BuildWithParams() (string, map[string]interface{}, error) {
+	query, _, params, err := vqb.build()
+	return query, params, err
+}
+
+func (vqb *VertexQueryBuilder) build() (string, map[string]string, map[string]interface{}, error) {
 
 	err := vqb.validate()
 	if err != nil {
-		return "", err
+		return "", nil, nil, err
 	}
 	operation := "MATCH"
 
@@ -83,24 +175,80 @@ func (vqb *VertexQueryBuilder) Build() (string, error) {
 		}
 	}
 
-	variableName := strings.ToLower(vqb.labels[0])[0:2]
+	variableName := generateVariableName(vqb.labels, "v", startVertexPosition)
 	if vqb.varName != "" {
 		variableName = vqb.varName
 	}
-	selectors := buildSelector(vqb.selector)
-	filters := buildMultiFilters(map[string]map[string]interface{}{variableName: vqb.filters})
+	params := newParameters(vqb.parameterized)
+	selectors := buildSelector(vqb.selector, params)
+	filters := appendRawWhere(buildMultiFilters(map[string]map[string]interface{}{variableName: vqb.filters}, params), vqb.rawWhere)
+	for _, existsCondition := range vqb.existsConditions {
+		condition, err := existsCondition.build(variableName, params)
+		if err != nil {
+			return "", nil, nil, err
+		}
+		filters = appendCondition(filters, condition)
+	}
 
 	labelSelectors := bytes.Buffer{}
 	for _, label := range vqb.labels {
-		labelSelectors.WriteString(fmt.Sprintf(":%s", label))
+		labelSelectors.WriteString(fmt.Sprintf(":%s", EscapeName(label)))
+	}
+	if vqb.createdTimestamp != "" {
+		setClause := "SET"
+		if operation == "MERGE" {
+			setClause = "ON CREATE SET"
+		}
+		filters += fmt.Sprintf(" %s %s.%s = timestamp()", setClause, variableName, EscapeName(vqb.createdTimestamp))
 	}
-	return fmt.Sprintf("%s (%s%s%s) %s return %s", operation, variableName, labelSelectors.String(), selectors, filters, variableName), nil
+	filters += vqb.buildDataClause(operation, variableName, params)
+	vars := map[string]string{VertexVar: variableName}
+	return fmt.Sprintf("%s (%s%s%s) %s return %s%s", operation, variableName, labelSelectors.String(), selectors, filters, variableName, buildLimit(vqb.limit)), vars, params, nil
 
 }
 
+// buildDataClause builds the clause setting the data properties of the vertex bound to the specified variable. A
+// merged vertex may already exist, hence the properties are set using FOREACH over a single element list only if
+// any of the properties is absent or differs, as Cypher provides no conditional SET.
+func (vqb *VertexQueryBuilder) buildDataClause(operation, varName string, params parameters) string {
+	if len(vqb.dataProperties) == 0 {
+		return ""
+	}
+	if operation != "MERGE" {
+		return fmt.Sprintf(" SET %s", buildAssignments(varName, vqb.dataProperties, params))
+	}
+	conditions := make([]string, 0, len(vqb.dataProperties))
+	assignments := make([]string, 0, len(vqb.dataProperties))
+	for _, k := range sortedKeys(vqb.dataProperties) {
+		property := fmt.Sprintf("%s.%s", varName, EscapeName(k))
+		value := params.value(vqb.dataProperties[k])
+		conditions = append(conditions, fmt.Sprintf("NOT coalesce(%s = %s, false)", property, value))
+		assignments = append(assignments, fmt.Sprintf("%s = %s", property, value))
+	}
+	return fmt.Sprintf(" FOREACH (_ IN CASE WHEN %s THEN [1] ELSE [] END | SET %s)", strings.Join(conditions, " OR "), strings.Join(assignments, ", "))
+}
+
 func (vqb *VertexQueryBuilder) validate() error {
 	if vqb.labels == nil || len(vqb.labels) == 0 {
 		return errors.New("no vertex labels specified in the query")
 	}
+	if err := validateLabels("vertex", vqb.labels); err != nil {
+		return err
+	}
+	if vqb.queryMode == core.Delete {
+		return errors.New("vertices cannot be deleted using the vertex query builder")
+	}
+	if err := validateFilters(vqb.filters); err != nil {
+		return err
+	}
+	if vqb.createdTimestamp != "" && vqb.queryMode != core.Write {
+		return errors.New("created timestamp can only be set by write queries")
+	}
+	if len(vqb.dataProperties) > 0 && vqb.queryMode != core.Write {
+		return errors.New("data properties can only be set by write queries")
+	}
+	if len(vqb.existsConditions) > 0 && vqb.queryMode == core.Write {
+		return errors.New("exists conditions can only be specified for read queries")
+	}
 	return nil
 }
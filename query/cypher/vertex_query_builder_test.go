@@ -28,8 +28,8 @@ func (suite *VertexQueryBuilderTestSuite) TestBuildQuerySingleLabelNoVariable()
 	suite.True(strings.Contains(query, "MATCH"))
 	mutateClausePresent := strings.Contains(query, "MERGE") || strings.Contains(query, "CREATE")
 	suite.False(mutateClausePresent)
-	suite.True(strings.Contains(query, "la:Label1{name:'TestName'}"))
-	expectedQuery := "MATCH (la:Label1{name:'TestName'})  WHERE la.age=10 return la"
+	suite.True(strings.Contains(query, "label10:Label1{name:'TestName'}"))
+	expectedQuery := "MATCH (label10:Label1{name:'TestName'})  WHERE label10.age=10 return label10"
 	suite.Equal(expectedQuery, query)
 }
 
@@ -61,8 +61,8 @@ func (suite *VertexQueryBuilderTestSuite) TestBuildQueryMultiLabelVariable() {
 	suite.True(strings.Contains(query, "MATCH"))
 	mutateClausePresent := strings.Contains(query, "MERGE") || strings.Contains(query, "CREATE")
 	suite.False(mutateClausePresent)
-	suite.True(strings.Contains(query, "la:Label1:Label2{name:'TestName'}"))
-	expectedQuery := "MATCH (la:Label1:Label2{name:'TestName'})  WHERE la.age=10 return la"
+	suite.True(strings.Contains(query, "label10:Label1:Label2{name:'TestName'}"))
+	expectedQuery := "MATCH (label10:Label1:Label2{name:'TestName'})  WHERE label10.age=10 return label10"
 	suite.Equal(expectedQuery, query)
 }
 
@@ -135,6 +135,327 @@ func (suite *VertexQueryBuilderTestSuite) TestFiltersMultiple() {
 	suite.True(allComponentsFound)
 }
 
+func (suite *VertexQueryBuilderTestSuite) TestSetLabelTwiceReplaces() {
+	labels := []string{"OldLabel"}
+	suite.queryBuilder.SetLabel(labels)
+	suite.queryBuilder.SetLabel([]string{"Label1"})
+	suite.queryBuilder.SetQueryMode(core.Read)
+	// modifying the passed in labels must not affect the builder
+	labels[0] = "Modified"
+
+	query, err := suite.queryBuilder.Build()
+	suite.NoError(err)
+	suite.Equal("MATCH (label10:Label1)  return label10", query)
+}
+
+func (suite *VertexQueryBuilderTestSuite) TestBuildWithVars() {
+	suite.queryBuilder.SetLabel([]string{"Label1"})
+	suite.queryBuilder.SetQueryMode(core.Read)
+	suite.queryBuilder.SetSelector(map[string]interface{}{"name": "TestName"})
+
+	query, vars, err := suite.queryBuilder.BuildWithVars()
+	suite.NoError(err)
+	suite.Equal(map[string]string{VertexVar: "label10"}, vars)
+	suite.Equal("MATCH (label10:Label1{name:'TestName'})  return label10", query)
+
+	suite.queryBuilder.SetVarName("var")
+	query, vars, err = suite.queryBuilder.BuildWithVars()
+	suite.NoError(err)
+	suite.Equal(map[string]string{VertexVar: "var"}, vars)
+	suite.Equal("MATCH (var:Label1{name:'TestName'})  return var", query)
+}
+
+func (suite *VertexQueryBuilderTestSuite) TestBuildWithRawWhere() {
+	suite.queryBuilder.SetLabel([]string{"Label1"})
+	suite.queryBuilder.SetQueryMode(core.Read)
+	suite.queryBuilder.SetVarName("v")
+	suite.queryBuilder.SetRawWhere("v.a + v.b > 10")
+
+	query, err := suite.queryBuilder.Build()
+	suite.NoError(err)
+	suite.Equal("MATCH (v:Label1)  WHERE (v.a + v.b > 10) return v", query)
+}
+
+func (suite *VertexQueryBuilderTestSuite) TestBuildWithRawWhereAndFilters() {
+	suite.queryBuilder.SetLabel([]string{"Label1"})
+	suite.queryBuilder.SetQueryMode(core.Read)
+	suite.queryBuilder.SetVarName("v")
+	suite.queryBuilder.SetFilters(map[string]interface{}{"age": 10})
+	suite.queryBuilder.SetRawWhere("v.a + v.b > 10 OR v.c = 1")
+
+	query, err := suite.queryBuilder.Build()
+	suite.NoError(err)
+	suite.Equal("MATCH (v:Label1)  WHERE v.age=10 AND (v.a + v.b > 10 OR v.c = 1) return v", query)
+}
+
+func (suite *VertexQueryBuilderTestSuite) TestBuildWithShortLabel() {
+	suite.queryBuilder.SetLabel([]string{"A"})
+	suite.queryBuilder.SetQueryMode(core.Read)
+
+	query, err := suite.queryBuilder.Build()
+	suite.NoError(err)
+	suite.Equal("MATCH (a0:A)  return a0", query)
+}
+
+func (suite *VertexQueryBuilderTestSuite) TestBuildWithNonIdentifierLabel() {
+	suite.queryBuilder.SetLabel([]string{"2nd-Label"})
+	suite.queryBuilder.SetQueryMode(core.Read)
+
+	_, vars, err := suite.queryBuilder.BuildWithVars()
+	suite.NoError(err)
+	suite.Equal("v2ndlabel0", vars[VertexVar])
+}
+
+func (suite *VertexQueryBuilderTestSuite) TestBuildWithMaxVariableNameLength() {
+	defer func(maxLength int) { MaxVariableNameLength = maxLength }(MaxVariableNameLength)
+	MaxVariableNameLength = 3
+	suite.queryBuilder.SetLabel([]string{"Person"})
+	suite.queryBuilder.SetQueryMode(core.Read)
+
+	query, err := suite.queryBuilder.Build()
+	suite.NoError(err)
+	suite.Equal("MATCH (per0:Person)  return per0", query)
+}
+
+func (suite *VertexQueryBuilderTestSuite) TestBuildWithLimit() {
+	suite.queryBuilder.SetLabel([]string{"Person"})
+	suite.queryBuilder.SetQueryMode(core.Read)
+	suite.queryBuilder.SetVarName("v")
+	suite.queryBuilder.SetSelector(map[string]interface{}{"name": "Tom"})
+	suite.queryBuilder.SetLimit(10)
+
+	query, err := suite.queryBuilder.Build()
+	suite.NoError(err)
+	suite.Equal("MATCH (v:Person{name:'Tom'})  return v LIMIT 10", query)
+}
+
+func (suite *VertexQueryBuilderTestSuite) TestBuildWithCreatedTimestamp() {
+	suite.queryBuilder.SetLabel([]string{"Person"})
+	suite.queryBuilder.SetQueryMode(core.Write)
+	suite.queryBuilder.SetVarName("sv")
+	suite.queryBuilder.SetSelector(map[string]interface{}{"name": "Tom"})
+	suite.queryBuilder.SetCreatedTimestamp("createdAt")
+
+	query, err := suite.queryBuilder.Build()
+	suite.NoError(err)
+	suite.Equal("MERGE (sv:Person{name:'Tom'})  ON CREATE SET sv.createdAt = timestamp() return sv", query)
+
+	suite.queryBuilder.SetWriteMode(core.Create)
+	query, err = suite.queryBuilder.Build()
+	suite.NoError(err)
+	suite.Equal("CREATE (sv:Person{name:'Tom'})  SET sv.createdAt = timestamp() return sv", query)
+}
+
+func (suite *VertexQueryBuilderTestSuite) TestBuildWithCreatedTimestampReadMode() {
+	suite.queryBuilder.SetLabel([]string{"Person"})
+	suite.queryBuilder.SetQueryMode(core.Read)
+	suite.queryBuilder.SetCreatedTimestamp("createdAt")
+
+	_, err := suite.queryBuilder.Build()
+	suite.Error(err)
+}
+
+func (suite *VertexQueryBuilderTestSuite) TestBuildWithDataProperties() {
+	suite.queryBuilder.SetLabel([]string{"Person"})
+	suite.queryBuilder.SetQueryMode(core.Write)
+	suite.queryBuilder.SetVarName("sv")
+	suite.queryBuilder.SetSelector(core.KVMap{"name": "Tom"})
+	suite.queryBuilder.SetDataProperties(core.KVMap{"age": 10, "city": "Paris"})
+	suite.queryBuilder.SetParameterized(true)
+
+	// the data properties of a merged vertex are set only if any of them differs
+	query, params, err := suite.queryBuilder.BuildWithParams()
+	suite.NoError(err)
+	suite.Equal("MERGE (sv:Person{name: $p0})  FOREACH (_ IN CASE WHEN NOT coalesce(sv.age = $p1, false) OR NOT coalesce(sv.city = $p2, false) THEN [1] ELSE [] END | SET sv.age = $p1, sv.city = $p2) return sv", query)
+	suite.Equal(map[string]interface{}{"p0": "Tom", "p1": 10, "p2": "Paris"}, params)
+
+	suite.queryBuilder.SetWriteMode(core.Create)
+	query, _, err = suite.queryBuilder.BuildWithParams()
+	suite.NoError(err)
+	suite.Equal("CREATE (sv:Person{name: $p0})  SET sv.age = $p1, sv.city = $p2 return sv", query)
+
+	suite.queryBuilder.SetQueryMode(core.Read)
+	_, err = suite.queryBuilder.Build()
+	suite.EqualError(err, "data properties can only be set by write queries")
+}
+
+func (suite *VertexQueryBuilderTestSuite) TestBuildQueryWithExistsCondition() {
+	suite.queryBuilder.SetLabel([]string{"Person"})
+	suite.queryBuilder.SetVarName("v")
+	suite.queryBuilder.SetQueryMode(core.Read)
+	suite.queryBuilder.AddExistsCondition(RelationshipPattern{EdgeLabel: "HAS", Direction: core.DirectionOutgoing, EndVertexLabels: []string{"Order"}})
+
+	query, err := suite.queryBuilder.Build()
+	suite.NoError(err)
+	suite.Equal("MATCH (v:Person)  WHERE EXISTS { (v)-[:HAS]->(:`Order`) } return v", query)
+}
+
+func (suite *VertexQueryBuilderTestSuite) TestBuildQueryWithNotExistsCondition() {
+	suite.queryBuilder.SetLabel([]string{"Person"})
+	suite.queryBuilder.SetVarName("v")
+	suite.queryBuilder.SetQueryMode(core.Read)
+	suite.queryBuilder.SetFilters(core.KVMap{"age": 10})
+	suite.queryBuilder.AddNotExistsCondition(RelationshipPattern{EdgeLabel: "HAS", Direction: core.DirectionOutgoing, EndVertexLabels: []string{"Order"}, EndVertexSelector: core.KVMap{"status": "open"}})
+
+	query, err := suite.queryBuilder.Build()
+	suite.NoError(err)
+	suite.Equal("MATCH (v:Person)  WHERE v.age=10 AND NOT EXISTS { (v)-[:HAS]->(:`Order`{status:'open'}) } return v", query)
+}
+
+func (suite *VertexQueryBuilderTestSuite) TestBuildQueryWithMultipleExistsConditions() {
+	suite.queryBuilder.SetLabel([]string{"Person"})
+	suite.queryBuilder.SetVarName("v")
+	suite.queryBuilder.SetQueryMode(core.Read)
+	suite.queryBuilder.SetRawWhere("v.age > 10")
+	suite.queryBuilder.AddExistsCondition(RelationshipPattern{Direction: core.DirectionIncoming})
+	suite.queryBuilder.AddNotExistsCondition(RelationshipPattern{EdgeLabel: "KNOWS", Direction: core.DirectionBoth, EndVertexLabels: []string{"Person"}})
+
+	query, err := suite.queryBuilder.Build()
+	suite.NoError(err)
+	suite.Equal("MATCH (v:Person)  WHERE (v.age > 10) AND EXISTS { (v)<-[]-() } AND NOT EXISTS { (v)-[:KNOWS]-(:Person) } return v", query)
+}
+
+func (suite *VertexQueryBuilderTestSuite) TestBuildQueryWithInvalidExistsCondition() {
+	suite.queryBuilder.SetLabel([]string{"Person"})
+	suite.queryBuilder.SetQueryMode(core.Read)
+	suite.queryBuilder.AddExistsCondition(RelationshipPattern{EdgeLabel: "HAS", Direction: core.EdgeDirection(5)})
+	_, err := suite.queryBuilder.Build()
+	suite.EqualError(err, "invalid edge direction 5")
+
+	suite.queryBuilder = NewVertexQueryBuilder()
+	suite.queryBuilder.SetLabel([]string{"Person"})
+	suite.queryBuilder.SetQueryMode(core.Write)
+	suite.queryBuilder.AddExistsCondition(RelationshipPattern{EdgeLabel: "HAS"})
+	_, err = suite.queryBuilder.Build()
+	suite.EqualError(err, "exists conditions can only be specified for read queries")
+}
+
+func (suite *VertexQueryBuilderTestSuite) TestBuildQueryWithFiltersOnly() {
+	suite.queryBuilder.SetLabel([]string{"Person"})
+	suite.queryBuilder.SetVarName("v")
+	suite.queryBuilder.SetQueryMode(core.Read)
+	suite.queryBuilder.SetSelector(nil)
+	suite.queryBuilder.SetFilters(core.KVMap{"name": "Tintin", "age": 18})
+
+	query, err := suite.queryBuilder.Build()
+	suite.NoError(err)
+	suite.Equal("MATCH (v:Person)  WHERE v.age=18 AND v.name='Tintin' return v", query)
+}
+
+func (suite *VertexQueryBuilderTestSuite) TestBuildQueryWithFiltersOnlyAndLimit() {
+	suite.queryBuilder.SetLabel([]string{"Person"})
+	suite.queryBuilder.SetVarName("v")
+	suite.queryBuilder.SetQueryMode(core.Read)
+	suite.queryBuilder.SetFilters(core.KVMap{"age": 18})
+	suite.queryBuilder.SetLimit(5)
+
+	query, err := suite.queryBuilder.Build()
+	suite.NoError(err)
+	suite.Equal("MATCH (v:Person)  WHERE v.age=18 return v LIMIT 5", query)
+}
+
+func (suite *VertexQueryBuilderTestSuite) TestBuildQueryWithReservedWords() {
+	suite.queryBuilder.SetLabel([]string{"Match", "Person"})
+	suite.queryBuilder.SetVarName("v")
+	suite.queryBuilder.SetQueryMode(core.Write)
+	suite.queryBuilder.SetSelector(core.KVMap{"order": 1})
+
+	query, err := suite.queryBuilder.Build()
+	suite.NoError(err)
+	suite.Equal("MERGE (v:`Match`:Person{`order`: 1})  return v", query)
+
+	suite.queryBuilder = NewVertexQueryBuilder()
+	suite.queryBuilder.SetLabel([]string{"Match"})
+	suite.queryBuilder.SetQueryMode(core.Read)
+	suite.queryBuilder.SetFilters(core.KVMap{"return": "x"})
+
+	query, err = suite.queryBuilder.Build()
+	suite.NoError(err)
+	suite.Equal("MATCH (match0:`Match`)  WHERE match0.`return`='x' return match0", query)
+}
+
+func (suite *VertexQueryBuilderTestSuite) TestBuildParameterized() {
+	suite.queryBuilder.SetLabel([]string{"Person"})
+	suite.queryBuilder.SetQueryMode(core.Read)
+	suite.queryBuilder.SetSelector(core.KVMap{"name": "O'Brien", "city": "Dublin"})
+	suite.queryBuilder.SetFilters(core.KVMap{"age": 42})
+	suite.queryBuilder.SetVarName("v")
+	suite.queryBuilder.SetParameterized(true)
+
+	query, params, err := suite.queryBuilder.BuildWithParams()
+	suite.NoError(err)
+	suite.Equal("MATCH (v:Person{city: $p0,name: $p1})  WHERE v.age=$p2 return v", query)
+	suite.Equal(map[string]interface{}{"p0": "Dublin", "p1": "O'Brien", "p2": 42}, params)
+
+	// queries differing only in the values share the same text
+	other := NewVertexQueryBuilder().SetLabel([]string{"Person"}).SetVarName("v").SetParameterized(true)
+	other.SetSelector(core.KVMap{"name": "Tintin", "city": "Brussels"}).SetFilters(core.KVMap{"age": 17})
+	otherQuery, _, err := other.BuildWithParams()
+	suite.NoError(err)
+	suite.Equal(query, otherQuery)
+}
+
+func (suite *VertexQueryBuilderTestSuite) TestBuildWithParamsNotParameterized() {
+	suite.queryBuilder.SetLabel([]string{"Person"})
+	suite.queryBuilder.SetQueryMode(core.Read)
+	suite.queryBuilder.SetSelector(core.KVMap{"name": "Tintin"})
+	suite.queryBuilder.SetVarName("v")
+
+	query, params, err := suite.queryBuilder.BuildWithParams()
+	suite.NoError(err)
+	suite.Equal("MATCH (v:Person{name:'Tintin'})  return v", query)
+	suite.Nil(params)
+}
+
+func (suite *VertexQueryBuilderTestSuite) TestBuildParameterizedExistsCondition() {
+	suite.queryBuilder.SetLabel([]string{"Person"})
+	suite.queryBuilder.SetQueryMode(core.Read)
+	suite.queryBuilder.SetFilters(core.KVMap{"age": 42})
+	suite.queryBuilder.SetVarName("v")
+	suite.queryBuilder.AddExistsCondition(RelationshipPattern{EdgeLabel: "PLACED", Direction: core.DirectionOutgoing, EndVertexLabels: []string{"Item"}, EndVertexSelector: core.KVMap{"status": "open"}})
+	suite.queryBuilder.SetParameterized(true)
+
+	query, params, err := suite.queryBuilder.BuildWithParams()
+	suite.NoError(err)
+	suite.Equal("MATCH (v:Person)  WHERE v.age=$p0 AND EXISTS { (v)-[:PLACED]->(:Item{status: $p1}) } return v", query)
+	suite.Equal(map[string]interface{}{"p0": 42, "p1": "open"}, params)
+}
+
+func (suite *VertexQueryBuilderTestSuite) TestBuildWithComparisonFilters() {
+	suite.queryBuilder.SetLabel([]string{"Person"})
+	suite.queryBuilder.SetQueryMode(core.Read)
+	suite.queryBuilder.SetFilters(core.KVMap{"age": core.Filter{Operator: core.Gt, Value: 18}, "name": "Tintin"})
+	suite.queryBuilder.SetVarName("v")
+
+	query, err := suite.queryBuilder.Build()
+	suite.NoError(err)
+	suite.Equal("MATCH (v:Person)  WHERE v.age>18 AND v.name='Tintin' return v", query)
+
+	suite.queryBuilder.SetFilters(core.KVMap{"age": core.Filter{Operator: core.FilterOperator(-1), Value: 18}})
+	_, err = suite.queryBuilder.Build()
+	suite.EqualError(err, "invalid operator -1 in the filter on age")
+}
+
+func (suite *VertexQueryBuilderTestSuite) TestBuildDeleteMode() {
+	suite.queryBuilder.SetLabel([]string{"Person"})
+	suite.queryBuilder.SetQueryMode(core.Delete)
+	_, err := suite.queryBuilder.Build()
+	suite.EqualError(err, "vertices cannot be deleted using the vertex query builder")
+}
+
+func (suite *VertexQueryBuilderTestSuite) TestEmptyLabel() {
+	suite.queryBuilder.SetLabel([]string{""})
+	suite.queryBuilder.SetQueryMode(core.Read)
+	suite.queryBuilder.SetSelector(map[string]interface{}{"name": "TestName"})
+
+	_, err := suite.queryBuilder.Build()
+	suite.EqualError(err, `invalid vertex label "": labels cannot be empty`)
+
+	suite.queryBuilder.SetLabel([]string{"Label1", "  "})
+	_, err = suite.queryBuilder.Build()
+	suite.EqualError(err, `invalid vertex label "  ": labels cannot be empty`)
+}
+
 func TestVertexQueryBuilderTestSuite(t *testing.T) {
 	suite.Run(t, new(VertexQueryBuilderTestSuite))
 }
@@ -0,0 +1,216 @@
+package gremlin
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/prahaladd/gograph/core"
+)
+
+// EdgeQueryBuilder exposes a builder pattern for building edge lookup Gremlin traversals
+//
+// The mode of the query builder controls whether the traversal reads edges or writes an edge between existing
+// start and end vertices. Write traversals add the edge using addE, or upsert the edge matching the selectors when
+// the write mode is core.Merge. Unlike Cypher MERGE, the start and end vertices are never created.
+//
+// The fetch mode controls whether read traversals return the edges alone or maps containing the start vertex, the
+// edge and the end vertex against the StartVertexKey, EdgeKey and EndVertexKey keys.
+//
+// selectors on the start and end vertices and the edge are used to select elements with the specific set of
+// properties. filters can be specified to filter out the selected elements based on some criteria. Filter values can
+// be a core.Filter specifying the comparison operator.
+type EdgeQueryBuilder struct {
+	queryMode           core.QueryMode
+	edgeFetchMode       core.EdgeFetchMode
+	startVertexLabels   []string
+	endVertexLabels     []string
+	labels              []string
+	startVertexSelector core.KVMap
+	endVertexSelector   core.KVMap
+	selector            core.KVMap
+	startVertexFilters  core.KVMap
+	endVertexFilters    core.KVMap
+	filters             core.KVMap
+	writeMode           core.WriteMode
+	anyLabel            bool
+	limit               int64
+	skip                int64
+}
+
+func NewEdgeQueryBuilder() *EdgeQueryBuilder {
+	return &EdgeQueryBuilder{selector: make(core.KVMap),
+		filters:             make(core.KVMap),
+		startVertexSelector: make(core.KVMap),
+		endVertexSelector:   make(core.KVMap),
+		startVertexFilters:  make(core.KVMap),
+		endVertexFilters:    make(core.KVMap),
+		writeMode:           core.Merge,
+	}
+}
+
+func (eqb *EdgeQueryBuilder) SetQueryMode(mode core.QueryMode) *EdgeQueryBuilder {
+	eqb.queryMode = mode
+	return eqb
+}
+
+func (eqb *EdgeQueryBuilder) SetEdgeFetchMode(edgeFetchMode core.EdgeFetchMode) *EdgeQueryBuilder {
+	eqb.edgeFetchMode = edgeFetchMode
+	return eqb
+}
+
+func (eqb *EdgeQueryBuilder) SetStartVertexLabels(labels []string) *EdgeQueryBuilder {
+	eqb.startVertexLabels = append([]string(nil), labels...)
+	return eqb
+}
+
+func (eqb *EdgeQueryBuilder) SetEndVertexLabels(labels []string) *EdgeQueryBuilder {
+	eqb.endVertexLabels = append([]string(nil), labels...)
+	return eqb
+}
+
+func (eqb *EdgeQueryBuilder) SetLabel(labels []string) *EdgeQueryBuilder {
+	eqb.labels = append([]string(nil), labels...)
+	return eqb
+}
+
+// SetAnyLabel controls whether the edge label is optional. When set and no edge label is specified, the
+// traversal selects edges of all labels between the start and end vertices.
+//
+// Edges cannot be written without a label. Hence the edge label is still required for write queries.
+func (eqb *EdgeQueryBuilder) SetAnyLabel(anyLabel bool) *EdgeQueryBuilder {
+	eqb.anyLabel = anyLabel
+	return eqb
+}
+
+func (eqb *EdgeQueryBuilder) SetStartVertexSelector(selector core.KVMap) *EdgeQueryBuilder {
+	for k, v := range selector {
+		eqb.startVertexSelector[k] = v
+	}
+	return eqb
+}
+
+func (eqb *EdgeQueryBuilder) SetEndVertexSelector(selector core.KVMap) *EdgeQueryBuilder {
+	for k, v := range selector {
+		eqb.endVertexSelector[k] = v
+	}
+	return eqb
+}
+
+func (eqb *EdgeQueryBuilder) SetSelector(selectors core.KVMap) *EdgeQueryBuilder {
+	for k, v := range selectors {
+		eqb.selector[k] = v
+	}
+	return eqb
+}
+
+func (eqb *EdgeQueryBuilder) SetFilters(filters core.KVMap) *EdgeQueryBuilder {
+	for k, v := range filters {
+		eqb.filters[k] = v
+	}
+	return eqb
+}
+
+func (eqb *EdgeQueryBuilder) SetStartVertexFilters(filters core.KVMap) *EdgeQueryBuilder {
+	for k, v := range filters {
+		eqb.startVertexFilters[k] = v
+	}
+	return eqb
+}
+
+func (eqb *EdgeQueryBuilder) SetEndVertexFilters(filters core.KVMap) *EdgeQueryBuilder {
+	for k, v := range filters {
+		eqb.endVertexFilters[k] = v
+	}
+	return eqb
+}
+
+func (eqb *EdgeQueryBuilder) SetWriteMode(writeMode core.WriteMode) *EdgeQueryBuilder {
+	eqb.writeMode = writeMode
+	return eqb
+}
+
+// SetLimit sets the maximum number of results returned by a read traversal. A limit of 0 returns all the results.
+func (eqb *EdgeQueryBuilder) SetLimit(limit int64) *EdgeQueryBuilder {
+	eqb.limit = limit
+	return eqb
+}
+
+// SetSkip sets the number of results skipped by a read traversal
+func (eqb *EdgeQueryBuilder) SetSkip(skip int64) *EdgeQueryBuilder {
+	eqb.skip = skip
+	return eqb
+}
+
+// Build builds the Gremlin traversal
+func (eqb *EdgeQueryBuilder) Build() (string, error) {
+	if err := eqb.validate(); err != nil {
+		return "", err
+	}
+	startVertex := fmt.Sprintf("g.V()%s%s", buildLabelStep(eqb.startVertexLabels), buildHasSteps(eqb.startVertexSelector, eqb.startVertexFilters))
+	endVertexSteps := buildLabelStep(eqb.endVertexLabels) + buildHasSteps(eqb.endVertexSelector, eqb.endVertexFilters)
+	if eqb.queryMode == core.Write {
+		addEdge := fmt.Sprintf("addE(%s).from('sv')%s", quoteString(eqb.labels[0]), buildPropertySteps(eqb.selector))
+		traversal := fmt.Sprintf("%s.as('sv').V()%s", startVertex, endVertexSteps)
+		if eqb.writeMode == core.Create {
+			return fmt.Sprintf("%s.%s", traversal, addEdge), nil
+		}
+		existingEdge := fmt.Sprintf("inE(%s).where(outV().as('sv'))%s", quoteString(eqb.labels[0]), buildHasSteps(eqb.selector, nil))
+		return fmt.Sprintf("%s.coalesce(%s,%s)", traversal, existingEdge, addEdge), nil
+	}
+
+	edgeLabel := ""
+	if len(eqb.labels) > 0 {
+		edgeLabel = quoteString(eqb.labels[0])
+	}
+	traversal := fmt.Sprintf("%s.outE(%s)%s", startVertex, edgeLabel, buildHasSteps(eqb.selector, eqb.filters))
+	if endVertexSteps != "" {
+		traversal += fmt.Sprintf(".where(inV()%s)", endVertexSteps)
+	}
+	if eqb.edgeFetchMode == core.EdgeWithCompleteVertex {
+		traversal += fmt.Sprintf(".project('%s','%s','%s').by(outV()).by().by(inV())", StartVertexKey, EdgeKey, EndVertexKey)
+	}
+	return traversal + buildRange(eqb.skip, eqb.limit), nil
+}
+
+func (eqb *EdgeQueryBuilder) validate() error {
+	if eqb.queryMode == core.Delete {
+		return errors.New("delete queries are not supported")
+	}
+	if len(eqb.labels) == 0 {
+		if !eqb.anyLabel {
+			return errors.New("no edge labels specified in the query")
+		}
+		if eqb.queryMode == core.Write {
+			return errors.New("edge label must be specified for write queries")
+		}
+	}
+	if len(eqb.labels) > 1 {
+		return errors.New("multiple edge labels cannot be specified")
+	}
+	for _, labels := range [][]string{eqb.labels, eqb.startVertexLabels, eqb.endVertexLabels} {
+		if err := validateLabels(labels); err != nil {
+			return err
+		}
+	}
+	if eqb.skip < 0 {
+		return fmt.Errorf("invalid skip %d", eqb.skip)
+	}
+	if err := validateFilters(eqb.startVertexFilters, eqb.endVertexFilters, eqb.filters); err != nil {
+		return err
+	}
+	if err := validateValues(eqb.startVertexSelector, eqb.endVertexSelector, eqb.selector, eqb.startVertexFilters, eqb.endVertexFilters, eqb.filters); err != nil {
+		return err
+	}
+	if eqb.queryMode == core.Write {
+		if len(eqb.filters) > 0 {
+			return errors.New("edge filters can only be specified for read queries")
+		}
+		if len(eqb.startVertexLabels) == 0 && len(eqb.startVertexSelector) == 0 {
+			return errors.New("either start vertex labels or start vertex selectors must be specified for write queries")
+		}
+		if len(eqb.endVertexLabels) == 0 && len(eqb.endVertexSelector) == 0 {
+			return errors.New("either end vertex labels or end vertex selectors must be specified for write queries")
+		}
+	}
+	return nil
+}
@@ -0,0 +1,173 @@
+package gremlin
+
+import (
+	"testing"
+
+	"github.com/prahaladd/gograph/core"
+	"github.com/stretchr/testify/suite"
+)
+
+type EdgeQueryBuilderTestSuite struct {
+	suite.Suite
+	edgeQueryBuilder *EdgeQueryBuilder
+}
+
+func (suite *EdgeQueryBuilderTestSuite) SetupTest() {
+	suite.edgeQueryBuilder = NewEdgeQueryBuilder()
+}
+
+func (suite *EdgeQueryBuilderTestSuite) TestBuildEdgeFetchModeIds() {
+	suite.edgeQueryBuilder.SetLabel([]string{"KNOWS"})
+	suite.edgeQueryBuilder.SetStartVertexLabels([]string{"Person"})
+	suite.edgeQueryBuilder.SetEndVertexLabels([]string{"Person"})
+	suite.edgeQueryBuilder.SetQueryMode(core.Read)
+	suite.edgeQueryBuilder.SetStartVertexSelector(core.KVMap{"name": "Tom"})
+	suite.edgeQueryBuilder.SetEndVertexSelector(core.KVMap{"name": "Jerry"})
+	suite.edgeQueryBuilder.SetSelector(core.KVMap{"weight": 10})
+	suite.edgeQueryBuilder.SetEdgeFetchMode(core.EdgeWithVertexIds)
+
+	queryString, err := suite.edgeQueryBuilder.Build()
+	suite.NoError(err)
+	suite.Equal("g.V().hasLabel('Person').has('name','Tom').outE('KNOWS').has('weight',10).where(inV().hasLabel('Person').has('name','Jerry'))", queryString)
+}
+
+func (suite *EdgeQueryBuilderTestSuite) TestBuildEdgeFetchModeComplete() {
+	suite.edgeQueryBuilder.SetLabel([]string{"KNOWS"})
+	suite.edgeQueryBuilder.SetStartVertexLabels([]string{"Person"})
+	suite.edgeQueryBuilder.SetQueryMode(core.Read)
+	suite.edgeQueryBuilder.SetEdgeFetchMode(core.EdgeWithCompleteVertex)
+
+	queryString, err := suite.edgeQueryBuilder.Build()
+	suite.NoError(err)
+	suite.Equal("g.V().hasLabel('Person').outE('KNOWS').project('startVertex','edge','endVertex').by(outV()).by().by(inV())", queryString)
+}
+
+func (suite *EdgeQueryBuilderTestSuite) TestBuildWithFilters() {
+	suite.edgeQueryBuilder.SetLabel([]string{"KNOWS"})
+	suite.edgeQueryBuilder.SetStartVertexLabels([]string{"Person"})
+	suite.edgeQueryBuilder.SetEndVertexLabels([]string{"Person"})
+	suite.edgeQueryBuilder.SetQueryMode(core.Read)
+	suite.edgeQueryBuilder.SetStartVertexFilters(core.KVMap{"age": core.Filter{Operator: core.Gte, Value: 18}})
+	suite.edgeQueryBuilder.SetEndVertexFilters(core.KVMap{"age": core.Filter{Operator: core.Lt, Value: 18}})
+	suite.edgeQueryBuilder.SetFilters(core.KVMap{"since": core.Filter{Operator: core.Lte, Value: 2000}})
+
+	queryString, err := suite.edgeQueryBuilder.Build()
+	suite.NoError(err)
+	suite.Equal("g.V().hasLabel('Person').has('age',gte(18)).outE('KNOWS').has('since',lte(2000)).where(inV().hasLabel('Person').has('age',lt(18)))", queryString)
+}
+
+func (suite *EdgeQueryBuilderTestSuite) TestBuildCreateQueryWithListProperty() {
+	suite.edgeQueryBuilder.SetLabel([]string{"KNOWS"})
+	suite.edgeQueryBuilder.SetStartVertexLabels([]string{"Person"})
+	suite.edgeQueryBuilder.SetEndVertexLabels([]string{"Person"})
+	suite.edgeQueryBuilder.SetQueryMode(core.Write)
+	suite.edgeQueryBuilder.SetWriteMode(core.Create)
+	suite.edgeQueryBuilder.SetStartVertexSelector(core.KVMap{"tags": []string{"a'), g.V().drop(), ('"}})
+	suite.edgeQueryBuilder.SetSelector(core.KVMap{"places": []string{"Brussels", "Moulinsart"}})
+
+	queryString, err := suite.edgeQueryBuilder.Build()
+	suite.NoError(err)
+	suite.Equal(`g.V().hasLabel('Person').has('tags',['a\'), g.V().drop(), (\'']).as('sv').V().hasLabel('Person')`+
+		`.addE('KNOWS').from('sv').property('places',['Brussels','Moulinsart'])`, queryString)
+
+	suite.edgeQueryBuilder.SetSelector(core.KVMap{"meta": map[string]int{"a": 1}})
+	_, err = suite.edgeQueryBuilder.Build()
+	suite.EqualError(err, "unsupported value of type map[string]int for the property meta")
+}
+
+func (suite *EdgeQueryBuilderTestSuite) TestBuildWithAnyLabel() {
+	suite.edgeQueryBuilder.SetStartVertexLabels([]string{"Person"})
+	suite.edgeQueryBuilder.SetQueryMode(core.Read)
+	suite.edgeQueryBuilder.SetAnyLabel(true)
+
+	queryString, err := suite.edgeQueryBuilder.Build()
+	suite.NoError(err)
+	suite.Equal("g.V().hasLabel('Person').outE()", queryString)
+
+	suite.edgeQueryBuilder.SetQueryMode(core.Write)
+	_, err = suite.edgeQueryBuilder.Build()
+	suite.EqualError(err, "edge label must be specified for write queries")
+}
+
+func (suite *EdgeQueryBuilderTestSuite) TestBuildWithPaging() {
+	suite.edgeQueryBuilder.SetLabel([]string{"KNOWS"})
+	suite.edgeQueryBuilder.SetStartVertexLabels([]string{"Person"})
+	suite.edgeQueryBuilder.SetQueryMode(core.Read)
+	suite.edgeQueryBuilder.SetSkip(10)
+	suite.edgeQueryBuilder.SetLimit(5)
+
+	queryString, err := suite.edgeQueryBuilder.Build()
+	suite.NoError(err)
+	suite.Equal("g.V().hasLabel('Person').outE('KNOWS').skip(10).limit(5)", queryString)
+
+	suite.edgeQueryBuilder.SetSkip(-1)
+	_, err = suite.edgeQueryBuilder.Build()
+	suite.EqualError(err, "invalid skip -1")
+}
+
+func (suite *EdgeQueryBuilderTestSuite) TestBuildMergeQuery() {
+	suite.edgeQueryBuilder.SetLabel([]string{"KNOWS"})
+	suite.edgeQueryBuilder.SetStartVertexLabels([]string{"Person"})
+	suite.edgeQueryBuilder.SetEndVertexLabels([]string{"Person"})
+	suite.edgeQueryBuilder.SetQueryMode(core.Write)
+	suite.edgeQueryBuilder.SetStartVertexSelector(core.KVMap{"name": "Tom"})
+	suite.edgeQueryBuilder.SetEndVertexSelector(core.KVMap{"name": "Jerry"})
+	suite.edgeQueryBuilder.SetSelector(core.KVMap{"since": int64(1940)})
+
+	queryString, err := suite.edgeQueryBuilder.Build()
+	suite.NoError(err)
+	suite.Equal("g.V().hasLabel('Person').has('name','Tom').as('sv').V().hasLabel('Person').has('name','Jerry')"+
+		".coalesce(inE('KNOWS').where(outV().as('sv')).has('since',1940L),addE('KNOWS').from('sv').property('since',1940L))", queryString)
+}
+
+func (suite *EdgeQueryBuilderTestSuite) TestBuildCreateQuery() {
+	suite.edgeQueryBuilder.SetLabel([]string{"KNOWS"})
+	suite.edgeQueryBuilder.SetStartVertexLabels([]string{"Person"})
+	suite.edgeQueryBuilder.SetEndVertexLabels([]string{"Person"})
+	suite.edgeQueryBuilder.SetQueryMode(core.Write)
+	suite.edgeQueryBuilder.SetWriteMode(core.Create)
+	suite.edgeQueryBuilder.SetStartVertexSelector(core.KVMap{"name": "Tom"})
+	suite.edgeQueryBuilder.SetEndVertexSelector(core.KVMap{"name": "Jerry"})
+	suite.edgeQueryBuilder.SetSelector(core.KVMap{"weight": 0.5})
+
+	queryString, err := suite.edgeQueryBuilder.Build()
+	suite.NoError(err)
+	suite.Equal("g.V().hasLabel('Person').has('name','Tom').as('sv').V().hasLabel('Person').has('name','Jerry').addE('KNOWS').from('sv').property('weight',0.5d)", queryString)
+}
+
+func (suite *EdgeQueryBuilderTestSuite) TestBuildWriteQueryValidation() {
+	suite.edgeQueryBuilder.SetLabel([]string{"KNOWS"})
+	suite.edgeQueryBuilder.SetQueryMode(core.Write)
+	_, err := suite.edgeQueryBuilder.Build()
+	suite.EqualError(err, "either start vertex labels or start vertex selectors must be specified for write queries")
+
+	suite.edgeQueryBuilder.SetStartVertexLabels([]string{"Person"})
+	_, err = suite.edgeQueryBuilder.Build()
+	suite.EqualError(err, "either end vertex labels or end vertex selectors must be specified for write queries")
+
+	suite.edgeQueryBuilder.SetEndVertexLabels([]string{"Person"})
+	suite.edgeQueryBuilder.SetFilters(core.KVMap{"weight": 10})
+	_, err = suite.edgeQueryBuilder.Build()
+	suite.EqualError(err, "edge filters can only be specified for read queries")
+}
+
+func (suite *EdgeQueryBuilderTestSuite) TestBuildWithoutLabel() {
+	suite.edgeQueryBuilder.SetStartVertexLabels([]string{"Person"})
+	_, err := suite.edgeQueryBuilder.Build()
+	suite.EqualError(err, "no edge labels specified in the query")
+
+	suite.edgeQueryBuilder.SetLabel([]string{"KNOWS", "LIKES"})
+	_, err = suite.edgeQueryBuilder.Build()
+	suite.EqualError(err, "multiple edge labels cannot be specified")
+}
+
+func (suite *EdgeQueryBuilderTestSuite) TestBuildDeleteMode() {
+	suite.edgeQueryBuilder.SetLabel([]string{"KNOWS"})
+	suite.edgeQueryBuilder.SetQueryMode(core.Delete)
+	_, err := suite.edgeQueryBuilder.Build()
+	suite.EqualError(err, "delete queries are not supported")
+}
+
+func TestEdgeQueryBuilderTestSuite(t *testing.T) {
+	suite.Run(t, new(EdgeQueryBuilderTestSuite))
+}
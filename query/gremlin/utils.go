@@ -0,0 +1,233 @@
+package gremlin
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/prahaladd/gograph/core"
+)
+
+// keys of the maps returned by edge traversals fetching the complete vertices
+const (
+	// StartVertexKey is the key against which the start vertex of an edge is returned
+	StartVertexKey = "startVertex"
+	// EndVertexKey is the key against which the end vertex of an edge is returned
+	EndVertexKey = "endVertex"
+	// EdgeKey is the key against which the edge is returned
+	EdgeKey = "edge"
+)
+
+// predicates are the Gremlin predicates of the filter operators. Equality is tested without a predicate.
+var predicates = map[core.FilterOperator]string{
+	core.Eq:  "",
+	core.Gt:  "gt",
+	core.Gte: "gte",
+	core.Lt:  "lt",
+	core.Lte: "lte",
+	core.Neq: "neq",
+}
+
+// stringEscaper escapes the characters of a string value which cannot appear as is within a single quoted Gremlin
+// string literal
+var stringEscaper = strings.NewReplacer(
+	`\`, `\\`,
+	`'`, `\'`,
+	"\n", `\n`,
+	"\r", `\r`,
+	"\t", `\t`,
+)
+
+// quoteString formats a string value as a single quoted Gremlin string literal. Backslashes, single quotes and line
+// breaks within the value are escaped so that the value cannot terminate the literal.
+func quoteString(s string) string {
+	return "'" + stringEscaper.Replace(s) + "'"
+}
+
+// formatValue formats a property value as a Gremlin literal.
+//
+// Gremlin scripts are evaluated as Groovy, which reads integral literals as 32 bit integers and decimal literals
+// as BigDecimal values. Hence int64 values are suffixed with L, float64 values with d and float32 values with f so
+// that the values are stored with the same type as specified.
+//
+// Values of user defined scalar types are formatted as the values of the underlying builtin types. Slices and arrays
+// are formatted as lists of the literals of their elements. Other values, for e.g. maps, cannot be formatted as
+// literals and must be rejected using validateValues before formatting.
+func formatValue(v interface{}) string {
+	switch value := v.(type) {
+	case nil:
+		return "null"
+	case string:
+		return quoteString(value)
+	case int64:
+		return fmt.Sprintf("%dL", value)
+	case float64:
+		return formatFloat(value, 64, "d")
+	case float32:
+		return formatFloat(float64(value), 32, "f")
+	}
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.String:
+		return quoteString(rv.String())
+	case reflect.Int64:
+		return formatValue(rv.Int())
+	case reflect.Float64:
+		return formatValue(rv.Float())
+	case reflect.Float32:
+		return formatValue(float32(rv.Float()))
+	case reflect.Slice, reflect.Array:
+		elems := make([]string, 0, rv.Len())
+		for i := 0; i < rv.Len(); i++ {
+			elems = append(elems, formatValue(rv.Index(i).Interface()))
+		}
+		return "[" + strings.Join(elems, ",") + "]"
+	default:
+		return fmt.Sprintf("%v", v)
+	}
+}
+
+// validateValues returns an error if the value of a selector or property, or the value of a filter, within the
+// specified maps cannot be formatted as a Gremlin literal. Only scalars and slices and arrays of scalars can be
+// formatted, since the formatting of other values would not be a valid literal and could alter the traversal.
+func validateValues(values ...core.KVMap) error {
+	for _, m := range values {
+		for _, k := range sortedKeys(m) {
+			value := core.FilterOf(m[k]).Value
+			if !isLiteralValue(value, true) {
+				return fmt.Errorf("unsupported value of type %T for the property %s", value, k)
+			}
+		}
+	}
+	return nil
+}
+
+// isLiteralValue reports whether the value can be formatted as a literal by formatValue. Lists are allowed only if
+// specified, since lists cannot be nested within property values.
+func isLiteralValue(v interface{}, allowList bool) bool {
+	if v == nil {
+		return true
+	}
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Bool, reflect.String,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	case reflect.Slice, reflect.Array:
+		if !allowList {
+			return false
+		}
+		for i := 0; i < rv.Len(); i++ {
+			if !isLiteralValue(rv.Index(i).Interface(), false) {
+				return false
+			}
+		}
+		return true
+	default:
+		return false
+	}
+}
+
+func formatFloat(f float64, bitSize int, suffix string) string {
+	switch {
+	case math.IsNaN(f):
+		return "Double.NaN"
+	case math.IsInf(f, 1):
+		return "Double.POSITIVE_INFINITY"
+	case math.IsInf(f, -1):
+		return "Double.NEGATIVE_INFINITY"
+	}
+	return strconv.FormatFloat(f, 'f', -1, bitSize) + suffix
+}
+
+// buildLabelStep builds the hasLabel step selecting the elements having any of the specified labels. No step is
+// built if no labels are specified.
+func buildLabelStep(labels []string) string {
+	if len(labels) == 0 {
+		return ""
+	}
+	quoted := make([]string, 0, len(labels))
+	for _, label := range labels {
+		quoted = append(quoted, quoteString(label))
+	}
+	return fmt.Sprintf(".hasLabel(%s)", strings.Join(quoted, ","))
+}
+
+// buildHasSteps builds the has steps selecting the elements whose properties are equal to the selectors and match
+// the filters. Filter values that are not a core.Filter are compared for equality.
+func buildHasSteps(selector, filters core.KVMap) string {
+	buffer := strings.Builder{}
+	for _, k := range sortedKeys(selector) {
+		buffer.WriteString(fmt.Sprintf(".has(%s,%s)", quoteString(k), formatValue(selector[k])))
+	}
+	for _, k := range sortedKeys(filters) {
+		filter := core.FilterOf(filters[k])
+		value := formatValue(filter.Value)
+		if predicate := predicates[filter.Operator]; predicate != "" {
+			value = fmt.Sprintf("%s(%s)", predicate, value)
+		}
+		buffer.WriteString(fmt.Sprintf(".has(%s,%s)", quoteString(k), value))
+	}
+	return buffer.String()
+}
+
+// buildPropertySteps builds the property steps setting the properties of an element to the specified values
+func buildPropertySteps(properties core.KVMap) string {
+	buffer := strings.Builder{}
+	for _, k := range sortedKeys(properties) {
+		buffer.WriteString(fmt.Sprintf(".property(%s,%s)", quoteString(k), formatValue(properties[k])))
+	}
+	return buffer.String()
+}
+
+// buildRange builds the steps skipping the specified number of results and limiting the results to the specified
+// limit. No steps are built for a skip and limit of 0.
+func buildRange(skip, limit int64) string {
+	steps := ""
+	if skip > 0 {
+		steps += fmt.Sprintf(".skip(%d)", skip)
+	}
+	if limit > 0 {
+		steps += fmt.Sprintf(".limit(%d)", limit)
+	}
+	return steps
+}
+
+// validateLabels returns an error if any of the specified labels is empty
+func validateLabels(labels []string) error {
+	for _, label := range labels {
+		if len(label) == 0 {
+			return errors.New("labels cannot be empty")
+		}
+	}
+	return nil
+}
+
+// validateFilters returns an error if a core.Filter within the specified filters uses an unknown operator
+func validateFilters(filters ...core.KVMap) error {
+	for _, f := range filters {
+		for _, k := range sortedKeys(f) {
+			operator := core.FilterOf(f[k]).Operator
+			if _, ok := predicates[operator]; !ok {
+				return fmt.Errorf("invalid operator %d in the filter on %s", operator, k)
+			}
+		}
+	}
+	return nil
+}
+
+// sortedKeys returns the keys of the specified map in ascending order
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,60 @@
+package gremlin
+
+import (
+	"math"
+	"testing"
+
+	"github.com/prahaladd/gograph/core"
+	"github.com/stretchr/testify/suite"
+)
+
+type UtilsTestSuite struct {
+	suite.Suite
+}
+
+func (suite *UtilsTestSuite) TestFormatValue() {
+	suite.Equal("'Tom'", formatValue("Tom"))
+	suite.Equal("10", formatValue(10))
+	suite.Equal("10L", formatValue(int64(10)))
+	suite.Equal("1000000d", formatValue(1e6))
+	suite.Equal("1.5f", formatValue(float32(1.5)))
+	suite.Equal("true", formatValue(true))
+	suite.Equal("null", formatValue(nil))
+}
+
+func (suite *UtilsTestSuite) TestFormatList() {
+	suite.Equal(`['a\'), g.V().drop(), (\'','b']`, formatValue([]string{"a'), g.V().drop(), ('", "b"}))
+	suite.Equal("[1L,2L]", formatValue([2]int64{1, 2}))
+	suite.Equal("[1.5d,'two',true]", formatValue([]interface{}{1.5, "two", true}))
+	suite.Equal("[]", formatValue([]string{}))
+}
+
+func (suite *UtilsTestSuite) TestFormatUserDefinedScalar() {
+	type status string
+	type count int64
+	suite.Equal(`'it\'s on'`, formatValue(status("it's on")))
+	suite.Equal("5L", formatValue(count(5)))
+}
+
+func (suite *UtilsTestSuite) TestValidateValues() {
+	suite.NoError(validateValues(core.KVMap{"tags": []string{"a", "b"}, "age": core.Filter{Operator: core.Gt, Value: 18}, "unset": nil}))
+	suite.EqualError(validateValues(nil, core.KVMap{"address": map[string]interface{}{"city": "Brussels"}}), "unsupported value of type map[string]interface {} for the property address")
+	suite.EqualError(validateValues(core.KVMap{"nested": [][]string{{"a"}}}), "unsupported value of type [][]string for the property nested")
+	suite.EqualError(validateValues(core.KVMap{"age": core.Filter{Operator: core.Gt, Value: struct{}{}}}), "unsupported value of type struct {} for the property age")
+}
+
+func (suite *UtilsTestSuite) TestFormatNonFiniteFloat() {
+	suite.Equal("Double.NaN", formatValue(math.NaN()))
+	suite.Equal("Double.POSITIVE_INFINITY", formatValue(math.Inf(1)))
+	suite.Equal("Double.NEGATIVE_INFINITY", formatValue(math.Inf(-1)))
+}
+
+func (suite *UtilsTestSuite) TestQuoteStringCannotTerminateLiteral() {
+	suite.Equal(`'trailing\\'`, quoteString(`trailing\`))
+	suite.Equal(`'\').drop().V(\''`, quoteString(`').drop().V('`))
+	suite.Equal(`'line\nbreak'`, quoteString("line\nbreak"))
+}
+
+func TestUtilsTestSuite(t *testing.T) {
+	suite.Run(t, new(UtilsTestSuite))
+}
@@ -0,0 +1,112 @@
+package gremlin
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/prahaladd/gograph/core"
+)
+
+// VertexQueryBuilder exposes a builder pattern for building vertex lookup Gremlin traversals
+//
+// The mode of the query builder controls whether the traversal reads vertices or writes a vertex. Write traversals
+// add the vertex using addV, or upsert the vertex matching the selectors when the write mode is core.Merge.
+//
+// selectors on a vertex are used to select vertices with the specific set of properties. Write traversals set the
+// properties of the written vertex to the selectors.
+//
+// filters can be specified to filter out selected vertices based on some criteria. Filter values can be a core.Filter
+// specifying the comparison operator.
+//
+// Multiple vertex labels can be specified for read traversals, which select the vertices having any of the labels.
+// TinkerPop vertices have a single label, hence exactly one label must be specified for write traversals.
+type VertexQueryBuilder struct {
+	queryMode core.QueryMode
+	labels    []string
+	selector  core.KVMap
+	filters   core.KVMap
+	writeMode core.WriteMode
+	limit     int64
+}
+
+func NewVertexQueryBuilder() *VertexQueryBuilder {
+	return &VertexQueryBuilder{selector: core.KVMap{}, filters: core.KVMap{}, writeMode: core.Merge}
+}
+
+func (vqb *VertexQueryBuilder) SetQueryMode(mode core.QueryMode) *VertexQueryBuilder {
+	vqb.queryMode = mode
+	return vqb
+}
+
+func (vqb *VertexQueryBuilder) SetLabel(labels []string) *VertexQueryBuilder {
+	vqb.labels = append([]string(nil), labels...)
+	return vqb
+}
+
+func (vqb *VertexQueryBuilder) SetSelector(selectors core.KVMap) *VertexQueryBuilder {
+	for k, v := range selectors {
+		vqb.selector[k] = v
+	}
+	return vqb
+}
+
+func (vqb *VertexQueryBuilder) SetFilters(filters core.KVMap) *VertexQueryBuilder {
+	for k, v := range filters {
+		vqb.filters[k] = v
+	}
+	return vqb
+}
+
+func (vqb *VertexQueryBuilder) SetWriteMode(writeMode core.WriteMode) *VertexQueryBuilder {
+	vqb.writeMode = writeMode
+	return vqb
+}
+
+// SetLimit sets the maximum number of vertices returned by a read traversal. A limit of 0 returns all the vertices.
+func (vqb *VertexQueryBuilder) SetLimit(limit int64) *VertexQueryBuilder {
+	vqb.limit = limit
+	return vqb
+}
+
+// Build builds the Gremlin traversal
+func (vqb *VertexQueryBuilder) Build() (string, error) {
+	if err := vqb.validate(); err != nil {
+		return "", err
+	}
+	match := fmt.Sprintf("g.V()%s%s", buildLabelStep(vqb.labels), buildHasSteps(vqb.selector, vqb.filters))
+	if vqb.queryMode == core.Read {
+		return match + buildRange(0, vqb.limit), nil
+	}
+	addVertex := fmt.Sprintf("addV(%s)%s", quoteString(vqb.labels[0]), buildPropertySteps(vqb.selector))
+	if vqb.writeMode == core.Create {
+		return "g." + addVertex, nil
+	}
+	return fmt.Sprintf("%s.fold().coalesce(unfold(),%s)", match, addVertex), nil
+}
+
+func (vqb *VertexQueryBuilder) validate() error {
+	if len(vqb.labels) == 0 {
+		return errors.New("no vertex labels specified in the query")
+	}
+	if vqb.queryMode == core.Delete {
+		return errors.New("delete queries are not supported")
+	}
+	if err := validateLabels(vqb.labels); err != nil {
+		return err
+	}
+	if err := validateFilters(vqb.filters); err != nil {
+		return err
+	}
+	if err := validateValues(vqb.selector, vqb.filters); err != nil {
+		return err
+	}
+	if vqb.queryMode == core.Write {
+		if len(vqb.labels) > 1 {
+			return errors.New("multiple vertex labels cannot be specified for write queries")
+		}
+		if len(vqb.filters) > 0 {
+			return errors.New("filters can only be specified for read queries")
+		}
+	}
+	return nil
+}
@@ -0,0 +1,147 @@
+package gremlin
+
+import (
+	"testing"
+
+	"github.com/prahaladd/gograph/core"
+	"github.com/stretchr/testify/suite"
+)
+
+type VertexQueryBuilderTestSuite struct {
+	suite.Suite
+	queryBuilder *VertexQueryBuilder
+}
+
+func (suite *VertexQueryBuilderTestSuite) SetupTest() {
+	suite.queryBuilder = NewVertexQueryBuilder()
+}
+
+func (suite *VertexQueryBuilderTestSuite) TestBuildQuerySingleLabel() {
+	suite.queryBuilder.SetLabel([]string{"Person"})
+	suite.queryBuilder.SetQueryMode(core.Read)
+	suite.queryBuilder.SetSelector(core.KVMap{"name": "Tom"})
+	suite.queryBuilder.SetFilters(core.KVMap{"age": 10})
+
+	query, err := suite.queryBuilder.Build()
+	suite.NoError(err)
+	suite.Equal("g.V().hasLabel('Person').has('name','Tom').has('age',10)", query)
+}
+
+func (suite *VertexQueryBuilderTestSuite) TestBuildQueryMultiLabel() {
+	suite.queryBuilder.SetLabel([]string{"Person", "Employee"})
+	suite.queryBuilder.SetQueryMode(core.Read)
+
+	query, err := suite.queryBuilder.Build()
+	suite.NoError(err)
+	suite.Equal("g.V().hasLabel('Person','Employee')", query)
+}
+
+func (suite *VertexQueryBuilderTestSuite) TestBuildQueryWithComparisonFilters() {
+	suite.queryBuilder.SetLabel([]string{"Person"})
+	suite.queryBuilder.SetQueryMode(core.Read)
+	suite.queryBuilder.SetFilters(core.KVMap{"age": core.Filter{Operator: core.Gt, Value: int64(18)}, "name": core.Filter{Operator: core.Neq, Value: "Tom"}})
+
+	query, err := suite.queryBuilder.Build()
+	suite.NoError(err)
+	suite.Equal("g.V().hasLabel('Person').has('age',gt(18L)).has('name',neq('Tom'))", query)
+}
+
+func (suite *VertexQueryBuilderTestSuite) TestBuildQueryWithLimit() {
+	suite.queryBuilder.SetLabel([]string{"Person"})
+	suite.queryBuilder.SetQueryMode(core.Read)
+	suite.queryBuilder.SetLimit(5)
+
+	query, err := suite.queryBuilder.Build()
+	suite.NoError(err)
+	suite.Equal("g.V().hasLabel('Person').limit(5)", query)
+}
+
+func (suite *VertexQueryBuilderTestSuite) TestBuildMergeQuery() {
+	suite.queryBuilder.SetLabel([]string{"Person"})
+	suite.queryBuilder.SetQueryMode(core.Write)
+	suite.queryBuilder.SetSelector(core.KVMap{"name": "Tom", "age": 10})
+
+	query, err := suite.queryBuilder.Build()
+	suite.NoError(err)
+	suite.Equal("g.V().hasLabel('Person').has('age',10).has('name','Tom').fold().coalesce(unfold(),addV('Person').property('age',10).property('name','Tom'))", query)
+}
+
+func (suite *VertexQueryBuilderTestSuite) TestBuildCreateQuery() {
+	suite.queryBuilder.SetLabel([]string{"Person"})
+	suite.queryBuilder.SetQueryMode(core.Write)
+	suite.queryBuilder.SetWriteMode(core.Create)
+	suite.queryBuilder.SetSelector(core.KVMap{"name": "O'Brien"})
+
+	query, err := suite.queryBuilder.Build()
+	suite.NoError(err)
+	suite.Equal(`g.addV('Person').property('name','O\'Brien')`, query)
+}
+
+func (suite *VertexQueryBuilderTestSuite) TestBuildQueryNoLabels() {
+	_, err := suite.queryBuilder.Build()
+	suite.EqualError(err, "no vertex labels specified in the query")
+
+	suite.queryBuilder.SetLabel([]string{""})
+	_, err = suite.queryBuilder.Build()
+	suite.EqualError(err, "labels cannot be empty")
+}
+
+func (suite *VertexQueryBuilderTestSuite) TestBuildWriteQueryValidation() {
+	suite.queryBuilder.SetLabel([]string{"Person", "Employee"})
+	suite.queryBuilder.SetQueryMode(core.Write)
+	_, err := suite.queryBuilder.Build()
+	suite.EqualError(err, "multiple vertex labels cannot be specified for write queries")
+
+	suite.queryBuilder.SetLabel([]string{"Person"})
+	suite.queryBuilder.SetFilters(core.KVMap{"age": 10})
+	_, err = suite.queryBuilder.Build()
+	suite.EqualError(err, "filters can only be specified for read queries")
+}
+
+func (suite *VertexQueryBuilderTestSuite) TestBuildQueryWithListSelector() {
+	suite.queryBuilder.SetLabel([]string{"Book"})
+	suite.queryBuilder.SetQueryMode(core.Read)
+	suite.queryBuilder.SetSelector(core.KVMap{"tags": []string{"a'), g.V().drop(), ('", "b"}})
+
+	query, err := suite.queryBuilder.Build()
+	suite.NoError(err)
+	suite.Equal(`g.V().hasLabel('Book').has('tags',['a\'), g.V().drop(), (\'','b'])`, query)
+}
+
+func (suite *VertexQueryBuilderTestSuite) TestBuildCreateQueryWithListProperty() {
+	suite.queryBuilder.SetLabel([]string{"Book"})
+	suite.queryBuilder.SetQueryMode(core.Write)
+	suite.queryBuilder.SetWriteMode(core.Create)
+	suite.queryBuilder.SetSelector(core.KVMap{"tags": []string{"comics", "adventure"}})
+
+	query, err := suite.queryBuilder.Build()
+	suite.NoError(err)
+	suite.Equal("g.addV('Book').property('tags',['comics','adventure'])", query)
+}
+
+func (suite *VertexQueryBuilderTestSuite) TestBuildQueryWithUnsupportedValue() {
+	suite.queryBuilder.SetLabel([]string{"Book"})
+	suite.queryBuilder.SetQueryMode(core.Read)
+	suite.queryBuilder.SetSelector(core.KVMap{"publisher": map[string]string{"name": "Casterman"}})
+
+	_, err := suite.queryBuilder.Build()
+	suite.EqualError(err, "unsupported value of type map[string]string for the property publisher")
+}
+
+func (suite *VertexQueryBuilderTestSuite) TestBuildQueryInvalidOperator() {
+	suite.queryBuilder.SetLabel([]string{"Person"})
+	suite.queryBuilder.SetFilters(core.KVMap{"age": core.Filter{Operator: core.FilterOperator(42), Value: 10}})
+	_, err := suite.queryBuilder.Build()
+	suite.EqualError(err, "invalid operator 42 in the filter on age")
+}
+
+func (suite *VertexQueryBuilderTestSuite) TestBuildDeleteMode() {
+	suite.queryBuilder.SetLabel([]string{"Person"})
+	suite.queryBuilder.SetQueryMode(core.Delete)
+	_, err := suite.queryBuilder.Build()
+	suite.EqualError(err, "delete queries are not supported")
+}
+
+func TestVertexQueryBuilderTestSuite(t *testing.T) {
+	suite.Run(t, new(VertexQueryBuilderTestSuite))
+}